@@ -0,0 +1,257 @@
+package bitrise_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/bitrise/bitrisetest"
+	"github.com/bitrise-io/go-utils/envutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newFakeServerAPI(t *testing.T, apps ...bitrisetest.App) (*bitrisetest.Server, *bitrise.API, func()) {
+	server := bitrisetest.NewServer(apps...)
+	revokeFns := []func() error{}
+	for key, value := range map[string]string{
+		"BITRISE_API_ROOT_URL":            server.URL,
+		"BITRISE_API_RETRY_BASE_DELAY_MS": "1",
+	} {
+		revokeFn, err := envutil.RevokableSetenv(key, value)
+		require.NoError(t, err)
+		revokeFns = append(revokeFns, revokeFn)
+	}
+	return server, bitrise.New(), func() {
+		server.Close()
+		for _, revokeFn := range revokeFns {
+			require.NoError(t, revokeFn())
+		}
+	}
+}
+
+func testFakeApp() bitrisetest.App {
+	ipaType := "ios-ipa"
+	downloadURL := "http://download.url/my-app.ipa"
+	return bitrisetest.App{
+		Slug:     "test-app-slug",
+		APIToken: "test-api-token",
+		Details:  bitrise.AppDetails{Title: "My App", ProjectType: "ios"},
+		Builds: map[string]bitrisetest.Build{
+			"test-build-slug": {
+				Details: bitrise.BuildDetails{BuildNumber: 42, Status: bitrise.BuildStatusSuccessful, Branch: "master"},
+				Artifacts: []bitrise.ArtifactShowResponseItemModel{
+					{Slug: "artifact-slug-1", Title: stringPtr("my-app.xcarchive.zip")},
+					{Slug: "artifact-slug-2", Title: stringPtr("test-results.html")},
+					{
+						Slug:                 "artifact-slug-3",
+						Title:                stringPtr("my-app.ipa"),
+						ArtifactType:         &ipaType,
+						ArtifactMeta:         json.RawMessage(`{"app_info":{"bundle_id":"io.bitrise.app"}}`),
+						DownloadPath:         &downloadURL,
+						PublicInstallPageURL: "http://install.page/my-app",
+					},
+				},
+			},
+		},
+		ProvisioningProfiles: []bitrise.ProvisioningProfile{{Slug: "prov-profile-slug", Filename: "dev.mobileprovision"}},
+		AndroidKeystoreFiles: []bitrise.AndroidKeystoreFile{{Slug: "keystore-slug", Filename: "release.keystore"}},
+		GenericProjectFiles: []bitrise.GenericProjectFile{
+			{Slug: "service-account-slug", Filename: "service-account.json"},
+			{Slug: "other-file-slug", Filename: "notes.txt"},
+		},
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func Test_API_WithFakeServer(t *testing.T) {
+	t.Run("app and build details", func(t *testing.T) {
+		_, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+
+		appDetails, err := api.GetAppDetails("test-api-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, &bitrise.AppDetails{Title: "My App", ProjectType: "ios"}, appDetails)
+
+		buildDetails, err := api.GetBuildDetails("test-api-token", "test-app-slug", "test-build-slug")
+		require.NoError(t, err)
+		require.Equal(t, 42, buildDetails.BuildNumber)
+		require.Equal(t, "master", buildDetails.Branch)
+	})
+
+	t.Run("when the auth token is invalid", func(t *testing.T) {
+		_, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+
+		_, err := api.GetAppDetails("invalid-token", "test-app-slug")
+		require.EqualError(t, err, "Failed to fetch app details: status: 401")
+	})
+
+	t.Run("when the build doesn't exist", func(t *testing.T) {
+		_, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+
+		_, err := api.GetBuildDetails("test-api-token", "test-app-slug", "missing-build-slug")
+		require.EqualError(t, err, "Failed to fetch build details: status: 404")
+	})
+
+	t.Run("fetches all the pages of the artifacts", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+		server.PageSize = 1
+
+		artifacts, err := api.GetArtifacts("test-api-token", "test-app-slug", "test-build-slug")
+		require.NoError(t, err)
+		require.Len(t, artifacts, 3)
+		for i, artifact := range artifacts {
+			require.Equal(t, fmt.Sprintf("artifact-slug-%d", i+1), artifact.Slug)
+		}
+		require.Equal(t, "io.bitrise.app", artifacts[2].ArtifactMeta.AppInfo.BundleID)
+
+		artifactData, err := api.GetArtifactData("test-api-token", "test-app-slug", "test-build-slug")
+		require.NoError(t, err)
+		require.Equal(t, "artifact-slug-3", artifactData.Slug)
+	})
+
+	t.Run("artifact details", func(t *testing.T) {
+		_, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+
+		artifact, err := api.GetArtifact("test-api-token", "test-app-slug", "test-build-slug", "artifact-slug-3")
+		require.NoError(t, err)
+		require.Equal(t, "http://download.url/my-app.ipa", *artifact.DownloadPath)
+
+		installPageURL, err := api.GetArtifactPublicInstallPageURL("test-api-token", "test-app-slug", "test-build-slug", "artifact-slug-3")
+		require.NoError(t, err)
+		require.Equal(t, "http://install.page/my-app", installPageURL)
+	})
+
+	t.Run("picks up the edited fixtures", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+
+		require.NoError(t, server.AddBuild("test-app-slug", "new-build-slug", bitrisetest.Build{
+			Details: bitrise.BuildDetails{BuildNumber: 43, Status: bitrise.BuildStatusNotFinished},
+		}))
+
+		buildDetails, err := api.GetBuildDetails("test-api-token", "test-app-slug", "new-build-slug")
+		require.NoError(t, err)
+		require.Equal(t, 43, buildDetails.BuildNumber)
+
+		_, err = api.GetArtifactData("test-api-token", "test-app-slug", "new-build-slug")
+		require.EqualError(t, err, "No matching artifact found")
+	})
+
+	t.Run("code signing files", func(t *testing.T) {
+		_, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+
+		provProfile, err := api.GetProvisioningProfile("test-api-token", "test-app-slug", "prov-profile-slug")
+		require.NoError(t, err)
+		require.Equal(t, "dev.mobileprovision", provProfile.Filename)
+
+		keystoreFile, err := api.GetAndroidKeystoreFile("test-api-token", "test-app-slug", "keystore-slug")
+		require.NoError(t, err)
+		require.Equal(t, "release.keystore", keystoreFile.Filename)
+
+		serviceAccountFiles, err := api.GetServiceAccountFiles("test-api-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, []bitrise.GenericProjectFile{{Slug: "service-account-slug", Filename: "service-account.json"}}, serviceAccountFiles)
+
+		_, err = api.GetCodeSigningIdentity("test-api-token", "test-app-slug", "missing-slug")
+		require.EqualError(t, err, "Failed to fetch build certificate: status: 404")
+	})
+
+	t.Run("retries the failed reads", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+		server.FailNext(http.MethodGet, "/apps/test-app-slug", http.StatusServiceUnavailable, 2)
+
+		appDetails, err := api.GetAppDetails("test-api-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, "My App", appDetails.Title)
+	})
+
+	t.Run("when a page of the artifacts fails", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+		server.PageSize = 1
+		server.FailNext(http.MethodGet, "/apps/test-app-slug/builds/test-build-slug/artifacts", http.StatusForbidden, 2)
+
+		_, err := api.GetArtifacts("test-api-token", "test-app-slug", "test-build-slug")
+		require.EqualError(t, err, "Failed to fetch artifact data: status: 403")
+	})
+
+	t.Run("registers and unregisters webhooks", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+		server.PageSize = 1
+
+		require.NoError(t, api.RegisterWebhook("test-api-token", "test-app-slug", "secret", "http://other.url/webhook"))
+		require.NoError(t, api.RegisterWebhook("test-api-token", "test-app-slug", "secret", "http://ship.url/webhook"))
+		require.NoError(t, api.RegisterWebhook("test-api-token", "test-app-slug", "secret", "http://ship.url/webhook"))
+		app, ok := server.App("test-app-slug")
+		require.True(t, ok)
+		require.Len(t, app.OutgoingWebhooks, 3)
+		require.Equal(t, []string{"build"}, app.OutgoingWebhooks[1].Events)
+
+		require.NoError(t, api.UnregisterWebhook("test-api-token", "test-app-slug", "http://ship.url/webhook"))
+		app, ok = server.App("test-app-slug")
+		require.True(t, ok)
+		require.Len(t, app.OutgoingWebhooks, 1)
+		require.Equal(t, "http://other.url/webhook", app.OutgoingWebhooks[0].URL)
+	})
+
+	t.Run("when registering the webhook fails", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t, testFakeApp())
+		defer cleanup()
+		server.FailNext(http.MethodPost, "/apps/test-app-slug/outgoing-webhooks", http.StatusInternalServerError, 1)
+
+		err := api.RegisterWebhook("test-api-token", "test-app-slug", "secret", "http://ship.url/webhook")
+		require.EqualError(t, err, "Failed to register webhook: status: 500")
+		app, ok := server.App("test-app-slug")
+		require.True(t, ok)
+		require.Empty(t, app.OutgoingWebhooks)
+	})
+
+	t.Run("triggers DEN tasks", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t)
+		defer cleanup()
+		server.DENSecretHeaderKey = "Den-Admin-Secret"
+		server.DENSecret = "den-secret"
+
+		for key, value := range map[string]string{
+			"BITRISE_DEN_SERVER_ADMIN_SECRET_HEADER_KEY": "Den-Admin-Secret",
+			"BITRISE_DEN_SERVER_ADMIN_SECRET":            "den-secret",
+		} {
+			revokeFn, err := envutil.RevokableSetenv(key, value)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, revokeFn()) }()
+		}
+
+		resp, err := api.TriggerDENTask(bitrise.TaskParams{Workflow: "resign_archive_app_store", WebhookURL: "http://ship.url/task-webhook"})
+		require.NoError(t, err)
+		require.Equal(t, "http://ship.url/task-webhook", resp.WebhookURL)
+		require.NotEmpty(t, resp.TaskIdentifier.String())
+		require.Equal(t, []bitrise.TaskParams{{Workflow: "resign_archive_app_store", WebhookURL: "http://ship.url/task-webhook"}}, server.TriggeredTasks())
+	})
+
+	t.Run("when the DEN secret is invalid", func(t *testing.T) {
+		server, api, cleanup := newFakeServerAPI(t)
+		defer cleanup()
+		server.DENSecretHeaderKey = "Den-Admin-Secret"
+		server.DENSecret = "den-secret"
+
+		revokeFn, err := envutil.RevokableSetenv("BITRISE_DEN_SERVER_ADMIN_SECRET_HEADER_KEY", "Den-Admin-Secret")
+		require.NoError(t, err)
+		defer func() { require.NoError(t, revokeFn()) }()
+
+		_, err = api.TriggerDENTask(bitrise.TaskParams{Workflow: "resign_archive_app_store"})
+		require.EqualError(t, err, "Failed to trigger DEN task: status: 401")
+		require.Empty(t, server.TriggeredTasks())
+	})
+}
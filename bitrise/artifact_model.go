@@ -17,6 +17,7 @@ type AppInfo struct {
 	BundleID          string `json:"bundle_id"`
 	BuildNumber       string `json:"build_number"`
 	DeviceFamilyList  []int  `json:"device_family_list"`
+	PlatformName      string `json:"platform_name"`
 	PackageName       string `json:"package_name"`
 	VersionName       string `json:"version_name"`
 	VersionCode       string `json:"version_code"`
@@ -2,7 +2,9 @@ package bitrise
 
 import (
 	"encoding/json"
+	"fmt"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -86,6 +88,148 @@ func (s *ArtifactSelector) PrepareAndroidAppVersions(buildSlug, buildNumber, bui
 	return appVersions, nil, nil
 }
 
+// PrepareIosAppVersions creates a version for each scheme and bundle ID the build archived. Archives of schemes
+// missing from trackedSchemes are skipped, an empty list tracks every scheme.
+func (s *ArtifactSelector) PrepareIosAppVersions(buildSlug, buildCommitMessage string, trackedSchemes []string) ([]models.AppVersion, error) {
+	appVersions := []models.AppVersion{}
+	archiveGroups := map[string][]ArtifactListElementResponseModel{}
+	for _, artifact := range s.artifacts {
+		if !artifact.IsXCodeArchive() {
+			continue
+		}
+		if artifact.ArtifactMeta == nil {
+			return nil, errors.New("No artifact meta data found for artifact")
+		}
+		if reflect.DeepEqual(artifact.ArtifactMeta.AppInfo, AppInfo{}) {
+			return nil, errors.New("No artifact app info found for artifact")
+		}
+		key := fmt.Sprintf("%s/%s", artifact.ArtifactMeta.Scheme, artifact.ArtifactMeta.AppInfo.BundleID)
+		archiveGroups[key] = append(archiveGroups[key], artifact)
+	}
+	if len(archiveGroups) == 0 {
+		return nil, errors.New("No iOS artifact found")
+	}
+	groupKeys := []string{}
+	for key := range archiveGroups {
+		groupKeys = append(groupKeys, key)
+	}
+	sort.Strings(groupKeys)
+
+	for _, key := range groupKeys {
+		group := archiveGroups[key]
+		// the last archive of the group is the one the version is made of, same as when there's a single scheme
+		artifactMeta := group[len(group)-1].ArtifactMeta
+		if !isSchemeTracked(artifactMeta.Scheme, trackedSchemes) {
+			continue
+		}
+
+		var supportedDeviceTypes []string
+		for _, familyID := range artifactMeta.AppInfo.DeviceFamilyList {
+			switch familyID {
+			case 1:
+				supportedDeviceTypes = append(supportedDeviceTypes, "iPhone", "iPod Touch")
+			case 2:
+				supportedDeviceTypes = append(supportedDeviceTypes, "iPad")
+			case 3:
+				supportedDeviceTypes = append(supportedDeviceTypes, "Apple TV")
+			case 4:
+				supportedDeviceTypes = append(supportedDeviceTypes, "Apple Watch")
+			case 6:
+				supportedDeviceTypes = append(supportedDeviceTypes, "Mac")
+			default:
+				supportedDeviceTypes = append(supportedDeviceTypes, "Unknown")
+			}
+		}
+		platform := applePlatform(artifactMeta.AppInfo)
+		if len(supportedDeviceTypes) == 0 && platform == "macos" {
+			supportedDeviceTypes = []string{"Mac"}
+		}
+		artifactInfo := models.ArtifactInfo{
+			Version:              artifactMeta.AppInfo.Version,
+			MinimumOS:            artifactMeta.AppInfo.MinimumOS,
+			BundleID:             artifactMeta.AppInfo.BundleID,
+			SupportedDeviceTypes: supportedDeviceTypes,
+		}
+		artifactInfoData, err := json.Marshal(artifactInfo)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		appVersion := models.AppVersion{
+			Platform:         platform,
+			BuildSlug:        buildSlug,
+			BuildNumber:      artifactMeta.AppInfo.BuildNumber,
+			ArtifactInfoData: artifactInfoData,
+			LastUpdate:       time.Now(),
+			CommitMessage:    buildCommitMessage,
+			Scheme:           artifactMeta.Scheme,
+		}
+		appVersions = append(appVersions, appVersion)
+	}
+	return appVersions, nil
+}
+
+// applePlatform tells the platform of the archive from the device families it supports (UIDeviceFamily), a Mac
+// Catalyst app is a macOS one even if it supports the iPad as well. Native macOS apps don't declare device
+// families, their platform is told by the SDK they were built with (DTPlatformName).
+func applePlatform(appInfo AppInfo) string {
+	if len(appInfo.DeviceFamilyList) == 0 {
+		switch appInfo.PlatformName {
+		case "macosx":
+			return "macos"
+		case "appletvos":
+			return "tvos"
+		case "watchos":
+			return "watchos"
+		}
+		return "ios"
+	}
+	platform := "ios"
+	for _, familyID := range appInfo.DeviceFamilyList {
+		switch familyID {
+		case 6:
+			return "macos"
+		case 3:
+			platform = "tvos"
+		case 4:
+			if platform == "ios" {
+				platform = "watchos"
+			}
+		}
+	}
+	return platform
+}
+
+// IosArtifacts returns the iOS artifacts of the build which belong to the scheme and bundle ID of the version.
+// Artifacts without the meta data to tell are kept, as are all of them for versions without artifact info.
+func (s *ArtifactSelector) IosArtifacts(appVersion *models.AppVersion) ([]ArtifactListElementResponseModel, error) {
+	var artifactInfo models.ArtifactInfo
+	if len(appVersion.ArtifactInfoData) > 0 {
+		var err error
+		artifactInfo, err = appVersion.ArtifactInfo()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	artifacts := []ArtifactListElementResponseModel{}
+	for _, artifact := range s.artifacts {
+		if !artifact.IsIPA() && !artifact.IsXCodeArchive() {
+			continue
+		}
+		if artifact.ArtifactMeta != nil {
+			if artifact.ArtifactMeta.Scheme != "" && appVersion.Scheme != "" &&
+				artifact.ArtifactMeta.Scheme != appVersion.Scheme {
+				continue
+			}
+			if artifact.ArtifactMeta.AppInfo.BundleID != "" && artifactInfo.BundleID != "" &&
+				artifact.ArtifactMeta.AppInfo.BundleID != artifactInfo.BundleID {
+				continue
+			}
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
 // Select ...
 func (s *ArtifactSelector) Select(module, flavor string) ([]string, error) {
 	artifactSlugs := []string{}
@@ -171,6 +315,18 @@ func (s *ArtifactSelector) HasAndroidArtifact() bool {
 	return false
 }
 
+func isSchemeTracked(scheme string, trackedSchemes []string) bool {
+	if len(trackedSchemes) == 0 {
+		return true
+	}
+	for _, trackedScheme := range trackedSchemes {
+		if trackedScheme == scheme {
+			return true
+		}
+	}
+	return false
+}
+
 func groupByBuildType(artifacts []ArtifactListElementResponseModel) map[string][]ArtifactListElementResponseModel {
 	buildTypeGroups := map[string][]ArtifactListElementResponseModel{}
 	for _, artifact := range artifacts {
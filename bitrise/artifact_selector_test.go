@@ -220,6 +220,174 @@ func Test_ArtifactSelector_PrepareAndroidAppVersions(t *testing.T) {
 	})
 }
 
+func Test_ArtifactSelector_PrepareIosAppVersions(t *testing.T) {
+	testBuildSlug := "test-build-slug"
+	testCommitMessage := "Some meaningful string"
+	testArtifacts := []bitrise.ArtifactListElementResponseModel{
+		bitrise.ArtifactListElementResponseModel{
+			Title: "WhiteLabel.xcarchive.zip",
+			ArtifactMeta: &bitrise.ArtifactMeta{
+				Scheme:  "WhiteLabel",
+				AppInfo: bitrise.AppInfo{Version: "2.0", BuildNumber: "7", BundleID: "io.bitrise.whitelabel"},
+			},
+		},
+		bitrise.ArtifactListElementResponseModel{
+			Title: "App.ipa",
+			ArtifactMeta: &bitrise.ArtifactMeta{
+				Scheme:  "App",
+				AppInfo: bitrise.AppInfo{Version: "1.0", BuildNumber: "12", BundleID: "io.bitrise.app"},
+			},
+		},
+		bitrise.ArtifactListElementResponseModel{
+			Title: "App.xcarchive.zip",
+			ArtifactMeta: &bitrise.ArtifactMeta{
+				Scheme:  "App",
+				AppInfo: bitrise.AppInfo{Version: "1.0", BuildNumber: "12", BundleID: "io.bitrise.app", DeviceFamilyList: []int{1, 2}},
+			},
+		},
+	}
+	expectedAppVersions := []models.AppVersion{
+		models.AppVersion{
+			Platform:         "ios",
+			BuildSlug:        testBuildSlug,
+			BuildNumber:      "12",
+			CommitMessage:    testCommitMessage,
+			ArtifactInfoData: json.RawMessage(`{"version":"1.0","version_code":"","minimum_os":"","minimum_sdk":"","bundle_id":"io.bitrise.app","supported_device_types":["iPhone","iPod Touch","iPad"],"package_name":"","expire_date":"0001-01-01T00:00:00Z","ipa_export_method":"","module":"","build_type":""}`),
+			Scheme:           "App",
+		},
+		models.AppVersion{
+			Platform:         "ios",
+			BuildSlug:        testBuildSlug,
+			BuildNumber:      "7",
+			CommitMessage:    testCommitMessage,
+			ArtifactInfoData: json.RawMessage(`{"version":"2.0","version_code":"","minimum_os":"","minimum_sdk":"","bundle_id":"io.bitrise.whitelabel","supported_device_types":null,"package_name":"","expire_date":"0001-01-01T00:00:00Z","ipa_export_method":"","module":"","build_type":""}`),
+			Scheme:           "WhiteLabel",
+		},
+	}
+
+	t.Run("ok - a version for each scheme", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector(testArtifacts)
+		appVersions, err := artifactSelector.PrepareIosAppVersions(testBuildSlug, testCommitMessage, nil)
+		require.NoError(t, err)
+		compareAppVersionArrays(t, expectedAppVersions, appVersions)
+	})
+
+	t.Run("ok - only tracked schemes", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector(testArtifacts)
+		appVersions, err := artifactSelector.PrepareIosAppVersions(testBuildSlug, testCommitMessage, []string{"WhiteLabel"})
+		require.NoError(t, err)
+		compareAppVersionArrays(t, expectedAppVersions[1:], appVersions)
+	})
+
+	t.Run("ok - a version for each bundle ID of a scheme", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector([]bitrise.ArtifactListElementResponseModel{
+			bitrise.ArtifactListElementResponseModel{
+				Title:        "App.xcarchive.zip",
+				ArtifactMeta: &bitrise.ArtifactMeta{Scheme: "App", AppInfo: bitrise.AppInfo{BundleID: "io.bitrise.app"}},
+			},
+			bitrise.ArtifactListElementResponseModel{
+				Title:        "App.xcarchive.zip",
+				ArtifactMeta: &bitrise.ArtifactMeta{Scheme: "App", AppInfo: bitrise.AppInfo{BundleID: "io.bitrise.app.beta"}},
+			},
+		})
+		appVersions, err := artifactSelector.PrepareIosAppVersions(testBuildSlug, testCommitMessage, nil)
+		require.NoError(t, err)
+		require.Len(t, appVersions, 2)
+	})
+
+	t.Run("ok - platform of the version by the device families of the archive", func(t *testing.T) {
+		for _, tc := range []struct {
+			deviceFamilyList             []int
+			platformName                 string
+			expectedPlatform             string
+			expectedSupportedDeviceTypes []string
+		}{
+			{deviceFamilyList: []int{1, 2}, expectedPlatform: "ios", expectedSupportedDeviceTypes: []string{"iPhone", "iPod Touch", "iPad"}},
+			{deviceFamilyList: []int{3}, expectedPlatform: "tvos", expectedSupportedDeviceTypes: []string{"Apple TV"}},
+			{deviceFamilyList: []int{4}, expectedPlatform: "watchos", expectedSupportedDeviceTypes: []string{"Apple Watch"}},
+			{deviceFamilyList: []int{2, 6}, expectedPlatform: "macos", expectedSupportedDeviceTypes: []string{"iPad", "Mac"}},
+			{deviceFamilyList: []int{}, platformName: "macosx", expectedPlatform: "macos", expectedSupportedDeviceTypes: []string{"Mac"}},
+			{deviceFamilyList: []int{}, expectedPlatform: "ios"},
+		} {
+			artifactSelector := bitrise.NewArtifactSelector([]bitrise.ArtifactListElementResponseModel{
+				bitrise.ArtifactListElementResponseModel{
+					Title:        "App.xcarchive.zip",
+					ArtifactMeta: &bitrise.ArtifactMeta{Scheme: "App", AppInfo: bitrise.AppInfo{Version: "1.0", BundleID: "io.bitrise.app", DeviceFamilyList: tc.deviceFamilyList, PlatformName: tc.platformName}},
+				},
+			})
+			appVersions, err := artifactSelector.PrepareIosAppVersions(testBuildSlug, testCommitMessage, nil)
+			require.NoError(t, err)
+			require.Len(t, appVersions, 1)
+			require.Equal(t, tc.expectedPlatform, appVersions[0].Platform)
+			artifactInfo, err := appVersions[0].ArtifactInfo()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSupportedDeviceTypes, artifactInfo.SupportedDeviceTypes)
+		}
+	})
+
+	t.Run("error - when there's no xcarchive", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector(testArtifacts[1:2])
+		appVersions, err := artifactSelector.PrepareIosAppVersions(testBuildSlug, testCommitMessage, nil)
+		require.EqualError(t, err, "No iOS artifact found")
+		require.Nil(t, appVersions)
+	})
+
+	t.Run("error - when xcarchive has no artifact meta", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector([]bitrise.ArtifactListElementResponseModel{
+			bitrise.ArtifactListElementResponseModel{Title: "App.xcarchive.zip"},
+		})
+		appVersions, err := artifactSelector.PrepareIosAppVersions(testBuildSlug, testCommitMessage, nil)
+		require.EqualError(t, err, "No artifact meta data found for artifact")
+		require.Nil(t, appVersions)
+	})
+}
+
+func Test_ArtifactSelector_IosArtifacts(t *testing.T) {
+	testArtifacts := []bitrise.ArtifactListElementResponseModel{
+		bitrise.ArtifactListElementResponseModel{
+			Title:        "App.ipa",
+			ArtifactMeta: &bitrise.ArtifactMeta{AppInfo: bitrise.AppInfo{BundleID: "io.bitrise.app"}},
+		},
+		bitrise.ArtifactListElementResponseModel{
+			Title:        "App.xcarchive.zip",
+			ArtifactMeta: &bitrise.ArtifactMeta{Scheme: "App", AppInfo: bitrise.AppInfo{BundleID: "io.bitrise.app"}},
+		},
+		bitrise.ArtifactListElementResponseModel{
+			Title:        "WhiteLabel.ipa",
+			ArtifactMeta: &bitrise.ArtifactMeta{AppInfo: bitrise.AppInfo{BundleID: "io.bitrise.whitelabel"}},
+		},
+		bitrise.ArtifactListElementResponseModel{
+			Title:        "WhiteLabel.xcarchive.zip",
+			ArtifactMeta: &bitrise.ArtifactMeta{Scheme: "WhiteLabel", AppInfo: bitrise.AppInfo{BundleID: "io.bitrise.whitelabel"}},
+		},
+		bitrise.ArtifactListElementResponseModel{Title: "app.apk"},
+	}
+
+	t.Run("ok - artifacts of the scheme and bundle ID", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector(testArtifacts)
+		artifacts, err := artifactSelector.IosArtifacts(&models.AppVersion{
+			Scheme:           "WhiteLabel",
+			ArtifactInfoData: json.RawMessage(`{"bundle_id":"io.bitrise.whitelabel"}`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, testArtifacts[2:4], artifacts)
+	})
+
+	t.Run("ok - every ios artifact when version has no artifact info", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector(testArtifacts)
+		artifacts, err := artifactSelector.IosArtifacts(&models.AppVersion{})
+		require.NoError(t, err)
+		require.Equal(t, testArtifacts[:4], artifacts)
+	})
+
+	t.Run("error - when artifact info is invalid", func(t *testing.T) {
+		artifactSelector := bitrise.NewArtifactSelector(testArtifacts)
+		artifacts, err := artifactSelector.IosArtifacts(&models.AppVersion{ArtifactInfoData: json.RawMessage(`invalid`)})
+		require.Error(t, err)
+		require.Nil(t, artifacts)
+	})
+}
+
 func Test_ArtifactSelector_Select(t *testing.T) {
 	for _, tc := range []struct {
 		testName            string
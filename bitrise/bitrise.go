@@ -2,6 +2,7 @@ package bitrise
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -36,12 +37,18 @@ type APIInterface interface {
 	GetServiceAccountFile(authToken, appSlug, serviceJSONSLug string) (*GenericProjectFile, error)
 	TriggerDENTask(params TaskParams) (*TriggerResponse, error)
 	RegisterWebhook(authToken, appSlug, secret, callbackURL string) error
+	UnregisterWebhook(authToken, appSlug, callbackURL string) error
+	WithContext(ctx context.Context) APIInterface
 }
 
 // API ...
 type API struct {
 	*http.Client
-	url string
+	url     string
+	ctx     context.Context
+	config  clientConfig
+	breaker *circuitBreaker
+	metrics *clientMetrics
 }
 
 // New ...
@@ -51,12 +58,30 @@ func New() *API {
 		url = apiBaseURL
 	}
 	url = fmt.Sprintf("%s/%s", url, apiVersion)
+	config := clientConfigFromEnv()
+	metrics := &clientMetrics{}
 	return &API{
-		Client: &http.Client{},
-		url:    url,
+		Client:  &http.Client{},
+		url:     url,
+		config:  config,
+		breaker: newCircuitBreaker(config.breakerThreshold, config.breakerCooldown, metrics),
+		metrics: metrics,
 	}
 }
 
+// Metrics returns the counters of the calls made by the client and its copies
+func (a *API) Metrics() Metrics {
+	return a.metrics.snapshot()
+}
+
+// WithContext returns a copy of the API client which cancels its calls together with the given
+// context. The copies share the circuit breaker and the metrics.
+func (a *API) WithContext(ctx context.Context) APIInterface {
+	apiWithContext := *a
+	apiWithContext.ctx = ctx
+	return &apiWithContext
+}
+
 func (a *API) doRequest(authToken, method, path string, requestPayload interface{}) (*http.Response, error) {
 	var payloadBytes []byte
 	if requestPayload != nil {
@@ -72,7 +97,7 @@ func (a *API) doRequest(authToken, method, path string, requestPayload interface
 	}
 	req.Header.Set("Bitrise-Addon-Auth-Token", authToken)
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := a.Do(req)
+	resp, err := a.do(req)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -366,7 +391,7 @@ func (a *API) TriggerDENTask(params TaskParams) (*TriggerResponse, error) {
 	}
 	req.Header.Set(denAuthHeaderKey, denAdminSecret)
 
-	resp, err := a.Do(req)
+	resp, err := a.do(req)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -400,7 +425,7 @@ func (a *API) RegisterWebhook(authToken, appSlug, secret, callbackURL string) er
 	req.Header.Set("Bitrise-Addon-Auth-Token", authToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.Do(req)
+	resp, err := a.do(req)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -412,6 +437,59 @@ func (a *API) RegisterWebhook(authToken, appSlug, secret, callbackURL string) er
 	return nil
 }
 
+// UnregisterWebhook deletes the outgoing webhooks of the app which call the given URL
+func (a *API) UnregisterWebhook(authToken, appSlug, callbackURL string) error {
+	webhookSlugs := []string{}
+	next := ""
+	for {
+		responseModel, err := a.listOutgoingWebhooks(authToken, appSlug, next)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, webhook := range responseModel.Data {
+			if webhook.URL == callbackURL {
+				webhookSlugs = append(webhookSlugs, webhook.Slug)
+			}
+		}
+		next = responseModel.Paging.Next
+		if next == "" {
+			break
+		}
+	}
+
+	for _, webhookSlug := range webhookSlugs {
+		resp, err := a.doRequest(authToken, "DELETE", fmt.Sprintf("/apps/%s/outgoing-webhooks/%s", appSlug, webhookSlug), nil)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		httpresponse.BodyCloseWithErrorLog(resp)
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			return errors.Errorf("Failed to unregister webhook: status: %d", resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+func (a *API) listOutgoingWebhooks(authToken, appSlug, next string) (*outgoingWebhookListResponseModel, error) {
+	path := fmt.Sprintf("/apps/%s/outgoing-webhooks", appSlug)
+	if next != "" {
+		path = fmt.Sprintf("%s?next=%s", path, next)
+	}
+	resp, err := a.doRequest(authToken, "GET", path, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer httpresponse.BodyCloseWithErrorLog(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Failed to fetch outgoing webhooks: status: %d", resp.StatusCode)
+	}
+	var responseModel outgoingWebhookListResponseModel
+	if err := json.NewDecoder(resp.Body).Decode(&responseModel); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &responseModel, nil
+}
+
 func (a *API) listArtifacts(authToken, appSlug, buildSlug, next string) (*artifactListResponseModel, error) {
 	path := fmt.Sprintf("/apps/%s/builds/%s/artifacts", appSlug, buildSlug)
 	if next != "" {
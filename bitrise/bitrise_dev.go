@@ -1,6 +1,7 @@
 package bitrise
 
 import (
+	"context"
 	"time"
 
 	"github.com/bitrise-io/go-utils/pointers"
@@ -9,6 +10,11 @@ import (
 // APIDev ...
 type APIDev struct{}
 
+// WithContext ...
+func (a *APIDev) WithContext(ctx context.Context) APIInterface {
+	return a
+}
+
 // GetArtifactData ...
 func (a *APIDev) GetArtifactData(authToken, appSlug, buildSlug string) (*ArtifactData, error) {
 	now := time.Now()
@@ -187,3 +193,8 @@ func (a *APIDev) TriggerDENTask(params TaskParams) (*TriggerResponse, error) {
 func (a *APIDev) RegisterWebhook(authToken, appSlug, secret, callbackURL string) error {
 	return nil
 }
+
+// UnregisterWebhook ...
+func (a *APIDev) UnregisterWebhook(authToken, appSlug, callbackURL string) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise/bitrisetest"
+)
+
+func main() {
+	fixturesPath := os.Getenv("FIXTURES_PATH")
+	if fixturesPath == "" {
+		fixturesPath = "fixtures.json"
+	}
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "3004"
+	}
+
+	fixturesFile, err := ioutil.ReadFile(fixturesPath)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %s", fixturesPath, err)
+	}
+	var fixtures bitrisetest.Fixtures
+	if err := json.Unmarshal(fixturesFile, &fixtures); err != nil {
+		log.Fatalf("Failed to parse %s: %s", fixturesPath, err)
+	}
+
+	fakeAPI := bitrisetest.NewFakeAPI(fixtures.Apps...)
+	fakeAPI.DENSecretHeaderKey = os.Getenv("BITRISE_DEN_SERVER_ADMIN_SECRET_HEADER_KEY")
+	fakeAPI.DENSecret = os.Getenv("BITRISE_DEN_SERVER_ADMIN_SECRET")
+
+	log.Printf("Serving fake Bitrise API on port %s for apps: %v", port, fakeAPI.AppSlugs())
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL)
+		fakeAPI.ServeHTTP(w, r)
+	})
+	if err := http.ListenAndServe(":"+port, handler); err != nil {
+		log.Fatalf("Failed to start the server: %s", err)
+	}
+}
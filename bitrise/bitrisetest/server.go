@@ -0,0 +1,478 @@
+// Package bitrisetest provides an in-process fake of the Bitrise API and the DEN task API endpoints
+// Ship calls, backed by an editable fixture store.
+package bitrisetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+)
+
+// DefaultPageSize is the number of items listed on a page, like on the Bitrise API
+const DefaultPageSize = 50
+
+// App is a Bitrise app with everything Ship reads of it
+type App struct {
+	Slug                  string                        `json:"slug"`
+	APIToken              string                        `json:"api_token"`
+	Details               bitrise.AppDetails            `json:"details"`
+	Builds                map[string]Build              `json:"builds"`
+	ProvisioningProfiles  []bitrise.ProvisioningProfile `json:"provisioning_profiles"`
+	CodeSigningIdentities []bitrise.CodeSigningIdentity `json:"code_signing_identities"`
+	AndroidKeystoreFiles  []bitrise.AndroidKeystoreFile `json:"android_keystore_files"`
+	GenericProjectFiles   []bitrise.GenericProjectFile  `json:"generic_project_files"`
+	OutgoingWebhooks      []OutgoingWebhook             `json:"outgoing_webhooks"`
+}
+
+// Build is a build of an app with its artifacts
+type Build struct {
+	Details   bitrise.BuildDetails                    `json:"details"`
+	Artifacts []bitrise.ArtifactShowResponseItemModel `json:"artifacts"`
+}
+
+// OutgoingWebhook is a webhook registered for an app
+type OutgoingWebhook struct {
+	Slug   string   `json:"slug"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+// Fixtures is the content of a fixtures file
+type Fixtures struct {
+	Apps []App `json:"apps"`
+}
+
+type failure struct {
+	method     string
+	path       string
+	statusCode int
+	remaining  int
+}
+
+// FakeAPI serves the Bitrise and DEN API endpoints from its fixture store. It's safe to edit the
+// fixtures while requests are served.
+type FakeAPI struct {
+	// PageSize is the number of items returned on a page of the paginated lists
+	PageSize int
+	// DENSecretHeaderKey and DENSecret are required on the DEN requests when the key is set
+	DENSecretHeaderKey string
+	DENSecret          string
+
+	mu       sync.Mutex
+	apps     map[string]App
+	failures []*failure
+	tasks    []bitrise.TaskParams
+}
+
+// NewFakeAPI ...
+func NewFakeAPI(apps ...App) *FakeAPI {
+	f := &FakeAPI{PageSize: DefaultPageSize, apps: map[string]App{}}
+	for _, app := range apps {
+		f.AddApp(app)
+	}
+	return f
+}
+
+// AddApp adds the app to the fixtures or replaces the one with the same slug
+func (f *FakeAPI) AddApp(app App) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if app.Builds == nil {
+		app.Builds = map[string]Build{}
+	}
+	f.apps[app.Slug] = app
+}
+
+// App returns a copy of the app with the given slug
+func (f *FakeAPI) App(appSlug string) (App, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	app, ok := f.apps[appSlug]
+	return app, ok
+}
+
+// AppSlugs returns the slugs of the apps in the fixtures, in order
+func (f *FakeAPI) AppSlugs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	slugs := []string{}
+	for slug := range f.apps {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// UpdateApp calls the function with the app of the given slug and stores the changes it makes
+func (f *FakeAPI) UpdateApp(appSlug string, update func(app *App)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	app, ok := f.apps[appSlug]
+	if !ok {
+		return errors.Errorf("No app found with slug %s", appSlug)
+	}
+	update(&app)
+	f.apps[appSlug] = app
+	return nil
+}
+
+// AddBuild adds the build to the app or replaces the one with the same slug
+func (f *FakeAPI) AddBuild(appSlug, buildSlug string, build Build) error {
+	return f.UpdateApp(appSlug, func(app *App) {
+		builds := map[string]Build{}
+		for slug, b := range app.Builds {
+			builds[slug] = b
+		}
+		builds[buildSlug] = build
+		app.Builds = builds
+	})
+}
+
+// FailNext makes the next count requests of the given method and path respond with the status code.
+// The path is relative to the API version, e.g. /apps/my-app/builds/my-build.
+func (f *FakeAPI) FailNext(method, path string, statusCode, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, &failure{
+		method:     method,
+		path:       normalizePath(path),
+		statusCode: statusCode,
+		remaining:  count,
+	})
+}
+
+// TriggeredTasks returns the parameters of the DEN tasks triggered so far
+func (f *FakeAPI) TriggeredTasks() []bitrise.TaskParams {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]bitrise.TaskParams{}, f.tasks...)
+}
+
+// ServeHTTP ...
+func (f *FakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := normalizePath(r.URL.Path)
+	if statusCode, ok := f.takeFailure(r.Method, path); ok {
+		respondWithMessage(w, statusCode, http.StatusText(statusCode))
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case len(segments) == 2 && segments[0] == "bitrise-den" && segments[1] == "tasks":
+		f.handleDENTask(w, r)
+	case len(segments) >= 2 && segments[0] == "apps":
+		f.handleApp(w, r, segments[1], segments[2:])
+	default:
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (f *FakeAPI) takeFailure(method, path string) (int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, fail := range f.failures {
+		if fail.method != method || fail.path != path {
+			continue
+		}
+		fail.remaining--
+		if fail.remaining <= 0 {
+			f.failures = append(f.failures[:i], f.failures[i+1:]...)
+		}
+		return fail.statusCode, true
+	}
+	return 0, false
+}
+
+func (f *FakeAPI) handleDENTask(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithMessage(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	if f.DENSecretHeaderKey != "" && r.Header.Get(f.DENSecretHeaderKey) != f.DENSecret {
+		respondWithMessage(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var params bitrise.TaskParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		respondWithMessage(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	f.mu.Lock()
+	f.tasks = append(f.tasks, params)
+	f.mu.Unlock()
+
+	now := time.Now()
+	respondWithJSON(w, http.StatusOK, bitrise.TriggerResponse{
+		ConfigType:     "bitrise",
+		CreatedAt:      now,
+		TaskIdentifier: uuid.NewV4(),
+		UpdatedAt:      now,
+		WebhookURL:     params.WebhookURL,
+	})
+}
+
+func (f *FakeAPI) handleApp(w http.ResponseWriter, r *http.Request, appSlug string, segments []string) {
+	app, ok := f.App(appSlug)
+	if !ok {
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if r.Header.Get("Bitrise-Addon-Auth-Token") != app.APIToken {
+		respondWithMessage(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if len(segments) == 0 {
+		if r.Method != http.MethodGet {
+			respondWithMessage(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"data": app.Details})
+		return
+	}
+
+	switch segments[0] {
+	case "builds":
+		f.handleBuild(w, r, app, segments[1:])
+	case "outgoing-webhooks":
+		f.handleOutgoingWebhooks(w, r, app, segments[1:])
+	case "provisioning-profiles":
+		respondWithListOrItem(w, r, segments[1:], len(app.ProvisioningProfiles), func(i int) (string, interface{}) {
+			return app.ProvisioningProfiles[i].Slug, app.ProvisioningProfiles[i]
+		})
+	case "build-certificates":
+		respondWithListOrItem(w, r, segments[1:], len(app.CodeSigningIdentities), func(i int) (string, interface{}) {
+			return app.CodeSigningIdentities[i].Slug, app.CodeSigningIdentities[i]
+		})
+	case "android-keystore-files":
+		respondWithListOrItem(w, r, segments[1:], len(app.AndroidKeystoreFiles), func(i int) (string, interface{}) {
+			return app.AndroidKeystoreFiles[i].Slug, app.AndroidKeystoreFiles[i]
+		})
+	case "generic-project-files":
+		if len(segments) == 1 {
+			respondWithListOrItem(w, r, segments[1:], len(app.GenericProjectFiles), func(i int) (string, interface{}) {
+				return app.GenericProjectFiles[i].Slug, app.GenericProjectFiles[i]
+			})
+			return
+		}
+		// on the Bitrise API the keystore files are generic project files too, and Ship fetches
+		// them one by one through this endpoint
+		files := []interface{}{}
+		slugs := []string{}
+		for _, file := range app.GenericProjectFiles {
+			files, slugs = append(files, file), append(slugs, file.Slug)
+		}
+		for _, file := range app.AndroidKeystoreFiles {
+			files, slugs = append(files, file), append(slugs, file.Slug)
+		}
+		respondWithListOrItem(w, r, segments[1:], len(files), func(i int) (string, interface{}) {
+			return slugs[i], files[i]
+		})
+	default:
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (f *FakeAPI) handleBuild(w http.ResponseWriter, r *http.Request, app App, segments []string) {
+	if len(segments) == 0 || r.Method != http.MethodGet {
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	build, ok := app.Builds[segments[0]]
+	if !ok {
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	switch {
+	case len(segments) == 1:
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"data": build.Details})
+	case len(segments) == 2 && segments[1] == "artifacts":
+		items := make([]interface{}, len(build.Artifacts))
+		slugs := make([]string, len(build.Artifacts))
+		for i, artifact := range build.Artifacts {
+			listElement, err := artifactListElement(artifact)
+			if err != nil {
+				respondWithMessage(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			items[i], slugs[i] = listElement, artifact.Slug
+		}
+		f.respondWithPage(w, r, items, slugs)
+	case len(segments) == 3 && segments[1] == "artifacts":
+		for _, artifact := range build.Artifacts {
+			if artifact.Slug == segments[2] {
+				respondWithJSON(w, http.StatusOK, map[string]interface{}{"data": artifact})
+				return
+			}
+		}
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+	default:
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (f *FakeAPI) handleOutgoingWebhooks(w http.ResponseWriter, r *http.Request, app App, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		items := make([]interface{}, len(app.OutgoingWebhooks))
+		slugs := make([]string, len(app.OutgoingWebhooks))
+		for i, webhook := range app.OutgoingWebhooks {
+			items[i], slugs[i] = webhook, webhook.Slug
+		}
+		f.respondWithPage(w, r, items, slugs)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var webhook OutgoingWebhook
+		if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil || webhook.URL == "" {
+			respondWithMessage(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		webhook.Slug = uuid.NewV4().String()
+		if err := f.UpdateApp(app.Slug, func(app *App) {
+			app.OutgoingWebhooks = append(append([]OutgoingWebhook{}, app.OutgoingWebhooks...), webhook)
+		}); err != nil {
+			respondWithMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		respondWithJSON(w, http.StatusCreated, map[string]interface{}{"data": webhook})
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		deleted := false
+		if err := f.UpdateApp(app.Slug, func(app *App) {
+			webhooks := []OutgoingWebhook{}
+			for _, webhook := range app.OutgoingWebhooks {
+				if webhook.Slug == segments[0] {
+					deleted = true
+					continue
+				}
+				webhooks = append(webhooks, webhook)
+			}
+			app.OutgoingWebhooks = webhooks
+		}); err != nil || !deleted {
+			respondWithMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		respondWithMessage(w, http.StatusOK, "ok")
+	default:
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// respondWithPage responds with the page of the items starting at the slug given in the next query
+// parameter
+func (f *FakeAPI) respondWithPage(w http.ResponseWriter, r *http.Request, items []interface{}, slugs []string) {
+	pageSize := f.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	start := 0
+	if next := r.URL.Query().Get("next"); next != "" {
+		start = -1
+		for i, slug := range slugs {
+			if slug == next {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			respondWithMessage(w, http.StatusBadRequest, "Invalid next parameter")
+			return
+		}
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	paging := map[string]interface{}{
+		"total_item_count": len(items),
+		"page_item_limit":  pageSize,
+	}
+	if end < len(items) {
+		paging["next"] = slugs[end]
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   items[start:end],
+		"paging": paging,
+	})
+}
+
+// respondWithListOrItem responds with all the items, or with the one of the slug if it's given
+func respondWithListOrItem(w http.ResponseWriter, r *http.Request, segments []string, count int, item func(int) (string, interface{})) {
+	if r.Method != http.MethodGet || len(segments) > 1 {
+		respondWithMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if len(segments) == 0 {
+		items := make([]interface{}, count)
+		for i := range items {
+			_, items[i] = item(i)
+		}
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"data": items})
+		return
+	}
+	for i := 0; i < count; i++ {
+		if slug, data := item(i); slug == segments[0] {
+			respondWithJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+			return
+		}
+	}
+	respondWithMessage(w, http.StatusNotFound, "Not Found")
+}
+
+// artifactListElement is the artifact as it's listed, without its download URLs
+func artifactListElement(artifact bitrise.ArtifactShowResponseItemModel) (bitrise.ArtifactListElementResponseModel, error) {
+	artifactBytes, err := json.Marshal(artifact)
+	if err != nil {
+		return bitrise.ArtifactListElementResponseModel{}, errors.WithStack(err)
+	}
+	var listElement bitrise.ArtifactListElementResponseModel
+	if err := json.Unmarshal(artifactBytes, &listElement); err != nil {
+		return bitrise.ArtifactListElementResponseModel{}, errors.WithStack(err)
+	}
+	return listElement, nil
+}
+
+// normalizePath drops the API version prefix and the duplicated slashes of the path
+func normalizePath(path string) string {
+	segments := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) > 0 && segments[0] == "v0.1" {
+		segments = segments[1:]
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func respondWithJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func respondWithMessage(w http.ResponseWriter, statusCode int, message string) {
+	respondWithJSON(w, statusCode, map[string]string{"message": message})
+}
+
+// Server is the fake API listening on a local port, its URL can be used as BITRISE_API_ROOT_URL
+type Server struct {
+	*httptest.Server
+	*FakeAPI
+}
+
+// NewServer starts a fake API server with the given apps, it has to be closed by the caller
+func NewServer(apps ...App) *Server {
+	fakeAPI := NewFakeAPI(apps...)
+	return &Server{Server: httptest.NewServer(fakeAPI), FakeAPI: fakeAPI}
+}
@@ -1,8 +1,29 @@
 package bitrise
 
+const (
+	// BuildStatusNotFinished ...
+	BuildStatusNotFinished = 0
+	// BuildStatusSuccessful ...
+	BuildStatusSuccessful = 1
+	// BuildStatusFailed ...
+	BuildStatusFailed = 2
+	// BuildStatusAbortedWithFailure ...
+	BuildStatusAbortedWithFailure = 3
+	// BuildStatusAbortedWithSuccess ...
+	BuildStatusAbortedWithSuccess = 4
+)
+
 // BuildDetails ...
 type BuildDetails struct {
-	CommitMessage string `json:"commit_message"`
+	BuildNumber       int    `json:"build_number"`
+	Status            int    `json:"status"`
+	CommitMessage     string `json:"commit_message"`
+	CommitHash        string `json:"commit_hash"`
+	Branch            string `json:"branch"`
+	Tag               string `json:"tag"`
+	PullRequestID     int    `json:"pull_request_id"`
+	TriggeredWorkflow string `json:"triggered_workflow"`
+	TriggeredBy       string `json:"triggered_by"`
 }
 
 type buildShowResponseModel struct {
@@ -0,0 +1,432 @@
+package bitrise
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/redis"
+	redigo "github.com/gomodule/redigo/redis"
+)
+
+const (
+	cacheKeyPrefix = "bitrise_api"
+	// expiringURLSafetyMargin is cut from the validity of the expiring URLs of a cached response, so
+	// that the URLs are still usable when the response is served at the end of its TTL
+	expiringURLSafetyMargin = time.Minute
+)
+
+// cacheTTLs are the durations for which the responses of the API methods are cached
+var cacheTTLs = map[string]time.Duration{
+	"GetAppDetails":                   5 * time.Minute,
+	"GetBuildDetails":                 time.Hour,
+	"GetArtifactData":                 10 * time.Minute,
+	"GetArtifacts":                    10 * time.Minute,
+	"GetArtifact":                     10 * time.Minute,
+	"GetArtifactPublicInstallPageURL": 10 * time.Minute,
+	"GetProvisioningProfiles":         5 * time.Minute,
+	"GetProvisioningProfile":          5 * time.Minute,
+	"GetCodeSigningIdentities":        5 * time.Minute,
+	"GetCodeSigningIdentity":          5 * time.Minute,
+	"GetAndroidKeystoreFiles":         5 * time.Minute,
+	"GetAndroidKeystoreFile":          5 * time.Minute,
+	"GetServiceAccountFiles":          5 * time.Minute,
+	"GetServiceAccountFile":           5 * time.Minute,
+}
+
+// CacheInvalidator is implemented by the API clients which cache the responses of the Bitrise API
+type CacheInvalidator interface {
+	InvalidateApp(appSlug string) error
+}
+
+// CachedAPI is an APIInterface which caches the reads of the wrapped API in Redis. The reads are
+// served from the API directly whenever Redis is unavailable.
+type CachedAPI struct {
+	api   APIInterface
+	redis redis.Interface
+}
+
+// NewCachedAPI ...
+func NewCachedAPI(api APIInterface, redisClient redis.Interface) *CachedAPI {
+	return &CachedAPI{api: api, redis: redisClient}
+}
+
+// WithContext ...
+func (c *CachedAPI) WithContext(ctx context.Context) APIInterface {
+	return &CachedAPI{api: c.api.WithContext(ctx), redis: c.redis}
+}
+
+// InvalidateApp drops every cached response of the app, by moving it to a new cache generation
+func (c *CachedAPI) InvalidateApp(appSlug string) error {
+	return c.redis.SetEX(generationKey(appSlug), time.Now().UnixNano(), int(maxCacheTTL().Seconds()))
+}
+
+// GetArtifactData ...
+func (c *CachedAPI) GetArtifactData(authToken, appSlug, buildSlug string) (*ArtifactData, error) {
+	key := c.cacheKey("GetArtifactData", authToken, appSlug, buildSlug)
+	var artifactData *ArtifactData
+	if c.get(key, &artifactData) {
+		return artifactData, nil
+	}
+	artifactData, err := c.api.GetArtifactData(authToken, appSlug, buildSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, artifactData)
+	return artifactData, nil
+}
+
+// GetArtifacts ...
+func (c *CachedAPI) GetArtifacts(authToken, appSlug, buildSlug string) ([]ArtifactListElementResponseModel, error) {
+	key := c.cacheKey("GetArtifacts", authToken, appSlug, buildSlug)
+	var artifacts []ArtifactListElementResponseModel
+	if c.get(key, &artifacts) {
+		return artifacts, nil
+	}
+	artifacts, err := c.api.GetArtifacts(authToken, appSlug, buildSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, artifacts)
+	return artifacts, nil
+}
+
+// GetArtifact ...
+func (c *CachedAPI) GetArtifact(authToken, appSlug, buildSlug, artifactSlug string) (*ArtifactShowResponseItemModel, error) {
+	key := c.cacheKey("GetArtifact", authToken, appSlug, buildSlug, artifactSlug)
+	var artifact *ArtifactShowResponseItemModel
+	if c.get(key, &artifact) {
+		return artifact, nil
+	}
+	artifact, err := c.api.GetArtifact(authToken, appSlug, buildSlug, artifactSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, artifact)
+	return artifact, nil
+}
+
+// GetArtifactPublicInstallPageURL ...
+func (c *CachedAPI) GetArtifactPublicInstallPageURL(authToken, appSlug, buildSlug, artifactSlug string) (string, error) {
+	key := c.cacheKey("GetArtifactPublicInstallPageURL", authToken, appSlug, buildSlug, artifactSlug)
+	var publicInstallPageURL string
+	if c.get(key, &publicInstallPageURL) {
+		return publicInstallPageURL, nil
+	}
+	publicInstallPageURL, err := c.api.GetArtifactPublicInstallPageURL(authToken, appSlug, buildSlug, artifactSlug)
+	if err != nil {
+		return "", err
+	}
+	c.set(key, publicInstallPageURL)
+	return publicInstallPageURL, nil
+}
+
+// GetAppDetails ...
+func (c *CachedAPI) GetAppDetails(authToken, appSlug string) (*AppDetails, error) {
+	key := c.cacheKey("GetAppDetails", authToken, appSlug)
+	var appDetails *AppDetails
+	if c.get(key, &appDetails) {
+		return appDetails, nil
+	}
+	appDetails, err := c.api.GetAppDetails(authToken, appSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, appDetails)
+	return appDetails, nil
+}
+
+// GetBuildDetails caches the details of the finished builds only, as the running ones still change
+func (c *CachedAPI) GetBuildDetails(authToken, appSlug, buildSlug string) (*BuildDetails, error) {
+	key := c.cacheKey("GetBuildDetails", authToken, appSlug, buildSlug)
+	var buildDetails *BuildDetails
+	if c.get(key, &buildDetails) {
+		return buildDetails, nil
+	}
+	buildDetails, err := c.api.GetBuildDetails(authToken, appSlug, buildSlug)
+	if err != nil {
+		return nil, err
+	}
+	if buildDetails.Status != BuildStatusNotFinished {
+		c.set(key, buildDetails)
+	}
+	return buildDetails, nil
+}
+
+// GetProvisioningProfiles ...
+func (c *CachedAPI) GetProvisioningProfiles(authToken, appSlug string) ([]ProvisioningProfile, error) {
+	key := c.cacheKey("GetProvisioningProfiles", authToken, appSlug)
+	var provisioningProfiles []ProvisioningProfile
+	if c.get(key, &provisioningProfiles) {
+		return provisioningProfiles, nil
+	}
+	provisioningProfiles, err := c.api.GetProvisioningProfiles(authToken, appSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, provisioningProfiles)
+	return provisioningProfiles, nil
+}
+
+// GetProvisioningProfile ...
+func (c *CachedAPI) GetProvisioningProfile(authToken, appSlug, provProfileSlug string) (*ProvisioningProfile, error) {
+	key := c.cacheKey("GetProvisioningProfile", authToken, appSlug, provProfileSlug)
+	var provisioningProfile *ProvisioningProfile
+	if c.get(key, &provisioningProfile) {
+		return provisioningProfile, nil
+	}
+	provisioningProfile, err := c.api.GetProvisioningProfile(authToken, appSlug, provProfileSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, provisioningProfile)
+	return provisioningProfile, nil
+}
+
+// GetCodeSigningIdentities ...
+func (c *CachedAPI) GetCodeSigningIdentities(authToken, appSlug string) ([]CodeSigningIdentity, error) {
+	key := c.cacheKey("GetCodeSigningIdentities", authToken, appSlug)
+	var codeSigningIdentities []CodeSigningIdentity
+	if c.get(key, &codeSigningIdentities) {
+		return codeSigningIdentities, nil
+	}
+	codeSigningIdentities, err := c.api.GetCodeSigningIdentities(authToken, appSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, codeSigningIdentities)
+	return codeSigningIdentities, nil
+}
+
+// GetCodeSigningIdentity ...
+func (c *CachedAPI) GetCodeSigningIdentity(authToken, appSlug, codeSigningSlug string) (*CodeSigningIdentity, error) {
+	key := c.cacheKey("GetCodeSigningIdentity", authToken, appSlug, codeSigningSlug)
+	var codeSigningIdentity *CodeSigningIdentity
+	if c.get(key, &codeSigningIdentity) {
+		return codeSigningIdentity, nil
+	}
+	codeSigningIdentity, err := c.api.GetCodeSigningIdentity(authToken, appSlug, codeSigningSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, codeSigningIdentity)
+	return codeSigningIdentity, nil
+}
+
+// GetAndroidKeystoreFiles ...
+func (c *CachedAPI) GetAndroidKeystoreFiles(authToken, appSlug string) ([]AndroidKeystoreFile, error) {
+	key := c.cacheKey("GetAndroidKeystoreFiles", authToken, appSlug)
+	var androidKeystoreFiles []AndroidKeystoreFile
+	if c.get(key, &androidKeystoreFiles) {
+		return androidKeystoreFiles, nil
+	}
+	androidKeystoreFiles, err := c.api.GetAndroidKeystoreFiles(authToken, appSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, androidKeystoreFiles)
+	return androidKeystoreFiles, nil
+}
+
+// GetAndroidKeystoreFile ...
+func (c *CachedAPI) GetAndroidKeystoreFile(authToken, appSlug, keystoreSlug string) (*AndroidKeystoreFile, error) {
+	key := c.cacheKey("GetAndroidKeystoreFile", authToken, appSlug, keystoreSlug)
+	var androidKeystoreFile *AndroidKeystoreFile
+	if c.get(key, &androidKeystoreFile) {
+		return androidKeystoreFile, nil
+	}
+	androidKeystoreFile, err := c.api.GetAndroidKeystoreFile(authToken, appSlug, keystoreSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, androidKeystoreFile)
+	return androidKeystoreFile, nil
+}
+
+// GetServiceAccountFiles ...
+func (c *CachedAPI) GetServiceAccountFiles(authToken, appSlug string) ([]GenericProjectFile, error) {
+	key := c.cacheKey("GetServiceAccountFiles", authToken, appSlug)
+	var serviceAccountFiles []GenericProjectFile
+	if c.get(key, &serviceAccountFiles) {
+		return serviceAccountFiles, nil
+	}
+	serviceAccountFiles, err := c.api.GetServiceAccountFiles(authToken, appSlug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, serviceAccountFiles)
+	return serviceAccountFiles, nil
+}
+
+// GetServiceAccountFile ...
+func (c *CachedAPI) GetServiceAccountFile(authToken, appSlug, serviceJSONSLug string) (*GenericProjectFile, error) {
+	key := c.cacheKey("GetServiceAccountFile", authToken, appSlug, serviceJSONSLug)
+	var serviceAccountFile *GenericProjectFile
+	if c.get(key, &serviceAccountFile) {
+		return serviceAccountFile, nil
+	}
+	serviceAccountFile, err := c.api.GetServiceAccountFile(authToken, appSlug, serviceJSONSLug)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, serviceAccountFile)
+	return serviceAccountFile, nil
+}
+
+// TriggerDENTask ...
+func (c *CachedAPI) TriggerDENTask(params TaskParams) (*TriggerResponse, error) {
+	return c.api.TriggerDENTask(params)
+}
+
+// RegisterWebhook ...
+func (c *CachedAPI) RegisterWebhook(authToken, appSlug, secret, callbackURL string) error {
+	return c.api.RegisterWebhook(authToken, appSlug, secret, callbackURL)
+}
+
+// UnregisterWebhook ...
+func (c *CachedAPI) UnregisterWebhook(authToken, appSlug, callbackURL string) error {
+	return c.api.UnregisterWebhook(authToken, appSlug, callbackURL)
+}
+
+// cacheEntry is the key and the TTL of a cached response, the caching is skipped for an empty key
+type cacheEntry struct {
+	key string
+	ttl time.Duration
+}
+
+// cacheKey identifies the response by the current cache generation of the app and the auth token,
+// so a response is never served with a token other than the one it was fetched with
+func (c *CachedAPI) cacheKey(method, authToken, appSlug string, args ...string) cacheEntry {
+	generation, err := c.redis.GetInt64(generationKey(appSlug))
+	if err != nil && err != redigo.ErrNil {
+		return cacheEntry{}
+	}
+	tokenHash := sha256.Sum256([]byte(authToken))
+	key := strings.Join(append([]string{
+		cacheKeyPrefix, appSlug, strconv.FormatInt(generation, 10), hex.EncodeToString(tokenHash[:8]), method,
+	}, args...), ":")
+	return cacheEntry{key: key, ttl: cacheTTLs[method]}
+}
+
+func (c *CachedAPI) get(entry cacheEntry, target interface{}) bool {
+	if entry.key == "" {
+		return false
+	}
+	value, err := c.redis.GetString(entry.key)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal([]byte(value), target) == nil
+}
+
+// set caches the value, for a shorter time than its TTL if it holds an expiring URL which isn't
+// valid for that long
+func (c *CachedAPI) set(entry cacheEntry, value interface{}) {
+	if entry.key == "" {
+		return
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	ttl := entry.ttl
+	if validity, ok := expiringURLValidity(valueBytes, time.Now()); ok && validity-expiringURLSafetyMargin < ttl {
+		ttl = validity - expiringURLSafetyMargin
+	}
+	if ttl < time.Second {
+		return
+	}
+	_ = c.redis.SetEX(entry.key, string(valueBytes), int(ttl.Seconds()))
+}
+
+func generationKey(appSlug string) string {
+	return fmt.Sprintf("%s:%s:generation", cacheKeyPrefix, appSlug)
+}
+
+func maxCacheTTL() time.Duration {
+	var maxTTL time.Duration
+	for _, ttl := range cacheTTLs {
+		if ttl > maxTTL {
+			maxTTL = ttl
+		}
+	}
+	return maxTTL
+}
+
+// expiringURLValidity returns how long the soonest expiring presigned URL of the JSON document is valid
+func expiringURLValidity(document []byte, now time.Time) (time.Duration, bool) {
+	var value interface{}
+	if err := json.Unmarshal(document, &value); err != nil {
+		return 0, false
+	}
+	var validity time.Duration
+	found := false
+	walkJSONStrings(value, func(str string) {
+		expiry, ok := urlExpiry(str)
+		if !ok {
+			return
+		}
+		if urlValidity := expiry.Sub(now); !found || urlValidity < validity {
+			validity = urlValidity
+			found = true
+		}
+	})
+	return validity, found
+}
+
+func walkJSONStrings(value interface{}, fn func(string)) {
+	switch typedValue := value.(type) {
+	case string:
+		fn(typedValue)
+	case []interface{}:
+		for _, element := range typedValue {
+			walkJSONStrings(element, fn)
+		}
+	case map[string]interface{}:
+		for _, element := range typedValue {
+			walkJSONStrings(element, fn)
+		}
+	}
+}
+
+// urlExpiry returns the expiry of the AWS or Google Cloud Storage presigned URL
+func urlExpiry(str string) (time.Time, bool) {
+	if !strings.HasPrefix(str, "http://") && !strings.HasPrefix(str, "https://") {
+		return time.Time{}, false
+	}
+	parsedURL, err := url.Parse(str)
+	if err != nil {
+		return time.Time{}, false
+	}
+	query := parsedURL.Query()
+
+	for _, provider := range []string{"Amz", "Goog"} {
+		date, expires := query.Get("X-"+provider+"-Date"), query.Get("X-"+provider+"-Expires")
+		if date == "" || expires == "" {
+			continue
+		}
+		signedAt, err := time.Parse("20060102T150405Z", date)
+		if err != nil {
+			return time.Time{}, true
+		}
+		seconds, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return signedAt, true
+		}
+		return signedAt.Add(time.Duration(seconds) * time.Second), true
+	}
+	if expires := query.Get("Expires"); expires != "" {
+		timestamp, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return time.Time{}, true
+		}
+		return time.Unix(timestamp, 0), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,159 @@
+package bitrise_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/redis"
+	redigo "github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedis(store map[string]string, ttls map[string]int) *redis.Mock {
+	return &redis.Mock{
+		GetStringFn: func(key string) (string, error) {
+			value, ok := store[key]
+			if !ok {
+				return "", redigo.ErrNil
+			}
+			return value, nil
+		},
+		GetInt64Fn: func(key string) (int64, error) {
+			value, ok := store[key]
+			if !ok {
+				return 0, redigo.ErrNil
+			}
+			return strconv.ParseInt(value, 10, 64)
+		},
+		SetEXFn: func(key string, value interface{}, ttl int) error {
+			store[key] = fmt.Sprint(value)
+			ttls[key] = ttl
+			return nil
+		},
+	}
+}
+
+func Test_CachedAPI(t *testing.T) {
+	t.Run("serves the app details from the cache", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			_, err := w.Write([]byte(`{"data":{"title":"My App","project_type":"ios"}}`))
+			require.NoError(t, err)
+		}, map[string]string{})
+		defer cleanup()
+		store, ttls := map[string]string{}, map[string]int{}
+		cachedAPI := bitrise.NewCachedAPI(api, newTestRedis(store, ttls))
+
+		for i := 0; i < 2; i++ {
+			appDetails, err := cachedAPI.GetAppDetails("auth-token", "test-app-slug")
+			require.NoError(t, err)
+			require.Equal(t, "My App", appDetails.Title)
+		}
+		require.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+		require.Len(t, ttls, 1)
+		for _, ttl := range ttls {
+			require.Equal(t, 300, ttl)
+		}
+
+		_, err := cachedAPI.GetAppDetails("other-auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("refetches the responses of an invalidated app", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			_, err := w.Write([]byte(`{"data":[]}`))
+			require.NoError(t, err)
+		}, map[string]string{})
+		defer cleanup()
+		cachedAPI := bitrise.NewCachedAPI(api, newTestRedis(map[string]string{}, map[string]int{}))
+
+		_, err := cachedAPI.GetProvisioningProfiles("auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.NoError(t, cachedAPI.InvalidateApp("other-app-slug"))
+		_, err = cachedAPI.GetProvisioningProfiles("auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+
+		require.NoError(t, cachedAPI.InvalidateApp("test-app-slug"))
+		_, err = cachedAPI.GetProvisioningProfiles("auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("caches expiring download URLs for their validity only", func(t *testing.T) {
+		signedAt := time.Now().UTC().Format("20060102T150405Z")
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			expires := "300"
+			if r.URL.Path == "/v0.1//apps/test-app-slug/builds/test-build-slug/artifacts/short-lived" {
+				expires = "30"
+			}
+			_, err := w.Write([]byte(fmt.Sprintf(
+				`{"data":{"slug":"artifact-slug","expiring_download_url":"https://bucket.s3.amazonaws.com/app.ipa?X-Amz-Date=%s&X-Amz-Expires=%s"}}`,
+				signedAt, expires,
+			)))
+			require.NoError(t, err)
+		}, map[string]string{})
+		defer cleanup()
+		store, ttls := map[string]string{}, map[string]int{}
+		cachedAPI := bitrise.NewCachedAPI(api, newTestRedis(store, ttls))
+
+		_, err := cachedAPI.GetArtifact("auth-token", "test-app-slug", "test-build-slug", "long-lived")
+		require.NoError(t, err)
+		require.Len(t, ttls, 1)
+		for _, ttl := range ttls {
+			require.True(t, ttl > 230 && ttl <= 240, "unexpected TTL: %d", ttl)
+		}
+
+		_, err = cachedAPI.GetArtifact("auth-token", "test-app-slug", "test-build-slug", "short-lived")
+		require.NoError(t, err)
+		require.Len(t, ttls, 1)
+	})
+
+	t.Run("doesn't cache the details of running builds", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			_, err := w.Write([]byte(`{"data":{"build_number":12,"status":0}}`))
+			require.NoError(t, err)
+		}, map[string]string{})
+		defer cleanup()
+		cachedAPI := bitrise.NewCachedAPI(api, newTestRedis(map[string]string{}, map[string]int{}))
+
+		for i := 0; i < 2; i++ {
+			buildDetails, err := cachedAPI.GetBuildDetails("auth-token", "test-app-slug", "test-build-slug")
+			require.NoError(t, err)
+			require.Equal(t, 12, buildDetails.BuildNumber)
+		}
+		require.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("falls back to the API when Redis is unavailable", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			_, err := w.Write([]byte(`{"data":{"title":"My App"}}`))
+			require.NoError(t, err)
+		}, map[string]string{})
+		defer cleanup()
+		cachedAPI := bitrise.NewCachedAPI(api, &redis.Mock{
+			GetInt64Fn: func(string) (int64, error) {
+				return 0, errors.New("SOME-REDIS-ERROR")
+			},
+		})
+
+		appDetails, err := cachedAPI.GetAppDetails("auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, "My App", appDetails.Title)
+		require.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+	})
+}
@@ -0,0 +1,267 @@
+package bitrise
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrCircuitOpen is returned without calling the Bitrise API while it's considered to be down
+var ErrCircuitOpen = errors.New("Bitrise API circuit breaker is open")
+
+// Metrics are the counters of the Bitrise API calls made by a client and its copies
+type Metrics struct {
+	Requests                 int64
+	Retries                  int64
+	Failures                 int64
+	Timeouts                 int64
+	CircuitBreakerOpens      int64
+	CircuitBreakerRejections int64
+}
+
+// clientMetrics holds the counters of the client, they are kept out of the global expvar registry
+// as that's served publicly together with the API
+type clientMetrics struct {
+	requests                 int64
+	retries                  int64
+	failures                 int64
+	timeouts                 int64
+	circuitBreakerOpens      int64
+	circuitBreakerRejections int64
+}
+
+func (m *clientMetrics) snapshot() Metrics {
+	return Metrics{
+		Requests:                 atomic.LoadInt64(&m.requests),
+		Retries:                  atomic.LoadInt64(&m.retries),
+		Failures:                 atomic.LoadInt64(&m.failures),
+		Timeouts:                 atomic.LoadInt64(&m.timeouts),
+		CircuitBreakerOpens:      atomic.LoadInt64(&m.circuitBreakerOpens),
+		CircuitBreakerRejections: atomic.LoadInt64(&m.circuitBreakerRejections),
+	}
+}
+
+const maxRetryDelay = 10 * time.Second
+
+// clientConfig holds the resilience settings of the API client
+type clientConfig struct {
+	requestTimeout   time.Duration
+	maxRetries       int
+	retryBaseDelay   time.Duration
+	breakerThreshold int
+	breakerCooldown  time.Duration
+}
+
+func clientConfigFromEnv() clientConfig {
+	return clientConfig{
+		requestTimeout:   time.Duration(utils.GetInt64EnvWithDefault("BITRISE_API_REQUEST_TIMEOUT_MS", 10000)) * time.Millisecond,
+		maxRetries:       int(utils.GetInt64EnvWithDefault("BITRISE_API_MAX_RETRIES", 3)),
+		retryBaseDelay:   time.Duration(utils.GetInt64EnvWithDefault("BITRISE_API_RETRY_BASE_DELAY_MS", 200)) * time.Millisecond,
+		breakerThreshold: int(utils.GetInt64EnvWithDefault("BITRISE_API_CIRCUIT_BREAKER_THRESHOLD", 5)),
+		breakerCooldown:  time.Duration(utils.GetInt64EnvWithDefault("BITRISE_API_CIRCUIT_BREAKER_COOLDOWN_MS", 30000)) * time.Millisecond,
+	}
+}
+
+// circuitBreaker opens after a number of consecutive failures, then lets a single probe request
+// through once the cooldown is over; the probe's outcome closes or reopens it
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	metrics *clientMetrics
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, metrics *clientMetrics) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, metrics: metrics}
+}
+
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return nil
+	}
+	if b.probing || time.Now().Sub(b.openedAt) < b.cooldown {
+		atomic.AddInt64(&b.metrics.circuitBreakerRejections, 1)
+		return ErrCircuitOpen
+	}
+	b.probing = true
+	return nil
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.open = false
+	b.probing = false
+}
+
+// recordCanceled lets an other probe through when the probe was canceled by its caller, without
+// counting it either way
+func (b *circuitBreaker) recordCanceled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.probing || (!b.open && b.threshold > 0 && b.failures >= b.threshold) {
+		b.open = true
+		b.openedAt = time.Now()
+		atomic.AddInt64(&b.metrics.circuitBreakerOpens, 1)
+	}
+	b.probing = false
+}
+
+// cancelOnCloseBody releases the deadline of the request once its response is read
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// do sends the request with a deadline on each attempt, retrying the GET requests on connection
+// errors, 429 and 5xx responses. Only those errors and 5xx responses count as failures of the API,
+// requests canceled by their caller don't.
+func (a *API) do(req *http.Request) (*http.Response, error) {
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	retryable := req.Method == http.MethodGet
+
+	for attempt := 0; ; attempt++ {
+		if err := a.breaker.allow(); err != nil {
+			return nil, err
+		}
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			req.Body = body
+		}
+
+		atomic.AddInt64(&a.metrics.requests, 1)
+		attemptCtx, cancel := context.WithCancel(ctx)
+		if a.config.requestTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, a.config.requestTimeout)
+		}
+		resp, err := a.Do(req.WithContext(attemptCtx))
+		switch {
+		case err != nil:
+			cancel()
+			if ctx.Err() != nil {
+				a.breaker.recordCanceled()
+				return nil, errors.WithStack(err)
+			}
+			atomic.AddInt64(&a.metrics.failures, 1)
+			if attemptCtx.Err() == context.DeadlineExceeded {
+				atomic.AddInt64(&a.metrics.timeouts, 1)
+			}
+			a.breaker.recordFailure()
+			if !retryable || attempt >= a.config.maxRetries {
+				return nil, errors.WithStack(err)
+			}
+			if err := sleepWithContext(ctx, retryDelay(a.config.retryBaseDelay, attempt, nil)); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+			atomic.AddInt64(&a.metrics.failures, 1)
+			if resp.StatusCode >= http.StatusInternalServerError {
+				a.breaker.recordFailure()
+			} else {
+				a.breaker.recordSuccess()
+			}
+			if !retryable || attempt >= a.config.maxRetries {
+				resp.Body = cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+				return resp, nil
+			}
+			delay := retryDelay(a.config.retryBaseDelay, attempt, resp)
+			closeResponse(resp)
+			cancel()
+			if err := sleepWithContext(ctx, delay); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		default:
+			a.breaker.recordSuccess()
+			resp.Body = cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		atomic.AddInt64(&a.metrics.retries, 1)
+	}
+}
+
+// retryDelay is the exponential backoff with full jitter, or the delay requested by the Retry-After
+// header of the response
+func retryDelay(baseDelay time.Duration, attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if retryAfter > maxRetryDelay {
+				return maxRetryDelay
+			}
+			return retryAfter
+		}
+	}
+	backoff := baseDelay << uint(attempt)
+	if backoff <= 0 || backoff > maxRetryDelay {
+		backoff = maxRetryDelay
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func closeResponse(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
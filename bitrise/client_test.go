@@ -0,0 +1,180 @@
+package bitrise_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/go-utils/envutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPI(t *testing.T, handler http.HandlerFunc, envs map[string]string) (*bitrise.API, func()) {
+	server := httptest.NewServer(handler)
+	revokeFns := []func() error{}
+	envs["BITRISE_API_ROOT_URL"] = server.URL
+	envs["BITRISE_API_RETRY_BASE_DELAY_MS"] = "1"
+	for key, value := range envs {
+		revokeFn, err := envutil.RevokableSetenv(key, value)
+		require.NoError(t, err)
+		revokeFns = append(revokeFns, revokeFn)
+	}
+	return bitrise.New(), func() {
+		server.Close()
+		for _, revokeFn := range revokeFns {
+			require.NoError(t, revokeFn())
+		}
+	}
+}
+
+func Test_API_Resilience(t *testing.T) {
+	t.Run("retries GET requests on 5xx responses", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&callCount, 1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, err := w.Write([]byte(`{"data":{"project_type":"ios"}}`))
+			require.NoError(t, err)
+		}, map[string]string{})
+		defer cleanup()
+
+		appDetails, err := api.GetAppDetails("auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, "ios", appDetails.ProjectType)
+		require.Equal(t, int32(3), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("retries GET requests on 429 responses after the Retry-After delay", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&callCount, 1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, err := w.Write([]byte(`{"data":{"project_type":"android"}}`))
+			require.NoError(t, err)
+		}, map[string]string{})
+		defer cleanup()
+
+		appDetails, err := api.GetAppDetails("auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, "android", appDetails.ProjectType)
+		require.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("gives up after the max retries", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, map[string]string{"BITRISE_API_MAX_RETRIES": "2"})
+		defer cleanup()
+
+		_, err := api.GetAppDetails("auth-token", "test-app-slug")
+		require.EqualError(t, err, "Failed to fetch app details: status: 503")
+		require.Equal(t, int32(3), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("doesn't retry non-idempotent requests", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		}, map[string]string{})
+		defer cleanup()
+
+		err := api.RegisterWebhook("auth-token", "test-app-slug", "secret", "https://ship.bitrise.io/webhook")
+		require.EqualError(t, err, "Failed to register webhook: status: 502")
+		require.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("times out the slow requests", func(t *testing.T) {
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}, map[string]string{"BITRISE_API_REQUEST_TIMEOUT_MS": "20", "BITRISE_API_MAX_RETRIES": "0"})
+		defer cleanup()
+
+		_, err := api.GetAppDetails("auth-token", "test-app-slug")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context deadline exceeded")
+	})
+
+	t.Run("stops with the context of the call", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+		}, map[string]string{})
+		defer cleanup()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := api.WithContext(ctx).GetAppDetails("auth-token", "test-app-slug")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context canceled")
+		require.Equal(t, int32(0), atomic.LoadInt32(&callCount))
+	})
+
+	t.Run("opens the circuit breaker after consecutive failures", func(t *testing.T) {
+		var callCount int32
+		var healthy int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			if atomic.LoadInt32(&healthy) == 0 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, err := w.Write([]byte(`{"data":{"project_type":"ios"}}`))
+			require.NoError(t, err)
+		}, map[string]string{
+			"BITRISE_API_MAX_RETRIES":                 "0",
+			"BITRISE_API_CIRCUIT_BREAKER_THRESHOLD":   "2",
+			"BITRISE_API_CIRCUIT_BREAKER_COOLDOWN_MS": "50",
+		})
+		defer cleanup()
+
+		for i := 0; i < 2; i++ {
+			_, err := api.GetAppDetails("auth-token", "test-app-slug")
+			require.EqualError(t, err, "Failed to fetch app details: status: 500")
+		}
+		_, err := api.WithContext(context.Background()).GetAppDetails("auth-token", "test-app-slug")
+		require.Equal(t, bitrise.ErrCircuitOpen, errors.Cause(err))
+		require.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+
+		atomic.StoreInt32(&healthy, 1)
+		time.Sleep(60 * time.Millisecond)
+		appDetails, err := api.GetAppDetails("auth-token", "test-app-slug")
+		require.NoError(t, err)
+		require.Equal(t, "ios", appDetails.ProjectType)
+		require.Equal(t, int32(3), atomic.LoadInt32(&callCount))
+		require.Equal(t, bitrise.Metrics{Requests: 3, Failures: 2, CircuitBreakerOpens: 1, CircuitBreakerRejections: 1}, api.Metrics())
+	})
+
+	t.Run("doesn't count the requests canceled by their caller as failures", func(t *testing.T) {
+		var callCount int32
+		api, cleanup := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&callCount, 1)
+			time.Sleep(100 * time.Millisecond)
+		}, map[string]string{
+			"BITRISE_API_MAX_RETRIES":               "0",
+			"BITRISE_API_CIRCUIT_BREAKER_THRESHOLD": "1",
+		})
+		defer cleanup()
+
+		for i := 0; i < 2; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			_, err := api.WithContext(ctx).GetAppDetails("auth-token", "test-app-slug")
+			cancel()
+			require.Error(t, err)
+			require.NotEqual(t, bitrise.ErrCircuitOpen, errors.Cause(err))
+		}
+		require.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+	})
+}
@@ -0,0 +1,11 @@
+package bitrise
+
+type outgoingWebhookResponseModel struct {
+	Slug string `json:"slug"`
+	URL  string `json:"url"`
+}
+
+type outgoingWebhookListResponseModel struct {
+	Data   []outgoingWebhookResponseModel `json:"data"`
+	Paging pagingResponseModel            `json:"paging"`
+}
@@ -0,0 +1,12 @@
+package dataservices
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+// AppPreviewService ...
+type AppPreviewService interface {
+	BatchCreate(appPreviews []*models.AppPreview) ([]*models.AppPreview, []error, error)
+	Find(appPreview *models.AppPreview) (*models.AppPreview, error)
+	FindAll(appVersion *models.AppVersion) ([]models.AppPreview, error)
+	Update(appPreview models.AppPreview, whitelist []string) (validationErrors []error, dbError error)
+	Delete(appPreview *models.AppPreview) error
+}
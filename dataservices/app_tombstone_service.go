@@ -0,0 +1,10 @@
+package dataservices
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+// AppTombstoneService ...
+type AppTombstoneService interface {
+	Bury(app *models.App) (*models.AppTombstone, error)
+	Find(tombstone *models.AppTombstone) (*models.AppTombstone, error)
+	Update(tombstone *models.AppTombstone, whitelist []string) error
+}
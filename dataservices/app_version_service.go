@@ -7,6 +7,8 @@ type AppVersionService interface {
 	Create(*models.AppVersion) (appVersion *models.AppVersion, validationErrors []error, dbErr error)
 	Find(*models.AppVersion) (*models.AppVersion, error)
 	FindAll(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error)
+	FindAllPublished(app *models.App) ([]models.AppVersion, error)
 	Update(appVersion *models.AppVersion, whitelist []string) (validationErrors []error, dbErr error)
-	Latest(appVersion *models.AppVersion) (*models.AppVersion, error)
+	Latest(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error)
+	Delete(appVersion *models.AppVersion) error
 }
@@ -0,0 +1,14 @@
+package dataservices
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AssetService ...
+type AssetService interface {
+	Acquire(asset *models.Asset) (*models.Asset, error)
+	Retain(assetIDs []uuid.UUID) error
+	FindAllReferenced() ([]models.Asset, error)
+	Release(assetID uuid.UUID, deleteObject func(*models.Asset) error) error
+}
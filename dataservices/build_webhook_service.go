@@ -0,0 +1,10 @@
+package dataservices
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+// BuildWebhookService ...
+type BuildWebhookService interface {
+	Create(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error)
+	Find(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error)
+	Update(buildWebhook *models.BuildWebhook, whitelist []string) error
+}
@@ -0,0 +1,10 @@
+package dataservices
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+// JobStatusService ...
+type JobStatusService interface {
+	Create(jobStatus *models.JobStatus) (*models.JobStatus, []error, error)
+	Find(jobStatus *models.JobStatus) (*models.JobStatus, error)
+	Update(jobStatus *models.JobStatus, whitelist []string) (validationErrors []error, dbErr error)
+}
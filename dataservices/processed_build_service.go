@@ -0,0 +1,14 @@
+package dataservices
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProcessedBuildService ...
+type ProcessedBuildService interface {
+	Claim(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error)
+	Update(processedBuild *models.ProcessedBuild, whitelist []string) error
+	ReleaseBuild(appID uuid.UUID, buildSlug string) error
+	Delete(processedBuild *models.ProcessedBuild) error
+}
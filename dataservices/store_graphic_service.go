@@ -0,0 +1,12 @@
+package dataservices
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+// StoreGraphicService ...
+type StoreGraphicService interface {
+	Create(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, []error, error)
+	Find(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error)
+	FindAll(appVersion *models.AppVersion) ([]models.StoreGraphic, error)
+	Update(storeGraphic models.StoreGraphic, whitelist []string) (validationErrors []error, dbError error)
+	Delete(storeGraphic *models.StoreGraphic) error
+}
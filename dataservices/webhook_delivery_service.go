@@ -0,0 +1,14 @@
+package dataservices
+
+import (
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+)
+
+// WebhookDeliveryService ...
+type WebhookDeliveryService interface {
+	Create(webhookDelivery *models.WebhookDelivery) (*models.WebhookDelivery, error)
+	FindAll(app *models.App) ([]models.WebhookDelivery, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
@@ -10,4 +10,13 @@ type WorkerService interface {
 	EnqueueStoreLogToAWS(appVersionEventID, publishTaskExternalID uuid.UUID, numberOfLogChunks int64, awsPath string, secondsFromNow int64) error
 	EnqueueStoreLogChunkToRedis(publishTaskExternalID string, logChunk models.LogChunk, secondsFromNow int64) error
 	EnqueueCopyUploadablesToNewAppVersion(appVersionFromCopyID, appVersionToCopyID string) error
+	EnqueueVerifyUploadedImage(uploadableType string, uploadableID uuid.UUID) error
+	EnqueueStoreUploadedAppPreview(appPreviewID uuid.UUID) error
+	EnqueueResizeScreenshots(appVersionID uuid.UUID) error
+	EnqueueImportScreenshotArchive(jobStatusID uuid.UUID) error
+	EnqueueProcessBuildWebhook(buildWebhookID uuid.UUID) error
+	EnqueueReplayBuildWebhook(buildWebhookID uuid.UUID, replaceVersions bool) error
+	EnqueueCopyFromAppVersion(jobStatusID, sourceAppVersionID uuid.UUID, parts models.AppVersionCopyParts) error
+	EnqueueDeprovisionApp(appTombstoneID uuid.UUID) error
+	EnqueueUpdateHeaderPalette(appID uuid.UUID, avatarURL string) error
 }
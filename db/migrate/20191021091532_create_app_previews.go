@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191021091532, down20191021091532)
+}
+
+func up20191021091532(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE app_previews (
+        id uuid primary key NOT NULL,
+        app_version_id uuid NOT NULL REFERENCES app_versions (id) ON DELETE CASCADE,
+        filename text NOT NULL,
+        filesize bigint,
+        uploaded boolean,
+        device_type text,
+        screen_size text,
+        locale text,
+        upload_id text,
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );`)
+	return err
+}
+
+func down20191021091532(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE app_previews;`)
+	return err
+}
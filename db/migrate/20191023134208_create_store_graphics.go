@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191023134208, down20191023134208)
+}
+
+func up20191023134208(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE store_graphics (
+        id uuid primary key NOT NULL,
+        app_version_id uuid NOT NULL REFERENCES app_versions (id) ON DELETE CASCADE,
+        type text NOT NULL,
+        filename text NOT NULL,
+        filesize integer,
+        uploaded boolean,
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE INDEX store_graphics_app_version_id_type_idx ON store_graphics(app_version_id, type);`)
+	return err
+}
+
+func down20191023134208(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE store_graphics;`)
+	return err
+}
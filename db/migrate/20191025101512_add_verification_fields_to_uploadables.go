@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191025101512, down20191025101512)
+}
+
+func up20191025101512(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE screenshots
+        ADD COLUMN width integer NOT NULL DEFAULT 0,
+        ADD COLUMN height integer NOT NULL DEFAULT 0,
+        ADD COLUMN checksum text NOT NULL DEFAULT '',
+        ADD COLUMN invalid_reason text NOT NULL DEFAULT '';
+    ALTER TABLE feature_graphics
+        ADD COLUMN width integer NOT NULL DEFAULT 0,
+        ADD COLUMN height integer NOT NULL DEFAULT 0,
+        ADD COLUMN checksum text NOT NULL DEFAULT '',
+        ADD COLUMN invalid_reason text NOT NULL DEFAULT '';
+    ALTER TABLE app_previews
+        ADD COLUMN width integer NOT NULL DEFAULT 0,
+        ADD COLUMN height integer NOT NULL DEFAULT 0,
+        ADD COLUMN checksum text NOT NULL DEFAULT '',
+        ADD COLUMN invalid_reason text NOT NULL DEFAULT '';
+    ALTER TABLE store_graphics
+        ADD COLUMN width integer NOT NULL DEFAULT 0,
+        ADD COLUMN height integer NOT NULL DEFAULT 0,
+        ADD COLUMN checksum text NOT NULL DEFAULT '',
+        ADD COLUMN invalid_reason text NOT NULL DEFAULT '';`)
+	return err
+}
+
+func down20191025101512(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE screenshots
+        DROP COLUMN width,
+        DROP COLUMN height,
+        DROP COLUMN checksum,
+        DROP COLUMN invalid_reason;
+    ALTER TABLE feature_graphics
+        DROP COLUMN width,
+        DROP COLUMN height,
+        DROP COLUMN checksum,
+        DROP COLUMN invalid_reason;
+    ALTER TABLE app_previews
+        DROP COLUMN width,
+        DROP COLUMN height,
+        DROP COLUMN checksum,
+        DROP COLUMN invalid_reason;
+    ALTER TABLE store_graphics
+        DROP COLUMN width,
+        DROP COLUMN height,
+        DROP COLUMN checksum,
+        DROP COLUMN invalid_reason;`)
+	return err
+}
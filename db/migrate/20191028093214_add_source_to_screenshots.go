@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191028093214, down20191028093214)
+}
+
+func up20191028093214(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE screenshots
+        ADD COLUMN source_screenshot_id uuid REFERENCES screenshots(id) ON DELETE CASCADE,
+        ADD COLUMN source_checksum text NOT NULL DEFAULT '';
+    CREATE INDEX screenshots_source_screenshot_id_idx ON screenshots(source_screenshot_id);`)
+	return err
+}
+
+func down20191028093214(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP INDEX screenshots_source_screenshot_id_idx;
+    ALTER TABLE screenshots
+        DROP COLUMN source_screenshot_id,
+        DROP COLUMN source_checksum;`)
+	return err
+}
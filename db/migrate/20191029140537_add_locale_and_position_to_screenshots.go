@@ -0,0 +1,31 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191029140537, down20191029140537)
+}
+
+func up20191029140537(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE screenshots
+        ADD COLUMN locale text NOT NULL DEFAULT '',
+        ADD COLUMN position integer NOT NULL DEFAULT 0;
+    UPDATE screenshots SET position = ordered.position FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY app_version_id, device_type, screen_size ORDER BY created_at) AS position
+        FROM screenshots
+    ) AS ordered WHERE screenshots.id = ordered.id;
+    CREATE INDEX screenshots_app_version_id_position_idx ON screenshots(app_version_id, device_type, screen_size, locale, position);`)
+	return err
+}
+
+func down20191029140537(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP INDEX screenshots_app_version_id_position_idx;
+    ALTER TABLE screenshots
+        DROP COLUMN locale,
+        DROP COLUMN position;`)
+	return err
+}
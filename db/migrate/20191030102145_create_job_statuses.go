@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191030102145, down20191030102145)
+}
+
+func up20191030102145(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE job_statuses (
+        id uuid primary key NOT NULL,
+        app_id uuid NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+        app_version_id uuid REFERENCES app_versions (id) ON DELETE CASCADE,
+        type text NOT NULL,
+        status text NOT NULL,
+        message text NOT NULL DEFAULT '',
+        result json NOT NULL DEFAULT '{}',
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE INDEX job_statuses_app_id_idx ON job_statuses(app_id);`)
+	return err
+}
+
+func down20191030102145(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE job_statuses;`)
+	return err
+}
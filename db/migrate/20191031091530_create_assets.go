@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191031091530, down20191031091530)
+}
+
+func up20191031091530(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE assets (
+        id uuid primary key NOT NULL,
+        checksum text NOT NULL,
+        extension text NOT NULL,
+        filesize bigint NOT NULL,
+        ref_count bigint NOT NULL,
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE UNIQUE INDEX assets_checksum_extension_idx ON assets(checksum, extension);
+
+    ALTER TABLE screenshots ADD COLUMN asset_id uuid REFERENCES assets(id);
+    CREATE INDEX screenshots_asset_id_idx ON screenshots(asset_id);
+
+    ALTER TABLE feature_graphics ADD COLUMN asset_id uuid REFERENCES assets(id);
+    CREATE INDEX feature_graphics_asset_id_idx ON feature_graphics(asset_id);`)
+	return err
+}
+
+func down20191031091530(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE feature_graphics DROP COLUMN asset_id;
+    ALTER TABLE screenshots DROP COLUMN asset_id;
+    DROP TABLE assets;`)
+	return err
+}
@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191104100512, down20191104100512)
+}
+
+func up20191104100512(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE app_tombstones (
+        id uuid primary key NOT NULL,
+        app_id uuid NOT NULL,
+        app_slug text NOT NULL,
+        bitrise_api_token text NOT NULL DEFAULT '',
+        asset_ids uuid[] NOT NULL DEFAULT '{}',
+        cleaned_up_at timestamp with time zone,
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE INDEX app_tombstones_app_slug_idx ON app_tombstones(app_slug);`)
+	return err
+}
+
+func down20191104100512(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE app_tombstones;`)
+	return err
+}
@@ -0,0 +1,23 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191105083127, down20191105083127)
+}
+
+func up20191105083127(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_settings
+        ADD COLUMN retention_settings json NOT NULL DEFAULT '{}';`)
+	return err
+}
+
+func down20191105083127(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_settings
+        DROP COLUMN retention_settings;`)
+	return err
+}
@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191106091204, down20191106091204)
+}
+
+func up20191106091204(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE processed_builds (
+        id uuid primary key NOT NULL,
+        app_id uuid NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+        build_slug text NOT NULL,
+        platform text NOT NULL,
+        product_flavor text NOT NULL DEFAULT '',
+        module text NOT NULL DEFAULT '',
+        app_version_id uuid REFERENCES app_versions (id) ON DELETE SET NULL,
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE UNIQUE INDEX processed_builds_key_idx ON processed_builds(build_slug, platform, product_flavor, module);`)
+	return err
+}
+
+func down20191106091204(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE processed_builds;`)
+	return err
+}
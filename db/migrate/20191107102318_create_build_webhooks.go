@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191107102318, down20191107102318)
+}
+
+func up20191107102318(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE build_webhooks (
+        id uuid primary key NOT NULL,
+        app_id uuid NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+        build_slug text NOT NULL,
+        payload json NOT NULL,
+        status text NOT NULL,
+        attempts integer NOT NULL DEFAULT 0,
+        message text NOT NULL DEFAULT '',
+        result json NOT NULL DEFAULT '{}',
+        processed_at timestamp with time zone,
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE INDEX build_webhooks_app_id_build_slug_idx ON build_webhooks(app_id, build_slug);`)
+	return err
+}
+
+func down20191107102318(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE build_webhooks;`)
+	return err
+}
@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191108093045, down20191108093045)
+}
+
+func up20191108093045(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_settings
+        ADD COLUMN workflow_rules json NOT NULL DEFAULT '[]';
+
+    UPDATE app_settings SET workflow_rules = rules.data
+    FROM (
+        SELECT id, json_agg(json_build_object(
+            'platform', platform, 'exclude', false, 'workflows', workflows, 'branches', '[]'::json, 'tags', '[]'::json
+        )) AS data
+        FROM (
+            SELECT id, 'ios' AS platform,
+                (SELECT COALESCE(json_agg(trim(w)), '[]'::json) FROM unnest(string_to_array(ios_workflow, ',')) AS w WHERE trim(w) <> '') AS workflows
+            FROM app_settings WHERE COALESCE(trim(ios_workflow), '') <> ''
+            UNION ALL
+            SELECT id, 'android' AS platform,
+                (SELECT COALESCE(json_agg(trim(w)), '[]'::json) FROM unnest(string_to_array(android_workflow, ',')) AS w WHERE trim(w) <> '') AS workflows
+            FROM app_settings WHERE COALESCE(trim(android_workflow), '') <> ''
+        ) AS platform_rules
+        GROUP BY id
+    ) AS rules
+    WHERE app_settings.id = rules.id;
+
+    ALTER TABLE app_settings
+        DROP COLUMN ios_workflow,
+        DROP COLUMN android_workflow;`)
+	return err
+}
+
+func down20191108093045(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_settings
+        ADD COLUMN ios_workflow text,
+        ADD COLUMN android_workflow text;
+
+    UPDATE app_settings SET
+        ios_workflow = (
+            SELECT string_agg(w, ',') FROM json_array_elements(workflow_rules) AS r, json_array_elements_text(r->'workflows') AS w
+            WHERE r->>'platform' = 'ios' AND NOT (r->>'exclude')::boolean
+        ),
+        android_workflow = (
+            SELECT string_agg(w, ',') FROM json_array_elements(workflow_rules) AS r, json_array_elements_text(r->'workflows') AS w
+            WHERE r->>'platform' = 'android' AND NOT (r->>'exclude')::boolean
+        );
+
+    ALTER TABLE app_settings
+        DROP COLUMN workflow_rules;`)
+	return err
+}
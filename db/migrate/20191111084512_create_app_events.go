@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191111084512, down20191111084512)
+}
+
+func up20191111084512(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE app_events (
+        id uuid primary key NOT NULL,
+        app_id uuid NOT NULL REFERENCES apps (id) ON DELETE CASCADE,
+        status text NOT NULL,
+        event_text text NOT NULL DEFAULT '',
+        workflow text NOT NULL DEFAULT '',
+        build_slug text NOT NULL DEFAULT '',
+        build_number integer NOT NULL DEFAULT 0,
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE INDEX app_events_app_id_created_at_idx ON app_events(app_id, created_at);`)
+	return err
+}
+
+func down20191111084512(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE app_events;`)
+	return err
+}
@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191112101634, down20191112101634)
+}
+
+func up20191112101634(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE apps
+        ADD COLUMN header_palette json NOT NULL DEFAULT '{}',
+        ADD COLUMN avatar_url text NOT NULL DEFAULT '',
+        ADD COLUMN avatar_checksum text NOT NULL DEFAULT '';`)
+	return err
+}
+
+func down20191112101634(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE apps
+        DROP COLUMN header_palette,
+        DROP COLUMN avatar_url,
+        DROP COLUMN avatar_checksum;`)
+	return err
+}
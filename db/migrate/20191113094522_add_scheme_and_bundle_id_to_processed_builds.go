@@ -0,0 +1,34 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191113094522, down20191113094522)
+}
+
+func up20191113094522(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE processed_builds
+        ADD COLUMN scheme text NOT NULL DEFAULT '',
+        ADD COLUMN bundle_id text NOT NULL DEFAULT '';
+
+    DROP INDEX processed_builds_key_idx;
+    CREATE UNIQUE INDEX processed_builds_key_idx ON processed_builds(build_slug, platform, product_flavor, module, scheme, bundle_id);`)
+	return err
+}
+
+func down20191113094522(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP INDEX processed_builds_key_idx;
+    DELETE FROM processed_builds a USING processed_builds b
+        WHERE a.build_slug = b.build_slug AND a.platform = b.platform AND a.product_flavor = b.product_flavor
+        AND a.module = b.module AND a.created_at > b.created_at;
+    CREATE UNIQUE INDEX processed_builds_key_idx ON processed_builds(build_slug, platform, product_flavor, module);
+
+    ALTER TABLE processed_builds
+        DROP COLUMN scheme,
+        DROP COLUMN bundle_id;`)
+	return err
+}
@@ -0,0 +1,21 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191114083015, down20191114083015)
+}
+
+func up20191114083015(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_settings ADD COLUMN code_signing_expiry json NOT NULL DEFAULT '{}';`)
+	return err
+}
+
+func down20191114083015(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_settings DROP COLUMN code_signing_expiry;`)
+	return err
+}
@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191115102341, down20191115102341)
+}
+
+func up20191115102341(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_versions ADD COLUMN branch text NOT NULL DEFAULT '';` +
+		` ALTER TABLE app_versions ADD COLUMN tag text NOT NULL DEFAULT '';` +
+		` ALTER TABLE app_versions ADD COLUMN commit_hash text NOT NULL DEFAULT '';` +
+		` ALTER TABLE app_versions ADD COLUMN pull_request_id integer NOT NULL DEFAULT 0;` +
+		` ALTER TABLE app_versions ADD COLUMN triggered_workflow text NOT NULL DEFAULT '';` +
+		` ALTER TABLE app_versions ADD COLUMN triggered_by text NOT NULL DEFAULT '';` +
+		` ALTER TABLE app_versions ADD COLUMN build_url text NOT NULL DEFAULT '';`)
+	return err
+}
+
+func down20191115102341(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_versions DROP COLUMN branch;` +
+		` ALTER TABLE app_versions DROP COLUMN tag;` +
+		` ALTER TABLE app_versions DROP COLUMN commit_hash;` +
+		` ALTER TABLE app_versions DROP COLUMN pull_request_id;` +
+		` ALTER TABLE app_versions DROP COLUMN triggered_workflow;` +
+		` ALTER TABLE app_versions DROP COLUMN triggered_by;` +
+		` ALTER TABLE app_versions DROP COLUMN build_url;`)
+	return err
+}
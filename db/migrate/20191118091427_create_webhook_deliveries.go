@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191118091427, down20191118091427)
+}
+
+func up20191118091427(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE webhook_deliveries (
+        id uuid primary key NOT NULL,
+        app_id uuid REFERENCES apps (id) ON DELETE CASCADE,
+        build_webhook_id uuid REFERENCES build_webhooks (id) ON DELETE SET NULL,
+        endpoint text NOT NULL,
+        event_type text NOT NULL DEFAULT '',
+        headers json NOT NULL DEFAULT '{}',
+        body text NOT NULL DEFAULT '',
+        signature_valid boolean,
+        status_code integer NOT NULL,
+        error text NOT NULL DEFAULT '',
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+
+    CREATE INDEX webhook_deliveries_app_id_created_at_idx ON webhook_deliveries(app_id, created_at);
+    CREATE INDEX webhook_deliveries_created_at_idx ON webhook_deliveries(created_at);`)
+	return err
+}
+
+func down20191118091427(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE webhook_deliveries;`)
+	return err
+}
@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191119101245, down20191119101245)
+}
+
+func up20191119101245(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE store_graphics ADD COLUMN asset_id uuid REFERENCES assets(id);
+    CREATE INDEX store_graphics_asset_id_idx ON store_graphics(asset_id);
+
+    INSERT INTO store_graphics (id, app_version_id, type, filename, filesize, uploaded, width, height,
+            checksum, invalid_reason, asset_id, created_at, updated_at)
+        SELECT id, app_version_id, 'feature_graphic', filename, filesize, uploaded, width, height,
+            checksum, invalid_reason, asset_id, created_at, updated_at
+        FROM feature_graphics;
+
+    DROP TABLE feature_graphics;`)
+	return err
+}
+
+func down20191119101245(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE feature_graphics (
+        id uuid primary key NOT NULL,
+        app_version_id uuid NOT NULL REFERENCES app_versions (id) ON DELETE CASCADE,
+        filename text NOT NULL,
+        filesize integer,
+        uploaded boolean,
+        width integer NOT NULL DEFAULT 0,
+        height integer NOT NULL DEFAULT 0,
+        checksum text NOT NULL DEFAULT '',
+        invalid_reason text NOT NULL DEFAULT '',
+        asset_id uuid REFERENCES assets(id),
+        created_at timestamp with time zone NOT NULL,
+        updated_at timestamp with time zone NOT NULL
+    );
+    CREATE INDEX feature_graphics_asset_id_idx ON feature_graphics(asset_id);
+
+    INSERT INTO feature_graphics (id, app_version_id, filename, filesize, uploaded, width, height,
+            checksum, invalid_reason, asset_id, created_at, updated_at)
+        SELECT id, app_version_id, filename, filesize, uploaded, width, height,
+            checksum, invalid_reason, asset_id, created_at, updated_at
+        FROM store_graphics WHERE type = 'feature_graphic';
+
+    DELETE FROM store_graphics WHERE type = 'feature_graphic';
+    ALTER TABLE store_graphics DROP COLUMN asset_id;`)
+	return err
+}
@@ -0,0 +1,22 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191125093012, down20191125093012)
+}
+
+func up20191125093012(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_previews ADD COLUMN asset_id uuid REFERENCES assets(id);
+    CREATE INDEX app_previews_asset_id_idx ON app_previews(asset_id);`)
+	return err
+}
+
+func down20191125093012(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE app_previews DROP COLUMN asset_id;`)
+	return err
+}
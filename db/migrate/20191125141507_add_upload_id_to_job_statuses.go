@@ -0,0 +1,21 @@
+package migration
+
+import (
+	"database/sql"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(up20191125141507, down20191125141507)
+}
+
+func up20191125141507(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE job_statuses ADD COLUMN upload_id text NOT NULL DEFAULT '';`)
+	return err
+}
+
+func down20191125141507(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE job_statuses DROP COLUMN upload_id;`)
+	return err
+}
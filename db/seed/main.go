@@ -104,18 +104,19 @@ func main() {
 		}
 	}
 
-	// create test feature graphics
-	for _, featureGraphicData := range testData.FeatureGraphics {
-		featureGraphic := models.FeatureGraphic{
-			Record: models.Record{ID: featureGraphicData.ID},
+	// create test store graphics
+	for _, storeGraphicData := range testData.StoreGraphics {
+		storeGraphic := models.StoreGraphic{
+			Record: models.Record{ID: storeGraphicData.ID},
 			UploadableObject: models.UploadableObject{
-				Filename: featureGraphicData.Filename,
-				Filesize: featureGraphicData.Filesize,
+				Filename: storeGraphicData.Filename,
+				Filesize: storeGraphicData.Filesize,
 			},
-			AppVersionID: featureGraphicData.AppVersionID,
+			Type:         storeGraphicData.Type,
+			AppVersionID: storeGraphicData.AppVersionID,
 		}
-		if err := db.Create(&featureGraphic).Error; err != nil {
-			fmt.Printf("Failed to seed db with feawture graphic: %#v, feature graphic: %#v", err, featureGraphic)
+		if err := db.Create(&storeGraphic).Error; err != nil {
+			fmt.Printf("Failed to seed db with store graphic: %#v, store graphic: %#v", err, storeGraphic)
 			os.Exit(1)
 		}
 	}
@@ -209,9 +210,10 @@ type screenshot struct {
 	ScreenSize   string    `yaml:"screen_size"`
 }
 
-type featureGraphic struct {
+type storeGraphic struct {
 	ID           uuid.UUID `yaml:"id"`
 	AppVersionID uuid.UUID `yaml:"app_version_id"`
+	Type         string    `yaml:"type"`
 	Filename     string    `yaml:"filename"`
 	Filesize     int64     `yaml:"filesize"`
 	Uploaded     bool      `yaml:"uploaded"`
@@ -221,6 +223,8 @@ type notificationPreferences struct {
 	NewVersion        bool `yaml:"new_version"`
 	SuccessfulPublish bool `yaml:"successful_publish"`
 	FailedPublish     bool `yaml:"failed_publish"`
+	BuildFailed       bool `yaml:"build_failed"`
+	CodeSigningExpiry bool `yaml:"code_signing_expiry"`
 }
 
 type appContact struct {
@@ -236,7 +240,7 @@ type testData struct {
 	Apps             []app             `yaml:"apps"`
 	AppVersions      []appVersion      `yaml:"app_versions"`
 	Screenshots      []screenshot      `yaml:"screenshots"`
-	FeatureGraphics  []featureGraphic  `yaml:"feature_graphics"`
+	StoreGraphics    []storeGraphic    `yaml:"store_graphics"`
 	AppVersionEvents []appVersionEvent `yaml:"app_version_events"`
 	AppContacts      []appContact      `yaml:"app_contacts"`
 }
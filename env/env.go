@@ -13,6 +13,7 @@ import (
 	"github.com/bitrise-io/addons-ship-backend/mailer"
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/addons-ship-backend/redis"
+	"github.com/bitrise-io/addons-ship-backend/storage"
 	"github.com/bitrise-io/api-utils/logging"
 	"github.com/bitrise-io/api-utils/providers"
 	"github.com/bitrise-io/api-utils/security"
@@ -41,13 +42,22 @@ type AppEnv struct {
 	AppContactService        dataservices.AppContactService
 	AppVersionService        dataservices.AppVersionService
 	ScreenshotService        dataservices.ScreenshotService
-	FeatureGraphicService    dataservices.FeatureGraphicService
+	AppPreviewService        dataservices.AppPreviewService
+	StoreGraphicService      dataservices.StoreGraphicService
 	AppSettingsService       dataservices.AppSettingsService
 	AppVersionEventService   dataservices.AppVersionEventService
+	AppEventService          dataservices.AppEventService
 	PublishTaskService       dataservices.PublishTaskService
+	JobStatusService         dataservices.JobStatusService
+	AssetService             dataservices.AssetService
+	AppTombstoneService      dataservices.AppTombstoneService
+	ProcessedBuildService    dataservices.ProcessedBuildService
+	BuildWebhookService      dataservices.BuildWebhookService
+	WebhookDeliveryService   dataservices.WebhookDeliveryService
 	BitriseAPI               bitrise.APIInterface
 	RequestParams            providers.RequestParamsInterface
 	AWS                      providers.AWSInterface
+	Storage                  storage.Interface
 	Redis                    redis.Interface
 	RedisExpirationTime      int
 	LogStoreService          dataservices.LogStore
@@ -94,14 +104,26 @@ func New(db *gorm.DB) (*AppEnv, error) {
 	env.AppContactService = &models.AppContactService{DB: db}
 	env.AppVersionService = &models.AppVersionService{DB: db}
 	env.ScreenshotService = &models.ScreenshotService{DB: db}
-	env.FeatureGraphicService = &models.FeatureGraphicService{DB: db}
+	env.AppPreviewService = &models.AppPreviewService{DB: db}
+	env.StoreGraphicService = &models.StoreGraphicService{DB: db}
 	env.AppSettingsService = &models.AppSettingsService{DB: db}
 	env.AppVersionEventService = &models.AppVersionEventService{DB: db}
+	env.AppEventService = &models.AppEventService{DB: db}
 	env.PublishTaskService = &models.PublishTaskService{DB: db}
-	if env.Environment == ServerEnvDevelopment {
+	env.JobStatusService = &models.JobStatusService{DB: db}
+	env.AssetService = &models.AssetService{DB: db}
+	env.AppTombstoneService = &models.AppTombstoneService{DB: db}
+	env.ProcessedBuildService = &models.ProcessedBuildService{DB: db}
+	env.BuildWebhookService = &models.BuildWebhookService{DB: db}
+	env.WebhookDeliveryService = &models.WebhookDeliveryService{DB: db}
+	if env.Environment == ServerEnvDevelopment && os.Getenv("BITRISE_API_ROOT_URL") == "" {
 		env.BitriseAPI = &bitrise.APIDev{}
-	} else {
+	} else if env.Environment == ServerEnvDevelopment {
+		// the fake Bitrise API server of bitrise/bitrisetest/fakeserver is called without caching,
+		// so the edited fixtures show up right away
 		env.BitriseAPI = bitrise.New()
+	} else {
+		env.BitriseAPI = bitrise.NewCachedAPI(bitrise.New(), redis.New())
 	}
 	env.RequestParams = &providers.RequestParams{}
 
@@ -111,6 +133,7 @@ func New(db *gorm.DB) (*AppEnv, error) {
 	}
 
 	env.AWS = &providers.AWS{Config: awsConfig}
+	env.Storage = &storage.AWS{Config: awsConfig}
 
 	redisExpiration := int64(1000)
 	redisExpirationStr, ok := os.LookupEnv("REDIS_KEY_EXPIRATION_TIME")
@@ -0,0 +1,60 @@
+package ingestion_test
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+type testAppVersionService struct {
+	createFn           func(*models.AppVersion) (*models.AppVersion, []error, error)
+	findFn             func(*models.AppVersion) (*models.AppVersion, error)
+	findAllFn          func(*models.App, map[string]interface{}) ([]models.AppVersion, error)
+	findAllPublishedFn func(*models.App) ([]models.AppVersion, error)
+	updateFn           func(*models.AppVersion, []string) (validationErrors []error, dbErr error)
+	latestFn           func(*models.AppVersion, string) (*models.AppVersion, error)
+	deleteFn           func(*models.AppVersion) error
+}
+
+func (a *testAppVersionService) Create(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+	if a.createFn != nil {
+		return a.createFn(appVersion)
+	}
+	panic("You have to override Create function in tests")
+}
+func (a *testAppVersionService) Find(appVersion *models.AppVersion) (*models.AppVersion, error) {
+	if a.findFn != nil {
+		return a.findFn(appVersion)
+	}
+	panic("You have to override Find function in tests")
+}
+func (a *testAppVersionService) FindAll(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+	if a.findAllFn != nil {
+		return a.findAllFn(app, filterParams)
+	}
+	panic("You have to override FindAll function in tests")
+}
+
+func (a *testAppVersionService) FindAllPublished(app *models.App) ([]models.AppVersion, error) {
+	if a.findAllPublishedFn != nil {
+		return a.findAllPublishedFn(app)
+	}
+	panic("You have to override FindAllPublished function in tests")
+}
+
+func (a *testAppVersionService) Update(appVersion *models.AppVersion, whitelist []string) (validationErrors []error, dbErr error) {
+	if a.updateFn != nil {
+		return a.updateFn(appVersion, whitelist)
+	}
+	panic("You have to override Update function in tests")
+}
+
+func (a *testAppVersionService) Latest(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+	if a.latestFn != nil {
+		return a.latestFn(appVersion, bundleID)
+	}
+	panic("You have to override Latest function in tests")
+}
+
+func (a *testAppVersionService) Delete(appVersion *models.AppVersion) error {
+	if a.deleteFn != nil {
+		return a.deleteFn(appVersion)
+	}
+	panic("You have to override Delete function in tests")
+}
@@ -0,0 +1,414 @@
+package ingestion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+// BuildWebhookPayload ...
+type BuildWebhookPayload struct {
+	AppSlug                string `json:"app_slug"`
+	BuildSlug              string `json:"build_slug"`
+	BuildNumber            int    `json:"build_number"`
+	BuildStatus            int    `json:"build_status"`
+	BuildTriggeredWorkflow string `json:"build_triggered_workflow"`
+}
+
+// ProcessBuildWebhook creates the versions of the app from the artifacts of the finished build. Versions
+// already created from an earlier processing of the build are returned without being created again.
+// Failed and aborted builds don't create versions, they are recorded as events of the app instead.
+func ProcessBuildWebhook(env *env.AppEnv, appID uuid.UUID, params BuildWebhookPayload) ([]*models.AppVersion, error) {
+	_, err := env.AppService.Find(&models.App{Record: models.Record{ID: appID}})
+	if err != nil {
+		return nil, errors.Wrap(err, "SQL Error")
+	}
+
+	appSettings, err := env.AppSettingsService.Find(&models.AppSettings{AppID: appID})
+	if err != nil {
+		return nil, errors.Wrap(err, "SQL Error")
+	}
+
+	app := appSettings.App
+
+	if isFailedBuild(params.BuildStatus) {
+		if err := recordFailedBuild(env, appID, appSettings, params); err != nil {
+			return nil, err
+		}
+		return []*models.AppVersion{}, nil
+	}
+
+	artifacts, err := env.BitriseAPI.GetArtifacts(app.BitriseAPIToken, app.AppSlug, params.BuildSlug)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	appDetails, err := env.BitriseAPI.GetAppDetails(app.BitriseAPIToken, app.AppSlug)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	buildDetails, err := env.BitriseAPI.GetBuildDetails(app.BitriseAPIToken, app.AppSlug, params.BuildSlug)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if appDetails.AvatarURL != nil {
+		err := env.WorkerService.EnqueueUpdateHeaderPalette(appID, *appDetails.AvatarURL)
+		if err != nil {
+			env.Logger.Warn("Failed to enqueue header palette update", zap.String("avatar_url", *appDetails.AvatarURL), zap.Error(err))
+		}
+	}
+
+	workflowRules, err := appSettings.WorkflowRules()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	build := models.WorkflowRuleBuild{
+		Workflow: params.BuildTriggeredWorkflow,
+		Branch:   buildDetails.Branch,
+		Tag:      buildDetails.Tag,
+	}
+
+	iosVersionCreated := false
+	appVersions := []*models.AppVersion{}
+
+	artifactSelector := bitrise.NewArtifactSelector(artifacts)
+	if workflowRules.AllowsAny(models.ApplePlatforms, build) && hasIosArtifact(artifacts) {
+		iosSettings, err := appSettings.IosSettings()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		iosAppVersions, err := artifactSelector.PrepareIosAppVersions(params.BuildSlug, buildDetails.CommitMessage, iosSettings.TrackedSchemes)
+		if err != nil {
+			return nil, err
+		}
+		for i := range iosAppVersions {
+			version := &iosAppVersions[i]
+			if !workflowRules.Allows(version.Platform, build) {
+				continue
+			}
+			artifactInfo, err := version.ArtifactInfo()
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			processedBuild, claimed, err := env.ProcessedBuildService.Claim(&models.ProcessedBuild{
+				AppID:     appID,
+				BuildSlug: params.BuildSlug,
+				Platform:  version.Platform,
+				Scheme:    version.Scheme,
+				BundleID:  artifactInfo.BundleID,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			if !claimed {
+				if processedBuild.AppVersion != nil {
+					appVersions = append(appVersions, processedBuild.AppVersion)
+				}
+				continue
+			}
+
+			latestAppVersion, err := env.AppVersionService.Latest(&models.AppVersion{
+				AppID:    app.ID,
+				Platform: version.Platform,
+				Scheme:   version.Scheme,
+			}, artifactInfo.BundleID)
+			if err != nil && errors.Cause(err) != gorm.ErrRecordNotFound {
+				releaseProcessedBuild(env, processedBuild)
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			version.AppID = appID
+			setBuildMetadata(version, params.BuildSlug, buildDetails)
+			if latestAppVersion != nil {
+				version.AppStoreInfoData = latestAppVersion.AppStoreInfoData
+			}
+			appVersion, verrs, err := env.AppVersionService.Create(version)
+			if len(verrs) > 0 {
+				releaseProcessedBuild(env, processedBuild)
+				return nil, validationErrorsToError(verrs)
+			}
+			if err != nil {
+				releaseProcessedBuild(env, processedBuild)
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			processedBuild.AppVersionID = &appVersion.ID
+			if err := env.ProcessedBuildService.Update(processedBuild, []string{"AppVersionID"}); err != nil {
+				discardAppVersion(env, appVersion)
+				releaseProcessedBuild(env, processedBuild)
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			appVersions = append(appVersions, appVersion)
+
+			if latestAppVersion != nil {
+				err := env.WorkerService.EnqueueCopyUploadablesToNewAppVersion(latestAppVersion.ID.String(), appVersion.ID.String())
+				if err != nil {
+					return nil, errors.Wrap(err, "Worker Error")
+				}
+			} else if !iosVersionCreated {
+				env.AnalyticsClient.FirstVersionCreated(app.AppSlug, params.BuildSlug, version.Platform)
+			}
+			iosVersionCreated = true
+
+			_, err = env.AppVersionEventService.Create(&models.AppVersionEvent{AppVersionID: appVersion.ID, Text: "New version was created"})
+			if err != nil {
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+
+			if err := sendNotification(env, appVersion, app, appDetails); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+	}
+
+	if workflowRules.Allows("android", build) && artifactSelector.HasAndroidArtifact() {
+		androidSettings, err := appSettings.AndroidSettings()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		androidAppVersions, settingsErr, err := artifactSelector.PrepareAndroidAppVersions(params.BuildSlug, fmt.Sprintf("%d", params.BuildNumber), buildDetails.CommitMessage, androidSettings.Module)
+		if settingsErr != nil {
+			app.AndroidErrors = []string{settingsErr.Error()}
+			verrs, err := env.AppService.Update(app, []string{"AndroidErrors"})
+			if len(verrs) > 0 {
+				return nil, validationErrorsToError(verrs)
+			}
+			if err != nil {
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+
+			return nil, errors.WithStack(settingsErr)
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		for i := range androidAppVersions {
+			version := &androidAppVersions[i]
+			artifactInfo, err := version.ArtifactInfo()
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			processedBuild, claimed, err := env.ProcessedBuildService.Claim(&models.ProcessedBuild{
+				AppID:         appID,
+				BuildSlug:     params.BuildSlug,
+				Platform:      "android",
+				ProductFlavor: version.ProductFlavor,
+				Module:        artifactInfo.Module,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			if !claimed {
+				if processedBuild.AppVersion != nil {
+					appVersions = append(appVersions, processedBuild.AppVersion)
+				}
+				continue
+			}
+
+			latestAppVersion, err := env.AppVersionService.Latest(&models.AppVersion{
+				AppID:         app.ID,
+				Platform:      "android",
+				ProductFlavor: version.ProductFlavor,
+			}, "")
+			if err != nil && errors.Cause(err) != gorm.ErrRecordNotFound {
+				releaseProcessedBuild(env, processedBuild)
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			version.AppID = appID
+			setBuildMetadata(version, params.BuildSlug, buildDetails)
+			appVersion, verrs, err := env.AppVersionService.Create(version)
+			if len(verrs) > 0 {
+				releaseProcessedBuild(env, processedBuild)
+				return nil, validationErrorsToError(verrs)
+			}
+			if err != nil {
+				releaseProcessedBuild(env, processedBuild)
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			processedBuild.AppVersionID = &appVersion.ID
+			if err := env.ProcessedBuildService.Update(processedBuild, []string{"AppVersionID"}); err != nil {
+				discardAppVersion(env, appVersion)
+				releaseProcessedBuild(env, processedBuild)
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+			appVersions = append(appVersions, appVersion)
+
+			if latestAppVersion != nil {
+				err := env.WorkerService.EnqueueCopyUploadablesToNewAppVersion(latestAppVersion.ID.String(), appVersion.ID.String())
+				if err != nil {
+					return nil, errors.Wrap(err, "Worker Error")
+				}
+			} else if !iosVersionCreated {
+				env.AnalyticsClient.FirstVersionCreated(app.AppSlug, params.BuildSlug, "android")
+			}
+
+			_, err = env.AppVersionEventService.Create(&models.AppVersionEvent{AppVersionID: appVersion.ID, Text: "New version was created"})
+			if err != nil {
+				return nil, errors.Wrap(err, "SQL Error")
+			}
+
+			if err := sendNotification(env, appVersion, app, appDetails); err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			if len(app.AndroidErrors) > 0 {
+				app.AndroidErrors = []string{}
+				verrs, err = env.AppService.Update(app, []string{"AndroidErrors"})
+				if len(verrs) > 0 {
+					return nil, validationErrorsToError(verrs)
+				}
+				if err != nil {
+					return nil, errors.Wrap(err, "SQL Error")
+				}
+			}
+		}
+	}
+
+	return appVersions, nil
+}
+
+func hasIosArtifact(artifacts []bitrise.ArtifactListElementResponseModel) bool {
+	for _, artifact := range artifacts {
+		if artifact.IsIPA() || artifact.IsXCodeArchive() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isFailedBuild(buildStatus int) bool {
+	switch buildStatus {
+	case bitrise.BuildStatusFailed, bitrise.BuildStatusAbortedWithFailure, bitrise.BuildStatusAbortedWithSuccess:
+		return true
+	}
+	return false
+}
+
+// recordFailedBuild adds the failed or aborted build to the activity feed of the app, if versions would
+// have been created from it for any platform of the project, and notifies the contacts who asked for it.
+// A build already recorded by an earlier processing of the webhook is skipped. The contacts are notified
+// before the build is recorded, so a failed notification is retried with the next attempt.
+func recordFailedBuild(env *env.AppEnv, appID uuid.UUID, appSettings *models.AppSettings, params BuildWebhookPayload) error {
+	app := appSettings.App
+
+	appDetails, err := env.BitriseAPI.GetAppDetails(app.BitriseAPIToken, app.AppSlug)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	buildDetails, err := env.BitriseAPI.GetBuildDetails(app.BitriseAPIToken, app.AppSlug, params.BuildSlug)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	workflowRules, err := appSettings.WorkflowRules()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	build := models.WorkflowRuleBuild{
+		Workflow: params.BuildTriggeredWorkflow,
+		Branch:   buildDetails.Branch,
+		Tag:      buildDetails.Tag,
+	}
+	if !workflowRules.AllowsAny(projectPlatforms(appDetails.ProjectType), build) {
+		return nil
+	}
+
+	_, err = env.AppEventService.Find(&models.AppEvent{AppID: appID, BuildSlug: params.BuildSlug})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Cause(err) != gorm.ErrRecordNotFound:
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	appEvent := &models.AppEvent{
+		AppID:       appID,
+		Status:      models.AppEventStatusBuildFailed,
+		Text:        "Build failed",
+		Workflow:    params.BuildTriggeredWorkflow,
+		BuildSlug:   params.BuildSlug,
+		BuildNumber: params.BuildNumber,
+	}
+	if params.BuildStatus != bitrise.BuildStatusFailed {
+		appEvent.Status = models.AppEventStatusBuildAborted
+		appEvent.Text = "Build was aborted"
+	}
+
+	appContacts, err := env.AppContactService.FindAll(app)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := env.Mailer.SendEmailBuildFailed(appEvent, appContacts, appDetails); err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = env.AppEventService.Create(appEvent)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	return nil
+}
+
+func setBuildMetadata(appVersion *models.AppVersion, buildSlug string, buildDetails *bitrise.BuildDetails) {
+	appVersion.CommitHash = buildDetails.CommitHash
+	appVersion.Branch = buildDetails.Branch
+	appVersion.Tag = buildDetails.Tag
+	appVersion.PullRequestID = buildDetails.PullRequestID
+	appVersion.TriggeredWorkflow = buildDetails.TriggeredWorkflow
+	appVersion.TriggeredBy = buildDetails.TriggeredBy
+	appVersion.BuildURL = models.BuildURL(buildSlug)
+}
+
+func projectPlatforms(projectType string) []string {
+	switch projectType {
+	case "ios":
+		return models.ApplePlatforms
+	case "macos", "android":
+		return []string{projectType}
+	}
+	return append([]string{"android"}, models.ApplePlatforms...)
+}
+
+func validationErrorsToError(verrs []error) error {
+	messages := []string{}
+	for _, verr := range verrs {
+		messages = append(messages, verr.Error())
+	}
+	return errors.Errorf("Validation error: %s", strings.Join(messages, ", "))
+}
+
+// releaseProcessedBuild drops the record of a build whose version failed to be created, so a redelivery
+// of the webhook can process it again
+func releaseProcessedBuild(env *env.AppEnv, processedBuild *models.ProcessedBuild) {
+	if err := env.ProcessedBuildService.Delete(processedBuild); err != nil {
+		env.Logger.Error("Failed to release processed build", zap.String("build_slug", processedBuild.BuildSlug), zap.Error(err))
+	}
+}
+
+// discardAppVersion deletes the version created for a build whose record couldn't be updated, so a
+// redelivery of the webhook doesn't leave a duplicate behind
+func discardAppVersion(env *env.AppEnv, appVersion *models.AppVersion) {
+	if err := env.AppVersionService.Delete(appVersion); err != nil {
+		env.Logger.Error("Failed to delete app version", zap.String("app_version_id", appVersion.ID.String()), zap.Error(err))
+	}
+}
+
+func sendNotification(env *env.AppEnv, appVersion *models.AppVersion, app *models.App, appDetails *bitrise.AppDetails) error {
+	appContacts, err := env.AppContactService.FindAll(app)
+	appVersion.App = *app
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return env.Mailer.SendEmailNewVersion(appVersion, appContacts, env.AddonFrontendHostURL, appDetails)
+}
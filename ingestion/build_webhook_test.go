@@ -0,0 +1,2701 @@
+package ingestion_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/ingestion"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/go-utils/pointers"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+func Test_ProcessBuildWebhook(t *testing.T) {
+	t.Run("when build is finished", func(t *testing.T) {
+		t.Run("ok - minimal", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: uuid.NewV4(),
+				env: &env.AppEnv{
+					ProcessedBuildService: claimingProcessedBuildService(),
+					AppService: &testAppService{
+						findFn: func(app *models.App) (*models.App, error) {
+							return app, nil
+						},
+					},
+					AppSettingsService: &testAppSettingsService{
+						findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+							return &models.AppSettings{
+								WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]},{"platform":"android","workflows":["some-android-wf"]}]`),
+								App:               &models.App{},
+							}, nil
+						},
+					},
+					AppVersionService:      &testAppVersionService{},
+					AppVersionEventService: &testAppVersionEventService{},
+					BitriseAPI: &testBitriseAPI{
+						getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+							return []bitrise.ArtifactListElementResponseModel{}, nil
+						},
+						getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+							return &bitrise.AppDetails{}, nil
+						},
+						getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+							return &bitrise.BuildDetails{}, nil
+						},
+					},
+					AppContactService: &testAppContactService{},
+					WorkerService:     &testWorkerService{},
+				},
+				payload: `{}`,
+			})
+		})
+
+		t.Run("ok - when app has an avatar", func(t *testing.T) {
+			testAppID := uuid.NewV4()
+			for _, enqueueErr := range []error{nil, errors.New("SOME-WORKER-ERROR")} {
+				headerPaletteUpdateEnqueued := false
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: testAppID,
+					env: &env.AppEnv{
+						Logger:                zap.NewNop(),
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{App: &models.App{}}, nil
+							},
+						},
+						AppVersionService:      &testAppVersionService{},
+						AppVersionEventService: &testAppVersionEventService{},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{AvatarURL: pointers.NewStringPtr("https://bit.ly/1LixVJu")}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService: &testWorkerService{
+							enqueueUpdateHeaderPaletteFn: func(appID uuid.UUID, avatarURL string) error {
+								require.Equal(t, testAppID, appID)
+								require.Equal(t, "https://bit.ly/1LixVJu", avatarURL)
+								headerPaletteUpdateEnqueued = true
+								return enqueueErr
+							},
+						},
+					},
+					payload:             `{}`,
+					expectedAppVersions: []*models.AppVersion{},
+				})
+				require.True(t, headerPaletteUpdateEnqueued)
+			}
+		})
+
+		t.Run("when db error happens at finding app", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: uuid.NewV4(),
+				env: &env.AppEnv{
+					ProcessedBuildService: claimingProcessedBuildService(),
+					AppService: &testAppService{
+						findFn: func(app *models.App) (*models.App, error) {
+							return nil, errors.New("SOME-SQL-ERROR")
+						},
+					},
+					AppSettingsService: &testAppSettingsService{
+						findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+							return appSettings, nil
+						},
+					},
+					AppVersionService:      &testAppVersionService{},
+					AppVersionEventService: &testAppVersionEventService{},
+					BitriseAPI:             &testBitriseAPI{},
+					AppContactService:      &testAppContactService{},
+					WorkerService:          &testWorkerService{},
+				},
+				payload:     `{}`,
+				expectedErr: "SQL Error: SOME-SQL-ERROR",
+			})
+		})
+
+		t.Run("when app settings not found in database", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: uuid.NewV4(),
+				env: &env.AppEnv{
+					ProcessedBuildService: claimingProcessedBuildService(),
+					AppService: &testAppService{
+						findFn: func(app *models.App) (*models.App, error) {
+							return app, nil
+						},
+					},
+					AppSettingsService: &testAppSettingsService{
+						findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+							return nil, gorm.ErrRecordNotFound
+						},
+					},
+					AppVersionService:      &testAppVersionService{},
+					AppVersionEventService: &testAppVersionEventService{},
+					BitriseAPI:             &testBitriseAPI{},
+					AppContactService:      &testAppContactService{},
+					WorkerService:          &testWorkerService{},
+				},
+				payload:     `{}`,
+				expectedErr: "SQL Error: record not found",
+			})
+		})
+
+		t.Run("when platform is ios", func(t *testing.T) {
+			testAppVersionID := uuid.FromStringOrNil("9f7b06d1-e736-42d3-94c3-c2bcfda0463c")
+			testAppVersion2ID := uuid.FromStringOrNil("f951e094-0ac1-4edf-ac8f-bb035dfd683c")
+			t.Run("ok - more complex - when ios workflow whitelist is empty", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AddonFrontendHostURL:  "https://ship.bitrise.io",
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"android","workflows":["some-android-wf"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								require.Equal(t, "12", appVersion.BuildNumber)
+								require.Equal(t, "The detailed commit message", appVersion.CommitMessage)
+								require.Equal(t, "a1b2c3", appVersion.CommitHash)
+								require.Equal(t, "master", appVersion.Branch)
+								require.Equal(t, 12, appVersion.PullRequestID)
+								require.Equal(t, "ios-wf", appVersion.TriggeredWorkflow)
+								require.Equal(t, "webhook", appVersion.TriggeredBy)
+								require.Equal(t, "https://app.bitrise.io/build/test-build-slug", appVersion.BuildURL)
+								require.Equal(t, "test-scheme", appVersion.Scheme)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								require.Equal(t, []string{"iPhone", "iPod Touch", "iPad", "Unknown"}, artifactData.SupportedDeviceTypes)
+								appVersion.ID = testAppVersionID
+								appVersion.App = models.App{
+									BitriseAPIToken: "test-api-token",
+									AppSlug:         "test-app-slug",
+								}
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								appVersion.ID = testAppVersion2ID
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								require.Equal(t, testAppVersionID, appVersionEvent.AppVersionID)
+								require.Equal(t, "New version was created", appVersionEvent.Text)
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								require.Equal(t, "test-build-slug", buildSlug)
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version:          "1.0",
+												DeviceFamilyList: []int{1, 2, 12},
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version:          "1.0",
+												BuildNumber:      "12",
+												DeviceFamilyList: []int{1, 2, 12},
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+											Scheme:           "test-scheme",
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								return &bitrise.AppDetails{Title: "My awesome app"}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								require.Equal(t, "test-build-slug", buildSlug)
+								return &bitrise.BuildDetails{
+									CommitMessage:     "The detailed commit message",
+									CommitHash:        "a1b2c3",
+									Branch:            "master",
+									PullRequestID:     12,
+									TriggeredWorkflow: "ios-wf",
+									TriggeredBy:       "webhook",
+								}, nil
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								require.Equal(t, "test-app-slug", app.AppSlug)
+								return []models.AppContact{
+									models.AppContact{Email: "the.address@we.send"},
+								}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								require.Equal(t, testAppVersionID, appVersion.ID)
+								require.Equal(t, "My awesome app", appDetails.Title)
+								require.Len(t, contacts, 1)
+								require.Equal(t, "the.address@we.send", contacts[0].Email)
+								require.Equal(t, "https://ship.bitrise.io", frontendBaseURL)
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{
+							enqueueCopyUploadablesToNewAppVersionFn: func(fromID, toID string) error {
+								require.Equal(t, testAppVersion2ID.String(), fromID)
+								require.Equal(t, testAppVersionID.String(), toID)
+								return nil
+							},
+						},
+					},
+					payload: `{"build_slug":"test-build-slug","build_number":12}`,
+				})
+			})
+
+			t.Run("ok - more complex - when triggered workflow is whitelisted for iOS", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf","ios-wf2"]},{"platform":"android","workflows":["some-android-wf"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								appVersion.ID = testAppVersionID
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								appVersion.ID = testAppVersion2ID
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								require.Equal(t, testAppVersionID, appVersionEvent.AppVersionID)
+								require.Equal(t, "New version was created", appVersionEvent.Text)
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								require.Equal(t, "test-build-slug", buildSlug)
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version: "1.0",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version:          "1.0",
+												DeviceFamilyList: []int{1, 2, 12},
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								require.Equal(t, "test-build-slug", buildSlug)
+								return &bitrise.BuildDetails{CommitMessage: "The detailed commit message"}, nil
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								return []models.AppContact{}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{
+							enqueueCopyUploadablesToNewAppVersionFn: func(fromID, toID string) error {
+								require.Equal(t, testAppVersion2ID.String(), fromID)
+								require.Equal(t, testAppVersionID.String(), toID)
+								return nil
+							},
+						},
+					},
+					payload: `{"build_slug":"test-build-slug","build_triggered_workflow":"ios-wf"}`,
+				})
+			})
+
+			t.Run("when the branch of the build is excluded for iOS", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf"]},{"platform":"ios","exclude":true,"branches":["feature/*"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService:      &testAppVersionService{},
+						AppVersionEventService: &testAppVersionEventService{},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo:          bitrise.AppInfo{Version: "1.0"},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{Branch: "feature/login"}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:             `{"build_slug":"test-build-slug","build_triggered_workflow":"ios-wf"}`,
+					expectedAppVersions: []*models.AppVersion{},
+				})
+			})
+			t.Run("ok - a version for each tracked scheme and bundle ID", func(t *testing.T) {
+				claimedSchemes := []string{}
+				createdSchemes := []string{}
+				latestSchemes := []string{}
+				firstVersionsCreated := 0
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: &testProcessedBuildService{
+							claimFn: func(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error) {
+								require.Equal(t, "ios", processedBuild.Platform)
+								claimedSchemes = append(claimedSchemes, processedBuild.Scheme+"/"+processedBuild.BundleID)
+								return processedBuild, true, nil
+							},
+							updateFn: func(processedBuild *models.ProcessedBuild, whitelist []string) error {
+								return nil
+							},
+						},
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData: json.RawMessage(`{"tracked_schemes":["App","WhiteLabel"]}`),
+									App:             &models.App{AppSlug: "test-app-slug"},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								createdSchemes = append(createdSchemes, appVersion.Scheme)
+								appVersion.ID = uuid.NewV4()
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								latestSchemes = append(latestSchemes, appVersion.Scheme+"/"+bundleID)
+								return nil, gorm.ErrRecordNotFound
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return appVersionEvent, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "App.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											Scheme:  "App",
+											AppInfo: bitrise.AppInfo{Version: "1.0", BundleID: "io.bitrise.app"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "WhiteLabel.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											Scheme:  "WhiteLabel",
+											AppInfo: bitrise.AppInfo{Version: "1.0", BundleID: "io.bitrise.whitelabel"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "Playground.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											Scheme:  "Playground",
+											AppInfo: bitrise.AppInfo{Version: "1.0", BundleID: "io.bitrise.playground"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AnalyticsClient: &testAnalyticsClient{
+							firstVersionCreatedFn: func(appSlug, buildSlug, platform string) {
+								require.Equal(t, "ios", platform)
+								firstVersionsCreated++
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								return []models.AppContact{}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{},
+					},
+					payload: `{"build_slug":"test-build-slug"}`,
+				})
+				require.Equal(t, []string{"App/io.bitrise.app", "WhiteLabel/io.bitrise.whitelabel"}, claimedSchemes)
+				require.Equal(t, []string{"App/io.bitrise.app", "WhiteLabel/io.bitrise.whitelabel"}, latestSchemes)
+				require.Equal(t, []string{"App", "WhiteLabel"}, createdSchemes)
+				require.Equal(t, 1, firstVersionsCreated)
+			})
+
+			t.Run("ok - tvOS version by its own workflow rules", func(t *testing.T) {
+				createdPlatforms := []string{}
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: &testProcessedBuildService{
+							claimFn: func(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error) {
+								require.Equal(t, "tvos", processedBuild.Platform)
+								return processedBuild, true, nil
+							},
+							updateFn: func(processedBuild *models.ProcessedBuild, whitelist []string) error {
+								return nil
+							},
+						},
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf"]},{"platform":"tvos","workflows":["tv-wf"]}]`),
+									App:               &models.App{AppSlug: "test-app-slug"},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								createdPlatforms = append(createdPlatforms, appVersion.Platform)
+								appVersion.ID = uuid.NewV4()
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								require.Equal(t, "tvos", appVersion.Platform)
+								return nil, gorm.ErrRecordNotFound
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return appVersionEvent, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "App.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											Scheme:  "App",
+											AppInfo: bitrise.AppInfo{Version: "1.0", BundleID: "io.bitrise.app", DeviceFamilyList: []int{1, 2}},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "AppTV.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											Scheme:  "AppTV",
+											AppInfo: bitrise.AppInfo{Version: "1.0", BundleID: "io.bitrise.app.tv", DeviceFamilyList: []int{3}},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AnalyticsClient: &testAnalyticsClient{
+							firstVersionCreatedFn: func(appSlug, buildSlug, platform string) {
+								require.Equal(t, "tvos", platform)
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								return []models.AppContact{}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{},
+					},
+					payload: `{"build_slug":"test-build-slug","build_triggered_workflow":"tv-wf"}`,
+				})
+				require.Equal(t, []string{"tvos"}, createdPlatforms)
+			})
+
+			t.Run("when error happens at finding app settings in database", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return nil, errors.New("SOME-SQL-ERROR")
+							},
+						},
+						AppVersionService:      &testAppVersionService{},
+						AppVersionEventService: &testAppVersionEventService{},
+						BitriseAPI:             &testBitriseAPI{},
+						AppContactService:      &testAppContactService{},
+						WorkerService:          &testWorkerService{},
+					},
+					payload:     `{}`,
+					expectedErr: "SQL Error: SOME-SQL-ERROR",
+				})
+			})
+
+			t.Run("when getting artifacts from API retrieves error", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf","ios-wf2"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								appVersion.ID = testAppVersion2ID
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return nil, errors.New("SOME-BITRISE-API-ERROR")
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{CommitMessage: "The detailed commit message"}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug","build_triggered_workflow":"ios-wf"}`,
+					expectedErr: "SOME-BITRISE-API-ERROR",
+				})
+			})
+
+			t.Run("when no matching artifact found", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf","ios-wf2"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload: `{"build_slug":"test-build-slug","build_triggered_workflow":"ios-wf"}`,
+				})
+			})
+
+			t.Run("when selected artifact has no artifact meta", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf","ios-wf2"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title:        "my-ios-xcarchive.zip",
+										ArtifactMeta: nil,
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug","build_triggered_workflow":"ios-wf"}`,
+					expectedErr: "No artifact meta data found for artifact",
+				})
+			})
+
+			t.Run("when selected artifact has no app info", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData:   json.RawMessage(`{}`),
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf","ios-wf2"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo:          bitrise.AppInfo{},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo:          bitrise.AppInfo{},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug","build_triggered_workflow":"ios-wf"}`,
+					expectedErr: "No artifact app info found for artifact",
+				})
+			})
+
+			// t.Run("when selected artifact has no provision info", func(t *testing.T) {
+			// 	performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+			// 		appID: uuid.NewV4(),
+			// 		env: &env.AppEnv{
+			// 			AppService: &testAppService{
+			// 				findFn: func(app *models.App) (*models.App, error) {
+			// 					return app, nil
+			// 				},
+			// 			},
+			// 			AppSettingsService: &testAppSettingsService{
+			// 				findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+			// 					return &models.AppSettings{
+			// 						WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf","ios-wf2"]}]`),
+			// 						App: &models.App{
+			// 							BitriseAPIToken: "test-api-token",
+			// 							AppSlug:         "test-app-slug",
+			// 						},
+			// 					}, nil
+			// 				},
+			// 			},
+			// 			AppVersionService: &testAppVersionService{
+			// 				createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+			// 					require.Equal(t, "ios", appVersion.Platform)
+			// 					require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+			// 					require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+			// 					artifactData, err := appVersion.ArtifactInfo()
+			// 					require.NoError(t, err)
+			// 					require.Equal(t, "1.0", artifactData.Version)
+			// 					return appVersion, nil, nil
+			// 				},
+			// 			},
+			// 			BitriseAPI: &testBitriseAPI{
+			// 				getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+			// 					return []bitrise.ArtifactListElementResponseModel{
+			// 						bitrise.ArtifactListElementResponseModel{
+			// 							Title: "my-xcarchive.zip",
+			// 							ArtifactMeta: &bitrise.ArtifactMeta{
+			// 								AppInfo: bitrise.AppInfo{
+			// 									Version: "1.0",
+			// 								},
+			// 								ProvisioningInfo: bitrise.ProvisioningInfo{},
+			// 							},
+			// 						},
+			// 					}, nil
+			// 				},
+			// 			},
+			// 			AppContactService: &testAppContactService{},
+			// 		},
+			// 		payload:     `{"build_slug":"test-build-slug","build_triggered_workflow":"ios-wf"}`,
+			// 		expectedErr: "No artifact provisioning info found for artifact",
+			// 	})
+			// })
+
+			t.Run("when validation error is retrieved when creating new ios version", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								return nil, []error{errors.New("SOME-VALIDATION-ERROR")}, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version: "1.0",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version: "1.0",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "Validation error: SOME-VALIDATION-ERROR",
+				})
+			})
+
+			t.Run("when db error is retrieved when creating new ios version", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								return nil, nil, errors.New("SOME-SQL-ERROR")
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version: "1.0",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version: "1.0",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "SQL Error: SOME-SQL-ERROR",
+				})
+			})
+
+			t.Run("when db error is retrieved when creating new version event", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, errors.New("SOME-SQL-ERROR")
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version: "1.0",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version: "1.0",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AnalyticsClient: &testAnalyticsClient{
+							firstVersionCreatedFn: func(appSlug, buildSlug, platform string) {
+								require.Equal(t, "ios", platform)
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "SQL Error: SOME-SQL-ERROR",
+				})
+			})
+
+			t.Run("when failed to get app contacts for email sending", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AddonFrontendHostURL:  "https://ship.bitrise.io",
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								require.Equal(t, "12", appVersion.BuildNumber)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								require.Equal(t, []string{"iPhone", "iPod Touch", "iPad", "Unknown"}, artifactData.SupportedDeviceTypes)
+								appVersion.ID = testAppVersionID
+								appVersion.App = models.App{
+									BitriseAPIToken: "test-api-token",
+									AppSlug:         "test-app-slug",
+								}
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								require.Equal(t, "test-build-slug", buildSlug)
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version:          "1.0",
+												DeviceFamilyList: []int{1, 2, 12},
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version:          "1.0",
+												BuildNumber:      "12",
+												DeviceFamilyList: []int{1, 2, 12},
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								return &bitrise.AppDetails{Title: "My awesome app"}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AnalyticsClient: &testAnalyticsClient{
+							firstVersionCreatedFn: func(appSlug, buildSlug, platform string) {
+								require.Equal(t, "ios", platform)
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								return []models.AppContact{}, errors.New("SOME-DB-ERROR")
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								require.Equal(t, testAppVersionID, appVersion.ID)
+								require.Equal(t, "My awesome app", appDetails.Title)
+								require.Len(t, contacts, 1)
+								require.Equal(t, "the.address@we.send", contacts[0].Email)
+								require.Equal(t, "https://ship.bitrise.io", frontendBaseURL)
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug","build_number":12}`,
+					expectedErr: "SOME-DB-ERROR",
+				})
+			})
+
+			t.Run("when failed to fetch app details from API", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AddonFrontendHostURL:  "https://ship.bitrise.io",
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									IosSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "ios", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								require.Equal(t, "12", appVersion.BuildNumber)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								require.Equal(t, []string{"iPhone", "iPod Touch", "iPad", "Unknown"}, artifactData.SupportedDeviceTypes)
+								appVersion.ID = testAppVersionID
+								appVersion.App = models.App{
+									BitriseAPIToken: "test-api-token",
+									AppSlug:         "test-app-slug",
+								}
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								require.Equal(t, "test-build-slug", buildSlug)
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.ipa",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version:          "1.0",
+												DeviceFamilyList: []int{1, 2, 12},
+												BuildNumber:      "12",
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-ios-artifact.xcarchive.zip",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												Version:          "1.0",
+												DeviceFamilyList: []int{1, 2, 12},
+											},
+											ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return nil, errors.New("SOME-BITRISE-API-ERROR")
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								require.Equal(t, "test-app-slug", app.AppSlug)
+								return []models.AppContact{
+									models.AppContact{Email: "the.address@we.send"},
+								}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								require.Equal(t, testAppVersionID, appVersion.ID)
+								require.Equal(t, "My awesome app", appDetails.Title)
+								require.Len(t, contacts, 1)
+								require.Equal(t, "the.address@we.send", contacts[0].Email)
+								require.Equal(t, "https://ship.bitrise.io", frontendBaseURL)
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug","build_number":12}`,
+					expectedErr: "SOME-BITRISE-API-ERROR",
+				})
+			})
+		})
+
+		t.Run("when platform is android", func(t *testing.T) {
+			testAppVersionID := uuid.FromStringOrNil("9f7b06d1-e736-42d3-94c3-c2bcfda0463c")
+			testAppVersion2ID := uuid.FromStringOrNil("f951e094-0ac1-4edf-ac8f-bb035dfd683c")
+			t.Run("ok - more complex - when android workflow whitelist is empty", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AddonFrontendHostURL:  "https://ship.bitrise.io",
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData:   json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									AndroidSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.Equal(t, "Some commit message", appVersion.CommitMessage)
+								require.Equal(t, "release/1.0", appVersion.Branch)
+								require.Equal(t, "https://app.bitrise.io/build/test-build-slug", appVersion.BuildURL)
+								require.Equal(t, "test-product-flavor", appVersion.ProductFlavor)
+								appInfo, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, models.ArtifactInfo{
+									Version: "1.0", MinimumSDK: "1.23", PackageName: "myPackage",
+									VersionCode: "abc123", Module: "test-module",
+									BuildType: "test-build-type",
+								}, appInfo)
+								appVersion.ID = testAppVersionID
+								appVersion.App = models.App{
+									BitriseAPIToken: "test-api-token",
+									AppSlug:         "test-app-slug",
+								}
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								appVersion.ID = testAppVersion2ID
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								require.Equal(t, testAppVersionID, appVersionEvent.AppVersionID)
+								require.Equal(t, "New version was created", appVersionEvent.Text)
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-android-artifact.aab",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName:       "1.0",
+												MinimumSDKVersion: "1.23",
+												PackageName:       "myPackage",
+												VersionCode:       "abc123",
+											},
+											Module:        "test-module",
+											ProductFlavor: "test-product-flavor",
+											BuildType:     "test-build-type",
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								return &bitrise.AppDetails{Title: "My awesome app"}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{CommitMessage: "Some commit message", Branch: "release/1.0"}, nil
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								require.Equal(t, "test-app-slug", app.AppSlug)
+								return []models.AppContact{
+									models.AppContact{Email: "the.address@we.send"},
+								}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								require.Equal(t, testAppVersionID, appVersion.ID)
+								require.Equal(t, "My awesome app", appDetails.Title)
+								require.Len(t, contacts, 1)
+								require.Equal(t, "the.address@we.send", contacts[0].Email)
+								require.Equal(t, "https://ship.bitrise.io", frontendBaseURL)
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{
+							enqueueCopyUploadablesToNewAppVersionFn: func(fromID, toID string) error {
+								require.Equal(t, testAppVersion2ID.String(), fromID)
+								require.Equal(t, testAppVersionID.String(), toID)
+								return nil
+							},
+						},
+					},
+					payload: `{"build_slug":"test-build-slug"}`,
+				})
+			})
+
+			t.Run("ok - more complex - when triggered workflow is whitelisted for Android", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData:   json.RawMessage(`[{"platform":"android","workflows":["android-wf","android-wf2"]},{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									AndroidSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								appInfo, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, models.ArtifactInfo{Version: "1.0", MinimumSDK: "1.23", PackageName: "myPackage"}, appInfo)
+								appVersion.ID = testAppVersionID
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								appVersion.ID = testAppVersion2ID
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								require.Equal(t, testAppVersionID, appVersionEvent.AppVersionID)
+								require.Equal(t, "New version was created", appVersionEvent.Text)
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-android-artifact.aab",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName:       "1.0",
+												MinimumSDKVersion: "1.23",
+												PackageName:       "myPackage",
+											},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								return []models.AppContact{}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{
+							enqueueCopyUploadablesToNewAppVersionFn: func(fromID, toID string) error {
+								require.Equal(t, testAppVersion2ID.String(), fromID)
+								require.Equal(t, testAppVersionID.String(), toID)
+								return nil
+							},
+						},
+					},
+					payload: `{"build_slug":"test-build-slug","build_triggered_workflow":"android-wf"}`,
+				})
+			})
+
+			t.Run("ok - more complex - when there are split APKs", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AddonFrontendHostURL:  "https://ship.bitrise.io",
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData:   json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									AndroidSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								appInfo, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, models.ArtifactInfo{Version: "1.0", PackageName: "myPackage"}, appInfo)
+								appVersion.ID = testAppVersionID
+								appVersion.App = models.App{
+									BitriseAPIToken: "test-api-token",
+									AppSlug:         "test-app-slug",
+								}
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								appVersion.ID = testAppVersion2ID
+								return appVersion, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								require.Equal(t, testAppVersionID, appVersionEvent.AppVersionID)
+								require.Equal(t, "New version was created", appVersionEvent.Text)
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "app-armeabi-my-android-artifact.apk",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName: "1.0",
+												AppName:     "My Android APK",
+												PackageName: "myPackage",
+											},
+										},
+									},
+									bitrise.ArtifactListElementResponseModel{
+										Title: "app-armeabi-my-android-artifact.apk",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName: "1.0",
+												AppName:     "My Android APK",
+												PackageName: "myPackage",
+											},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								return &bitrise.AppDetails{Title: "My awesome app"}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								require.Equal(t, "test-app-slug", app.AppSlug)
+								return []models.AppContact{
+									models.AppContact{Email: "the.address@we.send"},
+								}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								require.Equal(t, testAppVersionID, appVersion.ID)
+								require.Equal(t, "My awesome app", appDetails.Title)
+								require.Len(t, contacts, 1)
+								require.Equal(t, "the.address@we.send", contacts[0].Email)
+								require.Equal(t, "https://ship.bitrise.io", frontendBaseURL)
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{
+							enqueueCopyUploadablesToNewAppVersionFn: func(fromID, toID string) error {
+								require.Equal(t, testAppVersion2ID.String(), fromID)
+								require.Equal(t, testAppVersionID.String(), toID)
+								return nil
+							},
+						},
+					},
+					payload: `{"build_slug":"test-build-slug"}`,
+				})
+			})
+
+			t.Run("when getting artifacts from API retrieves error", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData: json.RawMessage(`[{"platform":"android","workflows":["android-wf","android-wf2"]},{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return nil, errors.New("SOME-BITRISE-API-ERROR")
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug","build_triggered_workflow":"android-wf"}`,
+					expectedErr: "SOME-BITRISE-API-ERROR",
+				})
+			})
+
+			t.Run("when no matching artifact found", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload: `{"build_slug":"test-build-slug"}`,
+				})
+			})
+
+			t.Run("when validation error is retrieved when creating new android version", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData:   json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									AndroidSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								return nil, []error{errors.New("SOME-VALIDATION-ERROR")}, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-android-artifact.aab",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName:       "1.0",
+												MinimumSDKVersion: "1.23",
+												PackageName:       "myPackage",
+											},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "Validation error: SOME-VALIDATION-ERROR",
+				})
+			})
+
+			t.Run("when db error is retrieved when creating new android version", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData:   json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									AndroidSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								return nil, nil, errors.New("SOME-SQL-ERROR")
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-android-artifact.aab",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName:       "1.0",
+												MinimumSDKVersion: "1.23",
+												PackageName:       "myPackage",
+											},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "SQL Error: SOME-SQL-ERROR",
+				})
+			})
+
+			t.Run("when db error is retrieved when creating new version event", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData:   json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									AndroidSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								require.NotEqual(t, time.Time{}, appVersion.LastUpdate)
+								artifactData, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, "1.0", artifactData.Version)
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, errors.New("SOME-SQL-ERROR")
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-android-artifact.aab",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName:       "1.0",
+												MinimumSDKVersion: "1.23",
+												PackageName:       "myPackage",
+											},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return &bitrise.AppDetails{}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AnalyticsClient: &testAnalyticsClient{
+							firstVersionCreatedFn: func(appSlug, buildSlug, platform string) {
+								require.Equal(t, "android", platform)
+							},
+						},
+						AppContactService: &testAppContactService{},
+						WorkerService:     &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "SQL Error: SOME-SQL-ERROR",
+				})
+			})
+
+			t.Run("when failed to get app contacts for email sending", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AddonFrontendHostURL:  "https://ship.bitrise.io",
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData:   json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									AndroidSettingsData: json.RawMessage(`{}`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								appInfo, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, models.ArtifactInfo{Version: "1.0", MinimumSDK: "1.23", PackageName: "myPackage"}, appInfo)
+								appVersion.ID = testAppVersionID
+								appVersion.App = models.App{
+									BitriseAPIToken: "test-api-token",
+									AppSlug:         "test-app-slug",
+								}
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-android-artifact.aab",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName:       "1.0",
+												MinimumSDKVersion: "1.23",
+												PackageName:       "myPackage",
+											},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								require.Equal(t, "test-api-token", apiToken)
+								require.Equal(t, "test-app-slug", appSlug)
+								return &bitrise.AppDetails{Title: "My awesome app"}, nil
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AnalyticsClient: &testAnalyticsClient{
+							firstVersionCreatedFn: func(appSlug, buildSlug, platform string) {
+								require.Equal(t, "android", platform)
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								require.Equal(t, "test-app-slug", app.AppSlug)
+								return []models.AppContact{}, errors.New("SOME-DB-ERROR")
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								require.Equal(t, testAppVersionID, appVersion.ID)
+								require.Equal(t, "My awesome app", appDetails.Title)
+								require.Len(t, contacts, 1)
+								require.Equal(t, "the.address@we.send", contacts[0].Email)
+								require.Equal(t, "https://ship.bitrise.io", frontendBaseURL)
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "SOME-DB-ERROR",
+				})
+			})
+
+			t.Run("when failed to fetch app details from API", func(t *testing.T) {
+				performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+					appID: uuid.NewV4(),
+					env: &env.AppEnv{
+						ProcessedBuildService: claimingProcessedBuildService(),
+						AddonFrontendHostURL:  "https://ship.bitrise.io",
+						AppService: &testAppService{
+							findFn: func(app *models.App) (*models.App, error) {
+								return app, nil
+							},
+						},
+						AppSettingsService: &testAppSettingsService{
+							findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+								return &models.AppSettings{
+									WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["some-ios-wf"]}]`),
+									App: &models.App{
+										BitriseAPIToken: "test-api-token",
+										AppSlug:         "test-app-slug",
+									},
+								}, nil
+							},
+						},
+						AppVersionService: &testAppVersionService{
+							createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+								require.Equal(t, "android", appVersion.Platform)
+								require.Equal(t, "test-build-slug", appVersion.BuildSlug)
+								appInfo, err := appVersion.ArtifactInfo()
+								require.NoError(t, err)
+								require.Equal(t, models.ArtifactInfo{Version: "1.0", MinimumSDK: "1.23", PackageName: "myPackage"}, appInfo)
+								appVersion.ID = testAppVersionID
+								appVersion.App = models.App{
+									BitriseAPIToken: "test-api-token",
+									AppSlug:         "test-app-slug",
+								}
+								return appVersion, nil, nil
+							},
+							latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+								return nil, nil
+							},
+						},
+						AppVersionEventService: &testAppVersionEventService{
+							createFn: func(appVersionEvent *models.AppVersionEvent) (*models.AppVersionEvent, error) {
+								return nil, nil
+							},
+						},
+						BitriseAPI: &testBitriseAPI{
+							getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+								return []bitrise.ArtifactListElementResponseModel{
+									bitrise.ArtifactListElementResponseModel{
+										Title: "my-android-artifact.aab",
+										ArtifactMeta: &bitrise.ArtifactMeta{
+											AppInfo: bitrise.AppInfo{
+												VersionName:       "1.0",
+												MinimumSDKVersion: "1.23",
+												PackageName:       "myPackage",
+											},
+										},
+									},
+								}, nil
+							},
+							getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+								return nil, errors.New("SOME-BITRISE-API-ERROR")
+							},
+							getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+								return &bitrise.BuildDetails{}, nil
+							},
+						},
+						AppContactService: &testAppContactService{
+							findAllFn: func(app *models.App) ([]models.AppContact, error) {
+								require.Equal(t, "test-app-slug", app.AppSlug)
+								return []models.AppContact{
+									models.AppContact{Email: "the.address@we.send"},
+								}, nil
+							},
+						},
+						Mailer: &testMailer{
+							sendEmailNewVersionFn: func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error {
+								require.Equal(t, testAppVersionID, appVersion.ID)
+								require.Equal(t, "My awesome app", appDetails.Title)
+								require.Len(t, contacts, 1)
+								require.Equal(t, "the.address@we.send", contacts[0].Email)
+								require.Equal(t, "https://ship.bitrise.io", frontendBaseURL)
+								return nil
+							},
+						},
+						WorkerService: &testWorkerService{},
+					},
+					payload:     `{"build_slug":"test-build-slug"}`,
+					expectedErr: "SOME-BITRISE-API-ERROR",
+				})
+			})
+		})
+	})
+
+	t.Run("when build failed", func(t *testing.T) {
+		testAppID := uuid.NewV4()
+		failedBuildEnv := func(appEventService *testAppEventService, mailer *testMailer) *env.AppEnv {
+			return &env.AppEnv{
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return app, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						return &models.AppSettings{
+							WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["ios-wf"]}]`),
+							App: &models.App{
+								BitriseAPIToken: "test-api-token",
+								AppSlug:         "test-app-slug",
+							},
+						}, nil
+					},
+				},
+				AppEventService: appEventService,
+				BitriseAPI: &testBitriseAPI{
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{Title: "Standup Timer", ProjectType: "ios"}, nil
+					},
+					getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+						require.Equal(t, "test-build-slug", buildSlug)
+						return &bitrise.BuildDetails{Branch: "master"}, nil
+					},
+				},
+				AppContactService: &testAppContactService{
+					findAllFn: func(app *models.App) ([]models.AppContact, error) {
+						return []models.AppContact{{Email: "someones@email.addr"}}, nil
+					},
+				},
+				Mailer: mailer,
+			}
+		}
+		notRecordedBuild := func(appEvent *models.AppEvent) (*models.AppEvent, error) {
+			require.Equal(t, testAppID, appEvent.AppID)
+			require.Equal(t, "test-build-slug", appEvent.BuildSlug)
+			return nil, gorm.ErrRecordNotFound
+		}
+
+		t.Run("ok - records the build and notifies the contacts", func(t *testing.T) {
+			emailSent := false
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: testAppID,
+				env: failedBuildEnv(&testAppEventService{
+					findFn: notRecordedBuild,
+					createFn: func(appEvent *models.AppEvent) (*models.AppEvent, error) {
+						require.True(t, emailSent)
+						require.Equal(t, models.AppEvent{
+							AppID:       testAppID,
+							Status:      models.AppEventStatusBuildFailed,
+							Text:        "Build failed",
+							Workflow:    "ios-wf",
+							BuildSlug:   "test-build-slug",
+							BuildNumber: 28,
+						}, *appEvent)
+						return appEvent, nil
+					},
+				}, &testMailer{
+					sendEmailBuildFailedFn: func(appEvent *models.AppEvent, contacts []models.AppContact, appDetails *bitrise.AppDetails) error {
+						require.Equal(t, models.AppEventStatusBuildFailed, appEvent.Status)
+						require.Equal(t, []models.AppContact{{Email: "someones@email.addr"}}, contacts)
+						require.Equal(t, "Standup Timer", appDetails.Title)
+						emailSent = true
+						return nil
+					},
+				}),
+				payload:             `{"build_slug":"test-build-slug","build_number":28,"build_status":2,"build_triggered_workflow":"ios-wf"}`,
+				expectedAppVersions: []*models.AppVersion{},
+			})
+			require.True(t, emailSent)
+		})
+
+		t.Run("ok - when build was aborted", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: testAppID,
+				env: failedBuildEnv(&testAppEventService{
+					findFn: notRecordedBuild,
+					createFn: func(appEvent *models.AppEvent) (*models.AppEvent, error) {
+						require.Equal(t, models.AppEventStatusBuildAborted, appEvent.Status)
+						require.Equal(t, "Build was aborted", appEvent.Text)
+						return appEvent, nil
+					},
+				}, &testMailer{
+					sendEmailBuildFailedFn: func(appEvent *models.AppEvent, contacts []models.AppContact, appDetails *bitrise.AppDetails) error {
+						return nil
+					},
+				}),
+				payload:             `{"build_slug":"test-build-slug","build_status":3,"build_triggered_workflow":"ios-wf"}`,
+				expectedAppVersions: []*models.AppVersion{},
+			})
+		})
+
+		t.Run("ok - when versions are not created from the workflow of the build", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID:               testAppID,
+				env:                 failedBuildEnv(&testAppEventService{}, &testMailer{}),
+				payload:             `{"build_slug":"test-build-slug","build_status":2,"build_triggered_workflow":"primary"}`,
+				expectedAppVersions: []*models.AppVersion{},
+			})
+		})
+
+		t.Run("ok - when build was already recorded", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: testAppID,
+				env: failedBuildEnv(&testAppEventService{
+					findFn: func(appEvent *models.AppEvent) (*models.AppEvent, error) {
+						return appEvent, nil
+					},
+				}, &testMailer{}),
+				payload:             `{"build_slug":"test-build-slug","build_status":2,"build_triggered_workflow":"ios-wf"}`,
+				expectedAppVersions: []*models.AppVersion{},
+			})
+		})
+
+		t.Run("when error happens at creating the event", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: testAppID,
+				env: failedBuildEnv(&testAppEventService{
+					findFn: notRecordedBuild,
+					createFn: func(appEvent *models.AppEvent) (*models.AppEvent, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				}, &testMailer{
+					sendEmailBuildFailedFn: func(appEvent *models.AppEvent, contacts []models.AppContact, appDetails *bitrise.AppDetails) error {
+						return nil
+					},
+				}),
+				payload:     `{"build_slug":"test-build-slug","build_status":2,"build_triggered_workflow":"ios-wf"}`,
+				expectedErr: "SQL Error: SOME-SQL-ERROR",
+			})
+		})
+
+		t.Run("when error happens at sending the notification", func(t *testing.T) {
+			performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+				appID: testAppID,
+				env: failedBuildEnv(&testAppEventService{
+					findFn: notRecordedBuild,
+				}, &testMailer{
+					sendEmailBuildFailedFn: func(appEvent *models.AppEvent, contacts []models.AppContact, appDetails *bitrise.AppDetails) error {
+						return errors.New("SOME-SES-ERROR")
+					},
+				}),
+				payload:     `{"build_slug":"test-build-slug","build_status":2,"build_triggered_workflow":"ios-wf"}`,
+				expectedErr: "SOME-SES-ERROR",
+			})
+		})
+	})
+
+	t.Run("when the build was already processed", func(t *testing.T) {
+		testAppID := uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+		testIosAppVersion := &models.AppVersion{
+			Record:    models.Record{ID: uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")},
+			Platform:  "ios",
+			BuildSlug: "test-build-slug",
+		}
+		testAndroidAppVersion := &models.AppVersion{
+			Record:        models.Record{ID: uuid.FromStringOrNil("5b6d5ae2-28a2-4c29-8e09-0e3ab5dc4b1f")},
+			Platform:      "android",
+			BuildSlug:     "test-build-slug",
+			ProductFlavor: "test-product-flavor",
+		}
+
+		performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+			appID: testAppID,
+			env: &env.AppEnv{
+				ProcessedBuildService: &testProcessedBuildService{
+					claimFn: func(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error) {
+						require.Equal(t, testAppID, processedBuild.AppID)
+						require.Equal(t, "test-build-slug", processedBuild.BuildSlug)
+						switch processedBuild.Platform {
+						case "ios":
+							require.Equal(t, "", processedBuild.ProductFlavor)
+							require.Equal(t, "", processedBuild.Module)
+							processedBuild.AppVersion = testIosAppVersion
+						case "android":
+							require.Equal(t, "test-product-flavor", processedBuild.ProductFlavor)
+							require.Equal(t, "test-module", processedBuild.Module)
+							processedBuild.AppVersion = testAndroidAppVersion
+						}
+						return processedBuild, false, nil
+					},
+				},
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return app, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						return &models.AppSettings{
+							IosSettingsData:     json.RawMessage(`{}`),
+							AndroidSettingsData: json.RawMessage(`{"module":"test-module"}`),
+							App:                 &models.App{},
+						}, nil
+					},
+				},
+				AppVersionService:      &testAppVersionService{},
+				AppVersionEventService: &testAppVersionEventService{},
+				BitriseAPI: &testBitriseAPI{
+					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{
+							bitrise.ArtifactListElementResponseModel{
+								Title: "my-ios-artifact.xcarchive.zip",
+								ArtifactMeta: &bitrise.ArtifactMeta{
+									AppInfo:          bitrise.AppInfo{Version: "1.0"},
+									ProvisioningInfo: bitrise.ProvisioningInfo{IPAExportMethod: "app-store"},
+								},
+							},
+							bitrise.ArtifactListElementResponseModel{
+								Title: "my-android-artifact.aab",
+								ArtifactMeta: &bitrise.ArtifactMeta{
+									AppInfo:       bitrise.AppInfo{VersionName: "1.0"},
+									Module:        "test-module",
+									ProductFlavor: "test-product-flavor",
+								},
+							},
+						}, nil
+					},
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{}, nil
+					},
+					getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+						return &bitrise.BuildDetails{}, nil
+					},
+				},
+				AppContactService: &testAppContactService{},
+				WorkerService:     &testWorkerService{},
+			},
+			payload:             `{"build_slug":"test-build-slug"}`,
+			expectedAppVersions: []*models.AppVersion{testIosAppVersion, testAndroidAppVersion},
+		})
+	})
+
+	t.Run("when creating the version of the build fails", func(t *testing.T) {
+		testProcessedBuildID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+		released := false
+
+		performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+			appID: uuid.NewV4(),
+			env: &env.AppEnv{
+				ProcessedBuildService: &testProcessedBuildService{
+					claimFn: func(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error) {
+						processedBuild.ID = testProcessedBuildID
+						return processedBuild, true, nil
+					},
+					deleteFn: func(processedBuild *models.ProcessedBuild) error {
+						require.Equal(t, testProcessedBuildID, processedBuild.ID)
+						released = true
+						return nil
+					},
+				},
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return app, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						return &models.AppSettings{
+							AndroidSettingsData: json.RawMessage(`{}`),
+							App:                 &models.App{},
+						}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+						return nil, nil, errors.New("SOME-SQL-ERROR")
+					},
+					latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+						return nil, gorm.ErrRecordNotFound
+					},
+				},
+				AppVersionEventService: &testAppVersionEventService{},
+				BitriseAPI: &testBitriseAPI{
+					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{
+							bitrise.ArtifactListElementResponseModel{
+								Title: "my-android-artifact.aab",
+								ArtifactMeta: &bitrise.ArtifactMeta{
+									AppInfo: bitrise.AppInfo{VersionName: "1.0"},
+								},
+							},
+						}, nil
+					},
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{}, nil
+					},
+					getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+						return &bitrise.BuildDetails{}, nil
+					},
+				},
+				AppContactService: &testAppContactService{},
+				WorkerService:     &testWorkerService{},
+			},
+			payload:     `{"build_slug":"test-build-slug"}`,
+			expectedErr: "SQL Error: SOME-SQL-ERROR",
+		})
+		require.True(t, released)
+	})
+
+	t.Run("when storing the version of the build fails", func(t *testing.T) {
+		testProcessedBuildID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+		testAppVersionID := uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+		deletedAppVersion := false
+		released := false
+
+		performBuildWebhookProcessingTest(t, buildWebhookProcessingTestCase{
+			appID: uuid.NewV4(),
+			env: &env.AppEnv{
+				ProcessedBuildService: &testProcessedBuildService{
+					claimFn: func(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error) {
+						processedBuild.ID = testProcessedBuildID
+						return processedBuild, true, nil
+					},
+					updateFn: func(processedBuild *models.ProcessedBuild, whitelist []string) error {
+						require.Equal(t, testAppVersionID, *processedBuild.AppVersionID)
+						return errors.New("SOME-SQL-ERROR")
+					},
+					deleteFn: func(processedBuild *models.ProcessedBuild) error {
+						require.True(t, deletedAppVersion)
+						require.Equal(t, testProcessedBuildID, processedBuild.ID)
+						released = true
+						return nil
+					},
+				},
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return app, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						return &models.AppSettings{
+							AndroidSettingsData: json.RawMessage(`{}`),
+							App:                 &models.App{},
+						}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					createFn: func(appVersion *models.AppVersion) (*models.AppVersion, []error, error) {
+						appVersion.ID = testAppVersionID
+						return appVersion, nil, nil
+					},
+					latestFn: func(appVersion *models.AppVersion, bundleID string) (*models.AppVersion, error) {
+						return nil, gorm.ErrRecordNotFound
+					},
+					deleteFn: func(appVersion *models.AppVersion) error {
+						require.Equal(t, testAppVersionID, appVersion.ID)
+						deletedAppVersion = true
+						return nil
+					},
+				},
+				AppVersionEventService: &testAppVersionEventService{},
+				BitriseAPI: &testBitriseAPI{
+					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{
+							bitrise.ArtifactListElementResponseModel{
+								Title: "my-android-artifact.aab",
+								ArtifactMeta: &bitrise.ArtifactMeta{
+									AppInfo: bitrise.AppInfo{VersionName: "1.0"},
+								},
+							},
+						}, nil
+					},
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{}, nil
+					},
+					getBuildDetailsFn: func(apiToken string, appSlug string, buildSlug string) (*bitrise.BuildDetails, error) {
+						return &bitrise.BuildDetails{}, nil
+					},
+				},
+				AppContactService: &testAppContactService{},
+				WorkerService:     &testWorkerService{},
+			},
+			payload:     `{"build_slug":"test-build-slug"}`,
+			expectedErr: "SQL Error: SOME-SQL-ERROR",
+		})
+		require.True(t, released)
+	})
+}
+
+func claimingProcessedBuildService() *testProcessedBuildService {
+	return &testProcessedBuildService{
+		claimFn: func(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error) {
+			return processedBuild, true, nil
+		},
+		updateFn: func(processedBuild *models.ProcessedBuild, whitelist []string) error {
+			return nil
+		},
+		deleteFn: func(processedBuild *models.ProcessedBuild) error {
+			return nil
+		},
+	}
+}
+
+type buildWebhookProcessingTestCase struct {
+	appID               uuid.UUID
+	env                 *env.AppEnv
+	payload             string
+	expectedErr         string
+	expectedAppVersions []*models.AppVersion
+}
+
+func performBuildWebhookProcessingTest(t *testing.T, tc buildWebhookProcessingTestCase) {
+	t.Helper()
+
+	var params ingestion.BuildWebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(tc.payload), &params))
+
+	appVersions, err := ingestion.ProcessBuildWebhook(tc.env, tc.appID, params)
+	if tc.expectedErr != "" {
+		require.EqualError(t, err, tc.expectedErr)
+		return
+	}
+	require.NoError(t, err)
+	if tc.expectedAppVersions != nil {
+		require.Equal(t, tc.expectedAppVersions, appVersions)
+	}
+}
@@ -0,0 +1,41 @@
+package ingestion_test
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	uuid "github.com/satori/go.uuid"
+)
+
+type testProcessedBuildService struct {
+	claimFn        func(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error)
+	updateFn       func(processedBuild *models.ProcessedBuild, whitelist []string) error
+	releaseBuildFn func(appID uuid.UUID, buildSlug string) error
+	deleteFn       func(processedBuild *models.ProcessedBuild) error
+}
+
+func (s *testProcessedBuildService) Claim(processedBuild *models.ProcessedBuild) (*models.ProcessedBuild, bool, error) {
+	if s.claimFn == nil {
+		panic("You have to override ProcessedBuildService.Claim function in tests")
+	}
+	return s.claimFn(processedBuild)
+}
+
+func (s *testProcessedBuildService) Update(processedBuild *models.ProcessedBuild, whitelist []string) error {
+	if s.updateFn == nil {
+		panic("You have to override ProcessedBuildService.Update function in tests")
+	}
+	return s.updateFn(processedBuild, whitelist)
+}
+
+func (s *testProcessedBuildService) ReleaseBuild(appID uuid.UUID, buildSlug string) error {
+	if s.releaseBuildFn == nil {
+		panic("You have to override ProcessedBuildService.ReleaseBuild function in tests")
+	}
+	return s.releaseBuildFn(appID, buildSlug)
+}
+
+func (s *testProcessedBuildService) Delete(processedBuild *models.ProcessedBuild) error {
+	if s.deleteFn == nil {
+		panic("You have to override ProcessedBuildService.Delete function in tests")
+	}
+	return s.deleteFn(processedBuild)
+}
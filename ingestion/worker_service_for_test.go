@@ -0,0 +1,105 @@
+package ingestion_test
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	uuid "github.com/satori/go.uuid"
+)
+
+type testWorkerService struct {
+	enqueueStoreLogToAWSFn                  func(uuid.UUID, int64, string, int64) error
+	enqueueStoreLogChunkToRedisFn           func(string, models.LogChunk, int64) error
+	enqueueCopyUploadablesToNewAppVersionFn func(appVersionFromCopyID, appVersionToCopyID string) error
+	enqueueVerifyUploadedImageFn            func(uploadableType string, uploadableID uuid.UUID) error
+	enqueueStoreUploadedAppPreviewFn        func(appPreviewID uuid.UUID) error
+	enqueueResizeScreenshotsFn              func(appVersionID uuid.UUID) error
+	enqueueImportScreenshotArchiveFn        func(jobStatusID uuid.UUID) error
+	enqueueProcessBuildWebhookFn            func(buildWebhookID uuid.UUID) error
+	enqueueReplayBuildWebhookFn             func(buildWebhookID uuid.UUID, replaceVersions bool) error
+	enqueueCopyFromAppVersionFn             func(jobStatusID, sourceAppVersionID uuid.UUID, parts models.AppVersionCopyParts) error
+	enqueueDeprovisionAppFn                 func(appTombstoneID uuid.UUID) error
+	enqueueUpdateHeaderPaletteFn            func(appID uuid.UUID, avatarURL string) error
+}
+
+func (s *testWorkerService) EnqueueStoreLogToAWS(appVersionEventID, publishTaskExternalID uuid.UUID, numberOfLogChunks int64, awsPath string, secondsFromNow int64) error {
+	if s.enqueueStoreLogToAWSFn == nil {
+		panic("You have to override EnqueueStoreLogToAWS function in tests")
+	}
+	return s.enqueueStoreLogToAWSFn(publishTaskExternalID, numberOfLogChunks, awsPath, secondsFromNow)
+}
+
+func (s *testWorkerService) EnqueueStoreLogChunkToRedis(publishTaskExternalID string, logChunk models.LogChunk, secondsFromNow int64) error {
+	if s.enqueueStoreLogChunkToRedisFn == nil {
+		panic("You have to override EnqueueStoreLogChunkToRedis function in tests")
+	}
+	return s.enqueueStoreLogChunkToRedisFn(publishTaskExternalID, logChunk, secondsFromNow)
+}
+
+func (s *testWorkerService) EnqueueCopyUploadablesToNewAppVersion(appVersionFromCopyID, appVersionToCopyID string) error {
+	if s.enqueueCopyUploadablesToNewAppVersionFn == nil {
+		panic("You have to override EnqueueCopyUploadablesToNewAppVersion function in tests")
+	}
+	return s.enqueueCopyUploadablesToNewAppVersionFn(appVersionFromCopyID, appVersionToCopyID)
+}
+
+func (s *testWorkerService) EnqueueVerifyUploadedImage(uploadableType string, uploadableID uuid.UUID) error {
+	if s.enqueueVerifyUploadedImageFn == nil {
+		panic("You have to override EnqueueVerifyUploadedImage function in tests")
+	}
+	return s.enqueueVerifyUploadedImageFn(uploadableType, uploadableID)
+}
+
+func (s *testWorkerService) EnqueueStoreUploadedAppPreview(appPreviewID uuid.UUID) error {
+	if s.enqueueStoreUploadedAppPreviewFn == nil {
+		panic("You have to override EnqueueStoreUploadedAppPreview function in tests")
+	}
+	return s.enqueueStoreUploadedAppPreviewFn(appPreviewID)
+}
+
+func (s *testWorkerService) EnqueueResizeScreenshots(appVersionID uuid.UUID) error {
+	if s.enqueueResizeScreenshotsFn == nil {
+		panic("You have to override EnqueueResizeScreenshots function in tests")
+	}
+	return s.enqueueResizeScreenshotsFn(appVersionID)
+}
+
+func (s *testWorkerService) EnqueueImportScreenshotArchive(jobStatusID uuid.UUID) error {
+	if s.enqueueImportScreenshotArchiveFn == nil {
+		panic("You have to override EnqueueImportScreenshotArchive function in tests")
+	}
+	return s.enqueueImportScreenshotArchiveFn(jobStatusID)
+}
+
+func (s *testWorkerService) EnqueueProcessBuildWebhook(buildWebhookID uuid.UUID) error {
+	if s.enqueueProcessBuildWebhookFn == nil {
+		panic("You have to override EnqueueProcessBuildWebhook function in tests")
+	}
+	return s.enqueueProcessBuildWebhookFn(buildWebhookID)
+}
+
+func (s *testWorkerService) EnqueueReplayBuildWebhook(buildWebhookID uuid.UUID, replaceVersions bool) error {
+	if s.enqueueReplayBuildWebhookFn == nil {
+		panic("You have to override EnqueueReplayBuildWebhook function in tests")
+	}
+	return s.enqueueReplayBuildWebhookFn(buildWebhookID, replaceVersions)
+}
+
+func (s *testWorkerService) EnqueueCopyFromAppVersion(jobStatusID, sourceAppVersionID uuid.UUID, parts models.AppVersionCopyParts) error {
+	if s.enqueueCopyFromAppVersionFn == nil {
+		panic("You have to override EnqueueCopyFromAppVersion function in tests")
+	}
+	return s.enqueueCopyFromAppVersionFn(jobStatusID, sourceAppVersionID, parts)
+}
+
+func (s *testWorkerService) EnqueueDeprovisionApp(appTombstoneID uuid.UUID) error {
+	if s.enqueueDeprovisionAppFn == nil {
+		panic("You have to override EnqueueDeprovisionApp function in tests")
+	}
+	return s.enqueueDeprovisionAppFn(appTombstoneID)
+}
+
+func (s *testWorkerService) EnqueueUpdateHeaderPalette(appID uuid.UUID, avatarURL string) error {
+	if s.enqueueUpdateHeaderPaletteFn == nil {
+		panic("You have to override EnqueueUpdateHeaderPalette function in tests")
+	}
+	return s.enqueueUpdateHeaderPaletteFn(appID, avatarURL)
+}
@@ -132,7 +132,7 @@ func (m *SES) SendEmailPublish(appVersion *models.AppVersion, contacts []models.
 	}
 
 	var publishTarget, publishURL string
-	if appVersion.Platform == "ios" {
+	if models.IsApplePlatform(appVersion.Platform) {
 		publishTarget = "App Store Connect"
 		publishURL = "https://appstoreconnect.apple.com"
 	} else if appVersion.Platform == "android" {
@@ -184,6 +184,102 @@ func (m *SES) SendEmailPublish(appVersion *models.AppVersion, contacts []models.
 	return nil
 }
 
+// SendEmailBuildFailed ...
+func (m *SES) SendEmailBuildFailed(appEvent *models.AppEvent, contacts []models.AppContact, appDetails *bitrise.AppDetails) error {
+	appIconURL := defaultIconURL(appDetails.ProjectType)
+	if appDetails.AvatarURL != nil {
+		appIconURL = *appDetails.AvatarURL
+	}
+	buildAborted := appEvent.Status == models.AppEventStatusBuildAborted
+
+	var subject string
+	if buildAborted {
+		subject = fmt.Sprintf("🛑 A build of %s has been aborted. 🛑", appDetails.Title)
+	} else {
+		subject = fmt.Sprintf("🍅 A build of %s has failed. 🍅", appDetails.Title)
+	}
+
+	for _, contact := range contacts {
+		notificationPreferences, err := contact.NotificationPreferences()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !notificationPreferences.BuildFailed {
+			continue
+		}
+		nameForHey := getUsernameFromEmail(contact.Email)
+		err = m.sendMail(&Request{
+			To:      []string{contact.Email},
+			From:    m.FromEmail,
+			Subject: subject,
+		},
+			"email/build_failed.html",
+			map[string]interface{}{
+				"CurrentTime":  func() time.Time { return time.Now() },
+				"Name":         func() string { return nameForHey },
+				"AppTitle":     func() string { return appDetails.Title },
+				"AppIconURL":   func() string { return appIconURL },
+				"BuildNumber":  func() int { return appEvent.BuildNumber },
+				"Workflow":     func() string { return appEvent.Workflow },
+				"BuildURL":     func() string { return appEvent.BuildURL() },
+				"BuildAborted": func() bool { return buildAborted },
+			})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// SendEmailCodeSigningExpiry ...
+func (m *SES) SendEmailCodeSigningExpiry(app *models.App, expiringFiles []models.CodeSigningFileExpiry, contacts []models.AppContact, appDetails *bitrise.AppDetails, frontendBaseURL string) error {
+	appIconURL := defaultIconURL(appDetails.ProjectType)
+	if appDetails.AvatarURL != nil {
+		appIconURL = *appDetails.AvatarURL
+	}
+
+	anyExpired := false
+	for _, file := range expiringFiles {
+		anyExpired = anyExpired || file.Expired()
+	}
+	subject := fmt.Sprintf("⏳ Code signing files of %s are about to expire. ⏳", appDetails.Title)
+	if anyExpired {
+		subject = fmt.Sprintf("⚠️ Code signing files of %s expired. ⚠️", appDetails.Title)
+	}
+
+	for _, contact := range contacts {
+		notificationPreferences, err := contact.NotificationPreferences()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !notificationPreferences.CodeSigningExpiry {
+			continue
+		}
+		nameForHey := getUsernameFromEmail(contact.Email)
+		err = m.sendMail(&Request{
+			To:      []string{contact.Email},
+			From:    m.FromEmail,
+			Subject: subject,
+		},
+			"email/code_signing_expiry.html",
+			map[string]interface{}{
+				"CurrentTime":   func() time.Time { return time.Now() },
+				"Name":          func() string { return nameForHey },
+				"AppTitle":      func() string { return appDetails.Title },
+				"AppIconURL":    func() string { return appIconURL },
+				"ExpiringFiles": func() []models.CodeSigningFileExpiry { return expiringFiles },
+				"AnyExpired":    func() bool { return anyExpired },
+				"AppURL": func() string {
+					return fmt.Sprintf("%s/apps/%s/settings", frontendBaseURL, app.AppSlug)
+				},
+			})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
 func getUsernameFromEmail(email string) string {
 	return strings.Split(email, "@")[0]
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/bitrise-io/addons-ship-backend/bitrise"
 	"github.com/bitrise-io/addons-ship-backend/mailer"
@@ -42,7 +43,7 @@ func main() {
 	}
 	testAppContacts := []models.AppContact{models.AppContact{
 		Email:                       targetEmail,
-		NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":true,"failed_publish":true}`),
+		NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":true,"failed_publish":true,"build_failed":true,"code_signing_expiry":true}`),
 		ConfirmationToken:           pointers.NewStringPtr("your-confirmation-token"),
 	}}
 	testAppDetails := &bitrise.AppDetails{Title: "Standup Timer", ProjectType: "flutter"}
@@ -72,6 +73,24 @@ func main() {
 		if err != nil {
 			failEmailSend(err)
 		}
+	case "build_failed":
+		err := ses.SendEmailBuildFailed(&models.AppEvent{
+			Status:      models.AppEventStatusBuildFailed,
+			Workflow:    "deploy",
+			BuildSlug:   "test-build-slug",
+			BuildNumber: 28,
+		}, testAppContacts, testAppDetails)
+		if err != nil {
+			failEmailSend(err)
+		}
+	case "code_signing_expiry":
+		err := ses.SendEmailCodeSigningExpiry(&testAppVersion.App, []models.CodeSigningFileExpiry{
+			models.CodeSigningFileExpiry{Filename: "AppStore.mobileprovision", ExpireDate: time.Now().AddDate(0, 0, 7)},
+			models.CodeSigningFileExpiry{Filename: "Distribution.p12", ExpireDate: time.Now().AddDate(0, 0, 30)},
+		}, testAppContacts, testAppDetails, "http://bitrise.io")
+		if err != nil {
+			failEmailSend(err)
+		}
 	default:
 		failEmailSend(errors.New("No MAIL_TO_SEND env var defined"))
 	}
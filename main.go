@@ -39,6 +39,8 @@ func main() {
 			logger.Error("Failed to initialize Application Environment object for worker", zap.Any("error", err))
 			os.Exit(1)
 		}
+		appEnv.WorkerService = &worker.Service{}
+
 		log.Println("Starting worker mode...")
 		log.Fatal(errors.WithStack(worker.Start(appEnv)))
 	} else {
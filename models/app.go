@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"os"
 
 	"github.com/bitrise-io/go-crypto/crypto"
@@ -13,16 +14,19 @@ import (
 // App ...
 type App struct {
 	Record
-	AppSlug           string         `json:"app_slug"`
-	Plan              string         `json:"plan"`
-	BitriseAPIToken   string         `json:"-"`
-	APIToken          string         `json:"-"`
-	EncryptedSecret   []byte         `json:"-"`
-	EncryptedSecretIV []byte         `json:"-"`
-	HeaderColor1      string         `db:"header_color_1" gorm:"column:header_color_1" json:"header_color_1"`
-	HeaderColor2      string         `db:"header_color_2" gorm:"column:header_color_2" json:"header_color_2"`
-	AndroidErrors     pq.StringArray `json:"android_errors" gorm:"type:varchar(128)[]"`
-	IosErrors         pq.StringArray `json:"ios_errors" gorm:"type:varchar(128)[]"`
+	AppSlug           string          `json:"app_slug"`
+	Plan              string          `json:"plan"`
+	BitriseAPIToken   string          `json:"-"`
+	APIToken          string          `json:"-"`
+	EncryptedSecret   []byte          `json:"-"`
+	EncryptedSecretIV []byte          `json:"-"`
+	HeaderColor1      string          `db:"header_color_1" gorm:"column:header_color_1" json:"header_color_1"`
+	HeaderColor2      string          `db:"header_color_2" gorm:"column:header_color_2" json:"header_color_2"`
+	HeaderPaletteData json.RawMessage `db:"header_palette" gorm:"column:header_palette;type:json" json:"-"`
+	AvatarURL         string          `db:"avatar_url" json:"-"`
+	AvatarChecksum    string          `db:"avatar_checksum" json:"-"`
+	AndroidErrors     pq.StringArray  `json:"android_errors" gorm:"type:varchar(128)[]"`
+	IosErrors         pq.StringArray  `json:"ios_errors" gorm:"type:varchar(128)[]"`
 
 	AppVersions []AppVersion `gorm:"foreignkey:AppID" json:"app_versions"`
 	AppSettings AppSettings  `gorm:"foreignkey:AppsID" json:"app_settings"`
@@ -34,6 +38,10 @@ func (a *App) BeforeCreate(scope *gorm.Scope) error {
 		a.ID = uuid.NewV4()
 	}
 
+	if a.HeaderPaletteData == nil {
+		a.HeaderPaletteData = json.RawMessage(`{}`)
+	}
+
 	if len(a.EncryptedSecretIV) != 0 {
 		return nil
 	}
@@ -92,3 +100,16 @@ func (a *App) Secret() (string, error) {
 
 	return secret, nil
 }
+
+// HeaderPalette ...
+func (a *App) HeaderPalette() (HeaderPalette, error) {
+	var headerPalette HeaderPalette
+	if len(a.HeaderPaletteData) == 0 {
+		return headerPalette, nil
+	}
+	err := json.Unmarshal(a.HeaderPaletteData, &headerPalette)
+	if err != nil {
+		return HeaderPalette{}, err
+	}
+	return headerPalette, nil
+}
@@ -18,6 +18,8 @@ type NotificationPreferences struct {
 	NewVersion        bool `json:"new_version"`
 	SuccessfulPublish bool `json:"successful_publish"`
 	FailedPublish     bool `json:"failed_publish"`
+	BuildFailed       bool `json:"build_failed"`
+	CodeSigningExpiry bool `json:"code_signing_expiry"`
 }
 
 // AppContact ...
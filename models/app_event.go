@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// AppEventStatusBuildFailed ...
+	AppEventStatusBuildFailed = "build_failed"
+	// AppEventStatusBuildAborted ...
+	AppEventStatusBuildAborted = "build_aborted"
+)
+
+// AppEvent is an entry of the activity feed of an app, which is not tied to any of its versions, like a
+// failed or aborted build
+type AppEvent struct {
+	Record
+	Status      string `json:"status"`
+	Text        string `json:"event_text" gorm:"column:event_text"`
+	Workflow    string `json:"workflow"`
+	BuildSlug   string `db:"build_slug" json:"build_slug"`
+	BuildNumber int    `db:"build_number" json:"build_number"`
+
+	AppID uuid.UUID `db:"app_id" json:"-"`
+	App   App       `gorm:"foreignkey:AppID" json:"-"`
+}
+
+// BeforeCreate ...
+func (a *AppEvent) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(a.ID, uuid.UUID{}) {
+		a.ID = uuid.NewV4()
+	}
+	return nil
+}
+
+// BuildURL is the link of the build of the event on Bitrise
+func (a *AppEvent) BuildURL() string {
+	if a.BuildSlug == "" {
+		return ""
+	}
+	return BuildURL(a.BuildSlug)
+}
+
+// BuildURL is the link of the build on Bitrise
+func BuildURL(buildSlug string) string {
+	return fmt.Sprintf("https://app.bitrise.io/build/%s", buildSlug)
+}
@@ -0,0 +1,35 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// AppEventService ...
+type AppEventService struct {
+	DB *gorm.DB
+}
+
+// Create ...
+func (a *AppEventService) Create(appEvent *AppEvent) (*AppEvent, error) {
+	if err := a.DB.Create(appEvent).Error; err != nil {
+		return nil, err
+	}
+	return appEvent, nil
+}
+
+// Find ...
+func (a *AppEventService) Find(appEvent *AppEvent) (*AppEvent, error) {
+	err := a.DB.Where(appEvent).First(appEvent).Error
+	if err != nil {
+		return nil, err
+	}
+	return appEvent, nil
+}
+
+// FindAll returns the events of the app, the latest first
+func (a *AppEventService) FindAll(app *App) ([]AppEvent, error) {
+	var appEvents []AppEvent
+	err := a.DB.Where(map[string]interface{}{"app_id": app.ID}).Order("created_at DESC").Find(&appEvents).Error
+	if err != nil {
+		return nil, err
+	}
+	return appEvents, nil
+}
@@ -0,0 +1,76 @@
+// +build database
+
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppEventService_Create(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appEventService := models.AppEventService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+
+	appEvent, err := appEventService.Create(&models.AppEvent{
+		AppID:     testApp.ID,
+		Status:    models.AppEventStatusBuildFailed,
+		Workflow:  "deploy",
+		BuildSlug: "test-build-slug",
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.UUID{}, appEvent.ID)
+	require.False(t, appEvent.CreatedAt.IsZero())
+}
+
+func Test_AppEventService_Find(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appEventService := models.AppEventService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	testAppEvent, err := appEventService.Create(&models.AppEvent{AppID: testApp.ID, Status: models.AppEventStatusBuildAborted, BuildSlug: "test-build-slug"})
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		foundAppEvent, err := appEventService.Find(&models.AppEvent{AppID: testApp.ID, BuildSlug: "test-build-slug"})
+		require.NoError(t, err)
+		require.Equal(t, testAppEvent.ID, foundAppEvent.ID)
+		require.Equal(t, models.AppEventStatusBuildAborted, foundAppEvent.Status)
+	})
+
+	t.Run("error - when there's no event of the build", func(t *testing.T) {
+		_, err := appEventService.Find(&models.AppEvent{AppID: testApp.ID, BuildSlug: "other-build-slug"})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+}
+
+func Test_AppEventService_FindAll(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appEventService := models.AppEventService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	otherTestApp := createTestApp(t, &models.App{AppSlug: "other-test-app-slug"})
+
+	firstAppEvent, err := appEventService.Create(&models.AppEvent{AppID: testApp.ID, Status: models.AppEventStatusBuildFailed, BuildSlug: "test-build-slug-1"})
+	require.NoError(t, err)
+	secondAppEvent, err := appEventService.Create(&models.AppEvent{AppID: testApp.ID, Status: models.AppEventStatusBuildFailed, BuildSlug: "test-build-slug-2"})
+	require.NoError(t, err)
+	_, err = appEventService.Create(&models.AppEvent{AppID: otherTestApp.ID, Status: models.AppEventStatusBuildFailed, BuildSlug: "test-build-slug-3"})
+	require.NoError(t, err)
+
+	foundAppEvents, err := appEventService.FindAll(testApp)
+	require.NoError(t, err)
+	require.Len(t, foundAppEvents, 2)
+	require.Equal(t, secondAppEvent.ID, foundAppEvents[0].ID)
+	require.Equal(t, firstAppEvent.ID, foundAppEvents[1].ID)
+}
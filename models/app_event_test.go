@@ -0,0 +1,20 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func Test_AppEvent_BuildURL(t *testing.T) {
+	t.Run("when the event belongs to a build", func(t *testing.T) {
+		appEvent := models.AppEvent{BuildSlug: "test-build-slug"}
+		require.Equal(t, "https://app.bitrise.io/build/test-build-slug", appEvent.BuildURL())
+	})
+
+	t.Run("when the event doesn't belong to a build", func(t *testing.T) {
+		appEvent := models.AppEvent{}
+		require.Equal(t, "", appEvent.BuildURL())
+	})
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/api-utils/constants"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// MaxAppPreviewFileByteSize ...
+	MaxAppPreviewFileByteSize = 500 * constants.MegaByte
+)
+
+var appPreviewFileExtensions = []string{".mov", ".m4v", ".mp4"}
+
+// AppPreview ...
+type AppPreview struct {
+	Record
+	UploadableObject
+	DeviceType string `json:"device_type"`
+	ScreenSize string `json:"screen_size"`
+	Locale     string `json:"locale"`
+	UploadID   string `db:"upload_id" json:"-"`
+	// AssetID is set once the upload is completed and stored by its content
+	AssetID *uuid.UUID `db:"asset_id" json:"-" gorm:"type:uuid"`
+
+	AppVersionID uuid.UUID  `db:"app_version_id" json:"-"`
+	AppVersion   AppVersion `gorm:"foreignkey:AppVersionID" json:"-"`
+}
+
+// BeforeCreate ...
+func (p *AppPreview) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(p.ID, uuid.UUID{}) {
+		p.ID = uuid.NewV4()
+	}
+	return nil
+}
+
+// BeforeSave ...
+func (p *AppPreview) BeforeSave(scope *gorm.Scope) error {
+	err := p.validate(scope)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (p *AppPreview) validate(scope *gorm.Scope) error {
+	var err error
+	if p.Filesize > MaxAppPreviewFileByteSize {
+		err = scope.DB().AddError(NewValidationError("filesize: Must be smaller than 500 megabytes"))
+	}
+	if !hasFileExtension(p.Filename, appPreviewFileExtensions) {
+		err = scope.DB().AddError(NewValidationError("filename: Must be a .mov, .m4v or .mp4 file"))
+	}
+	if err != nil {
+		return errors.New("Validation failed")
+	}
+	return nil
+}
+
+// AWSPath returns the key of the object of the app preview, which is the asset storing its content
+// once the upload is completed
+func (p *AppPreview) AWSPath() string {
+	if p.AssetID != nil {
+		return AssetAWSPath(p.Checksum, AssetExtension(p.Filename))
+	}
+	return p.UploadAWSPath()
+}
+
+// UploadAWSPath returns the key the file of the app preview is uploaded to
+func (p *AppPreview) UploadAWSPath() string {
+	pathElements := []string{
+		p.AppVersion.App.AppSlug,
+		p.AppVersion.ID.String(),
+		"app_previews",
+	}
+	if p.Locale != "" {
+		pathElements = append(pathElements, p.Locale)
+	}
+	pathElements = append(pathElements,
+		fmt.Sprintf("%s (%s)", p.DeviceType, p.ScreenSize),
+		p.ID.String()+filepath.Ext(p.Filename),
+	)
+	return strings.Join(pathElements, "/")
+}
@@ -1,3 +1,5 @@
+// +build database
+
 package models_test
 
 import (
@@ -8,8 +10,8 @@ import (
 	"github.com/c2fo/testify/require"
 )
 
-func createTestFeatureGraphic(t *testing.T, featureGraphic *models.FeatureGraphic) *models.FeatureGraphic {
-	err := dataservices.GetDB().Create(featureGraphic).Error
+func createTestAppPreview(t *testing.T, appPreview *models.AppPreview) *models.AppPreview {
+	err := dataservices.GetDB().Create(appPreview).Error
 	require.NoError(t, err)
-	return featureGraphic
+	return appPreview
 }
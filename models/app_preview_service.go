@@ -0,0 +1,89 @@
+package models
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// AppPreviewService ...
+type AppPreviewService struct {
+	DB *gorm.DB
+	UpdatableModelService
+}
+
+// BatchCreate ...
+func (s *AppPreviewService) BatchCreate(appPreviews []*AppPreview) ([]*AppPreview, []error, error) {
+	tx := s.DB.Begin()
+	for _, appPreview := range appPreviews {
+		result := tx.Create(appPreview)
+		verrs := ValidationErrors(result.GetErrors())
+		if len(verrs) > 0 {
+			tx.Rollback()
+			return nil, verrs, nil
+		}
+		if result.Error != nil {
+			tx.Rollback()
+			return nil, nil, result.Error
+		}
+
+		err := tx.Preload("AppVersion").Preload("AppVersion.App").First(appPreview).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, nil, err
+		}
+	}
+	return appPreviews, nil, tx.Commit().Error
+}
+
+// Find ...
+func (s *AppPreviewService) Find(appPreview *AppPreview) (*AppPreview, error) {
+	err := s.DB.Preload("AppVersion").Preload("AppVersion.App").Where(appPreview).First(appPreview).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return appPreview, nil
+}
+
+// FindAll ...
+func (s *AppPreviewService) FindAll(appVersion *AppVersion) ([]AppPreview, error) {
+	var appPreviews []AppPreview
+	err := s.DB.Preload("AppVersion").Preload("AppVersion.App").
+		Where(map[string]interface{}{"app_version_id": appVersion.ID}).
+		Order("created_at ASC").
+		Find(&appPreviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return appPreviews, nil
+}
+
+// Update ...
+func (s *AppPreviewService) Update(appPreview AppPreview, whitelist []string) ([]error, error) {
+	updateData, err := s.UpdateData(appPreview, whitelist)
+	if err != nil {
+		return nil, err
+	}
+	result := s.DB.Model(&appPreview).Updates(updateData)
+	verrs := ValidationErrors(result.GetErrors())
+	if len(verrs) > 0 {
+		return verrs, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return nil, nil
+}
+
+// Delete ...
+func (s *AppPreviewService) Delete(appPreview *AppPreview) error {
+	result := s.DB.Delete(&appPreview)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
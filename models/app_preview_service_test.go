@@ -0,0 +1,154 @@
+// +build database
+
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppPreviewService_BatchCreate(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appPreviewService := models.AppPreviewService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: testApp.ID, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+
+	t.Run("ok", func(t *testing.T) {
+		createdAppPreviews, verrs, err := appPreviewService.BatchCreate([]*models.AppPreview{
+			&models.AppPreview{
+				UploadableObject: models.UploadableObject{Filename: "preview.mp4", Filesize: 1234},
+				Locale:           "en-US",
+				AppVersionID:     testAppVersion.ID,
+			},
+		})
+		require.Empty(t, verrs)
+		require.NoError(t, err)
+		require.False(t, createdAppPreviews[0].ID.String() == "")
+		require.Equal(t, "test-app-slug", createdAppPreviews[0].AppVersion.App.AppSlug)
+	})
+
+	t.Run("when filesize is too big", func(t *testing.T) {
+		createdAppPreviews, verrs, err := appPreviewService.BatchCreate([]*models.AppPreview{
+			&models.AppPreview{
+				UploadableObject: models.UploadableObject{Filename: "preview.mp4", Filesize: models.MaxAppPreviewFileByteSize + 1},
+				AppVersionID:     testAppVersion.ID,
+			},
+		})
+		require.Equal(t, 1, len(verrs))
+		require.Equal(t, "filesize: Must be smaller than 500 megabytes", verrs[0].Error())
+		require.NoError(t, err)
+		require.Nil(t, createdAppPreviews)
+	})
+
+	t.Run("when file extension is not supported", func(t *testing.T) {
+		createdAppPreviews, verrs, err := appPreviewService.BatchCreate([]*models.AppPreview{
+			&models.AppPreview{
+				UploadableObject: models.UploadableObject{Filename: "preview.avi", Filesize: 1234},
+				AppVersionID:     testAppVersion.ID,
+			},
+		})
+		require.Equal(t, 1, len(verrs))
+		require.Equal(t, "filename: Must be a .mov, .m4v or .mp4 file", verrs[0].Error())
+		require.NoError(t, err)
+		require.Nil(t, createdAppPreviews)
+	})
+}
+
+func Test_AppPreviewService_Find(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appPreviewService := models.AppPreviewService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+	testAppPreview := createTestAppPreview(t, &models.AppPreview{
+		UploadableObject: models.UploadableObject{Filename: "preview.mp4"},
+		AppVersion:       *testAppVersion,
+	})
+
+	t.Run("when querying an app preview that belongs to an app version", func(t *testing.T) {
+		foundAppPreview, err := appPreviewService.Find(&models.AppPreview{Record: models.Record{ID: testAppPreview.ID}, AppVersionID: testAppVersion.ID})
+		require.NoError(t, err)
+		require.Equal(t, testAppPreview.ID, foundAppPreview.ID)
+	})
+
+	t.Run("error - when app preview is not found", func(t *testing.T) {
+		foundAppPreview, err := appPreviewService.Find(&models.AppPreview{Record: models.Record{ID: testAppPreview.ID}, AppVersionID: uuid.NewV4()})
+		require.Equal(t, errors.Cause(err), gorm.ErrRecordNotFound)
+		require.Nil(t, foundAppPreview)
+	})
+}
+
+func Test_AppPreviewService_FindAll(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appPreviewService := models.AppPreviewService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+	otherTestAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.1"}`)})
+	testAppPreview1 := createTestAppPreview(t, &models.AppPreview{UploadableObject: models.UploadableObject{Filename: "preview1.mp4"}, AppVersion: *testAppVersion})
+	testAppPreview2 := createTestAppPreview(t, &models.AppPreview{UploadableObject: models.UploadableObject{Filename: "preview2.mp4"}, AppVersion: *testAppVersion})
+	createTestAppPreview(t, &models.AppPreview{UploadableObject: models.UploadableObject{Filename: "preview3.mp4"}, AppVersion: *otherTestAppVersion})
+
+	foundAppPreviews, err := appPreviewService.FindAll(testAppVersion)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(foundAppPreviews))
+	require.Equal(t, testAppPreview1.ID, foundAppPreviews[0].ID)
+	require.Equal(t, testAppPreview2.ID, foundAppPreviews[1].ID)
+}
+
+func Test_AppPreviewService_Update(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appPreviewService := models.AppPreviewService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+
+	t.Run("ok", func(t *testing.T) {
+		testAppPreview := *createTestAppPreview(t, &models.AppPreview{UploadableObject: models.UploadableObject{Filename: "preview.mp4"}, AppVersion: *testAppVersion})
+		testAppPreview.UploadID = "test-upload-id"
+		testAppPreview.Uploaded = true
+		verrs, err := appPreviewService.Update(testAppPreview, []string{"UploadID", "Uploaded"})
+		require.Empty(t, verrs)
+		require.NoError(t, err)
+
+		foundAppPreview, err := appPreviewService.Find(&models.AppPreview{Record: models.Record{ID: testAppPreview.ID}})
+		require.NoError(t, err)
+		require.Equal(t, "test-upload-id", foundAppPreview.UploadID)
+		require.True(t, foundAppPreview.Uploaded)
+	})
+
+	t.Run("when trying to update non-existing field", func(t *testing.T) {
+		testAppPreview := *createTestAppPreview(t, &models.AppPreview{UploadableObject: models.UploadableObject{Filename: "preview.mp4"}, AppVersion: *testAppVersion})
+		verrs, err := appPreviewService.Update(testAppPreview, []string{"NonExistingField"})
+		require.EqualError(t, err, "Attribute name doesn't exist in the model")
+		require.Equal(t, 0, len(verrs))
+	})
+}
+
+func Test_AppPreviewService_Delete(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appPreviewService := models.AppPreviewService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+	testAppPreview := createTestAppPreview(t, &models.AppPreview{UploadableObject: models.UploadableObject{Filename: "preview.mp4"}, AppVersion: *testAppVersion})
+
+	t.Run("when deleting an app preview", func(t *testing.T) {
+		err := appPreviewService.Delete(testAppPreview)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - when app preview is not found", func(t *testing.T) {
+		err := appPreviewService.Delete(&models.AppPreview{Record: models.Record{ID: uuid.NewV4()}})
+		require.Equal(t, errors.Cause(err), gorm.ErrRecordNotFound)
+	})
+}
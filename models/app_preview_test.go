@@ -0,0 +1,44 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppPreview_AWSPath(t *testing.T) {
+	testAppPreview := models.AppPreview{
+		Record:           models.Record{ID: uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")},
+		UploadableObject: models.UploadableObject{Filename: "preview.mp4"},
+		DeviceType:       "iPhone XS Max",
+		ScreenSize:       "6.5 inch",
+		AppVersion: models.AppVersion{
+			Record: models.Record{
+				ID: uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879"),
+			},
+			App: models.App{AppSlug: "test-app-slug"},
+		},
+	}
+
+	t.Run("without locale", func(t *testing.T) {
+		require.Equal(t, "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/app_previews/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.mp4", testAppPreview.AWSPath())
+	})
+
+	t.Run("with locale", func(t *testing.T) {
+		testAppPreview.Locale = "de-DE"
+		require.Equal(t, "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/app_previews/de-DE/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.mp4", testAppPreview.AWSPath())
+	})
+
+	t.Run("when stored as an asset", func(t *testing.T) {
+		assetID := uuid.NewV4()
+		assetAppPreview := testAppPreview
+		assetAppPreview.AssetID = &assetID
+		assetAppPreview.Filename = "preview.MP4"
+		assetAppPreview.Checksum = "f1d2d2f924e986ac86fdf7b36c94bcdf32beec15"
+
+		require.Equal(t, "assets/f1/f1d2d2f924e986ac86fdf7b36c94bcdf32beec15.mp4", assetAppPreview.AWSPath())
+		require.Equal(t, testAppPreview.AWSPath(), testAppPreview.UploadAWSPath())
+	})
+}
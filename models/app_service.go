@@ -27,6 +27,16 @@ func (a *AppService) Find(app *App) (*App, error) {
 	return app, nil
 }
 
+// FindAll ...
+func (a *AppService) FindAll() ([]App, error) {
+	var apps []App
+	err := a.DB.Order("created_at").Find(&apps).Error
+	if err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
 // Update ...
 func (a *AppService) Update(app *App, whitelist []string) (validationErrors []error, dbErr error) {
 	updateData, err := a.UpdateData(*app, whitelist)
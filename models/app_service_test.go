@@ -56,6 +56,21 @@ func Test_AppService_Create(t *testing.T) {
 	})
 }
 
+func Test_AppService_FindAll(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appService := models.AppService{DB: dataservices.GetDB()}
+	testApp1 := createTestApp(t, &models.App{AppSlug: "test-app-slug-1"})
+	testApp2 := createTestApp(t, &models.App{AppSlug: "test-app-slug-2"})
+
+	foundApps, err := appService.FindAll()
+	require.NoError(t, err)
+	require.Len(t, foundApps, 2)
+	require.Equal(t, testApp1.ID, foundApps[0].ID)
+	require.Equal(t, testApp2.ID, foundApps[1].ID)
+}
+
 func Test_AppService_Find(t *testing.T) {
 	dbCloseCallbackMethod := prepareDB(t)
 	defer dbCloseCallbackMethod()
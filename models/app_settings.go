@@ -2,9 +2,11 @@ package models
 
 import (
 	"encoding/json"
+	"image/color"
 	"reflect"
 
 	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -16,6 +18,9 @@ type IosSettings struct {
 	SelectedAppStoreProvisioningProfiles []string `json:"selected_app_store_provisioning_profiles"`
 	SelectedCodeSigningIdentity          string   `json:"selected_code_signing_identity"`
 	IncludeBitCode                       bool     `json:"include_bit_code"`
+	AutoResizeScreenshots                bool     `json:"auto_resize_screenshots"`
+	ScreenshotBackgroundColor            string   `json:"screenshot_background_color"`
+	TrackedSchemes                       []string `json:"tracked_schemes"`
 }
 
 // Valid ...
@@ -23,6 +28,14 @@ func (s IosSettings) Valid() bool {
 	return !reflect.DeepEqual(s, IosSettings{})
 }
 
+// ScreenshotBackground returns the color resized screenshots are padded with
+func (s IosSettings) ScreenshotBackground() (color.RGBA, error) {
+	if s.ScreenshotBackgroundColor == "" {
+		return DefaultScreenshotBackgroundColor, nil
+	}
+	return ParseHexColor(s.ScreenshotBackgroundColor)
+}
+
 // AndroidSettings ...
 type AndroidSettings struct {
 	Track                  string `json:"track"`
@@ -36,13 +49,27 @@ func (s AndroidSettings) Valid() bool {
 	return s != (AndroidSettings{})
 }
 
+// RetentionSettings tells which versions of an app are deleted automatically. Zero values turn the rules
+// off, so by default every version is kept.
+type RetentionSettings struct {
+	KeepLastVersions           int  `json:"keep_last_versions"`
+	KeepPublished              bool `json:"keep_published"`
+	DeleteUnpublishedAfterDays int  `json:"delete_unpublished_after_days"`
+}
+
+// Enabled ...
+func (s RetentionSettings) Enabled() bool {
+	return s.KeepLastVersions > 0 || s.DeleteUnpublishedAfterDays > 0
+}
+
 // AppSettings ...
 type AppSettings struct {
 	Record
-	IosWorkflow         string          `json:"ios_workflow"`
-	AndroidWorkflow     string          `json:"android_workflow"`
-	IosSettingsData     json.RawMessage `json:"-" db:"ios_settings" gorm:"column:ios_settings;type:json"`
-	AndroidSettingsData json.RawMessage `json:"-" db:"android_settings" gorm:"column:android_settings;type:json"`
+	IosSettingsData       json.RawMessage `json:"-" db:"ios_settings" gorm:"column:ios_settings;type:json"`
+	AndroidSettingsData   json.RawMessage `json:"-" db:"android_settings" gorm:"column:android_settings;type:json"`
+	RetentionSettingsData json.RawMessage `json:"-" db:"retention_settings" gorm:"column:retention_settings;type:json"`
+	WorkflowRulesData     json.RawMessage `json:"-" db:"workflow_rules" gorm:"column:workflow_rules;type:json"`
+	CodeSigningExpiryData json.RawMessage `json:"-" db:"code_signing_expiry" gorm:"column:code_signing_expiry;type:json"`
 
 	AppID uuid.UUID `db:"app_id" json:"-"`
 	App   *App      `gorm:"foreignkey:AppID" json:"-"`
@@ -59,6 +86,64 @@ func (a *AppSettings) BeforeCreate(scope *gorm.Scope) error {
 	if a.AndroidSettingsData == nil {
 		a.AndroidSettingsData = json.RawMessage(`{}`)
 	}
+	if a.RetentionSettingsData == nil {
+		a.RetentionSettingsData = json.RawMessage(`{}`)
+	}
+	if a.WorkflowRulesData == nil {
+		a.WorkflowRulesData = json.RawMessage(`[]`)
+	}
+	if a.CodeSigningExpiryData == nil {
+		a.CodeSigningExpiryData = json.RawMessage(`{}`)
+	}
+	return nil
+}
+
+// BeforeSave ...
+func (a *AppSettings) BeforeSave(scope *gorm.Scope) error {
+	err := a.validate(scope)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (a *AppSettings) validate(scope *gorm.Scope) error {
+	var err error
+	if len(a.IosSettingsData) > 0 {
+		iosSettings, parseErr := a.IosSettings()
+		if parseErr != nil {
+			return errors.WithStack(parseErr)
+		}
+		if _, colorErr := iosSettings.ScreenshotBackground(); colorErr != nil {
+			err = scope.DB().AddError(NewValidationError("screenshot_background_color: Must be a hex color like #FFFFFF"))
+		}
+	}
+	if len(a.RetentionSettingsData) > 0 {
+		retentionSettings, parseErr := a.RetentionSettings()
+		if parseErr != nil {
+			return errors.WithStack(parseErr)
+		}
+		if retentionSettings.KeepLastVersions < 0 {
+			err = scope.DB().AddError(NewValidationError("keep_last_versions: Must not be negative"))
+		}
+		if retentionSettings.DeleteUnpublishedAfterDays < 0 {
+			err = scope.DB().AddError(NewValidationError("delete_unpublished_after_days: Must not be negative"))
+		}
+	}
+	if len(a.WorkflowRulesData) > 0 {
+		workflowRules, parseErr := a.WorkflowRules()
+		if parseErr != nil {
+			return errors.WithStack(parseErr)
+		}
+		for _, rule := range workflowRules {
+			if ruleErr := rule.validate(); ruleErr != nil {
+				err = scope.DB().AddError(NewValidationError("workflow_rules: " + ruleErr.Error()))
+			}
+		}
+	}
+	if err != nil {
+		return errors.New("Validation failed")
+	}
 	return nil
 }
 
@@ -81,3 +166,42 @@ func (a *AppSettings) AndroidSettings() (AndroidSettings, error) {
 	}
 	return androidSettings, nil
 }
+
+// RetentionSettings ...
+func (a *AppSettings) RetentionSettings() (RetentionSettings, error) {
+	var retentionSettings RetentionSettings
+	if len(a.RetentionSettingsData) == 0 {
+		return retentionSettings, nil
+	}
+	err := json.Unmarshal(a.RetentionSettingsData, &retentionSettings)
+	if err != nil {
+		return RetentionSettings{}, err
+	}
+	return retentionSettings, nil
+}
+
+// WorkflowRules ...
+func (a *AppSettings) WorkflowRules() (WorkflowRules, error) {
+	workflowRules := WorkflowRules{}
+	if len(a.WorkflowRulesData) == 0 {
+		return workflowRules, nil
+	}
+	err := json.Unmarshal(a.WorkflowRulesData, &workflowRules)
+	if err != nil {
+		return WorkflowRules{}, err
+	}
+	return workflowRules, nil
+}
+
+// CodeSigningExpiry ...
+func (a *AppSettings) CodeSigningExpiry() (CodeSigningExpiry, error) {
+	var codeSigningExpiry CodeSigningExpiry
+	if len(a.CodeSigningExpiryData) == 0 {
+		return codeSigningExpiry, nil
+	}
+	err := json.Unmarshal(a.CodeSigningExpiryData, &codeSigningExpiry)
+	if err != nil {
+		return CodeSigningExpiry{}, err
+	}
+	return codeSigningExpiry, nil
+}
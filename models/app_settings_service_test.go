@@ -31,7 +31,7 @@ func Test_AppSettingsService_Find(t *testing.T) {
 	appSettingsService := models.AppSettingsService{DB: dataservices.GetDB()}
 
 	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
-	testAppSettings := createTestAppSettings(t, &models.AppSettings{App: testApp, AndroidWorkflow: "android-deploy"})
+	testAppSettings := createTestAppSettings(t, &models.AppSettings{App: testApp, WorkflowRulesData: json.RawMessage(`[{"platform":"android","workflows":["android-deploy"]}]`)})
 
 	t.Run("when querying app settings that belongs to an app", func(t *testing.T) {
 		foundAppSettings, err := appSettingsService.Find(&models.AppSettings{Record: models.Record{ID: testAppSettings.ID}, AppID: testApp.ID})
@@ -56,8 +56,8 @@ func Test_AppSettingsService_Update(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		testAppSettings := []*models.AppSettings{
-			createTestAppSettings(t, &models.AppSettings{IosWorkflow: "my-ios-wf"}),
-			createTestAppSettings(t, &models.AppSettings{IosWorkflow: "awesome-ios-wf"}),
+			createTestAppSettings(t, &models.AppSettings{WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["my-ios-wf"]}]`)}),
+			createTestAppSettings(t, &models.AppSettings{WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["awesome-ios-wf"]}]`)}),
 		}
 
 		testAppSettings[0].IosSettingsData = json.RawMessage(`{"app_sku": "20180601"}`)
@@ -78,4 +78,24 @@ func Test_AppSettingsService_Update(t *testing.T) {
 		require.NoError(t, err)
 		compareAppSettings(t, *testAppSettings[1], *foundAppSettings)
 	})
+	t.Run("when retention settings are invalid", func(t *testing.T) {
+		testAppSettings := createTestAppSettings(t, &models.AppSettings{WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["my-ios-wf"]}]`)})
+
+		testAppSettings.RetentionSettingsData = json.RawMessage(`{"keep_last_versions": -1}`)
+		verrs, err := appSettingsService.Update(testAppSettings, []string{"RetentionSettingsData"})
+		require.Equal(t, []error{errors.New("keep_last_versions: Must not be negative")}, verrs)
+		require.NoError(t, err)
+	})
+
+	t.Run("when workflow rules are invalid", func(t *testing.T) {
+		testAppSettings := createTestAppSettings(t, &models.AppSettings{})
+
+		testAppSettings.WorkflowRulesData = json.RawMessage(`[{"platform":"windows"},{"platform":"ios","branches":["release/["]}]`)
+		verrs, err := appSettingsService.Update(testAppSettings, []string{"WorkflowRulesData"})
+		require.Equal(t, []error{
+			errors.New("workflow_rules: Unknown platform windows"),
+			errors.New("workflow_rules: Invalid pattern release/["),
+		}, verrs)
+		require.NoError(t, err)
+	})
 }
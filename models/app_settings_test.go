@@ -63,3 +63,69 @@ func Test_AppSettings_AndroidSettings(t *testing.T) {
 		require.Equal(t, models.AndroidSettings{}, iosSettings)
 	})
 }
+
+func Test_AppSettings_RetentionSettings(t *testing.T) {
+	t.Run("when retention settings is valid", func(t *testing.T) {
+		testAppSettings := models.AppSettings{RetentionSettingsData: json.RawMessage(`{"keep_last_versions":5,"keep_published":true}`)}
+		retentionSettings, err := testAppSettings.RetentionSettings()
+		require.NoError(t, err)
+		require.Equal(t, models.RetentionSettings{KeepLastVersions: 5, KeepPublished: true}, retentionSettings)
+		require.True(t, retentionSettings.Enabled())
+	})
+
+	t.Run("when retention settings is not set", func(t *testing.T) {
+		testAppSettings := models.AppSettings{}
+		retentionSettings, err := testAppSettings.RetentionSettings()
+		require.NoError(t, err)
+		require.Equal(t, models.RetentionSettings{}, retentionSettings)
+		require.False(t, retentionSettings.Enabled())
+	})
+
+	t.Run("when retention settings is invalid", func(t *testing.T) {
+		testAppSettings := models.AppSettings{RetentionSettingsData: json.RawMessage(`invalid json`)}
+		retentionSettings, err := testAppSettings.RetentionSettings()
+		require.EqualError(t, err, "invalid character 'i' looking for beginning of value")
+		require.Equal(t, models.RetentionSettings{}, retentionSettings)
+	})
+}
+
+func Test_AppSettings_WorkflowRules(t *testing.T) {
+	t.Run("when workflow rules are valid", func(t *testing.T) {
+		testAppSettings := models.AppSettings{WorkflowRulesData: json.RawMessage(`[{"platform":"ios","workflows":["deploy"],"branches":["release/*"]}]`)}
+		workflowRules, err := testAppSettings.WorkflowRules()
+		require.NoError(t, err)
+		require.Equal(t, models.WorkflowRules{
+			{Platform: "ios", Workflows: []string{"deploy"}, Branches: []string{"release/*"}},
+		}, workflowRules)
+	})
+
+	t.Run("when workflow rules are not set", func(t *testing.T) {
+		testAppSettings := models.AppSettings{}
+		workflowRules, err := testAppSettings.WorkflowRules()
+		require.NoError(t, err)
+		require.Equal(t, models.WorkflowRules{}, workflowRules)
+	})
+
+	t.Run("when workflow rules are invalid", func(t *testing.T) {
+		testAppSettings := models.AppSettings{WorkflowRulesData: json.RawMessage(`invalid json`)}
+		workflowRules, err := testAppSettings.WorkflowRules()
+		require.EqualError(t, err, "invalid character 'i' looking for beginning of value")
+		require.Equal(t, models.WorkflowRules{}, workflowRules)
+	})
+}
+
+func Test_AppSettings_CodeSigningExpiry(t *testing.T) {
+	t.Run("when code signing expiry is not set", func(t *testing.T) {
+		testAppSettings := models.AppSettings{}
+		codeSigningExpiry, err := testAppSettings.CodeSigningExpiry()
+		require.NoError(t, err)
+		require.Equal(t, models.CodeSigningExpiry{}, codeSigningExpiry)
+	})
+
+	t.Run("when code signing expiry is invalid", func(t *testing.T) {
+		testAppSettings := models.AppSettings{CodeSigningExpiryData: json.RawMessage(`invalid json`)}
+		codeSigningExpiry, err := testAppSettings.CodeSigningExpiry()
+		require.EqualError(t, err, "invalid character 'i' looking for beginning of value")
+		require.Equal(t, models.CodeSigningExpiry{}, codeSigningExpiry)
+	})
+}
@@ -1,6 +1,7 @@
 package models_test
 
 import (
+	"encoding/json"
 	"os"
 	"testing"
 
@@ -50,3 +51,26 @@ func Test_App_Secret(t *testing.T) {
 		require.NoError(t, revokeFn())
 	})
 }
+
+func Test_App_HeaderPalette(t *testing.T) {
+	t.Run("when header palette is set", func(t *testing.T) {
+		testApp := models.App{HeaderPaletteData: json.RawMessage(`{"primary":"#c83c3c","secondary":"#c84c3c","text":"#ffffff"}`)}
+		headerPalette, err := testApp.HeaderPalette()
+		require.NoError(t, err)
+		require.Equal(t, models.HeaderPalette{Primary: "#c83c3c", Secondary: "#c84c3c", Text: "#ffffff"}, headerPalette)
+	})
+
+	t.Run("when header palette is not set", func(t *testing.T) {
+		testApp := models.App{}
+		headerPalette, err := testApp.HeaderPalette()
+		require.NoError(t, err)
+		require.Equal(t, models.HeaderPalette{}, headerPalette)
+	})
+
+	t.Run("when header palette is invalid", func(t *testing.T) {
+		testApp := models.App{HeaderPaletteData: json.RawMessage(`invalid json`)}
+		headerPalette, err := testApp.HeaderPalette()
+		require.EqualError(t, err, "invalid character 'i' looking for beginning of value")
+		require.Equal(t, models.HeaderPalette{}, headerPalette)
+	})
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AppTombstone is recorded when an app is deprovisioned. It keeps what is needed to clean up the data of
+// the app after its record is deleted, and lets late webhooks of the app be recognized.
+type AppTombstone struct {
+	Record
+	AppID           uuid.UUID      `db:"app_id" json:"app_id"`
+	AppSlug         string         `json:"app_slug"`
+	BitriseAPIToken string         `db:"bitrise_api_token" json:"-"`
+	AssetIDs        pq.StringArray `db:"asset_ids" json:"-" gorm:"type:uuid[]"`
+	CleanedUpAt     *time.Time     `json:"cleaned_up_at"`
+}
+
+// BeforeCreate ...
+func (t *AppTombstone) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(t.ID, uuid.UUID{}) {
+		t.ID = uuid.NewV4()
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// AppTombstoneService ...
+type AppTombstoneService struct {
+	DB *gorm.DB
+	UpdatableModelService
+}
+
+// Bury deletes the app and records its tombstone in a single transaction. The screenshots and store
+// graphics of the app are deleted together with it, so the references they held on assets are kept
+// on the tombstone to be released later.
+func (s *AppTombstoneService) Bury(app *App) (*AppTombstone, error) {
+	tx := s.DB.Begin()
+	assetIDs, err := referencedAssetIDsOfApp(tx, app)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	tombstone := &AppTombstone{
+		AppID:           app.ID,
+		AppSlug:         app.AppSlug,
+		BitriseAPIToken: app.BitriseAPIToken,
+		AssetIDs:        assetIDs,
+	}
+	if err := tx.Create(tombstone).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	result := tx.Delete(&App{Record: Record{ID: app.ID}})
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, result.Error
+	}
+	if result.RowsAffected < 1 {
+		tx.Rollback()
+		return nil, gorm.ErrRecordNotFound
+	}
+	return tombstone, tx.Commit().Error
+}
+
+// Find returns the latest tombstone matching the given one
+func (s *AppTombstoneService) Find(tombstone *AppTombstone) (*AppTombstone, error) {
+	err := s.DB.Where(tombstone).Order("created_at DESC").First(tombstone).Error
+	if err != nil {
+		return nil, err
+	}
+	return tombstone, nil
+}
+
+// Update ...
+func (s *AppTombstoneService) Update(tombstone *AppTombstone, whitelist []string) error {
+	updateData, err := s.UpdateData(*tombstone, whitelist)
+	if err != nil {
+		return err
+	}
+	return s.DB.Model(tombstone).Updates(updateData).Error
+}
+
+// referencedAssetIDsOfApp lists the assets referenced by the screenshots, app previews and store
+// graphics of the app, an asset is listed once for each of its references
+func referencedAssetIDsOfApp(db *gorm.DB, app *App) ([]string, error) {
+	var references []struct {
+		AssetID string
+	}
+	err := db.Raw(`SELECT screenshots.asset_id FROM screenshots
+		JOIN app_versions ON app_versions.id = screenshots.app_version_id
+		WHERE app_versions.app_id = ? AND screenshots.asset_id IS NOT NULL
+		UNION ALL
+		SELECT app_previews.asset_id FROM app_previews
+		JOIN app_versions ON app_versions.id = app_previews.app_version_id
+		WHERE app_versions.app_id = ? AND app_previews.asset_id IS NOT NULL
+		UNION ALL
+		SELECT store_graphics.asset_id FROM store_graphics
+		JOIN app_versions ON app_versions.id = store_graphics.app_version_id
+		WHERE app_versions.app_id = ? AND store_graphics.asset_id IS NOT NULL`, app.ID, app.ID, app.ID).
+		Scan(&references).Error
+	if err != nil {
+		return nil, err
+	}
+
+	assetIDs := []string{}
+	for _, reference := range references {
+		assetIDs = append(assetIDs, reference.AssetID)
+	}
+	return assetIDs, nil
+}
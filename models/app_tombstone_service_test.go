@@ -0,0 +1,103 @@
+// +build database
+
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppTombstoneService_Bury(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	tombstoneService := models.AppTombstoneService{DB: dataservices.GetDB()}
+	assetService := models.AssetService{DB: dataservices.GetDB()}
+
+	t.Run("ok - deletes the app and keeps the asset references", func(t *testing.T) {
+		testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug", BitriseAPIToken: "test-api-token"})
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: testApp.ID, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+		asset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-1", Extension: ".png"})
+		require.NoError(t, err)
+		createTestScreenshot(t, &models.Screenshot{AppVersionID: testAppVersion.ID, AssetID: &asset.ID, Position: 1})
+		createTestScreenshot(t, &models.Screenshot{AppVersionID: testAppVersion.ID, Position: 2})
+		createTestStoreGraphic(t, &models.StoreGraphic{AppVersionID: testAppVersion.ID, Type: models.StoreGraphicTypeFeatureGraphic, AssetID: &asset.ID})
+		appPreviewAsset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-2", Extension: ".mp4"})
+		require.NoError(t, err)
+		createTestAppPreview(t, &models.AppPreview{
+			UploadableObject: models.UploadableObject{Filename: "preview.mp4"},
+			AppVersionID:     testAppVersion.ID,
+			AssetID:          &appPreviewAsset.ID,
+		})
+
+		tombstone, err := tombstoneService.Bury(testApp)
+		require.NoError(t, err)
+		require.Equal(t, testApp.ID, tombstone.AppID)
+		require.Equal(t, "test-app-slug", tombstone.AppSlug)
+		require.Equal(t, "test-api-token", tombstone.BitriseAPIToken)
+		require.Equal(t, []string{asset.ID.String(), appPreviewAsset.ID.String(), asset.ID.String()}, []string(tombstone.AssetIDs))
+
+		err = dataservices.GetDB().Where("id = ?", testApp.ID).First(&models.App{}).Error
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+
+	t.Run("error - when app doesn't exist", func(t *testing.T) {
+		tombstone, err := tombstoneService.Bury(&models.App{Record: models.Record{ID: uuid.NewV4()}, AppSlug: "missing-app-slug"})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+		require.Nil(t, tombstone)
+
+		_, err = tombstoneService.Find(&models.AppTombstone{AppSlug: "missing-app-slug"})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+}
+
+func Test_AppTombstoneService_Find(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	tombstoneService := models.AppTombstoneService{DB: dataservices.GetDB()}
+
+	t.Run("ok - returns the latest tombstone of the app", func(t *testing.T) {
+		_, err := tombstoneService.Bury(createTestApp(t, &models.App{AppSlug: "test-app-slug"}))
+		require.NoError(t, err)
+		latestTombstone, err := tombstoneService.Bury(createTestApp(t, &models.App{AppSlug: "test-app-slug"}))
+		require.NoError(t, err)
+
+		foundTombstone, err := tombstoneService.Find(&models.AppTombstone{AppSlug: "test-app-slug"})
+		require.NoError(t, err)
+		require.Equal(t, latestTombstone.ID, foundTombstone.ID)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		foundTombstone, err := tombstoneService.Find(&models.AppTombstone{AppSlug: "other-app-slug"})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+		require.Nil(t, foundTombstone)
+	})
+}
+
+func Test_AppTombstoneService_Update(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	tombstoneService := models.AppTombstoneService{DB: dataservices.GetDB()}
+	tombstone, err := tombstoneService.Bury(createTestApp(t, &models.App{AppSlug: "test-app-slug", BitriseAPIToken: "test-api-token"}))
+	require.NoError(t, err)
+
+	cleanedUpAt := time.Now()
+	tombstone.CleanedUpAt = &cleanedUpAt
+	tombstone.BitriseAPIToken = ""
+	require.NoError(t, tombstoneService.Update(tombstone, []string{"CleanedUpAt", "BitriseAPIToken"}))
+
+	foundTombstone, err := tombstoneService.Find(&models.AppTombstone{Record: models.Record{ID: tombstone.ID}})
+	require.NoError(t, err)
+	require.NotNil(t, foundTombstone.CleanedUpAt)
+	require.Equal(t, "", foundTombstone.BitriseAPIToken)
+}
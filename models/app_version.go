@@ -9,6 +9,19 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// ApplePlatforms are the platforms of the versions created from Xcode archives and published to App Store Connect
+var ApplePlatforms = []string{"ios", "tvos", "macos", "watchos"}
+
+// IsApplePlatform ...
+func IsApplePlatform(platform string) bool {
+	for _, applePlatform := range ApplePlatforms {
+		if platform == applePlatform {
+			return true
+		}
+	}
+	return false
+}
+
 // ArtifactInfo ...
 type ArtifactInfo struct {
 	Version              string    `json:"version"`
@@ -39,16 +52,23 @@ type AppStoreInfo struct {
 // AppVersion ...
 type AppVersion struct {
 	Record
-	Platform         string          `json:"platform"`
-	BuildNumber      string          `json:"build_number"`
-	BuildSlug        string          `json:"build_slug"`
-	LastUpdate       time.Time       `json:"last_update"`
-	Scheme           string          `json:"scheme"`
-	Configuration    string          `json:"configuration"`
-	CommitMessage    string          `json:"commit_message"`
-	ProductFlavor    string          `json:"product_flavor"`
-	ArtifactInfoData json.RawMessage `json:"-" db:"artifact_info" gorm:"column:artifact_info;type:json"`
-	AppStoreInfoData json.RawMessage `json:"-" db:"app_store_info" gorm:"column:app_store_info;type:json"`
+	Platform          string          `json:"platform"`
+	BuildNumber       string          `json:"build_number"`
+	BuildSlug         string          `json:"build_slug"`
+	LastUpdate        time.Time       `json:"last_update"`
+	Scheme            string          `json:"scheme"`
+	Configuration     string          `json:"configuration"`
+	CommitMessage     string          `json:"commit_message"`
+	CommitHash        string          `json:"commit_hash"`
+	Branch            string          `json:"branch"`
+	Tag               string          `json:"tag"`
+	PullRequestID     int             `json:"pull_request_id"`
+	TriggeredWorkflow string          `json:"triggered_workflow"`
+	TriggeredBy       string          `json:"triggered_by"`
+	BuildURL          string          `json:"build_url"`
+	ProductFlavor     string          `json:"product_flavor"`
+	ArtifactInfoData  json.RawMessage `json:"-" db:"artifact_info" gorm:"column:artifact_info;type:json"`
+	AppStoreInfoData  json.RawMessage `json:"-" db:"app_store_info" gorm:"column:app_store_info;type:json"`
 
 	AppID uuid.UUID `db:"app_id" json:"-"`
 	App   App       `gorm:"foreignkey:AppID" json:"-"`
@@ -113,6 +133,17 @@ func (a *AppVersion) ArtifactInfo() (ArtifactInfo, error) {
 	return artifactInfo, nil
 }
 
+// AppStorePlatform returns the platform of the version as App Store Connect (and fastlane deliver) names it
+func (a *AppVersion) AppStorePlatform() string {
+	switch a.Platform {
+	case "tvos":
+		return "appletvos"
+	case "macos":
+		return "osx"
+	}
+	return "ios"
+}
+
 func (a *ArtifactInfo) validate(scope *gorm.Scope) error {
 	if a.Version == "" {
 		return scope.DB().AddError(NewValidationError("version: Cannot be empty"))
@@ -0,0 +1,14 @@
+package models
+
+// AppVersionCopyParts tells which parts of the listing of a version are copied to another one. The
+// selected parts of the target version are replaced.
+type AppVersionCopyParts struct {
+	StoreInfo      bool `json:"store_info"`
+	Screenshots    bool `json:"screenshots"`
+	FeatureGraphic bool `json:"feature_graphic"`
+}
+
+// Empty ...
+func (p AppVersionCopyParts) Empty() bool {
+	return p == (AppVersionCopyParts{})
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ExpiredAppVersions returns the versions of an app which have to be deleted by the retention settings.
+// The latest versions of every group are kept up to the configured count, but at least the latest one,
+// since new versions copy their store listing from it. The versions are grouped the same way the version
+// to copy from is looked up, see latestGroupKey. Published versions are kept if
+// the settings say so. Any other version is expired if there are more than the configured count of
+// newer ones, or if it's unpublished and older than the configured days.
+func (s RetentionSettings) ExpiredAppVersions(appVersions []AppVersion, publishedIDs map[uuid.UUID]bool, now time.Time) []AppVersion {
+	if !s.Enabled() {
+		return []AppVersion{}
+	}
+
+	groups := map[string][]AppVersion{}
+	for _, appVersion := range appVersions {
+		key := latestGroupKey(appVersion)
+		groups[key] = append(groups[key], appVersion)
+	}
+
+	expiredBefore := now.AddDate(0, 0, -s.DeleteUnpublishedAfterDays)
+	expired := []AppVersion{}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].CreatedAt.After(group[j].CreatedAt)
+		})
+		for idx, appVersion := range group {
+			published := publishedIDs[appVersion.ID]
+			if idx == 0 || idx < s.KeepLastVersions {
+				continue
+			}
+			if published && s.KeepPublished {
+				continue
+			}
+			switch {
+			case s.KeepLastVersions > 0:
+				expired = append(expired, appVersion)
+			case s.DeleteUnpublishedAfterDays > 0 && !published && appVersion.CreatedAt.Before(expiredBefore):
+				expired = append(expired, appVersion)
+			}
+		}
+	}
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].CreatedAt.Before(expired[j].CreatedAt)
+	})
+	return expired
+}
+
+// latestGroupKey returns the key of the versions a new version is copied from by the build webhook, the
+// platform and the product flavor of Android versions, the platform, the scheme and the bundle ID of
+// the others
+func latestGroupKey(appVersion AppVersion) string {
+	if appVersion.Platform == "android" {
+		return appVersion.Platform + "/" + appVersion.ProductFlavor
+	}
+	bundleID := ""
+	if artifactInfo, err := appVersion.ArtifactInfo(); err == nil {
+		bundleID = artifactInfo.BundleID
+	}
+	return appVersion.Platform + "/" + appVersion.Scheme + "/" + bundleID
+}
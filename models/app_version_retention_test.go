@@ -0,0 +1,73 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_RetentionSettings_ExpiredAppVersions(t *testing.T) {
+	now := time.Date(2019, 11, 5, 12, 0, 0, 0, time.UTC)
+	testAppVersion := func(id, platform, productFlavor string, age time.Duration) models.AppVersion {
+		return models.AppVersion{
+			Record:        models.Record{ID: uuid.FromStringOrNil(id), CreatedAt: now.Add(-age)},
+			Platform:      platform,
+			ProductFlavor: productFlavor,
+		}
+	}
+	day := 24 * time.Hour
+	iosLatest := testAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0001", "ios", "", 1*day)
+	iosPrevious := testAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0002", "ios", "", 10*day)
+	iosOldest := testAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0003", "ios", "", 40*day)
+	androidLatest := testAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0004", "android", "paid", 50*day)
+	androidOldest := testAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0005", "android", "paid", 60*day)
+	androidOtherFlavor := testAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0006", "android", "free", 70*day)
+	appVersions := []models.AppVersion{iosOldest, androidOtherFlavor, iosLatest, androidOldest, iosPrevious, androidLatest}
+
+	t.Run("when retention is not enabled", func(t *testing.T) {
+		require.Equal(t, []models.AppVersion{}, models.RetentionSettings{KeepPublished: true}.ExpiredAppVersions(appVersions, map[uuid.UUID]bool{}, now))
+	})
+
+	t.Run("keeps the last versions of every platform and flavor", func(t *testing.T) {
+		expired := models.RetentionSettings{KeepLastVersions: 1}.ExpiredAppVersions(appVersions, map[uuid.UUID]bool{}, now)
+		require.Equal(t, []models.AppVersion{androidOldest, iosOldest, iosPrevious}, expired)
+	})
+
+	t.Run("keeps published versions", func(t *testing.T) {
+		publishedIDs := map[uuid.UUID]bool{iosOldest.ID: true}
+		expired := models.RetentionSettings{KeepLastVersions: 1, KeepPublished: true}.ExpiredAppVersions(appVersions, publishedIDs, now)
+		require.Equal(t, []models.AppVersion{androidOldest, iosPrevious}, expired)
+	})
+
+	t.Run("deletes old unpublished versions, but the latest one", func(t *testing.T) {
+		publishedIDs := map[uuid.UUID]bool{iosOldest.ID: true}
+		expired := models.RetentionSettings{DeleteUnpublishedAfterDays: 30}.ExpiredAppVersions(appVersions, publishedIDs, now)
+		require.Equal(t, []models.AppVersion{androidOldest}, expired)
+	})
+
+	t.Run("keeps the last versions of every scheme and bundle ID", func(t *testing.T) {
+		testIosAppVersion := func(id, scheme, bundleID string, age time.Duration) models.AppVersion {
+			appVersion := testAppVersion(id, "ios", "", age)
+			appVersion.Scheme = scheme
+			appVersion.ArtifactInfoData = json.RawMessage(`{"bundle_id":"` + bundleID + `"}`)
+			return appVersion
+		}
+		appLatest := testIosAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0011", "App", "io.bitrise.app", 1*day)
+		appOldest := testIosAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0012", "App", "io.bitrise.app", 10*day)
+		extensionLatest := testIosAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0013", "Extension", "io.bitrise.app", 20*day)
+		otherBundleLatest := testIosAppVersion("0e2bb1a2-0b6f-4f3e-9d6c-1f3f0c0a0014", "App", "io.bitrise.app.beta", 30*day)
+
+		expired := models.RetentionSettings{KeepLastVersions: 1}.ExpiredAppVersions([]models.AppVersion{otherBundleLatest, appOldest, extensionLatest, appLatest}, map[uuid.UUID]bool{}, now)
+		require.Equal(t, []models.AppVersion{appOldest}, expired)
+	})
+
+	t.Run("with every rule", func(t *testing.T) {
+		publishedIDs := map[uuid.UUID]bool{iosOldest.ID: true}
+		expired := models.RetentionSettings{KeepLastVersions: 2, KeepPublished: true, DeleteUnpublishedAfterDays: 5}.ExpiredAppVersions(appVersions, publishedIDs, now)
+		require.Equal(t, []models.AppVersion{}, expired)
+	})
+}
@@ -34,7 +34,19 @@ func (a *AppVersionService) Find(appVersion *AppVersion) (*AppVersion, error) {
 func (a *AppVersionService) FindAll(app *App, filterParams map[string]interface{}) ([]AppVersion, error) {
 	var appVersions []AppVersion
 	filterParams["app_id"] = app.ID
-	err := a.DB.Where(filterParams).Find(&appVersions).Order("created_at DESC").Error
+	err := a.DB.Where(filterParams).Order("created_at DESC").Find(&appVersions).Error
+	if err != nil {
+		return nil, err
+	}
+	return appVersions, nil
+}
+
+// FindAllPublished returns the versions of the app which have been published successfully
+func (a *AppVersionService) FindAllPublished(app *App) ([]AppVersion, error) {
+	var appVersions []AppVersion
+	err := a.DB.Where("app_id = ?", app.ID).
+		Where("EXISTS (SELECT 1 FROM app_version_events WHERE app_version_events.app_version_id = app_versions.id AND app_version_events.status = ?)", "success").
+		Find(&appVersions).Error
 	if err != nil {
 		return nil, err
 	}
@@ -58,11 +70,31 @@ func (a *AppVersionService) Update(appVersion *AppVersion, whitelist []string) (
 	return nil, nil
 }
 
-// Latest ...
-func (a *AppVersionService) Latest(appVersion *AppVersion) (*AppVersion, error) {
-	err := a.DB.Preload("App").Order("created_at DESC").First(appVersion, appVersion).Error
+// Latest returns the last created of the versions matching the app version. Unless the bundle ID is
+// empty, only versions built with that bundle ID are matched.
+func (a *AppVersionService) Latest(appVersion *AppVersion, bundleID string) (*AppVersion, error) {
+	query := a.DB.Preload("App").Order("created_at DESC")
+	if bundleID != "" {
+		query = query.Where("artifact_info->>'bundle_id' = ?", bundleID)
+	}
+	err := query.First(appVersion, appVersion).Error
 	if err != nil {
 		return nil, err
 	}
 	return appVersion, nil
 }
+
+// Delete ...
+func (a *AppVersionService) Delete(appVersion *AppVersion) error {
+	result := a.DB.Delete(&appVersion)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
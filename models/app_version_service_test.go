@@ -11,6 +11,7 @@ import (
 	"github.com/bitrise-io/addons-ship-backend/dataservices"
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
 
@@ -176,6 +177,21 @@ func Test_AppVersionService_FindAll(t *testing.T) {
 		require.NoError(t, err)
 		reflect.DeepEqual([]models.AppVersion{*testApp1VersionIOS}, foundAppVersions)
 	})
+
+	testApp1VersionRelease := createTestAppVersion(t, &models.AppVersion{
+		App:              *testApp1,
+		Platform:         "ios",
+		Branch:           "release/1.0",
+		PullRequestID:    12,
+		ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`),
+	})
+
+	t.Run("when query versions of test app 1 by build metadata", func(t *testing.T) {
+		foundAppVersions, err := appVersionService.FindAll(testApp1, map[string]interface{}{"branch": "release/1.0", "pull_request_id": 12})
+		require.NoError(t, err)
+		require.Len(t, foundAppVersions, 1)
+		require.Equal(t, testApp1VersionRelease.ID, foundAppVersions[0].ID)
+	})
 }
 
 func Test_AppVersionService_Update(t *testing.T) {
@@ -249,24 +265,75 @@ func Test_AppVersionService_Latest(t *testing.T) {
 	testApp1VersionIOS := createTestAppVersion(t, &models.AppVersion{
 		App:              *testApp1,
 		Platform:         "ios",
-		ArtifactInfoData: json.RawMessage(`{"version":"1.1"}`),
+		ArtifactInfoData: json.RawMessage(`{"version":"1.1","bundle_id":"io.bitrise.app"}`),
+	})
+	testApp1VersionIOSWhiteLabel := createTestAppVersion(t, &models.AppVersion{
+		App:              *testApp1,
+		Platform:         "ios",
+		ArtifactInfoData: json.RawMessage(`{"version":"1.0","bundle_id":"io.bitrise.whitelabel"}`),
 	})
 
 	t.Run("ok - finds the latest android version", func(t *testing.T) {
-		foundAppVersion, err := appVersionService.Latest(&models.AppVersion{AppID: testApp1.ID, Platform: "android"})
+		foundAppVersion, err := appVersionService.Latest(&models.AppVersion{AppID: testApp1.ID, Platform: "android"}, "")
 		require.NoError(t, err)
 		compareAppVersion(t, *testApp1VersionAndroid, *foundAppVersion)
 	})
 
 	t.Run("ok - finds the latest ios version", func(t *testing.T) {
-		foundAppVersion, err := appVersionService.Latest(&models.AppVersion{AppID: testApp1.ID, Platform: "ios"})
+		foundAppVersion, err := appVersionService.Latest(&models.AppVersion{AppID: testApp1.ID, Platform: "ios"}, "")
+		require.NoError(t, err)
+		compareAppVersion(t, *testApp1VersionIOSWhiteLabel, *foundAppVersion)
+	})
+
+	t.Run("ok - finds the latest ios version of the bundle ID", func(t *testing.T) {
+		foundAppVersion, err := appVersionService.Latest(&models.AppVersion{AppID: testApp1.ID, Platform: "ios"}, "io.bitrise.app")
 		require.NoError(t, err)
 		compareAppVersion(t, *testApp1VersionIOS, *foundAppVersion)
 	})
 
 	t.Run("when no app version found", func(t *testing.T) {
-		foundAppVersion, err := appVersionService.Latest(&models.AppVersion{AppID: testApp1.ID, Platform: "nope"})
+		foundAppVersion, err := appVersionService.Latest(&models.AppVersion{AppID: testApp1.ID, Platform: "nope"}, "")
 		require.EqualError(t, err, "record not found")
 		require.Nil(t, foundAppVersion)
 	})
 }
+
+func Test_AppVersionService_FindAllPublished(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appVersionService := models.AppVersionService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{})
+	publishedAppVersion := createTestAppVersion(t, &models.AppVersion{App: *testApp, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+	createTestAppVersionEvent(t, &models.AppVersionEvent{AppVersion: *publishedAppVersion, Status: "failed"})
+	createTestAppVersionEvent(t, &models.AppVersionEvent{AppVersion: *publishedAppVersion, Status: "success"})
+	failedAppVersion := createTestAppVersion(t, &models.AppVersion{App: *testApp, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.1"}`)})
+	createTestAppVersionEvent(t, &models.AppVersionEvent{AppVersion: *failedAppVersion, Status: "failed"})
+	createTestAppVersion(t, &models.AppVersion{App: *testApp, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.2"}`)})
+
+	foundAppVersions, err := appVersionService.FindAllPublished(testApp)
+	require.NoError(t, err)
+	require.Len(t, foundAppVersions, 1)
+	require.Equal(t, publishedAppVersion.ID, foundAppVersions[0].ID)
+}
+
+func Test_AppVersionService_Delete(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	appVersionService := models.AppVersionService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{})
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{App: *testApp, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, appVersionService.Delete(&models.AppVersion{Record: models.Record{ID: testAppVersion.ID}}))
+
+		_, err := appVersionService.Find(&models.AppVersion{Record: models.Record{ID: testAppVersion.ID}})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+
+	t.Run("when app version doesn't exist", func(t *testing.T) {
+		err := appVersionService.Delete(&models.AppVersion{Record: models.Record{ID: testAppVersion.ID}})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+}
@@ -39,3 +39,15 @@ func Test_AppVersion_ArtifactInfo(t *testing.T) {
 		require.Equal(t, models.ArtifactInfo{}, artifactInfo)
 	})
 }
+
+func Test_AppVersion_AppStorePlatform(t *testing.T) {
+	for platform, expectedAppStorePlatform := range map[string]string{
+		"ios":     "ios",
+		"watchos": "ios",
+		"tvos":    "appletvos",
+		"macos":   "osx",
+	} {
+		appVersion := models.AppVersion{Platform: platform}
+		require.Equal(t, expectedAppStorePlatform, appVersion.AppStorePlatform())
+	}
+}
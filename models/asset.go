@@ -0,0 +1,70 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Asset is an uploaded file stored once by its content. The uploadables of every app version having
+// the same content reference the same asset, the object is deleted when the last reference goes away.
+type Asset struct {
+	Record
+	Checksum  string `json:"checksum" gorm:"unique_index:assets_checksum_extension_idx"`
+	Extension string `json:"extension" gorm:"unique_index:assets_checksum_extension_idx"`
+	Filesize  int64  `json:"filesize"`
+	RefCount  int64  `db:"ref_count" json:"ref_count"`
+}
+
+// BeforeCreate ...
+func (a *Asset) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(a.ID, uuid.UUID{}) {
+		a.ID = uuid.NewV4()
+	}
+	return nil
+}
+
+// AWSPath ...
+func (a *Asset) AWSPath() string {
+	return AssetAWSPath(a.Checksum, a.Extension)
+}
+
+// AssetAWSPath returns the key of the object with the given content, the first characters of the
+// checksum are used as a prefix to spread the objects of the bucket
+func AssetAWSPath(checksum, extension string) string {
+	prefix := checksum
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return strings.Join([]string{"assets", prefix, checksum + extension}, "/")
+}
+
+// AssetExtension returns the normalized extension of the file, as it is part of the key of the asset
+func AssetExtension(filename string) string {
+	return strings.ToLower(filepath.Ext(filename))
+}
+
+// NewAssetFor returns the asset storing the content of the verified uploadable
+func NewAssetFor(uploadable UploadableObject) *Asset {
+	return &Asset{
+		Checksum:  uploadable.Checksum,
+		Extension: AssetExtension(uploadable.Filename),
+		Filesize:  uploadable.Filesize,
+	}
+}
+
+// ContentChecksum calculates the SHA-256 checksum of the content read from r, the same way as it's
+// calculated for verified images
+func ContentChecksum(r io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AssetService ...
+type AssetService struct {
+	DB *gorm.DB
+}
+
+// Acquire takes a reference on the asset with the checksum and extension of the given one, the asset
+// is created if it doesn't exist yet
+func (s *AssetService) Acquire(asset *Asset) (*Asset, error) {
+	if asset.Checksum == "" {
+		return nil, errors.New("Checksum of asset is not set")
+	}
+	now := time.Now()
+	var acquiredAsset Asset
+	err := s.DB.Raw(`INSERT INTO assets (id, checksum, extension, filesize, ref_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 1, ?, ?)
+		ON CONFLICT (checksum, extension) DO UPDATE SET ref_count = assets.ref_count + 1, updated_at = EXCLUDED.updated_at
+		RETURNING *`,
+		uuid.NewV4(), asset.Checksum, asset.Extension, asset.Filesize, now, now).
+		Scan(&acquiredAsset).Error
+	if err != nil {
+		return nil, err
+	}
+	return &acquiredAsset, nil
+}
+
+// Retain takes one more reference on each of the given assets, an ID listed multiple times is counted
+// multiple times
+func (s *AssetService) Retain(assetIDs []uuid.UUID) error {
+	if len(assetIDs) == 0 {
+		return nil
+	}
+	counts := map[uuid.UUID]int64{}
+	for _, assetID := range assetIDs {
+		counts[assetID]++
+	}
+
+	tx := s.DB.Begin()
+	for assetID, count := range counts {
+		result := tx.Model(&Asset{}).Where("id = ?", assetID).
+			Update("ref_count", gorm.Expr("ref_count + ?", count))
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			tx.Rollback()
+			return gorm.ErrRecordNotFound
+		}
+	}
+	return tx.Commit().Error
+}
+
+// FindAllReferenced returns the assets referenced by at least one screenshot, app preview or store graphic
+func (s *AssetService) FindAllReferenced() ([]Asset, error) {
+	var assets []Asset
+	err := s.DB.Raw(`SELECT * FROM assets WHERE id IN (
+		SELECT asset_id FROM screenshots WHERE asset_id IS NOT NULL
+		UNION
+		SELECT asset_id FROM app_previews WHERE asset_id IS NOT NULL
+		UNION
+		SELECT asset_id FROM store_graphics WHERE asset_id IS NOT NULL)`).
+		Scan(&assets).Error
+	if err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
+
+// Release drops a reference of the asset. When the last reference goes away the asset is deleted,
+// and its object is removed by deleteObject before the deletion is committed. An Acquire of the same
+// content waits on the deleted row until then, so it never gets an asset whose object is being deleted.
+// The asset is released even if deleteObject fails, its error is returned and the object is left to the
+// orphaned objects GC.
+func (s *AssetService) Release(assetID uuid.UUID, deleteObject func(*Asset) error) error {
+	tx := s.DB.Begin()
+	var asset Asset
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", assetID).First(&asset).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if asset.RefCount > 1 {
+		err = tx.Model(&asset).Update("ref_count", gorm.Expr("ref_count - 1")).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit().Error
+	}
+
+	err = tx.Delete(&asset).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	deleteErr := deleteObject(&asset)
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	if deleteErr != nil {
+		return errors.Wrap(deleteErr, "Failed to delete object of released asset")
+	}
+	return nil
+}
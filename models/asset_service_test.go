@@ -0,0 +1,145 @@
+// +build database
+
+package models_test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AssetService_Acquire(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	assetService := models.AssetService{DB: dataservices.GetDB()}
+
+	t.Run("ok - creates the asset, then takes a reference on it", func(t *testing.T) {
+		asset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-1", Extension: ".png", Filesize: 1234})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), asset.RefCount)
+		require.Equal(t, int64(1234), asset.Filesize)
+
+		sameAsset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-1", Extension: ".png", Filesize: 1234})
+		require.NoError(t, err)
+		require.Equal(t, asset.ID, sameAsset.ID)
+		require.Equal(t, int64(2), sameAsset.RefCount)
+	})
+
+	t.Run("ok - same content with other extension is another asset", func(t *testing.T) {
+		asset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-1", Extension: ".jpg"})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), asset.RefCount)
+	})
+
+	t.Run("error - without checksum", func(t *testing.T) {
+		asset, err := assetService.Acquire(&models.Asset{Extension: ".png"})
+		require.EqualError(t, err, "Checksum of asset is not set")
+		require.Nil(t, asset)
+	})
+}
+
+func Test_AssetService_Retain(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	assetService := models.AssetService{DB: dataservices.GetDB()}
+	asset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-1", Extension: ".png"})
+	require.NoError(t, err)
+
+	t.Run("ok - counts every reference", func(t *testing.T) {
+		require.NoError(t, assetService.Retain([]uuid.UUID{asset.ID, asset.ID}))
+
+		var foundAsset models.Asset
+		require.NoError(t, dataservices.GetDB().Where("id = ?", asset.ID).First(&foundAsset).Error)
+		require.Equal(t, int64(3), foundAsset.RefCount)
+	})
+
+	t.Run("error - when asset doesn't exist", func(t *testing.T) {
+		err := assetService.Retain([]uuid.UUID{uuid.NewV4()})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+}
+
+func Test_AssetService_FindAllReferenced(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	assetService := models.AssetService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: testApp.ID, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`)})
+	screenshotAsset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-1", Extension: ".png"})
+	require.NoError(t, err)
+	storeGraphicAsset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-2", Extension: ".png"})
+	require.NoError(t, err)
+	appPreviewAsset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-4", Extension: ".mp4"})
+	require.NoError(t, err)
+	_, err = assetService.Acquire(&models.Asset{Checksum: "checksum-3", Extension: ".png"})
+	require.NoError(t, err)
+	createTestScreenshot(t, &models.Screenshot{AppVersionID: testAppVersion.ID, AssetID: &screenshotAsset.ID, Position: 1})
+	createTestScreenshot(t, &models.Screenshot{AppVersionID: testAppVersion.ID, AssetID: &screenshotAsset.ID, Position: 2})
+	createTestStoreGraphic(t, &models.StoreGraphic{AppVersionID: testAppVersion.ID, Type: models.StoreGraphicTypeIcon, AssetID: &storeGraphicAsset.ID})
+	createTestAppPreview(t, &models.AppPreview{
+		UploadableObject: models.UploadableObject{Filename: "preview.mp4"},
+		AppVersionID:     testAppVersion.ID,
+		AssetID:          &appPreviewAsset.ID,
+	})
+
+	assets, err := assetService.FindAllReferenced()
+	require.NoError(t, err)
+	checksums := []string{}
+	for _, asset := range assets {
+		checksums = append(checksums, asset.Checksum)
+	}
+	sort.Strings(checksums)
+	require.Equal(t, []string{"checksum-1", "checksum-2", "checksum-4"}, checksums)
+}
+
+func Test_AssetService_Release(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	assetService := models.AssetService{DB: dataservices.GetDB()}
+	asset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-1", Extension: ".png"})
+	require.NoError(t, err)
+	require.NoError(t, assetService.Retain([]uuid.UUID{asset.ID}))
+
+	deletedPaths := []string{}
+	deleteObject := func(asset *models.Asset) error {
+		deletedPaths = append(deletedPaths, asset.AWSPath())
+		return nil
+	}
+
+	t.Run("ok - keeps the asset while it's referenced", func(t *testing.T) {
+		require.NoError(t, assetService.Release(asset.ID, deleteObject))
+		require.Empty(t, deletedPaths)
+	})
+
+	t.Run("ok - deletes the asset with the last reference", func(t *testing.T) {
+		require.NoError(t, assetService.Release(asset.ID, deleteObject))
+		require.Equal(t, []string{"assets/ch/checksum-1.png"}, deletedPaths)
+
+		err := dataservices.GetDB().Where("id = ?", asset.ID).First(&models.Asset{}).Error
+		require.Equal(t, gorm.ErrRecordNotFound, err)
+	})
+
+	t.Run("when the object can't be deleted", func(t *testing.T) {
+		asset, err := assetService.Acquire(&models.Asset{Checksum: "checksum-2", Extension: ".png"})
+		require.NoError(t, err)
+
+		err = assetService.Release(asset.ID, func(*models.Asset) error {
+			return errors.New("SOME-AWS-ERROR")
+		})
+		require.EqualError(t, err, "Failed to delete object of released asset: SOME-AWS-ERROR")
+
+		err = dataservices.GetDB().Where("id = ?", asset.ID).First(&models.Asset{}).Error
+		require.Equal(t, gorm.ErrRecordNotFound, err)
+	})
+}
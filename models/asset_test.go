@@ -0,0 +1,19 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func Test_ContentChecksum(t *testing.T) {
+	checksum, err := models.ContentChecksum(strings.NewReader("app preview content"))
+	require.NoError(t, err)
+	require.Len(t, checksum, 64)
+
+	otherChecksum, err := models.ContentChecksum(strings.NewReader("other app preview content"))
+	require.NoError(t, err)
+	require.NotEqual(t, checksum, otherChecksum)
+}
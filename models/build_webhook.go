@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// BuildWebhook is the payload of a finished build's webhook stored to be processed by the worker. The
+// outcome of the processing is recorded on it, so it can be checked for each build.
+type BuildWebhook struct {
+	Record
+	BuildSlug   string          `db:"build_slug" json:"build_slug"`
+	Payload     json.RawMessage `json:"payload" gorm:"type:json"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	Message     string          `json:"message"`
+	Result      json.RawMessage `json:"result" gorm:"type:json"`
+	ProcessedAt *time.Time      `db:"processed_at" json:"processed_at"`
+
+	AppID uuid.UUID `db:"app_id" json:"-"`
+}
+
+// BuildWebhookResult ...
+type BuildWebhookResult struct {
+	AppVersionIDs []uuid.UUID `json:"app_version_ids"`
+}
+
+// BeforeCreate ...
+func (b *BuildWebhook) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(b.ID, uuid.UUID{}) {
+		b.ID = uuid.NewV4()
+	}
+	if b.Result == nil {
+		b.Result = json.RawMessage(`{}`)
+	}
+	return nil
+}
+
+// IsDone ...
+func (b *BuildWebhook) IsDone() bool {
+	return b.Status == JobStatusFinished || b.Status == JobStatusFailed
+}
@@ -0,0 +1,35 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// BuildWebhookService ...
+type BuildWebhookService struct {
+	DB *gorm.DB
+	UpdatableModelService
+}
+
+// Create ...
+func (s *BuildWebhookService) Create(buildWebhook *BuildWebhook) (*BuildWebhook, error) {
+	if err := s.DB.Create(buildWebhook).Error; err != nil {
+		return nil, err
+	}
+	return buildWebhook, nil
+}
+
+// Find returns the latest build webhook matching the given one
+func (s *BuildWebhookService) Find(buildWebhook *BuildWebhook) (*BuildWebhook, error) {
+	err := s.DB.Where(buildWebhook).Order("created_at DESC").First(buildWebhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return buildWebhook, nil
+}
+
+// Update ...
+func (s *BuildWebhookService) Update(buildWebhook *BuildWebhook, whitelist []string) error {
+	updateData, err := s.UpdateData(*buildWebhook, whitelist)
+	if err != nil {
+		return err
+	}
+	return s.DB.Model(buildWebhook).Updates(updateData).Error
+}
@@ -0,0 +1,79 @@
+// +build database
+
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_BuildWebhookService_Create(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	buildWebhookService := models.BuildWebhookService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+
+	buildWebhook, err := buildWebhookService.Create(&models.BuildWebhook{
+		AppID:     testApp.ID,
+		BuildSlug: "test-build-slug",
+		Payload:   json.RawMessage(`{"build_slug":"test-build-slug"}`),
+		Status:    models.JobStatusPending,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.UUID{}, buildWebhook.ID)
+	require.Equal(t, json.RawMessage(`{}`), buildWebhook.Result)
+}
+
+func Test_BuildWebhookService_Find(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	buildWebhookService := models.BuildWebhookService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+
+	t.Run("ok - returns the latest webhook of the build", func(t *testing.T) {
+		_, err := buildWebhookService.Create(&models.BuildWebhook{AppID: testApp.ID, BuildSlug: "test-build-slug", Payload: json.RawMessage(`{}`), Status: models.JobStatusFailed})
+		require.NoError(t, err)
+		latestBuildWebhook, err := buildWebhookService.Create(&models.BuildWebhook{AppID: testApp.ID, BuildSlug: "test-build-slug", Payload: json.RawMessage(`{}`), Status: models.JobStatusPending})
+		require.NoError(t, err)
+
+		foundBuildWebhook, err := buildWebhookService.Find(&models.BuildWebhook{AppID: testApp.ID, BuildSlug: "test-build-slug"})
+		require.NoError(t, err)
+		require.Equal(t, latestBuildWebhook.ID, foundBuildWebhook.ID)
+	})
+
+	t.Run("error - when no webhook was received for the build", func(t *testing.T) {
+		_, err := buildWebhookService.Find(&models.BuildWebhook{AppID: testApp.ID, BuildSlug: "other-build-slug"})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+}
+
+func Test_BuildWebhookService_Update(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	buildWebhookService := models.BuildWebhookService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+
+	buildWebhook, err := buildWebhookService.Create(&models.BuildWebhook{AppID: testApp.ID, BuildSlug: "test-build-slug", Payload: json.RawMessage(`{}`), Status: models.JobStatusPending})
+	require.NoError(t, err)
+
+	buildWebhook.Status = models.JobStatusRunning
+	buildWebhook.Attempts = 1
+	buildWebhook.Message = "should not be updated"
+	require.NoError(t, buildWebhookService.Update(buildWebhook, []string{"Status", "Attempts"}))
+
+	foundBuildWebhook, err := buildWebhookService.Find(&models.BuildWebhook{Record: models.Record{ID: buildWebhook.ID}})
+	require.NoError(t, err)
+	require.Equal(t, models.JobStatusRunning, foundBuildWebhook.Status)
+	require.Equal(t, 1, foundBuildWebhook.Attempts)
+	require.Equal(t, "", foundBuildWebhook.Message)
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"bytes"
+	"regexp"
+	"time"
+
+	"github.com/bitrise-io/go-utils/pkcs12"
+	"github.com/pkg/errors"
+)
+
+// CodeSigningExpiryWarningDays are the days before expiry the contacts of the app are warned at
+var CodeSigningExpiryWarningDays = []int{30, 7, 1}
+
+// CodeSigningExpiredDaysAhead is stored as the days ahead of the notice sent once the file expired, it's
+// sent after every warning
+const CodeSigningExpiredDaysAhead = -1
+
+var provisioningProfileExpirationDatePattern = regexp.MustCompile(`<key>ExpirationDate</key>\s*<date>([^<]+)</date>`)
+
+// CodeSigningFileExpiry tells when a code signing file selected in the iOS settings expires, and the last
+// warning the contacts got about it
+type CodeSigningFileExpiry struct {
+	Slug              string    `json:"slug"`
+	Filename          string    `json:"filename"`
+	ExpireDate        time.Time `json:"expire_date"`
+	NotifiedDaysAhead int       `json:"notified_days_ahead,omitempty"`
+}
+
+// DueWarning returns the days ahead of the warning due at the given time, if the contacts didn't get it
+// yet. CodeSigningExpiredDaysAhead is returned once the file expired.
+func (e CodeSigningFileExpiry) DueWarning(now time.Time) (int, bool) {
+	dueDaysAhead := 0
+	if e.ExpireDate.After(now) {
+		daysLeft := int(e.ExpireDate.Sub(now).Hours() / 24)
+		for _, days := range CodeSigningExpiryWarningDays {
+			if daysLeft <= days {
+				dueDaysAhead = days
+			}
+		}
+	} else {
+		dueDaysAhead = CodeSigningExpiredDaysAhead
+	}
+	if dueDaysAhead == 0 {
+		return 0, false
+	}
+	if e.NotifiedDaysAhead != 0 && e.NotifiedDaysAhead <= dueDaysAhead {
+		return 0, false
+	}
+	return dueDaysAhead, true
+}
+
+// Expired tells if the last notice about the file was sent once it expired
+func (e CodeSigningFileExpiry) Expired() bool {
+	return e.NotifiedDaysAhead == CodeSigningExpiredDaysAhead
+}
+
+// CodeSigningExpiry ...
+type CodeSigningExpiry struct {
+	ProvisioningProfiles []CodeSigningFileExpiry `json:"provisioning_profiles"`
+	CodeSigningIdentity  *CodeSigningFileExpiry  `json:"code_signing_identity"`
+	CheckedAt            time.Time               `json:"checked_at"`
+}
+
+// CarryOver returns the file with the last warning sent about it, if it's stored with the same expire date
+func (e CodeSigningExpiry) CarryOver(file CodeSigningFileExpiry) CodeSigningFileExpiry {
+	storedFiles := append([]CodeSigningFileExpiry{}, e.ProvisioningProfiles...)
+	if e.CodeSigningIdentity != nil {
+		storedFiles = append(storedFiles, *e.CodeSigningIdentity)
+	}
+	for _, storedFile := range storedFiles {
+		if storedFile.Slug == file.Slug && storedFile.ExpireDate.Equal(file.ExpireDate) {
+			file.NotifiedDaysAhead = storedFile.NotifiedDaysAhead
+			break
+		}
+	}
+	return file
+}
+
+// ProvisioningProfileExpireDate returns the expiration date of the signed provisioning profile
+func ProvisioningProfileExpireDate(content []byte) (time.Time, error) {
+	matches := provisioningProfileExpirationDatePattern.FindSubmatch(content)
+	if matches == nil {
+		return time.Time{}, errors.New("No expiration date found in provisioning profile")
+	}
+	expireDate, err := time.Parse(time.RFC3339, string(bytes.TrimSpace(matches[1])))
+	if err != nil {
+		return time.Time{}, errors.WithStack(err)
+	}
+	return expireDate, nil
+}
+
+// CodeSigningIdentityExpireDate returns the earliest expiry of the certificates in the PKCS #12 file
+func CodeSigningIdentityExpireDate(content []byte, password string) (time.Time, error) {
+	certificates, err := pkcs12.DecodeAllCerts(content, password)
+	if err != nil {
+		return time.Time{}, errors.WithStack(err)
+	}
+	if len(certificates) == 0 {
+		return time.Time{}, errors.New("No certificate found in code signing identity")
+	}
+	expireDate := certificates[0].NotAfter
+	for _, certificate := range certificates[1:] {
+		if certificate.NotAfter.Before(expireDate) {
+			expireDate = certificate.NotAfter
+		}
+	}
+	return expireDate, nil
+}
@@ -0,0 +1,120 @@
+package models_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+// testCodeSigningIdentity is a PKCS #12 file with a self signed certificate expiring at 2020-03-01,
+// protected by the password "test-password"
+const testCodeSigningIdentity = "MIIFuQIBAzCCBX8GCSqGSIb3DQEHAaCCBXAEggVsMIIFaDCCAmcGCSqGSIb3DQEHBqCCAlgwggJUAgEAMIICTQYJKoZIhvcNAQcB" +
+	"MBwGCiqGSIb3DQEMAQMwDgQIcjA8cgujah8CAggAgIICIGeMXLyzcPsOOtLAnKtNSpm21YoCP/9aFpqH3Bp1zTh3g13RSalXs1qy" +
+	"VYwatGOgETByxVyvPKHVMvexximxDnDhxin3qpjgGstW40M//Q+TtUzoaErQC8sVvZxnIk1CeaG2aowdOXn6WnYtPGwG7UXWX1bT" +
+	"SCDYhY8ZBrjjn/a5Rj03Ce1N3MXAKlLasCwoH4dpvEqp0KXd6fN8tebAoGSnO720mnf0OW0b/cL0QTdzviEH54Nkc66NJ/Vuh/gs" +
+	"I54Dl/Ly6guiPQZPiHq5SPK0OGI2S5WIUigbV8qWlG7vUeqoq2myAK6+7tKHEMyyXQZ+MUrWz2bKFFEt3I+N3qNeR+fKU3ZnVq7p" +
+	"OxcE24oj4XegjhzhgM6AmKDJUjWWjY8TXf5fQ1EQQBvkpRtblzOu5bx2g0ZW1Nr/XfMx3e9V0ZxioRgNN33S9F0YIHQiC/cFU1D6" +
+	"KCNzbb6YOyB7qZgqX0q6hZxWUAKzjhj0KctjwQW2Li9Kwwys14ex69DlHQrfsPVVGA7MAZERHjb6+MH/BhwtK3thMBr0gKJfp0jP" +
+	"1GMFnZPDdCAcqR3uaORg2hDtdVlGaB7vGuDde85CCAcZePnIigEEQGiL9VzQGh8fFoziEx17ykVdoLM+Y+8Ux+O/4j5aTHi6yaSl" +
+	"vhHuMdTbEASB6OZ9lcl5qoQmqiggXfrPTYxS9iaMsfIHB0wP11iSur8CeVN3U1WUSZ3HX98wggL5BgkqhkiG9w0BBwGgggLqBIIC" +
+	"5jCCAuIwggLeBgsqhkiG9w0BDAoBAqCCAqYwggKiMBwGCiqGSIb3DQEMAQMwDgQIYX985QWCBYsCAggABIICgASFX41PeJ5/YRjk" +
+	"ijRkX2X/wcdJTPFNus34RDtfqnQQrZOUwvFDieAVtcLLCOh6n2Z3FbFGKh17br4HuOUz9ByGztgFtIcWw1chsczNoC+uLYMeH2aD" +
+	"z2e7WpvMlNMTTAwdjb4GppZpbjgzu19P5BRHOg+F05p/Npx7Y65IEuh1snPavE0sgSRGCzny3KoHuvigUGmLMhQA7Q3PQDfBHTdO" +
+	"k7jLxPytcujoBdFN5Wt/qZh1v7tnodBR2ahUocII6LOO/UviMNi7OrCv+XPZGQ24BTBLxZMeNH563TtDfazLjV/q7MQ1VGjugJ2T" +
+	"nuGVQLucm3FOvVEPqXv54LULOHOKTigYk4y6FWywDEy78qDAbcXxK0jCPMHKbOQ2rSBELbpxXdCHRMXPi8TTCdPidDhZv/d2kJ1F" +
+	"DF/sP/h0GM2nMOz6UnGzl3xJgoAPWzCwnYwG4N4qgSJA7WeM/iaoOrcs3EdJK3vvqIEarSNOhUbD0Ft8DESs2PjJv5UjLbcRRspH" +
+	"Ob0f7os+pWVGmIw8K44MFJiVuwWduYh9GtmvVvzjSBi/m0KlMm8PG3hqiYxxflYGtOITbbrgQEUnkBwa6fzsaE49uYRKnlZsVHkL" +
+	"FiVjKmdhDoUk+ntTqjUlfjdCD3IZ5oZfuknagsZCRlO3XOyA8NYxub26EznPrivpMPMBb0o1sATk3jpzmldIluh3yzYtzOib29qB" +
+	"onbQc+HMZXjNaLkH3PsY0sJxeKY8vqYNexI0GQXcHS3pCTiUzKDmDmIsfX855fzndggr1RvSgvZ/c/6zTR/PZoKfL9HjJ4qoNzVw" +
+	"KY8U6BWGsPX0Y/wDNkGfCKKb+DJ5DQ0IeOMBZusxJTAjBgkqhkiG9w0BCRUxFgQUyGk2KAm102cDLoVtx+rZP9JamIUwMTAhMAkG" +
+	"BSsOAwIaBQAEFCpIVBqhpWXcbCnws87NwKFqSjUHBAis3DS3s5ypCAICCAA="
+
+func Test_CodeSigningFileExpiry_DueWarning(t *testing.T) {
+	testNow := time.Date(2020, time.January, 1, 4, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		testName          string
+		expireDate        time.Time
+		notifiedDaysAhead int
+		expectedDaysAhead int
+		expectedDue       bool
+	}{
+		{testName: "when expiry is more than 30 days ahead", expireDate: testNow.AddDate(0, 0, 45)},
+		{testName: "when expiry is 30 days ahead", expireDate: testNow.AddDate(0, 0, 30), expectedDaysAhead: 30, expectedDue: true},
+		{testName: "when 30 days warning was already sent", expireDate: testNow.AddDate(0, 0, 20), notifiedDaysAhead: 30},
+		{testName: "when expiry is 7 days ahead", expireDate: testNow.AddDate(0, 0, 7), notifiedDaysAhead: 30, expectedDaysAhead: 7, expectedDue: true},
+		{testName: "when warnings were missed", expireDate: testNow.Add(12 * time.Hour), expectedDaysAhead: 1, expectedDue: true},
+		{testName: "when file expired after the last warning", expireDate: testNow.AddDate(0, 0, -3), notifiedDaysAhead: 1, expectedDaysAhead: models.CodeSigningExpiredDaysAhead, expectedDue: true},
+		{testName: "when file expired less than a day ago", expireDate: testNow.Add(-12 * time.Hour), expectedDaysAhead: models.CodeSigningExpiredDaysAhead, expectedDue: true},
+		{testName: "when file expired and the notice was sent", expireDate: testNow.AddDate(0, 0, -3), notifiedDaysAhead: models.CodeSigningExpiredDaysAhead},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			fileExpiry := models.CodeSigningFileExpiry{ExpireDate: tc.expireDate, NotifiedDaysAhead: tc.notifiedDaysAhead}
+			daysAhead, due := fileExpiry.DueWarning(testNow)
+			require.Equal(t, tc.expectedDaysAhead, daysAhead)
+			require.Equal(t, tc.expectedDue, due)
+		})
+	}
+}
+
+func Test_CodeSigningExpiry_CarryOver(t *testing.T) {
+	testExpireDate := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	testExpiry := models.CodeSigningExpiry{
+		ProvisioningProfiles: []models.CodeSigningFileExpiry{
+			models.CodeSigningFileExpiry{Slug: "prov-profile-slug", ExpireDate: testExpireDate, NotifiedDaysAhead: 30},
+		},
+		CodeSigningIdentity: &models.CodeSigningFileExpiry{Slug: "code-signing-slug", ExpireDate: testExpireDate, NotifiedDaysAhead: 7},
+	}
+
+	t.Run("when file is stored with the same expire date", func(t *testing.T) {
+		fileExpiry := testExpiry.CarryOver(models.CodeSigningFileExpiry{Slug: "code-signing-slug", ExpireDate: testExpireDate})
+		require.Equal(t, 7, fileExpiry.NotifiedDaysAhead)
+	})
+
+	t.Run("when file was renewed", func(t *testing.T) {
+		fileExpiry := testExpiry.CarryOver(models.CodeSigningFileExpiry{Slug: "prov-profile-slug", ExpireDate: testExpireDate.AddDate(1, 0, 0)})
+		require.Equal(t, 0, fileExpiry.NotifiedDaysAhead)
+	})
+
+	t.Run("when file is not stored", func(t *testing.T) {
+		fileExpiry := testExpiry.CarryOver(models.CodeSigningFileExpiry{Slug: "other-slug", ExpireDate: testExpireDate})
+		require.Equal(t, 0, fileExpiry.NotifiedDaysAhead)
+	})
+}
+
+func Test_ProvisioningProfileExpireDate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		content := []byte("0\x80\x06\t*<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<plist version=\"1.0\">\n<dict>\n" +
+			"\t<key>CreationDate</key>\n\t<date>2019-03-01T10:00:00Z</date>\n" +
+			"\t<key>ExpirationDate</key>\n\t<date>2020-03-01T10:00:00Z</date>\n</dict>\n</plist>\x00\x01")
+		expireDate, err := models.ProvisioningProfileExpireDate(content)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC), expireDate)
+	})
+
+	t.Run("when profile has no expiration date", func(t *testing.T) {
+		expireDate, err := models.ProvisioningProfileExpireDate([]byte("<plist><dict></dict></plist>"))
+		require.EqualError(t, err, "No expiration date found in provisioning profile")
+		require.Equal(t, time.Time{}, expireDate)
+	})
+}
+
+func Test_CodeSigningIdentityExpireDate(t *testing.T) {
+	content, err := base64.StdEncoding.DecodeString(testCodeSigningIdentity)
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		expireDate, err := models.CodeSigningIdentityExpireDate(content, "test-password")
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC), expireDate.UTC())
+	})
+
+	t.Run("when password is wrong", func(t *testing.T) {
+		expireDate, err := models.CodeSigningIdentityExpireDate(content, "wrong-password")
+		require.Error(t, err)
+		require.Equal(t, time.Time{}, expireDate)
+	})
+}
@@ -97,6 +97,15 @@ func runTestMigrations(t *testing.T, db *gorm.DB) {
 				return nil
 			},
 		},
+		{
+			message: "create assets table",
+			fn: func() error {
+				if !db.HasTable(&models.Asset{}) {
+					return db.CreateTable(&models.Asset{}).Error
+				}
+				return nil
+			},
+		},
 		{
 			message: "create screenshots table",
 			fn: func() error {
@@ -107,10 +116,19 @@ func runTestMigrations(t *testing.T, db *gorm.DB) {
 			},
 		},
 		{
-			message: "create feature_graphics table",
+			message: "create app_previews table",
 			fn: func() error {
-				if !db.HasTable(&models.FeatureGraphic{}) {
-					return db.CreateTable(&models.FeatureGraphic{}).Error
+				if !db.HasTable(&models.AppPreview{}) {
+					return db.CreateTable(&models.AppPreview{}).Error
+				}
+				return nil
+			},
+		},
+		{
+			message: "create store_graphics table",
+			fn: func() error {
+				if !db.HasTable(&models.StoreGraphic{}) {
+					return db.CreateTable(&models.StoreGraphic{}).Error
 				}
 				return nil
 			},
@@ -133,6 +151,15 @@ func runTestMigrations(t *testing.T, db *gorm.DB) {
 				return nil
 			},
 		},
+		{
+			message: "create app_events table",
+			fn: func() error {
+				if !db.HasTable(&models.AppEvent{}) {
+					return db.CreateTable(&models.AppEvent{}).Error
+				}
+				return nil
+			},
+		},
 		{
 			message: "create publish_tasks table",
 			fn: func() error {
@@ -160,6 +187,51 @@ func runTestMigrations(t *testing.T, db *gorm.DB) {
 				return nil
 			},
 		},
+		{
+			message: "create job_statuses table",
+			fn: func() error {
+				if !db.HasTable(&models.JobStatus{}) {
+					return db.CreateTable(&models.JobStatus{}).Error
+				}
+				return nil
+			},
+		},
+		{
+			message: "create processed_builds table",
+			fn: func() error {
+				if !db.HasTable(&models.ProcessedBuild{}) {
+					return db.CreateTable(&models.ProcessedBuild{}).Error
+				}
+				return nil
+			},
+		},
+		{
+			message: "create build_webhooks table",
+			fn: func() error {
+				if !db.HasTable(&models.BuildWebhook{}) {
+					return db.CreateTable(&models.BuildWebhook{}).Error
+				}
+				return nil
+			},
+		},
+		{
+			message: "create app_tombstones table",
+			fn: func() error {
+				if !db.HasTable(&models.AppTombstone{}) {
+					return db.CreateTable(&models.AppTombstone{}).Error
+				}
+				return nil
+			},
+		},
+		{
+			message: "create webhook_deliveries table",
+			fn: func() error {
+				if !db.HasTable(&models.WebhookDelivery{}) {
+					return db.CreateTable(&models.WebhookDelivery{}).Error
+				}
+				return nil
+			},
+		},
 	} {
 		t.Log(migration.message)
 		panicIfErr(migration.fn())
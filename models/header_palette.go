@@ -0,0 +1,64 @@
+package models
+
+import (
+	"image"
+
+	"github.com/nfnt/resize"
+	"github.com/pkg/errors"
+	"github.com/simonmarton/common-colors/calculator"
+	ccolor "github.com/simonmarton/common-colors/color"
+	ccmodels "github.com/simonmarton/common-colors/models"
+)
+
+const (
+	headerPaletteSampleSize = 32
+	// a YIQ brightness above this is light enough for dark text
+	headerPaletteTextBrightnessThreshold = 128
+)
+
+var headerPaletteCalculatorConfig = ccmodels.CalculatorConfig{
+	Algorithm:            "yiq",
+	TransparencyTreshold: 10,
+	IterationCount:       3,
+	MinLuminance:         0.3,
+	MaxLuminance:         0.9,
+	DistanceThreshold:    20,
+	MinSaturation:        0.3,
+}
+
+// HeaderPalette is the color palette of the app header derived from the avatar of the app: the primary
+// and secondary colors of the gradient, and the text color contrasting with them
+type HeaderPalette struct {
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Text      string `json:"text"`
+}
+
+// NewHeaderPalette calculates the header palette from the most common colors of the avatar
+func NewHeaderPalette(avatar image.Image) (HeaderPalette, error) {
+	bounds := avatar.Bounds()
+	if bounds.Dx() > headerPaletteSampleSize || bounds.Dy() > headerPaletteSampleSize {
+		avatar = resize.Resize(headerPaletteSampleSize, headerPaletteSampleSize, avatar, resize.Lanczos3)
+		bounds = avatar.Bounds()
+	}
+
+	colors := []ccolor.Color{}
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			colors = append(colors, ccolor.NewFromRGBA(avatar.At(x, y)))
+		}
+	}
+
+	calc := calculator.New(headerPaletteCalculatorConfig)
+	commonColors, _ := calc.GetCommonColors(colors)
+	if len(commonColors) == 0 {
+		return HeaderPalette{}, errors.New("All colors were filtered")
+	}
+	gradientColors := calc.GenrateGradientColors(commonColors)
+
+	palette := HeaderPalette{Primary: gradientColors[0], Secondary: gradientColors[1], Text: "#ffffff"}
+	if commonColors[0].Y() > headerPaletteTextBrightnessThreshold {
+		palette.Text = "#000000"
+	}
+	return palette, nil
+}
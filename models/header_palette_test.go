@@ -0,0 +1,46 @@
+package models_test
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func testAvatar(size int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: c}, image.ZP, draw.Src)
+	return img
+}
+
+func Test_NewHeaderPalette(t *testing.T) {
+	t.Run("when avatar is dark", func(t *testing.T) {
+		palette, err := models.NewHeaderPalette(testAvatar(16, color.RGBA{R: 200, G: 60, B: 60, A: 255}))
+		require.NoError(t, err)
+		require.Equal(t, "#c83c3c", palette.Primary)
+		require.NotEmpty(t, palette.Secondary)
+		require.Equal(t, "#ffffff", palette.Text)
+	})
+
+	t.Run("when avatar is light", func(t *testing.T) {
+		palette, err := models.NewHeaderPalette(testAvatar(16, color.RGBA{R: 250, G: 220, B: 90, A: 255}))
+		require.NoError(t, err)
+		require.Equal(t, "#fadc5a", palette.Primary)
+		require.Equal(t, "#000000", palette.Text)
+	})
+
+	t.Run("when avatar is bigger than the sample size", func(t *testing.T) {
+		palette, err := models.NewHeaderPalette(testAvatar(256, color.RGBA{R: 200, G: 60, B: 60, A: 255}))
+		require.NoError(t, err)
+		require.Equal(t, "#c83c3c", palette.Primary)
+	})
+
+	t.Run("when every color of the avatar is filtered", func(t *testing.T) {
+		palette, err := models.NewHeaderPalette(testAvatar(16, color.RGBA{R: 128, G: 128, B: 128, A: 255}))
+		require.EqualError(t, err, "All colors were filtered")
+		require.Equal(t, models.HeaderPalette{}, palette)
+	})
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	// register the decoders of the accepted image formats
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ImageDimensions ...
+type ImageDimensions struct {
+	Width  int
+	Height int
+}
+
+func (d ImageDimensions) String() string {
+	return fmt.Sprintf("%dx%d", d.Width, d.Height)
+}
+
+// ImageInfo holds the measured properties of an uploaded image
+type ImageInfo struct {
+	ImageDimensions
+	Format   string
+	Checksum string
+}
+
+// ErrInvalidImage ...
+var ErrInvalidImage = errors.New("Must be a valid PNG or JPEG image")
+
+// InspectImage reads the dimensions from the header of the image read from r and calculates the
+// SHA-256 checksum of the content. The pixel data isn't decoded, so that an image declaring huge
+// dimensions can't exhaust the memory. ErrInvalidImage is returned if the content is not a PNG or
+// JPEG image.
+func InspectImage(r io.Reader) (ImageInfo, error) {
+	hash := sha256.New()
+	tee := io.TeeReader(r, hash)
+
+	config, format, err := image.DecodeConfig(tee)
+	if err != nil || (format != "png" && format != "jpeg") {
+		return ImageInfo{}, ErrInvalidImage
+	}
+	if _, err := io.Copy(ioutil.Discard, tee); err != nil {
+		return ImageInfo{}, errors.WithStack(err)
+	}
+
+	return ImageInfo{
+		ImageDimensions: ImageDimensions{Width: config.Width, Height: config.Height},
+		Format:          format,
+		Checksum:        hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+var iosScreenshotDimensions = map[string][]ImageDimensions{
+	"6.5 inch":  {{1242, 2688}, {1284, 2778}},
+	"5.8 inch":  {{1125, 2436}},
+	"5.5 inch":  {{1242, 2208}},
+	"4.7 inch":  {{750, 1334}},
+	"4 inch":    {{640, 1096}, {640, 1136}},
+	"3.5 inch":  {{640, 920}, {640, 960}},
+	"12.9 inch": {{2048, 2732}},
+	"11 inch":   {{1668, 2388}},
+	"10.5 inch": {{1668, 2224}},
+	"9.7 inch":  {{1536, 2008}, {1536, 2048}},
+}
+
+// appleDeviceScreenshotDimensions are the screenshot sizes of the tvOS, macOS and watchOS apps, by device type
+var appleDeviceScreenshotDimensions = map[string][]ImageDimensions{
+	"Apple Watch": {{312, 390}, {368, 448}, {396, 484}},
+	"Apple TV":    {{1920, 1080}, {3840, 2160}},
+	"Mac":         {{1280, 800}, {1440, 900}, {2560, 1600}, {2880, 1800}},
+}
+
+var androidScreenSizes = []string{"phone", "seven_inch", "ten_inch", "tv", "wear"}
+
+const (
+	androidScreenshotMinSideLength = 320
+	androidScreenshotMaxSideLength = 3840
+)
+
+// VerifyDimensions checks the measured dimensions of the screenshot against the store requirements
+// of its screen size and device type. Screen sizes without known requirements are accepted.
+func (s *Screenshot) VerifyDimensions(dimensions ImageDimensions) error {
+	if allowed, ok := iosScreenshotDimensions[s.ScreenSize]; ok {
+		return verifyDimensionsAreOneOf(dimensions, allowed, true)
+	}
+	if allowed, ok := appleDeviceScreenshotDimensions[s.DeviceType]; ok {
+		return verifyDimensionsAreOneOf(dimensions, allowed, false)
+	}
+	for _, screenSize := range androidScreenSizes {
+		if s.ScreenSize == screenSize {
+			return verifyAndroidScreenshotDimensions(dimensions)
+		}
+	}
+	return nil
+}
+
+// VerifyDimensions ...
+func (g *StoreGraphic) VerifyDimensions(dimensions ImageDimensions) error {
+	rule, ok := StoreGraphicRules[g.Type]
+	if !ok {
+		return nil
+	}
+	return verifyDimensionsAreOneOf(dimensions, []ImageDimensions{{rule.Width, rule.Height}}, false)
+}
+
+func verifyDimensionsAreOneOf(dimensions ImageDimensions, allowed []ImageDimensions, allowLandscape bool) error {
+	allowedStrs := []string{}
+	for _, a := range allowed {
+		if dimensions == a || (allowLandscape && dimensions == ImageDimensions{Width: a.Height, Height: a.Width}) {
+			return nil
+		}
+		allowedStrs = append(allowedStrs, a.String())
+	}
+	orientationNote := ""
+	if allowLandscape {
+		orientationNote = " (portrait or landscape)"
+	}
+	return errors.Errorf("dimensions: %s is not allowed, must be %s%s", dimensions, strings.Join(allowedStrs, " or "), orientationNote)
+}
+
+func verifyAndroidScreenshotDimensions(dimensions ImageDimensions) error {
+	shorter, longer := dimensions.Width, dimensions.Height
+	if shorter > longer {
+		shorter, longer = longer, shorter
+	}
+	if shorter < androidScreenshotMinSideLength || longer > androidScreenshotMaxSideLength {
+		return errors.Errorf("dimensions: %s is not allowed, sides must be between %d and %d pixels",
+			dimensions, androidScreenshotMinSideLength, androidScreenshotMaxSideLength)
+	}
+	if longer > 2*shorter {
+		return errors.Errorf("dimensions: %s is not allowed, the longer side can't be more than twice as long as the shorter side", dimensions)
+	}
+	return nil
+}
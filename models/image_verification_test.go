@@ -0,0 +1,172 @@
+package models_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func Test_InspectImage(t *testing.T) {
+	testImage := image.NewRGBA(image.Rect(0, 0, 12, 34))
+
+	t.Run("ok - png", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage))
+
+		info, err := models.InspectImage(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, models.ImageDimensions{Width: 12, Height: 34}, info.ImageDimensions)
+		require.Equal(t, "png", info.Format)
+		require.Len(t, info.Checksum, 64)
+	})
+
+	t.Run("ok - jpeg", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, testImage, nil))
+
+		info, err := models.InspectImage(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, models.ImageDimensions{Width: 12, Height: 34}, info.ImageDimensions)
+		require.Equal(t, "jpeg", info.Format)
+	})
+
+	t.Run("ok - checksum covers the whole content", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage))
+		info, err := models.InspectImage(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+
+		infoWithTrailingData, err := models.InspectImage(bytes.NewReader(append(buf.Bytes(), 0x00)))
+		require.NoError(t, err)
+		require.NotEqual(t, info.Checksum, infoWithTrailingData.Checksum)
+	})
+
+	t.Run("ok - pixel data is not decoded", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+		header := buf.Bytes()[:33]
+		// declare a 100000x100000 image, which would take 40GB decoded
+		binary.BigEndian.PutUint32(header[16:20], 100000)
+		binary.BigEndian.PutUint32(header[20:24], 100000)
+		binary.BigEndian.PutUint32(header[29:33], crc32.ChecksumIEEE(header[12:29]))
+
+		info, err := models.InspectImage(bytes.NewReader(header))
+		require.NoError(t, err)
+		require.Equal(t, models.ImageDimensions{Width: 100000, Height: 100000}, info.ImageDimensions)
+	})
+
+	t.Run("error - when content is not an image", func(t *testing.T) {
+		_, err := models.InspectImage(bytes.NewReader([]byte("not an image")))
+		require.Equal(t, models.ErrInvalidImage, err)
+	})
+
+	t.Run("error - when image is not PNG or JPEG", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, gif.Encode(&buf, testImage, nil))
+
+		_, err := models.InspectImage(bytes.NewReader(buf.Bytes()))
+		require.Equal(t, models.ErrInvalidImage, err)
+	})
+}
+
+func Test_Screenshot_VerifyDimensions(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		screenshot  models.Screenshot
+		dimensions  models.ImageDimensions
+		expectedErr string
+	}{
+		{
+			name:       "ok - iOS portrait",
+			screenshot: models.Screenshot{DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch"},
+			dimensions: models.ImageDimensions{Width: 1242, Height: 2688},
+		},
+		{
+			name:       "ok - iOS landscape",
+			screenshot: models.Screenshot{DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch"},
+			dimensions: models.ImageDimensions{Width: 2778, Height: 1284},
+		},
+		{
+			name:        "error - iOS with dimensions of an other screen size",
+			screenshot:  models.Screenshot{DeviceType: "iPhone 8 Plus", ScreenSize: "5.5 inch"},
+			dimensions:  models.ImageDimensions{Width: 1242, Height: 2688},
+			expectedErr: "dimensions: 1242x2688 is not allowed, must be 1242x2208 (portrait or landscape)",
+		},
+		{
+			name:       "ok - Apple Watch",
+			screenshot: models.Screenshot{DeviceType: "Apple Watch"},
+			dimensions: models.ImageDimensions{Width: 368, Height: 448},
+		},
+		{
+			name:        "error - Apple Watch in landscape",
+			screenshot:  models.Screenshot{DeviceType: "Apple Watch"},
+			dimensions:  models.ImageDimensions{Width: 448, Height: 368},
+			expectedErr: "dimensions: 448x368 is not allowed, must be 312x390 or 368x448 or 396x484",
+		},
+		{
+			name:       "ok - Apple TV",
+			screenshot: models.Screenshot{DeviceType: "Apple TV"},
+			dimensions: models.ImageDimensions{Width: 1920, Height: 1080},
+		},
+		{
+			name:        "error - Mac with an iPad size",
+			screenshot:  models.Screenshot{DeviceType: "Mac"},
+			dimensions:  models.ImageDimensions{Width: 2048, Height: 2732},
+			expectedErr: "dimensions: 2048x2732 is not allowed, must be 1280x800 or 1440x900 or 2560x1600 or 2880x1800",
+		},
+		{
+			name:       "ok - Android",
+			screenshot: models.Screenshot{DeviceType: "Phone", ScreenSize: "phone"},
+			dimensions: models.ImageDimensions{Width: 1080, Height: 1920},
+		},
+		{
+			name:        "error - Android with a too small side",
+			screenshot:  models.Screenshot{DeviceType: "Watch", ScreenSize: "wear"},
+			dimensions:  models.ImageDimensions{Width: 300, Height: 400},
+			expectedErr: "dimensions: 300x400 is not allowed, sides must be between 320 and 3840 pixels",
+		},
+		{
+			name:        "error - Android with a too wide aspect ratio",
+			screenshot:  models.Screenshot{DeviceType: "TV", ScreenSize: "tv"},
+			dimensions:  models.ImageDimensions{Width: 2000, Height: 900},
+			expectedErr: "dimensions: 2000x900 is not allowed, the longer side can't be more than twice as long as the shorter side",
+		},
+		{
+			name:       "ok - unknown screen size",
+			screenshot: models.Screenshot{DeviceType: "Something", ScreenSize: "something"},
+			dimensions: models.ImageDimensions{Width: 1, Height: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.screenshot.VerifyDimensions(tc.dimensions)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func Test_StoreGraphic_VerifyDimensions(t *testing.T) {
+	featureGraphic := models.StoreGraphic{Type: models.StoreGraphicTypeFeatureGraphic}
+	require.NoError(t, featureGraphic.VerifyDimensions(models.ImageDimensions{Width: 1024, Height: 500}))
+	require.EqualError(t, featureGraphic.VerifyDimensions(models.ImageDimensions{Width: 500, Height: 1024}),
+		"dimensions: 500x1024 is not allowed, must be 1024x500")
+
+	icon := models.StoreGraphic{Type: models.StoreGraphicTypeIcon}
+	require.NoError(t, icon.VerifyDimensions(models.ImageDimensions{Width: 512, Height: 512}))
+	require.EqualError(t, icon.VerifyDimensions(models.ImageDimensions{Width: 1024, Height: 1024}),
+		"dimensions: 1024x1024 is not allowed, must be 512x512")
+
+	tvBanner := models.StoreGraphic{Type: models.StoreGraphicTypeTvBanner}
+	require.NoError(t, tvBanner.VerifyDimensions(models.ImageDimensions{Width: 1280, Height: 720}))
+}
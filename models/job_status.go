@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// JobStatusAwaitingUpload ...
+	JobStatusAwaitingUpload = "awaiting_upload"
+	// JobStatusPending ...
+	JobStatusPending = "pending"
+	// JobStatusRunning ...
+	JobStatusRunning = "running"
+	// JobStatusFinished ...
+	JobStatusFinished = "finished"
+	// JobStatusFailed ...
+	JobStatusFailed = "failed"
+)
+
+const (
+	// JobTypeScreenshotArchiveImport ...
+	JobTypeScreenshotArchiveImport = "screenshot_archive_import"
+	// JobTypeAppVersionCopy ...
+	JobTypeAppVersionCopy = "app_version_copy"
+)
+
+// JobStatus tracks the progress and the result of a background job started by the user
+type JobStatus struct {
+	Record
+	Type    string          `json:"type"`
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result" gorm:"type:json"`
+	// UploadID identifies the multipart upload of the file the job processes
+	UploadID string `db:"upload_id" json:"-"`
+
+	AppID        uuid.UUID  `db:"app_id" json:"-"`
+	App          App        `gorm:"foreignkey:AppID" json:"-"`
+	AppVersionID *uuid.UUID `db:"app_version_id" json:"app_version_id" gorm:"type:uuid"`
+}
+
+// BeforeCreate ...
+func (j *JobStatus) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(j.ID, uuid.UUID{}) {
+		j.ID = uuid.NewV4()
+	}
+	if j.Result == nil {
+		j.Result = json.RawMessage(`{}`)
+	}
+	return nil
+}
+
+// IsDone ...
+func (j *JobStatus) IsDone() bool {
+	return j.Status == JobStatusFinished || j.Status == JobStatusFailed
+}
+
+// ScreenshotArchiveAWSPath ...
+func (j *JobStatus) ScreenshotArchiveAWSPath() string {
+	appVersionID := ""
+	if j.AppVersionID != nil {
+		appVersionID = j.AppVersionID.String()
+	}
+	pathElements := []string{
+		j.App.AppSlug,
+		appVersionID,
+		"screenshot_archives",
+		j.ID.String() + ".zip",
+	}
+	return strings.Join(pathElements, "/")
+}
@@ -0,0 +1,48 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// JobStatusService ...
+type JobStatusService struct {
+	DB *gorm.DB
+	UpdatableModelService
+}
+
+// Create ...
+func (s *JobStatusService) Create(jobStatus *JobStatus) (*JobStatus, []error, error) {
+	result := s.DB.Create(jobStatus)
+	verrs := ValidationErrors(result.GetErrors())
+	if len(verrs) > 0 {
+		return nil, verrs, nil
+	}
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	return jobStatus, nil, s.DB.Where("id = ?", jobStatus.ID).Preload("App").First(jobStatus).Error
+}
+
+// Find ...
+func (s *JobStatusService) Find(jobStatus *JobStatus) (*JobStatus, error) {
+	err := s.DB.Preload("App").Where(jobStatus).First(jobStatus).Error
+	if err != nil {
+		return nil, err
+	}
+	return jobStatus, nil
+}
+
+// Update ...
+func (s *JobStatusService) Update(jobStatus *JobStatus, whitelist []string) (validationErrors []error, dbErr error) {
+	updateData, err := s.UpdateData(*jobStatus, whitelist)
+	if err != nil {
+		return nil, err
+	}
+	result := s.DB.Model(jobStatus).Updates(updateData)
+	verrs := ValidationErrors(result.GetErrors())
+	if len(verrs) > 0 {
+		return verrs, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return nil, nil
+}
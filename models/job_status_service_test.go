@@ -0,0 +1,81 @@
+// +build database
+
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+func Test_JobStatusService_Create(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	jobStatusService := models.JobStatusService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+
+	createdJobStatus, verrs, err := jobStatusService.Create(&models.JobStatus{
+		Type:   models.JobTypeScreenshotArchiveImport,
+		Status: models.JobStatusAwaitingUpload,
+		AppID:  testApp.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, verrs)
+	require.False(t, createdJobStatus.ID.String() == "")
+	require.Equal(t, "test-app-slug", createdJobStatus.App.AppSlug)
+	require.Equal(t, json.RawMessage(`{}`), createdJobStatus.Result)
+}
+
+func Test_JobStatusService_Find(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	jobStatusService := models.JobStatusService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	testJobStatus, _, err := jobStatusService.Create(&models.JobStatus{Type: models.JobTypeScreenshotArchiveImport, AppID: testApp.ID})
+	require.NoError(t, err)
+
+	t.Run("when querying a job status that belongs to an app", func(t *testing.T) {
+		foundJobStatus, err := jobStatusService.Find(&models.JobStatus{Record: models.Record{ID: testJobStatus.ID}, AppID: testApp.ID})
+		require.NoError(t, err)
+		require.Equal(t, testJobStatus.ID, foundJobStatus.ID)
+		require.Equal(t, "test-app-slug", foundJobStatus.App.AppSlug)
+	})
+
+	t.Run("error - when job status belongs to another app", func(t *testing.T) {
+		otherTestApp := createTestApp(t, &models.App{AppSlug: "test-app-slug-2"})
+
+		foundJobStatus, err := jobStatusService.Find(&models.JobStatus{Record: models.Record{ID: testJobStatus.ID}, AppID: otherTestApp.ID})
+		require.Equal(t, errors.Cause(err), gorm.ErrRecordNotFound)
+		require.Nil(t, foundJobStatus)
+	})
+}
+
+func Test_JobStatusService_Update(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	jobStatusService := models.JobStatusService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	testJobStatus, _, err := jobStatusService.Create(&models.JobStatus{Type: models.JobTypeScreenshotArchiveImport, AppID: testApp.ID})
+	require.NoError(t, err)
+
+	testJobStatus.Status = models.JobStatusFinished
+	testJobStatus.Message = "1 of 1 files imported"
+	testJobStatus.Result = json.RawMessage(`{"files":[]}`)
+	verrs, err := jobStatusService.Update(testJobStatus, []string{"Status", "Message", "Result"})
+	require.NoError(t, err)
+	require.Empty(t, verrs)
+
+	foundJobStatus, err := jobStatusService.Find(&models.JobStatus{Record: models.Record{ID: testJobStatus.ID}})
+	require.NoError(t, err)
+	require.Equal(t, models.JobStatusFinished, foundJobStatus.Status)
+	require.Equal(t, "1 of 1 files imported", foundJobStatus.Message)
+	require.Equal(t, `{"files":[]}`, string(foundJobStatus.Result))
+}
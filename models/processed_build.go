@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProcessedBuild records that a version was created from the build for a platform, flavor and module, or
+// for an iOS scheme and bundle ID, so a redelivered build webhook doesn't create it again
+type ProcessedBuild struct {
+	Record
+	BuildSlug     string     `db:"build_slug" json:"build_slug" gorm:"unique_index:processed_builds_key_idx"`
+	Platform      string     `json:"platform" gorm:"unique_index:processed_builds_key_idx"`
+	ProductFlavor string     `db:"product_flavor" json:"product_flavor" gorm:"unique_index:processed_builds_key_idx"`
+	Module        string     `json:"module" gorm:"unique_index:processed_builds_key_idx"`
+	Scheme        string     `json:"scheme" gorm:"unique_index:processed_builds_key_idx"`
+	BundleID      string     `db:"bundle_id" json:"bundle_id" gorm:"unique_index:processed_builds_key_idx"`
+	AppVersionID  *uuid.UUID `db:"app_version_id" json:"app_version_id"`
+
+	AppID      uuid.UUID   `db:"app_id" json:"-"`
+	AppVersion *AppVersion `gorm:"foreignkey:AppVersionID" json:"-"`
+}
+
+// BeforeCreate ...
+func (b *ProcessedBuild) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(b.ID, uuid.UUID{}) {
+		b.ID = uuid.NewV4()
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProcessedBuildService ...
+type ProcessedBuildService struct {
+	DB *gorm.DB
+	UpdatableModelService
+}
+
+// Claim records the processing of the build for the platform, flavor, module, scheme and bundle ID of the
+// given one.
+// When it was already recorded the existing record is returned with its app version, and claimed is
+// false.
+func (s *ProcessedBuildService) Claim(processedBuild *ProcessedBuild) (*ProcessedBuild, bool, error) {
+	now := time.Now()
+	var claimedBuild ProcessedBuild
+	err := s.DB.Raw(`INSERT INTO processed_builds (id, app_id, build_slug, platform, product_flavor, module, scheme, bundle_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (build_slug, platform, product_flavor, module, scheme, bundle_id) DO NOTHING
+		RETURNING *`,
+		uuid.NewV4(), processedBuild.AppID, processedBuild.BuildSlug, processedBuild.Platform,
+		processedBuild.ProductFlavor, processedBuild.Module, processedBuild.Scheme, processedBuild.BundleID, now, now).
+		Scan(&claimedBuild).Error
+	if err == nil {
+		return &claimedBuild, true, nil
+	}
+	if errors.Cause(err) != gorm.ErrRecordNotFound {
+		return nil, false, err
+	}
+
+	err = s.DB.Preload("AppVersion").
+		Where("build_slug = ? AND platform = ? AND product_flavor = ? AND module = ? AND scheme = ? AND bundle_id = ?",
+			processedBuild.BuildSlug, processedBuild.Platform, processedBuild.ProductFlavor, processedBuild.Module,
+			processedBuild.Scheme, processedBuild.BundleID).
+		First(&claimedBuild).Error
+	if err != nil {
+		return nil, false, err
+	}
+	return &claimedBuild, false, nil
+}
+
+// Update ...
+func (s *ProcessedBuildService) Update(processedBuild *ProcessedBuild, whitelist []string) error {
+	updateData, err := s.UpdateData(*processedBuild, whitelist)
+	if err != nil {
+		return err
+	}
+	return s.DB.Model(processedBuild).Updates(updateData).Error
+}
+
+// ReleaseBuild drops the records of every version processed from the build of the app, so all of them
+// can be created again
+func (s *ProcessedBuildService) ReleaseBuild(appID uuid.UUID, buildSlug string) error {
+	return s.DB.Where("app_id = ? AND build_slug = ?", appID, buildSlug).Delete(&ProcessedBuild{}).Error
+}
+
+// Delete drops the record, so the build can be processed again
+func (s *ProcessedBuildService) Delete(processedBuild *ProcessedBuild) error {
+	result := s.DB.Delete(processedBuild)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
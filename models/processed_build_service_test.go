@@ -0,0 +1,126 @@
+// +build database
+
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_ProcessedBuildService_Claim(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	processedBuildService := models.ProcessedBuildService{DB: dataservices.GetDB()}
+
+	t.Run("ok - claims the build only once", func(t *testing.T) {
+		testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: testApp.ID, Platform: "android", BuildSlug: "test-build-slug"})
+
+		processedBuild, claimed, err := processedBuildService.Claim(&models.ProcessedBuild{
+			AppID: testApp.ID, BuildSlug: "test-build-slug", Platform: "android", ProductFlavor: "free", Module: "app",
+		})
+		require.NoError(t, err)
+		require.True(t, claimed)
+		require.NotEqual(t, uuid.UUID{}, processedBuild.ID)
+		require.Nil(t, processedBuild.AppVersionID)
+
+		processedBuild.AppVersionID = &testAppVersion.ID
+		require.NoError(t, processedBuildService.Update(processedBuild, []string{"AppVersionID"}))
+
+		existingBuild, claimed, err := processedBuildService.Claim(&models.ProcessedBuild{
+			AppID: testApp.ID, BuildSlug: "test-build-slug", Platform: "android", ProductFlavor: "free", Module: "app",
+		})
+		require.NoError(t, err)
+		require.False(t, claimed)
+		require.Equal(t, processedBuild.ID, existingBuild.ID)
+		require.NotNil(t, existingBuild.AppVersion)
+		require.Equal(t, testAppVersion.ID, existingBuild.AppVersion.ID)
+
+		_, claimed, err = processedBuildService.Claim(&models.ProcessedBuild{
+			AppID: testApp.ID, BuildSlug: "test-build-slug", Platform: "android", ProductFlavor: "paid", Module: "app",
+		})
+		require.NoError(t, err)
+		require.True(t, claimed)
+	})
+
+	t.Run("ok - ios schemes and bundle IDs are claimed separately", func(t *testing.T) {
+		testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+
+		_, claimed, err := processedBuildService.Claim(&models.ProcessedBuild{
+			AppID: testApp.ID, BuildSlug: "test-ios-build-slug", Platform: "ios", Scheme: "App", BundleID: "io.bitrise.app",
+		})
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		_, claimed, err = processedBuildService.Claim(&models.ProcessedBuild{
+			AppID: testApp.ID, BuildSlug: "test-ios-build-slug", Platform: "ios", Scheme: "App", BundleID: "io.bitrise.app.whitelabel",
+		})
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		_, claimed, err = processedBuildService.Claim(&models.ProcessedBuild{
+			AppID: testApp.ID, BuildSlug: "test-ios-build-slug", Platform: "ios", Scheme: "App", BundleID: "io.bitrise.app",
+		})
+		require.NoError(t, err)
+		require.False(t, claimed)
+	})
+}
+
+func Test_ProcessedBuildService_ReleaseBuild(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	processedBuildService := models.ProcessedBuildService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	for _, scheme := range []string{"App", "WhiteLabel"} {
+		_, claimed, err := processedBuildService.Claim(&models.ProcessedBuild{AppID: testApp.ID, BuildSlug: "test-build-slug", Platform: "ios", Scheme: scheme})
+		require.NoError(t, err)
+		require.True(t, claimed)
+	}
+	_, claimed, err := processedBuildService.Claim(&models.ProcessedBuild{AppID: testApp.ID, BuildSlug: "other-build-slug", Platform: "ios"})
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	require.NoError(t, processedBuildService.ReleaseBuild(testApp.ID, "test-build-slug"))
+
+	for _, scheme := range []string{"App", "WhiteLabel"} {
+		_, claimed, err := processedBuildService.Claim(&models.ProcessedBuild{AppID: testApp.ID, BuildSlug: "test-build-slug", Platform: "ios", Scheme: scheme})
+		require.NoError(t, err)
+		require.True(t, claimed)
+	}
+	_, claimed, err = processedBuildService.Claim(&models.ProcessedBuild{AppID: testApp.ID, BuildSlug: "other-build-slug", Platform: "ios"})
+	require.NoError(t, err)
+	require.False(t, claimed)
+}
+
+func Test_ProcessedBuildService_Delete(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	processedBuildService := models.ProcessedBuildService{DB: dataservices.GetDB()}
+
+	t.Run("ok - the build can be claimed again", func(t *testing.T) {
+		testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+		processedBuild, claimed, err := processedBuildService.Claim(&models.ProcessedBuild{AppID: testApp.ID, BuildSlug: "test-build-slug", Platform: "ios"})
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		require.NoError(t, processedBuildService.Delete(processedBuild))
+
+		_, claimed, err = processedBuildService.Claim(&models.ProcessedBuild{AppID: testApp.ID, BuildSlug: "test-build-slug", Platform: "ios"})
+		require.NoError(t, err)
+		require.True(t, claimed)
+	})
+
+	t.Run("error - when processed build doesn't exist", func(t *testing.T) {
+		err := processedBuildService.Delete(&models.ProcessedBuild{Record: models.Record{ID: uuid.NewV4()}})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+	})
+}
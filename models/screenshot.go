@@ -22,6 +22,18 @@ type Screenshot struct {
 	UploadableObject
 	DeviceType string `json:"device_type"`
 	ScreenSize string `json:"screen_size"`
+	Locale     string `json:"locale"`
+	// Position is the place of the screenshot on the store listing among the screenshots of the same
+	// device type, screen size and locale, starting from 1
+	Position int `json:"position"`
+
+	// SourceScreenshotID is set on screenshots derived from another screenshot by the automatic resizing
+	SourceScreenshotID *uuid.UUID `db:"source_screenshot_id" json:"source_screenshot_id" gorm:"type:uuid"`
+	// SourceChecksum is the checksum of the source screenshot the derived screenshot was generated from
+	SourceChecksum string `db:"source_checksum" json:"-"`
+
+	// AssetID is set once the uploaded file is verified and stored by its content
+	AssetID *uuid.UUID `db:"asset_id" json:"-" gorm:"type:uuid"`
 
 	AppVersionID uuid.UUID  `db:"app_version_id" json:"-"`
 	AppVersion   AppVersion `gorm:"foreignkey:AppVersionID" json:"-"`
@@ -55,8 +67,22 @@ func (s *Screenshot) validate(scope *gorm.Scope) error {
 	return nil
 }
 
-// AWSPath ...
+// IsDerived ...
+func (s *Screenshot) IsDerived() bool {
+	return s.SourceScreenshotID != nil
+}
+
+// AWSPath returns the key of the object of the screenshot, which is the asset storing its content
+// once the upload is verified
 func (s *Screenshot) AWSPath() string {
+	if s.AssetID != nil {
+		return AssetAWSPath(s.Checksum, AssetExtension(s.Filename))
+	}
+	return s.UploadAWSPath()
+}
+
+// UploadAWSPath returns the key the file of the screenshot is uploaded to
+func (s *Screenshot) UploadAWSPath() string {
 	pathElements := []string{
 		s.AppVersion.App.AppSlug,
 		s.AppVersion.ID.String(),
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/api-utils/constants"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// MaxScreenshotArchiveFileByteSize ...
+	MaxScreenshotArchiveFileByteSize = 500 * constants.MegaByte
+	// MaxScreenshotArchiveEntryCount is the number of entries an archive can have, folders included
+	MaxScreenshotArchiveEntryCount = 1000
+	// MaxScreenshotArchiveUncompressedByteSize is the total size the files of an archive can unpack to
+	MaxScreenshotArchiveUncompressedByteSize = 1000 * constants.MegaByte
+	// ScreenshotArchiveManifestFilename is the name of the optional manifest in the root of the archive
+	ScreenshotArchiveManifestFilename = "manifest.json"
+)
+
+var (
+	screenshotFileExtensions = []string{".png", ".jpg", ".jpeg"}
+	deviceFolderPattern      = regexp.MustCompile(`^(.+) \((.+)\)$`)
+	localeFolderPattern      = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]+)*$`)
+)
+
+// ScreenshotArchiveEntry describes where a screenshot of the archive belongs to
+type ScreenshotArchiveEntry struct {
+	File       string `json:"file"`
+	DeviceType string `json:"device_type"`
+	ScreenSize string `json:"screen_size"`
+	Locale     string `json:"locale"`
+}
+
+// ScreenshotArchiveManifest ...
+type ScreenshotArchiveManifest struct {
+	Screenshots []ScreenshotArchiveEntry `json:"screenshots"`
+}
+
+// ScreenshotArchiveFileResult ...
+type ScreenshotArchiveFileResult struct {
+	File         string     `json:"file"`
+	ScreenshotID *uuid.UUID `json:"screenshot_id,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// ScreenshotArchiveImportResult is stored as the result of the screenshot archive import job
+type ScreenshotArchiveImportResult struct {
+	Files []ScreenshotArchiveFileResult `json:"files"`
+}
+
+// ParseScreenshotArchiveManifest ...
+func ParseScreenshotArchiveManifest(content []byte) (map[string]ScreenshotArchiveEntry, error) {
+	var manifest ScreenshotArchiveManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, errors.New("manifest: Must be a valid JSON file")
+	}
+	entries := map[string]ScreenshotArchiveEntry{}
+	for _, entry := range manifest.Screenshots {
+		entries[path.Clean(entry.File)] = entry
+	}
+	return entries, nil
+}
+
+// IsIgnoredScreenshotArchiveFile returns true for the files of the archive which are not screenshots,
+// like the manifest or the metadata created by the archiving tools
+func IsIgnoredScreenshotArchiveFile(filePath string) bool {
+	if filePath == ScreenshotArchiveManifestFilename || strings.HasSuffix(filePath, "/") {
+		return true
+	}
+	for _, element := range strings.Split(filePath, "/") {
+		if strings.HasPrefix(element, ".") || element == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+// ScreenshotArchiveEntryFor returns where the file of the archive belongs to, based on the manifest if
+// it lists the file, otherwise based on the folder convention
+func ScreenshotArchiveEntryFor(filePath string, manifest map[string]ScreenshotArchiveEntry) (ScreenshotArchiveEntry, error) {
+	entry, ok := manifest[filePath]
+	if !ok {
+		return ScreenshotArchiveEntryFromPath(filePath)
+	}
+	if !hasScreenshotFileExtension(filePath) {
+		return ScreenshotArchiveEntry{}, errors.New("file: Must be a .png, .jpg or .jpeg file")
+	}
+	if entry.DeviceType == "" || entry.ScreenSize == "" {
+		return ScreenshotArchiveEntry{}, errors.New("manifest: Device type and screen size must be set")
+	}
+	entry.File = filePath
+	return entry, nil
+}
+
+// ScreenshotArchiveEntryFromPath infers the device type, screen size and the optional locale of the
+// screenshot from the folder convention of the archive, which follows the storage layout of the
+// screenshots: [<locale>/]<device type> (<screen size>)/<file>
+func ScreenshotArchiveEntryFromPath(filePath string) (ScreenshotArchiveEntry, error) {
+	entry := ScreenshotArchiveEntry{File: filePath}
+	if !hasScreenshotFileExtension(filePath) {
+		return ScreenshotArchiveEntry{}, errors.New("file: Must be a .png, .jpg or .jpeg file")
+	}
+
+	folders := strings.Split(path.Dir(filePath), "/")
+	matches := deviceFolderPattern.FindStringSubmatch(folders[len(folders)-1])
+	if matches == nil {
+		return ScreenshotArchiveEntry{}, errors.New("file: Must be in a folder named like 'iPhone XS Max (6.5 inch)' or listed in the manifest")
+	}
+	entry.DeviceType, entry.ScreenSize = matches[1], matches[2]
+
+	if len(folders) > 1 && localeFolderPattern.MatchString(folders[len(folders)-2]) {
+		entry.Locale = folders[len(folders)-2]
+	}
+	return entry, nil
+}
+
+func hasScreenshotFileExtension(filePath string) bool {
+	extension := strings.ToLower(path.Ext(filePath))
+	for _, allowed := range screenshotFileExtensions {
+		if extension == allowed {
+			return true
+		}
+	}
+	return false
+}
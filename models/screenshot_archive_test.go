@@ -0,0 +1,89 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func Test_ScreenshotArchiveEntryFromPath(t *testing.T) {
+	t.Run("ok - device folder", func(t *testing.T) {
+		entry, err := models.ScreenshotArchiveEntryFromPath("iPhone XS Max (6.5 inch)/1.png")
+		require.NoError(t, err)
+		require.Equal(t, models.ScreenshotArchiveEntry{
+			File:       "iPhone XS Max (6.5 inch)/1.png",
+			DeviceType: "iPhone XS Max",
+			ScreenSize: "6.5 inch",
+		}, entry)
+	})
+
+	t.Run("ok - locale and device folder inside a root folder", func(t *testing.T) {
+		entry, err := models.ScreenshotArchiveEntryFromPath("screenshots/de-DE/iPad Pro (12.9-inch) (12.9 inch)/1.JPG")
+		require.NoError(t, err)
+		require.Equal(t, models.ScreenshotArchiveEntry{
+			File:       "screenshots/de-DE/iPad Pro (12.9-inch) (12.9 inch)/1.JPG",
+			DeviceType: "iPad Pro (12.9-inch)",
+			ScreenSize: "12.9 inch",
+			Locale:     "de-DE",
+		}, entry)
+	})
+
+	t.Run("ok - parent folder which is not a locale", func(t *testing.T) {
+		entry, err := models.ScreenshotArchiveEntryFromPath("My Screenshots/iPhone XS Max (6.5 inch)/1.png")
+		require.NoError(t, err)
+		require.Equal(t, "", entry.Locale)
+	})
+
+	t.Run("error - not a screenshot file", func(t *testing.T) {
+		_, err := models.ScreenshotArchiveEntryFromPath("iPhone XS Max (6.5 inch)/notes.txt")
+		require.EqualError(t, err, "file: Must be a .png, .jpg or .jpeg file")
+	})
+
+	t.Run("error - not in a device folder", func(t *testing.T) {
+		_, err := models.ScreenshotArchiveEntryFromPath("screenshots/1.png")
+		require.EqualError(t, err, "file: Must be in a folder named like 'iPhone XS Max (6.5 inch)' or listed in the manifest")
+	})
+}
+
+func Test_ScreenshotArchiveEntryFor(t *testing.T) {
+	manifest, err := models.ParseScreenshotArchiveManifest([]byte(`{"screenshots":[
+		{"file":"./first.png","device_type":"iPhone 8 Plus","screen_size":"5.5 inch","locale":"fr-FR"},
+		{"file":"second.png","device_type":"iPhone 8 Plus"}
+	]}`))
+	require.NoError(t, err)
+
+	t.Run("ok - listed in the manifest", func(t *testing.T) {
+		entry, err := models.ScreenshotArchiveEntryFor("first.png", manifest)
+		require.NoError(t, err)
+		require.Equal(t, models.ScreenshotArchiveEntry{
+			File:       "first.png",
+			DeviceType: "iPhone 8 Plus",
+			ScreenSize: "5.5 inch",
+			Locale:     "fr-FR",
+		}, entry)
+	})
+
+	t.Run("ok - falls back to the folder convention", func(t *testing.T) {
+		entry, err := models.ScreenshotArchiveEntryFor("iPhone XS Max (6.5 inch)/1.png", manifest)
+		require.NoError(t, err)
+		require.Equal(t, "iPhone XS Max", entry.DeviceType)
+	})
+
+	t.Run("error - incomplete manifest entry", func(t *testing.T) {
+		_, err := models.ScreenshotArchiveEntryFor("second.png", manifest)
+		require.EqualError(t, err, "manifest: Device type and screen size must be set")
+	})
+
+	t.Run("error - invalid manifest", func(t *testing.T) {
+		_, err := models.ParseScreenshotArchiveManifest([]byte(`not JSON`))
+		require.EqualError(t, err, "manifest: Must be a valid JSON file")
+	})
+}
+
+func Test_IsIgnoredScreenshotArchiveFile(t *testing.T) {
+	for _, filePath := range []string{"manifest.json", "iPhone XS Max (6.5 inch)/", "__MACOSX/iPhone XS Max (6.5 inch)/._1.png", "iPhone XS Max (6.5 inch)/.DS_Store"} {
+		require.True(t, models.IsIgnoredScreenshotArchiveFile(filePath), filePath)
+	}
+	require.False(t, models.IsIgnoredScreenshotArchiveFile("iPhone XS Max (6.5 inch)/1.png"))
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/nfnt/resize"
+	"github.com/pkg/errors"
+)
+
+// DefaultScreenshotBackgroundColor is used to pad resized screenshots if no color is set in the settings
+var DefaultScreenshotBackgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+// RequiredScreenshotSize ...
+type RequiredScreenshotSize struct {
+	DeviceType string
+	ScreenSize string
+	ImageDimensions
+}
+
+// requiredIosScreenshotSizes lists the screen sizes App Store Connect requires screenshots for,
+// grouped by the screen sizes of the same device family they can be derived from, largest first
+var requiredIosScreenshotSizes = []struct {
+	family   []string
+	required []RequiredScreenshotSize
+}{
+	{
+		family: []string{"6.5 inch", "5.8 inch", "5.5 inch", "4.7 inch", "4 inch", "3.5 inch"},
+		required: []RequiredScreenshotSize{
+			{DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", ImageDimensions: ImageDimensions{1242, 2688}},
+			{DeviceType: "iPhone 8 Plus", ScreenSize: "5.5 inch", ImageDimensions: ImageDimensions{1242, 2208}},
+		},
+	},
+	{
+		family: []string{"12.9 inch", "11 inch", "10.5 inch", "9.7 inch"},
+		required: []RequiredScreenshotSize{
+			{DeviceType: "iPad Pro (12.9-inch)", ScreenSize: "12.9 inch", ImageDimensions: ImageDimensions{2048, 2732}},
+		},
+	},
+}
+
+// RequiredScreenshotSizes returns the required sizes which can be derived from the screenshot.
+// The returned dimensions follow the orientation of the screenshot.
+func (s *Screenshot) RequiredScreenshotSizes() []RequiredScreenshotSize {
+	for _, group := range requiredIosScreenshotSizes {
+		for _, screenSize := range group.family {
+			if screenSize != s.ScreenSize {
+				continue
+			}
+			sizes := []RequiredScreenshotSize{}
+			for _, required := range group.required {
+				if s.Width > s.Height {
+					required.Width, required.Height = required.Height, required.Width
+				}
+				sizes = append(sizes, required)
+			}
+			return sizes
+		}
+	}
+	return nil
+}
+
+// ResizingSourceScreenSizes returns the screen size of each device family the required screenshots
+// are derived from, which is the largest one of the given uploaded screen sizes of the family
+func ResizingSourceScreenSizes(uploadedScreenSizes []string) []string {
+	uploaded := map[string]bool{}
+	for _, screenSize := range uploadedScreenSizes {
+		uploaded[screenSize] = true
+	}
+	sourceScreenSizes := []string{}
+	for _, group := range requiredIosScreenshotSizes {
+		for _, screenSize := range group.family {
+			if uploaded[screenSize] {
+				sourceScreenSizes = append(sourceScreenSizes, screenSize)
+				break
+			}
+		}
+	}
+	return sourceScreenSizes
+}
+
+// ParseHexColor parses colors in the #RRGGBB format
+func ParseHexColor(hexColor string) (color.RGBA, error) {
+	if len(hexColor) != 7 || !strings.HasPrefix(hexColor, "#") {
+		return color.RGBA{}, errors.Errorf("Invalid hex color: %s", hexColor)
+	}
+	value, err := strconv.ParseUint(hexColor[1:], 16, 32)
+	if err != nil {
+		return color.RGBA{}, errors.Errorf("Invalid hex color: %s", hexColor)
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}, nil
+}
+
+// ResizeImageToFit scales the image to fit into the given dimensions keeping its aspect ratio,
+// and pads the remaining area with the background color
+func ResizeImageToFit(img image.Image, dimensions ImageDimensions, background color.Color) image.Image {
+	bounds := img.Bounds()
+	width, height := dimensions.Width, bounds.Dy()*dimensions.Width/bounds.Dx()
+	if height > dimensions.Height {
+		width, height = bounds.Dx()*dimensions.Height/bounds.Dy(), dimensions.Height
+	}
+	scaled := resize.Resize(uint(width), uint(height), img, resize.Lanczos3)
+
+	canvas := image.NewRGBA(image.Rect(0, 0, dimensions.Width, dimensions.Height))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: background}, image.ZP, draw.Src)
+	offset := image.Pt((dimensions.Width-width)/2, (dimensions.Height-height)/2)
+	draw.Draw(canvas, scaled.Bounds().Add(offset), scaled, image.ZP, draw.Over)
+	return canvas
+}
+
+// EncodePNG encodes the image and returns it with its SHA-256 checksum
+func EncodePNG(img image.Image) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+	checksum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), hex.EncodeToString(checksum[:]), nil
+}
@@ -0,0 +1,94 @@
+package models_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func Test_ParseHexColor(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		c, err := models.ParseHexColor("#1A2b3C")
+		require.NoError(t, err)
+		require.Equal(t, color.RGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 0xff}, c)
+	})
+
+	for _, invalidColor := range []string{"", "#FFF", "FFFFFF", "#GGGGGG", "#FFFFFFFF"} {
+		t.Run("error - "+invalidColor, func(t *testing.T) {
+			_, err := models.ParseHexColor(invalidColor)
+			require.EqualError(t, err, "Invalid hex color: "+invalidColor)
+		})
+	}
+}
+
+func Test_IosSettings_ScreenshotBackground(t *testing.T) {
+	t.Run("ok - defaults to white", func(t *testing.T) {
+		c, err := models.IosSettings{}.ScreenshotBackground()
+		require.NoError(t, err)
+		require.Equal(t, models.DefaultScreenshotBackgroundColor, c)
+	})
+
+	t.Run("ok - uses the configured color", func(t *testing.T) {
+		c, err := models.IosSettings{ScreenshotBackgroundColor: "#000000"}.ScreenshotBackground()
+		require.NoError(t, err)
+		require.Equal(t, color.RGBA{A: 0xff}, c)
+	})
+}
+
+func Test_Screenshot_RequiredScreenshotSizes(t *testing.T) {
+	t.Run("iPhone screenshot", func(t *testing.T) {
+		screenshot := models.Screenshot{ScreenSize: "5.8 inch", UploadableObject: models.UploadableObject{Width: 1125, Height: 2436}}
+		require.Equal(t, []models.RequiredScreenshotSize{
+			{DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", ImageDimensions: models.ImageDimensions{Width: 1242, Height: 2688}},
+			{DeviceType: "iPhone 8 Plus", ScreenSize: "5.5 inch", ImageDimensions: models.ImageDimensions{Width: 1242, Height: 2208}},
+		}, screenshot.RequiredScreenshotSizes())
+	})
+
+	t.Run("landscape iPad screenshot", func(t *testing.T) {
+		screenshot := models.Screenshot{ScreenSize: "11 inch", UploadableObject: models.UploadableObject{Width: 2388, Height: 1668}}
+		require.Equal(t, []models.RequiredScreenshotSize{
+			{DeviceType: "iPad Pro (12.9-inch)", ScreenSize: "12.9 inch", ImageDimensions: models.ImageDimensions{Width: 2732, Height: 2048}},
+		}, screenshot.RequiredScreenshotSizes())
+	})
+
+	t.Run("screen size without required sizes", func(t *testing.T) {
+		screenshot := models.Screenshot{DeviceType: "Apple Watch", ScreenSize: "44mm"}
+		require.Empty(t, screenshot.RequiredScreenshotSizes())
+	})
+}
+
+func Test_ResizingSourceScreenSizes(t *testing.T) {
+	t.Run("largest screen size of each family", func(t *testing.T) {
+		require.Equal(t, []string{"5.8 inch", "11 inch"},
+			models.ResizingSourceScreenSizes([]string{"4.7 inch", "11 inch", "5.8 inch", "9.7 inch"}))
+	})
+
+	t.Run("screen sizes without required sizes", func(t *testing.T) {
+		require.Empty(t, models.ResizingSourceScreenSizes([]string{"44mm", "Mac"}))
+	})
+}
+
+func Test_ResizeImageToFit(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	source := image.NewRGBA(image.Rect(0, 0, 100, 200))
+	draw.Draw(source, source.Bounds(), &image.Uniform{C: red}, image.ZP, draw.Src)
+
+	resized := models.ResizeImageToFit(source, models.ImageDimensions{Width: 200, Height: 200}, blue)
+	require.Equal(t, image.Rect(0, 0, 200, 200), resized.Bounds())
+	require.Equal(t, blue, color.RGBAModel.Convert(resized.At(10, 100)))
+	require.Equal(t, red, color.RGBAModel.Convert(resized.At(100, 100)))
+	require.Equal(t, blue, color.RGBAModel.Convert(resized.At(190, 100)))
+
+	imageBytes, checksum, err := models.EncodePNG(resized)
+	require.NoError(t, err)
+	info, err := models.InspectImage(bytes.NewReader(imageBytes))
+	require.NoError(t, err)
+	require.Equal(t, models.ImageDimensions{Width: 200, Height: 200}, info.ImageDimensions)
+	require.Equal(t, checksum, info.Checksum)
+}
@@ -14,6 +14,14 @@ type ScreenshotService struct {
 func (s *ScreenshotService) BatchCreate(screenshots []*Screenshot) ([]*Screenshot, []error, error) {
 	tx := s.DB.Begin()
 	for _, screenshot := range screenshots {
+		if screenshot.Position == 0 {
+			position, err := s.nextPosition(tx, screenshot)
+			if err != nil {
+				tx.Rollback()
+				return nil, nil, err
+			}
+			screenshot.Position = position
+		}
 		result := tx.Create(screenshot)
 		verrs := ValidationErrors(result.GetErrors())
 		if len(verrs) > 0 {
@@ -33,9 +41,30 @@ func (s *ScreenshotService) BatchCreate(screenshots []*Screenshot) ([]*Screensho
 	return screenshots, nil, tx.Commit().Error
 }
 
+// nextPosition returns the position after the last screenshot of the same device type, screen size
+// and locale in the app version
+func (s *ScreenshotService) nextPosition(tx *gorm.DB, screenshot *Screenshot) (int, error) {
+	var result struct {
+		Position int
+	}
+	err := tx.Model(&Screenshot{}).
+		Select("COALESCE(MAX(position), 0) AS position").
+		Where(map[string]interface{}{
+			"app_version_id": screenshot.AppVersionID,
+			"device_type":    screenshot.DeviceType,
+			"screen_size":    screenshot.ScreenSize,
+			"locale":         screenshot.Locale,
+		}).
+		Scan(&result).Error
+	if err != nil {
+		return 0, err
+	}
+	return result.Position + 1, nil
+}
+
 // Find ...
 func (s *ScreenshotService) Find(screenshot *Screenshot) (*Screenshot, error) {
-	err := s.DB.Where(screenshot).First(screenshot).Error
+	err := s.DB.Preload("AppVersion").Preload("AppVersion.App").Where(screenshot).First(screenshot).Error
 
 	if err != nil {
 		return nil, err
@@ -44,12 +73,13 @@ func (s *ScreenshotService) Find(screenshot *Screenshot) (*Screenshot, error) {
 	return screenshot, nil
 }
 
-// FindAll ...
+// FindAll returns the screenshots of the app version grouped by device type, screen size and locale,
+// in the order of their positions
 func (s *ScreenshotService) FindAll(appVersion *AppVersion) ([]Screenshot, error) {
 	var screenshots []Screenshot
 	err := s.DB.Preload("AppVersion").Preload("AppVersion.App").Where(map[string]interface{}{"app_version_id": appVersion.ID}).
-		Find(&screenshots).
-		Order("created_at DESC").Error
+		Order("device_type, screen_size, locale, position, created_at").
+		Find(&screenshots).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +43,24 @@ func Test_ScreenshotService_BatchCreate(t *testing.T) {
 		require.Equal(t, "test-app-slug", createdScreeshots[0].AppVersion.App.AppSlug)
 	})
 
+	t.Run("ok - appends new screenshots to their device type, screen size and locale", func(t *testing.T) {
+		otherTestAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: testApp.ID, Platform: "ios", ArtifactInfoData: json.RawMessage(`{"version":"1.1"}`)})
+		createTestScreenshot(t, &models.Screenshot{AppVersionID: otherTestAppVersion.ID, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", Position: 1})
+		testScreenshots := []*models.Screenshot{
+			&models.Screenshot{AppVersionID: otherTestAppVersion.ID, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch"},
+			&models.Screenshot{AppVersionID: otherTestAppVersion.ID, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch"},
+			&models.Screenshot{AppVersionID: otherTestAppVersion.ID, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", Locale: "de-DE"},
+			&models.Screenshot{AppVersionID: otherTestAppVersion.ID, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", Position: 7},
+		}
+		createdScreeshots, verrs, err := screenshotService.BatchCreate(testScreenshots)
+		require.Empty(t, verrs)
+		require.NoError(t, err)
+		require.Equal(t, 2, createdScreeshots[0].Position)
+		require.Equal(t, 3, createdScreeshots[1].Position)
+		require.Equal(t, 1, createdScreeshots[2].Position)
+		require.Equal(t, 7, createdScreeshots[3].Position)
+	})
+
 	t.Run("when filesize is too big", func(t *testing.T) {
 		testScreenshot := []*models.Screenshot{
 			&models.Screenshot{
@@ -161,6 +179,24 @@ func Test_ScreenshotService_FindAll(t *testing.T) {
 		require.NoError(t, err)
 		reflect.DeepEqual([]models.Screenshot{*testScreenshot2, *testScreenshot1}, foundScreenshots)
 	})
+
+	t.Run("returns screenshots in the order of their positions", func(t *testing.T) {
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{
+			AppID:            uuid.NewV4(),
+			Platform:         "iOS",
+			ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`),
+		})
+		testScreenshot1 := createTestScreenshot(t, &models.Screenshot{AppVersion: *testAppVersion, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", Position: 2})
+		testScreenshot2 := createTestScreenshot(t, &models.Screenshot{AppVersion: *testAppVersion, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", Position: 1})
+		testScreenshot3 := createTestScreenshot(t, &models.Screenshot{AppVersion: *testAppVersion, DeviceType: "iPhone XS Max", ScreenSize: "6.5 inch", Position: 3})
+
+		foundScreenshots, err := screenshotService.FindAll(testAppVersion)
+		require.NoError(t, err)
+		require.Len(t, foundScreenshots, 3)
+		require.Equal(t, testScreenshot2.ID, foundScreenshots[0].ID)
+		require.Equal(t, testScreenshot1.ID, foundScreenshots[1].ID)
+		require.Equal(t, testScreenshot3.ID, foundScreenshots[2].ID)
+	})
 }
 
 func Test_ScreenshotService_BatchUpdate(t *testing.T) {
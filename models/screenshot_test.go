@@ -23,4 +23,15 @@ func Test_Screenshot_AWSPath(t *testing.T) {
 	}
 
 	require.Equal(t, "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.png", testScreenshot.AWSPath())
+
+	t.Run("when stored as an asset", func(t *testing.T) {
+		assetID := uuid.NewV4()
+		assetScreenshot := testScreenshot
+		assetScreenshot.AssetID = &assetID
+		assetScreenshot.Filename = "screenshot1.PNG"
+		assetScreenshot.Checksum = "f1d2d2f924e986ac86fdf7b36c94bcdf32beec15"
+
+		require.Equal(t, "assets/f1/f1d2d2f924e986ac86fdf7b36c94bcdf32beec15.png", assetScreenshot.AWSPath())
+		require.Equal(t, testScreenshot.AWSPath(), testScreenshot.UploadAWSPath())
+	})
 }
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/api-utils/constants"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// MaxFeatureGraphicFileByteSize ...
+	MaxFeatureGraphicFileByteSize = 10 * constants.MegaByte
+)
+
+const (
+	// StoreGraphicTypeFeatureGraphic ...
+	StoreGraphicTypeFeatureGraphic = "feature_graphic"
+	// StoreGraphicTypeIcon ...
+	StoreGraphicTypeIcon = "icon"
+	// StoreGraphicTypePromoGraphic ...
+	StoreGraphicTypePromoGraphic = "promo_graphic"
+	// StoreGraphicTypeTvBanner ...
+	StoreGraphicTypeTvBanner = "tv_banner"
+)
+
+// StoreGraphicRule describes the required dimensions and the maximum size of a store graphic type
+type StoreGraphicRule struct {
+	Width           int
+	Height          int
+	MaxFileByteSize int64
+	FileExtensions  []string
+}
+
+// StoreGraphicRules ...
+var StoreGraphicRules = map[string]StoreGraphicRule{
+	StoreGraphicTypeFeatureGraphic: StoreGraphicRule{Width: 1024, Height: 500, MaxFileByteSize: MaxFeatureGraphicFileByteSize, FileExtensions: []string{".png", ".jpg", ".jpeg"}},
+	StoreGraphicTypeIcon:           StoreGraphicRule{Width: 512, Height: 512, MaxFileByteSize: 1 * constants.MegaByte, FileExtensions: []string{".png"}},
+	StoreGraphicTypePromoGraphic:   StoreGraphicRule{Width: 180, Height: 120, MaxFileByteSize: 1 * constants.MegaByte, FileExtensions: []string{".png", ".jpg", ".jpeg"}},
+	StoreGraphicTypeTvBanner:       StoreGraphicRule{Width: 1280, Height: 720, MaxFileByteSize: 1 * constants.MegaByte, FileExtensions: []string{".png", ".jpg", ".jpeg"}},
+}
+
+// StoreGraphicTypes ...
+var StoreGraphicTypes = []string{StoreGraphicTypeFeatureGraphic, StoreGraphicTypeIcon, StoreGraphicTypePromoGraphic, StoreGraphicTypeTvBanner}
+
+// IsValidStoreGraphicType ...
+func IsValidStoreGraphicType(graphicType string) bool {
+	for _, t := range StoreGraphicTypes {
+		if t == graphicType {
+			return true
+		}
+	}
+	return false
+}
+
+// StoreGraphic ...
+type StoreGraphic struct {
+	Record
+	UploadableObject
+	Type string `json:"type"`
+
+	// AssetID is set once the uploaded file is verified and stored by its content
+	AssetID *uuid.UUID `db:"asset_id" json:"-" gorm:"type:uuid"`
+
+	AppVersionID uuid.UUID  `db:"app_version_id" json:"-"`
+	AppVersion   AppVersion `gorm:"foreignkey:AppVersionID" json:"-"`
+}
+
+// BeforeCreate ...
+func (g *StoreGraphic) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(g.ID, uuid.UUID{}) {
+		g.ID = uuid.NewV4()
+	}
+	err := g.validate(scope, "create")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// BeforeUpdate ...
+func (g *StoreGraphic) BeforeUpdate(scope *gorm.Scope) error {
+	err := g.validate(scope, "update")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (g *StoreGraphic) validate(scope *gorm.Scope, action string) error {
+	var err error
+	if action == "create" && !IsValidStoreGraphicType(g.Type) {
+		err = scope.DB().AddError(NewValidationError(fmt.Sprintf("type: Must be one of %s", strings.Join(StoreGraphicTypes, ", "))))
+		return errors.New("Validation failed")
+	}
+	rule, ok := StoreGraphicRules[g.Type]
+	if ok {
+		if g.Filesize > rule.MaxFileByteSize {
+			err = scope.DB().AddError(NewValidationError(fmt.Sprintf("filesize: Must be smaller than %d megabytes", rule.MaxFileByteSize/constants.MegaByte)))
+		}
+		if action == "create" && !hasFileExtension(g.Filename, rule.FileExtensions) {
+			err = scope.DB().AddError(NewValidationError(fmt.Sprintf("filename: Must be a %s file", strings.Join(rule.FileExtensions, ", "))))
+		}
+	}
+	if action == "create" {
+		var storeGraphicCnt int64
+		err = scope.DB().Model(&StoreGraphic{}).Where("app_version_id = ? AND type = ?", g.AppVersionID, g.Type).Count(&storeGraphicCnt).Error
+		if storeGraphicCnt > 0 {
+			err = scope.DB().AddError(NewValidationError(fmt.Sprintf("store_graphics: Maximum count of %s store graphics is 1", g.Type)))
+		}
+	}
+	if err != nil {
+		return errors.New("Validation failed")
+	}
+	return nil
+}
+
+// AWSPath returns the key of the object of the store graphic, which is the asset storing its content
+// once the upload is verified
+func (g *StoreGraphic) AWSPath() string {
+	if g.AssetID != nil {
+		return AssetAWSPath(g.Checksum, AssetExtension(g.Filename))
+	}
+	return g.UploadAWSPath()
+}
+
+// UploadAWSPath returns the key the file of the store graphic is uploaded to. Feature graphics keep
+// the keys they had before they became store graphics.
+func (g *StoreGraphic) UploadAWSPath() string {
+	pathElements := []string{g.AppVersion.App.AppSlug, g.AppVersion.ID.String()}
+	if g.Type != StoreGraphicTypeFeatureGraphic {
+		pathElements = append(pathElements, g.Type)
+	}
+	pathElements = append(pathElements, g.ID.String()+filepath.Ext(g.Filename))
+	return strings.Join(pathElements, "/")
+}
+
+func hasFileExtension(filename string, extensions []string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, validExt := range extensions {
+		if ext == validExt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+// +build database
+
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func createTestStoreGraphic(t *testing.T, storeGraphic *models.StoreGraphic) *models.StoreGraphic {
+	err := dataservices.GetDB().Create(storeGraphic).Error
+	require.NoError(t, err)
+	return storeGraphic
+}
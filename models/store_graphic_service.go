@@ -0,0 +1,79 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// StoreGraphicService ...
+type StoreGraphicService struct {
+	DB *gorm.DB
+	UpdatableModelService
+}
+
+// Create ...
+func (s *StoreGraphicService) Create(storeGraphic *StoreGraphic) (*StoreGraphic, []error, error) {
+	result := s.DB.Create(storeGraphic)
+	verrs := ValidationErrors(result.GetErrors())
+	if len(verrs) > 0 {
+		return nil, verrs, nil
+	}
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	return storeGraphic, nil, s.DB.Where("id = ?", storeGraphic.ID).
+		Preload("AppVersion").Preload("AppVersion.App").
+		First(storeGraphic).Error
+}
+
+// Find ...
+func (s *StoreGraphicService) Find(storeGraphic *StoreGraphic) (*StoreGraphic, error) {
+	err := s.DB.Preload("AppVersion").Preload("AppVersion.App").Where(storeGraphic).First(storeGraphic).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return storeGraphic, nil
+}
+
+// FindAll ...
+func (s *StoreGraphicService) FindAll(appVersion *AppVersion) ([]StoreGraphic, error) {
+	var storeGraphics []StoreGraphic
+	err := s.DB.Preload("AppVersion").Preload("AppVersion.App").
+		Where(map[string]interface{}{"app_version_id": appVersion.ID}).
+		Order("type ASC").
+		Find(&storeGraphics).Error
+	if err != nil {
+		return nil, err
+	}
+	return storeGraphics, nil
+}
+
+// Update ...
+func (s *StoreGraphicService) Update(storeGraphic StoreGraphic, whitelist []string) ([]error, error) {
+	updateData, err := s.UpdateData(storeGraphic, whitelist)
+	if err != nil {
+		return nil, err
+	}
+	result := s.DB.Model(&storeGraphic).Updates(updateData)
+	verrs := ValidationErrors(result.GetErrors())
+	if len(verrs) > 0 {
+		return verrs, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return nil, nil
+}
+
+// Delete ...
+func (s *StoreGraphicService) Delete(storeGraphic *StoreGraphic) error {
+	result := s.DB.Delete(&storeGraphic)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
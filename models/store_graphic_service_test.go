@@ -0,0 +1,203 @@
+// +build database
+
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_StoreGraphicService_Create(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	storeGraphicService := models.StoreGraphicService{DB: dataservices.GetDB()}
+
+	t.Run("ok", func(t *testing.T) {
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{
+			AppID:            uuid.NewV4(),
+			Platform:         "android",
+			ArtifactInfoData: json.RawMessage(`{"version":"1.0"}`),
+		})
+		createdStoreGraphic, verrs, err := storeGraphicService.Create(&models.StoreGraphic{
+			AppVersionID:     testAppVersion.ID,
+			Type:             models.StoreGraphicTypeIcon,
+			UploadableObject: models.UploadableObject{Filename: "icon.png", Filesize: 1234},
+		})
+		require.Empty(t, verrs)
+		require.NoError(t, err)
+		require.False(t, createdStoreGraphic.ID.String() == "")
+		require.Equal(t, testAppVersion.ID, createdStoreGraphic.AppVersion.ID)
+	})
+
+	t.Run("when type is invalid", func(t *testing.T) {
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+		createdStoreGraphic, verrs, err := storeGraphicService.Create(&models.StoreGraphic{
+			AppVersionID:     testAppVersion.ID,
+			Type:             "wallpaper",
+			UploadableObject: models.UploadableObject{Filename: "wallpaper.png", Filesize: 1234},
+		})
+		require.Equal(t, []error{errors.New("type: Must be one of feature_graphic, icon, promo_graphic, tv_banner")}, verrs)
+		require.NoError(t, err)
+		require.Nil(t, createdStoreGraphic)
+	})
+
+	t.Run("when filesize is too big and extension is not allowed", func(t *testing.T) {
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+		createdStoreGraphic, verrs, err := storeGraphicService.Create(&models.StoreGraphic{
+			AppVersionID:     testAppVersion.ID,
+			Type:             models.StoreGraphicTypeIcon,
+			UploadableObject: models.UploadableObject{Filename: "icon.jpg", Filesize: models.StoreGraphicRules[models.StoreGraphicTypeIcon].MaxFileByteSize + 1},
+		})
+		require.Equal(t, []error{
+			errors.New("filesize: Must be smaller than 1 megabytes"),
+			errors.New("filename: Must be a .png file"),
+		}, verrs)
+		require.NoError(t, err)
+		require.Nil(t, createdStoreGraphic)
+	})
+
+	t.Run("when feature graphic is bigger than 10 megabytes", func(t *testing.T) {
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+		createdStoreGraphic, verrs, err := storeGraphicService.Create(&models.StoreGraphic{
+			AppVersionID:     testAppVersion.ID,
+			Type:             models.StoreGraphicTypeFeatureGraphic,
+			UploadableObject: models.UploadableObject{Filename: "feature_graphic.png", Filesize: models.MaxFeatureGraphicFileByteSize + 1},
+		})
+		require.Equal(t, []error{errors.New("filesize: Must be smaller than 10 megabytes")}, verrs)
+		require.NoError(t, err)
+		require.Nil(t, createdStoreGraphic)
+	})
+
+	t.Run("when app version already has a store graphic of the same type", func(t *testing.T) {
+		testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+		createTestStoreGraphic(t, &models.StoreGraphic{
+			AppVersionID:     testAppVersion.ID,
+			Type:             models.StoreGraphicTypePromoGraphic,
+			UploadableObject: models.UploadableObject{Filename: "promo.png", Filesize: 1234},
+		})
+		createdStoreGraphic, verrs, err := storeGraphicService.Create(&models.StoreGraphic{
+			AppVersionID:     testAppVersion.ID,
+			Type:             models.StoreGraphicTypePromoGraphic,
+			UploadableObject: models.UploadableObject{Filename: "promo.png", Filesize: 1234},
+		})
+		require.Equal(t, []error{errors.New("store_graphics: Maximum count of promo_graphic store graphics is 1")}, verrs)
+		require.NoError(t, err)
+		require.Nil(t, createdStoreGraphic)
+	})
+}
+
+func Test_StoreGraphicService_Find(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	storeGraphicService := models.StoreGraphicService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+	testStoreGraphic := createTestStoreGraphic(t, &models.StoreGraphic{
+		AppVersionID:     testAppVersion.ID,
+		Type:             models.StoreGraphicTypeTvBanner,
+		UploadableObject: models.UploadableObject{Filename: "banner.png", Filesize: 1234},
+	})
+
+	t.Run("when querying a store graphic by app version and type", func(t *testing.T) {
+		foundStoreGraphic, err := storeGraphicService.Find(&models.StoreGraphic{AppVersionID: testAppVersion.ID, Type: models.StoreGraphicTypeTvBanner})
+		require.NoError(t, err)
+		require.Equal(t, testStoreGraphic.ID, foundStoreGraphic.ID)
+	})
+
+	t.Run("error - when store graphic is not found", func(t *testing.T) {
+		foundStoreGraphic, err := storeGraphicService.Find(&models.StoreGraphic{AppVersionID: testAppVersion.ID, Type: models.StoreGraphicTypeIcon})
+		require.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+		require.Nil(t, foundStoreGraphic)
+	})
+}
+
+func Test_StoreGraphicService_FindAll(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	storeGraphicService := models.StoreGraphicService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+	otherTestAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+	createTestStoreGraphic(t, &models.StoreGraphic{
+		AppVersionID:     testAppVersion.ID,
+		Type:             models.StoreGraphicTypeTvBanner,
+		UploadableObject: models.UploadableObject{Filename: "banner.png", Filesize: 1234},
+	})
+	createTestStoreGraphic(t, &models.StoreGraphic{
+		AppVersionID:     testAppVersion.ID,
+		Type:             models.StoreGraphicTypeIcon,
+		UploadableObject: models.UploadableObject{Filename: "icon.png", Filesize: 1234},
+	})
+	createTestStoreGraphic(t, &models.StoreGraphic{
+		AppVersionID:     otherTestAppVersion.ID,
+		Type:             models.StoreGraphicTypeIcon,
+		UploadableObject: models.UploadableObject{Filename: "icon.png", Filesize: 1234},
+	})
+
+	foundStoreGraphics, err := storeGraphicService.FindAll(testAppVersion)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(foundStoreGraphics))
+	require.Equal(t, models.StoreGraphicTypeIcon, foundStoreGraphics[0].Type)
+	require.Equal(t, models.StoreGraphicTypeTvBanner, foundStoreGraphics[1].Type)
+}
+
+func Test_StoreGraphicService_Update(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	storeGraphicService := models.StoreGraphicService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+
+	t.Run("ok", func(t *testing.T) {
+		testStoreGraphic := *createTestStoreGraphic(t, &models.StoreGraphic{
+			AppVersionID:     testAppVersion.ID,
+			Type:             models.StoreGraphicTypeIcon,
+			UploadableObject: models.UploadableObject{Filename: "icon.png", Filesize: 1234},
+		})
+		testStoreGraphic.Uploaded = true
+		verrs, err := storeGraphicService.Update(testStoreGraphic, []string{"Uploaded"})
+		require.Empty(t, verrs)
+		require.NoError(t, err)
+
+		foundStoreGraphic, err := storeGraphicService.Find(&models.StoreGraphic{Record: models.Record{ID: testStoreGraphic.ID}})
+		require.NoError(t, err)
+		require.True(t, foundStoreGraphic.Uploaded)
+	})
+
+	t.Run("when trying to update non-existing field", func(t *testing.T) {
+		verrs, err := storeGraphicService.Update(models.StoreGraphic{}, []string{"NonExistingField"})
+		require.EqualError(t, err, "Attribute name doesn't exist in the model")
+		require.Equal(t, 0, len(verrs))
+	})
+}
+
+func Test_StoreGraphicService_Delete(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	storeGraphicService := models.StoreGraphicService{DB: dataservices.GetDB()}
+	testAppVersion := createTestAppVersion(t, &models.AppVersion{AppID: uuid.NewV4(), Platform: "android"})
+	testStoreGraphic := createTestStoreGraphic(t, &models.StoreGraphic{
+		AppVersionID:     testAppVersion.ID,
+		Type:             models.StoreGraphicTypeIcon,
+		UploadableObject: models.UploadableObject{Filename: "icon.png", Filesize: 1234},
+	})
+
+	t.Run("when deleting a store graphic", func(t *testing.T) {
+		err := storeGraphicService.Delete(&models.StoreGraphic{Record: models.Record{ID: testStoreGraphic.ID}})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - when store graphic is not found", func(t *testing.T) {
+		err := storeGraphicService.Delete(&models.StoreGraphic{Record: models.Record{ID: uuid.NewV4()}})
+		require.Equal(t, gorm.ErrRecordNotFound, err)
+	})
+}
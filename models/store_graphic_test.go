@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_StoreGraphic_AWSPath(t *testing.T) {
+	testStoreGraphic := models.StoreGraphic{
+		Record:           models.Record{ID: uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")},
+		UploadableObject: models.UploadableObject{Filename: "banner.jpg"},
+		Type:             models.StoreGraphicTypeTvBanner,
+		AppVersion: models.AppVersion{
+			Record: models.Record{
+				ID: uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879"),
+			},
+			App: models.App{AppSlug: "test-app-slug"},
+		},
+	}
+	require.Equal(t, "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/tv_banner/42156ba6-3473-493f-ba08-6d74d26c320e.jpg", testStoreGraphic.AWSPath())
+
+	t.Run("when stored as an asset", func(t *testing.T) {
+		assetID := uuid.NewV4()
+		assetStoreGraphic := testStoreGraphic
+		assetStoreGraphic.AssetID = &assetID
+		assetStoreGraphic.Checksum = "f1d2d2f924e986ac86fdf7b36c94bcdf32beec15"
+
+		require.Equal(t, "assets/f1/f1d2d2f924e986ac86fdf7b36c94bcdf32beec15.jpg", assetStoreGraphic.AWSPath())
+		require.Equal(t, testStoreGraphic.AWSPath(), assetStoreGraphic.UploadAWSPath())
+	})
+
+	t.Run("when it's a feature graphic", func(t *testing.T) {
+		featureGraphic := testStoreGraphic
+		featureGraphic.Type = models.StoreGraphicTypeFeatureGraphic
+
+		require.Equal(t, "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/42156ba6-3473-493f-ba08-6d74d26c320e.jpg", featureGraphic.AWSPath())
+	})
+}
+
+func Test_IsValidStoreGraphicType(t *testing.T) {
+	require.True(t, models.IsValidStoreGraphicType("icon"))
+	require.True(t, models.IsValidStoreGraphicType("promo_graphic"))
+	require.True(t, models.IsValidStoreGraphicType("tv_banner"))
+	require.True(t, models.IsValidStoreGraphicType("feature_graphic"))
+	require.False(t, models.IsValidStoreGraphicType("screenshot"))
+	require.False(t, models.IsValidStoreGraphicType(""))
+}
@@ -1,8 +1,34 @@
 package models
 
+const (
+	// UploadableTypeScreenshot ...
+	UploadableTypeScreenshot = "screenshot"
+	// UploadableTypeFeatureGraphic ...
+	UploadableTypeFeatureGraphic = "feature_graphic"
+	// UploadableTypeStoreGraphic ...
+	UploadableTypeStoreGraphic = "store_graphic"
+)
+
 // UploadableObject ...
 type UploadableObject struct {
-	Filename string `json:"filename"`
-	Filesize int64  `json:"filesize"`
-	Uploaded bool   `json:"uploaded"`
+	Filename      string `json:"filename"`
+	Filesize      int64  `json:"filesize"`
+	Uploaded      bool   `json:"uploaded"`
+	Width         int    `json:"width"`
+	Height        int    `json:"height"`
+	Checksum      string `json:"checksum"`
+	InvalidReason string `json:"invalid_reason"`
+}
+
+// UploadableVerificationFields lists the attributes set by the upload verification
+var UploadableVerificationFields = []string{"Uploaded", "Width", "Height", "Checksum", "InvalidReason"}
+
+// IsInvalid ...
+func (o *UploadableObject) IsInvalid() bool {
+	return o.InvalidReason != ""
+}
+
+// IsPendingVerification ...
+func (o *UploadableObject) IsPendingVerification() bool {
+	return !o.Uploaded && !o.IsInvalid()
 }
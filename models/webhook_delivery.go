@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// webhookDeliveryMaxBodyLength is the number of bytes of the body recorded with the deliveries
+const webhookDeliveryMaxBodyLength = 64 * 1024
+
+// webhookDeliverySecretHeaders are not recorded with the deliveries
+var webhookDeliverySecretHeaders = []string{"Authorization", "Authentication", "Bitrise-Den-Webhook-Secret", "Cookie"}
+
+// WebhookDelivery is a request received on the build or the task webhook endpoint, recorded with the
+// outcome of its handling. The app is unknown for the deliveries which couldn't be authorized, the
+// signature validity is unknown for the ones of apps without a webhook secret.
+type WebhookDelivery struct {
+	Record
+	Endpoint       string          `json:"endpoint"`
+	EventType      string          `json:"event_type"`
+	Headers        json.RawMessage `json:"headers" gorm:"type:json"`
+	Body           string          `json:"body"`
+	SignatureValid *bool           `db:"signature_valid" json:"signature_valid"`
+	StatusCode     int             `db:"status_code" json:"status_code"`
+	Error          string          `json:"error"`
+
+	AppID          *uuid.UUID    `db:"app_id" json:"-"`
+	BuildWebhookID *uuid.UUID    `db:"build_webhook_id" json:"-"`
+	BuildWebhook   *BuildWebhook `gorm:"foreignkey:BuildWebhookID" json:"build_webhook,omitempty"`
+}
+
+// NewWebhookDelivery returns the delivery of the request, without its outcome
+func NewWebhookDelivery(endpoint string, header http.Header, body []byte) (*WebhookDelivery, error) {
+	headers := map[string]string{}
+	for name := range header {
+		headers[name] = header.Get(name)
+	}
+	for _, name := range webhookDeliverySecretHeaders {
+		delete(headers, http.CanonicalHeaderKey(name))
+	}
+	headersData, err := json.Marshal(headers)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookDelivery{
+		Endpoint:  endpoint,
+		EventType: header.Get("Bitrise-Event-Type"),
+		Headers:   headersData,
+		Body:      truncateBody(body, webhookDeliveryMaxBodyLength),
+	}, nil
+}
+
+// truncateBody cuts the body at a rune boundary, to keep it valid UTF-8
+func truncateBody(body []byte, maxLength int) string {
+	if len(body) <= maxLength {
+		return string(body)
+	}
+	length := maxLength
+	for length > 0 && !utf8.RuneStart(body[length]) {
+		length--
+	}
+	return string(body[:length])
+}
+
+// BeforeCreate ...
+func (d *WebhookDelivery) BeforeCreate(scope *gorm.Scope) error {
+	if uuid.Equal(d.ID, uuid.UUID{}) {
+		d.ID = uuid.NewV4()
+	}
+	if d.Headers == nil {
+		d.Headers = json.RawMessage(`{}`)
+	}
+	return nil
+}
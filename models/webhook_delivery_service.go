@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// WebhookDeliveryService ...
+type WebhookDeliveryService struct {
+	DB *gorm.DB
+}
+
+// Create ...
+func (s *WebhookDeliveryService) Create(webhookDelivery *WebhookDelivery) (*WebhookDelivery, error) {
+	if err := s.DB.Create(webhookDelivery).Error; err != nil {
+		return nil, err
+	}
+	return webhookDelivery, nil
+}
+
+// FindAll returns the deliveries of the app with the build webhooks they stored, the latest first
+func (s *WebhookDeliveryService) FindAll(app *App) ([]WebhookDelivery, error) {
+	var webhookDeliveries []WebhookDelivery
+	err := s.DB.Preload("BuildWebhook").Where("app_id = ?", app.ID).Order("created_at DESC").Find(&webhookDeliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhookDeliveries, nil
+}
+
+// DeleteOlderThan deletes the deliveries received before the given time, and returns their count
+func (s *WebhookDeliveryService) DeleteOlderThan(before time.Time) (int64, error) {
+	result := s.DB.Where("created_at < ?", before).Delete(&WebhookDelivery{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,77 @@
+// +build database
+
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_WebhookDeliveryService_Create(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	webhookDeliveryService := models.WebhookDeliveryService{DB: dataservices.GetDB()}
+
+	t.Run("ok - of an unknown app", func(t *testing.T) {
+		webhookDelivery, err := webhookDeliveryService.Create(&models.WebhookDelivery{Endpoint: "/webhook", StatusCode: 404})
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.UUID{}, webhookDelivery.ID)
+		require.Equal(t, json.RawMessage(`{}`), webhookDelivery.Headers)
+	})
+}
+
+func Test_WebhookDeliveryService_FindAll(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	webhookDeliveryService := models.WebhookDeliveryService{DB: dataservices.GetDB()}
+	buildWebhookService := models.BuildWebhookService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+	otherApp := createTestApp(t, &models.App{AppSlug: "other-app-slug"})
+
+	buildWebhook, err := buildWebhookService.Create(&models.BuildWebhook{AppID: testApp.ID, BuildSlug: "test-build-slug", Payload: json.RawMessage(`{}`), Status: models.JobStatusPending})
+	require.NoError(t, err)
+	firstDelivery, err := webhookDeliveryService.Create(&models.WebhookDelivery{AppID: &testApp.ID, Endpoint: "/webhook", StatusCode: 202, BuildWebhookID: &buildWebhook.ID})
+	require.NoError(t, err)
+	latestDelivery, err := webhookDeliveryService.Create(&models.WebhookDelivery{AppID: &testApp.ID, Endpoint: "/webhook", StatusCode: 404})
+	require.NoError(t, err)
+	_, err = webhookDeliveryService.Create(&models.WebhookDelivery{AppID: &otherApp.ID, Endpoint: "/webhook", StatusCode: 202})
+	require.NoError(t, err)
+
+	webhookDeliveries, err := webhookDeliveryService.FindAll(testApp)
+	require.NoError(t, err)
+	require.Len(t, webhookDeliveries, 2)
+	require.Equal(t, latestDelivery.ID, webhookDeliveries[0].ID)
+	require.Equal(t, firstDelivery.ID, webhookDeliveries[1].ID)
+	require.Equal(t, buildWebhook.ID, webhookDeliveries[1].BuildWebhook.ID)
+}
+
+func Test_WebhookDeliveryService_DeleteOlderThan(t *testing.T) {
+	dbCloseCallbackMethod := prepareDB(t)
+	defer dbCloseCallbackMethod()
+
+	webhookDeliveryService := models.WebhookDeliveryService{DB: dataservices.GetDB()}
+	testApp := createTestApp(t, &models.App{AppSlug: "test-app-slug"})
+
+	expiredDelivery, err := webhookDeliveryService.Create(&models.WebhookDelivery{AppID: &testApp.ID, Endpoint: "/webhook", StatusCode: 202})
+	require.NoError(t, err)
+	require.NoError(t, dataservices.GetDB().Model(expiredDelivery).UpdateColumn("created_at", time.Now().Add(-31*24*time.Hour)).Error)
+	keptDelivery, err := webhookDeliveryService.Create(&models.WebhookDelivery{AppID: &testApp.ID, Endpoint: "/task-webhook", StatusCode: 200})
+	require.NoError(t, err)
+
+	deletedCount, err := webhookDeliveryService.DeleteOlderThan(time.Now().Add(-30 * 24 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deletedCount)
+
+	webhookDeliveries, err := webhookDeliveryService.FindAll(testApp)
+	require.NoError(t, err)
+	require.Len(t, webhookDeliveries, 1)
+	require.Equal(t, keptDelivery.ID, webhookDeliveries[0].ID)
+}
@@ -0,0 +1,38 @@
+package models_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func Test_NewWebhookDelivery(t *testing.T) {
+	header := http.Header{}
+	header.Set("Bitrise-Event-Type", "build/finished")
+	header.Set("Bitrise-App-Id", "test-app-slug")
+	header.Set("Bitrise-Den-Webhook-Secret", "super-secret")
+	header.Set("Authorization", "token secret")
+
+	webhookDelivery, err := models.NewWebhookDelivery("/webhook", header, []byte(`{"build_slug":"test-build-slug"}`))
+	require.NoError(t, err)
+	require.Equal(t, "/webhook", webhookDelivery.Endpoint)
+	require.Equal(t, "build/finished", webhookDelivery.EventType)
+	require.Equal(t, `{"build_slug":"test-build-slug"}`, webhookDelivery.Body)
+
+	var headers map[string]string
+	require.NoError(t, json.Unmarshal(webhookDelivery.Headers, &headers))
+	require.Equal(t, map[string]string{"Bitrise-Event-Type": "build/finished", "Bitrise-App-Id": "test-app-slug"}, headers)
+
+	t.Run("when body is too long", func(t *testing.T) {
+		body := append([]byte{'a'}, []byte(strings.Repeat("ő", 64*1024))...)
+		webhookDelivery, err := models.NewWebhookDelivery("/webhook", header, body)
+		require.NoError(t, err)
+		require.Len(t, webhookDelivery.Body, 64*1024-1)
+		require.True(t, utf8.ValidString(webhookDelivery.Body))
+	})
+}
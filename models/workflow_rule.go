@@ -0,0 +1,127 @@
+package models
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WorkflowRule selects the builds versions of a platform are created from, by the workflow, branch and
+// tag of the build. The patterns are globs as understood by path.Match, where * doesn't match a /, and a
+// ** segment matches any number of segments, e.g. release/** matches release/1.2/hotfix too. A build
+// matches the rule if it matches one of the patterns of every non-empty list, so a rule without patterns
+// matches every build, and a rule with tag patterns never matches an untagged build.
+// An empty platform applies the rule to every platform, the ios one to every Apple platform.
+type WorkflowRule struct {
+	Platform  string   `json:"platform"`
+	Exclude   bool     `json:"exclude"`
+	Workflows []string `json:"workflows"`
+	Branches  []string `json:"branches"`
+	Tags      []string `json:"tags"`
+}
+
+// WorkflowRuleBuild is what workflow rules are matched against
+type WorkflowRuleBuild struct {
+	Workflow string
+	Branch   string
+	Tag      string
+}
+
+// Matches ...
+func (r WorkflowRule) Matches(build WorkflowRuleBuild) bool {
+	return matchesAnyPattern(r.Workflows, build.Workflow) &&
+		matchesAnyPattern(r.Branches, build.Branch) &&
+		matchesAnyPattern(r.Tags, build.Tag)
+}
+
+func (r WorkflowRule) appliesTo(platform string) bool {
+	return r.Platform == "" || r.Platform == platform || (r.Platform == "ios" && IsApplePlatform(platform))
+}
+
+func (r WorkflowRule) validate() error {
+	if r.Platform != "" && r.Platform != "android" && !IsApplePlatform(r.Platform) {
+		return errors.Errorf("Unknown platform %s", r.Platform)
+	}
+	for _, patterns := range [][]string{r.Workflows, r.Branches, r.Tags} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Errorf("Invalid pattern %s", pattern)
+			}
+		}
+	}
+	return nil
+}
+
+func matchesAnyPattern(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	if value == "" {
+		return false
+	}
+	valueSegments := strings.Split(value, "/")
+	for _, pattern := range patterns {
+		if matchesSegments(strings.Split(pattern, "/"), valueSegments) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesSegments(patternSegments, valueSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(valueSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(valueSegments); i++ {
+			if matchesSegments(patternSegments[1:], valueSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(valueSegments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(patternSegments[0], valueSegments[0]); err != nil || !matched {
+		return false
+	}
+	return matchesSegments(patternSegments[1:], valueSegments[1:])
+}
+
+// WorkflowRules ...
+type WorkflowRules []WorkflowRule
+
+// Allows tells whether versions of the platform are created from the build. The build has to match one
+// of the including rules of the platform, if there is any, and none of the excluding ones.
+func (rules WorkflowRules) Allows(platform string, build WorkflowRuleBuild) bool {
+	hasIncludingRule := false
+	included := false
+	for _, rule := range rules {
+		if !rule.appliesTo(platform) {
+			continue
+		}
+		if rule.Exclude {
+			if rule.Matches(build) {
+				return false
+			}
+			continue
+		}
+		hasIncludingRule = true
+		if rule.Matches(build) {
+			included = true
+		}
+	}
+	return !hasIncludingRule || included
+}
+
+// AllowsAny tells whether versions of any of the platforms are created from the build
+func (rules WorkflowRules) AllowsAny(platforms []string, build WorkflowRuleBuild) bool {
+	for _, platform := range platforms {
+		if rules.Allows(platform, build) {
+			return true
+		}
+	}
+	return false
+}
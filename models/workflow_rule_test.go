@@ -0,0 +1,115 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/c2fo/testify/require"
+)
+
+func Test_WorkflowRule_Matches(t *testing.T) {
+	build := models.WorkflowRuleBuild{Workflow: "deploy-staging", Branch: "release/1.2", Tag: ""}
+
+	t.Run("when rule has no patterns", func(t *testing.T) {
+		require.True(t, models.WorkflowRule{}.Matches(build))
+	})
+
+	t.Run("when every pattern list matches", func(t *testing.T) {
+		rule := models.WorkflowRule{Workflows: []string{"deploy-*"}, Branches: []string{"master", "release/*"}}
+		require.True(t, rule.Matches(build))
+	})
+
+	t.Run("when workflow only contains the pattern", func(t *testing.T) {
+		rule := models.WorkflowRule{Workflows: []string{"deploy"}}
+		require.False(t, rule.Matches(build))
+	})
+
+	t.Run("when one of the pattern lists doesn't match", func(t *testing.T) {
+		rule := models.WorkflowRule{Workflows: []string{"deploy-*"}, Tags: []string{"v*"}}
+		require.False(t, rule.Matches(build))
+	})
+
+	t.Run("when build has no tag", func(t *testing.T) {
+		rule := models.WorkflowRule{Tags: []string{"*"}}
+		require.False(t, rule.Matches(build))
+		require.True(t, rule.Matches(models.WorkflowRuleBuild{Workflow: "deploy-staging", Tag: "v1.2"}))
+	})
+
+	t.Run("when pattern is matched across slashes", func(t *testing.T) {
+		require.False(t, models.WorkflowRule{Branches: []string{"*"}}.Matches(build))
+		require.True(t, models.WorkflowRule{Branches: []string{"**"}}.Matches(build))
+		require.True(t, models.WorkflowRule{Branches: []string{"release/**"}}.Matches(
+			models.WorkflowRuleBuild{Branch: "release/1.2/hotfix"}))
+		require.False(t, models.WorkflowRule{Branches: []string{"release/**"}}.Matches(
+			models.WorkflowRuleBuild{Branch: "feature/release"}))
+	})
+}
+
+func Test_WorkflowRules_Allows(t *testing.T) {
+	build := models.WorkflowRuleBuild{Workflow: "deploy", Branch: "feature/login"}
+
+	t.Run("when there are no rules", func(t *testing.T) {
+		require.True(t, models.WorkflowRules{}.Allows("ios", build))
+	})
+
+	t.Run("when an including rule of the platform matches", func(t *testing.T) {
+		rules := models.WorkflowRules{
+			{Platform: "ios", Workflows: []string{"archive"}},
+			{Platform: "ios", Workflows: []string{"deploy"}},
+		}
+		require.True(t, rules.Allows("ios", build))
+	})
+
+	t.Run("when no including rule of the platform matches", func(t *testing.T) {
+		rules := models.WorkflowRules{
+			{Platform: "ios", Workflows: []string{"archive"}},
+			{Platform: "android", Workflows: []string{"deploy"}},
+		}
+		require.False(t, rules.Allows("ios", build))
+		require.True(t, rules.Allows("android", build))
+	})
+
+	t.Run("when only rules of other platforms are set", func(t *testing.T) {
+		rules := models.WorkflowRules{{Platform: "android", Workflows: []string{"archive"}}}
+		require.True(t, rules.Allows("ios", build))
+	})
+
+	t.Run("when an excluding rule matches", func(t *testing.T) {
+		rules := models.WorkflowRules{
+			{Workflows: []string{"deploy"}},
+			{Exclude: true, Branches: []string{"feature/*"}},
+		}
+		require.False(t, rules.Allows("ios", build))
+		require.False(t, rules.Allows("android", build))
+	})
+
+	t.Run("when rules of iOS are set for another Apple platform", func(t *testing.T) {
+		rules := models.WorkflowRules{{Platform: "ios", Exclude: true, Branches: []string{"feature/*"}}}
+		require.False(t, rules.Allows("tvos", build))
+		require.True(t, rules.Allows("android", build))
+	})
+
+	t.Run("when rules of the Apple platform itself are set", func(t *testing.T) {
+		rules := models.WorkflowRules{{Platform: "macos", Workflows: []string{"archive"}}}
+		require.False(t, rules.Allows("macos", build))
+		require.True(t, rules.Allows("ios", build))
+	})
+
+	t.Run("when an excluding rule doesn't match", func(t *testing.T) {
+		rules := models.WorkflowRules{{Platform: "ios", Exclude: true, Branches: []string{"master"}}}
+		require.True(t, rules.Allows("ios", build))
+	})
+}
+
+func Test_WorkflowRules_AllowsAny(t *testing.T) {
+	build := models.WorkflowRuleBuild{Workflow: "deploy", Branch: "feature/x"}
+	rules := models.WorkflowRules{{Platform: "ios", Exclude: true, Branches: []string{"feature/*"}}}
+
+	t.Run("when one of the platforms is allowed", func(t *testing.T) {
+		require.True(t, rules.AllowsAny([]string{"ios", "android"}, build))
+	})
+
+	t.Run("when none of the platforms is allowed", func(t *testing.T) {
+		require.False(t, rules.AllowsAny(models.ApplePlatforms, build))
+	})
+}
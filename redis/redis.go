@@ -17,6 +17,7 @@ type Interface interface {
 	GetString(string) (string, error)
 	GetInt64(key string) (int64, error)
 	Set(string, interface{}, int) error
+	SetEX(string, interface{}, int) error
 }
 
 // Client ...
@@ -64,11 +65,13 @@ func (c *Client) Set(key string, value interface{}, ttl int) error {
 	conn := c.pool.Get()
 	_, err := conn.Do("SET", key, value)
 	if err != nil {
+		_ = conn.Close()
 		return err
 	}
 	if ttl > 0 {
 		_, err := conn.Do("EXPIRE", key, ttl)
 		if err != nil {
+			_ = conn.Close()
 			return err
 		}
 	}
@@ -76,11 +79,23 @@ func (c *Client) Set(key string, value interface{}, ttl int) error {
 	return conn.Close()
 }
 
+// SetEX sets the value with its TTL in a single command, so the key can't be left without expiry
+func (c *Client) SetEX(key string, value interface{}, ttl int) error {
+	conn := c.pool.Get()
+	_, err := conn.Do("SET", key, value, "EX", ttl)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	return conn.Close()
+}
+
 // GetString ...
 func (c *Client) GetString(key string) (string, error) {
 	conn := c.pool.Get()
 	value, err := redis.String(conn.Do("GET", key))
 	if err != nil {
+		_ = conn.Close()
 		return "", err
 	}
 	return value, conn.Close()
@@ -91,6 +106,7 @@ func (c *Client) GetInt64(key string) (int64, error) {
 	conn := c.pool.Get()
 	value, err := redis.Int64(conn.Do("GET", key))
 	if err != nil {
+		_ = conn.Close()
 		return 0, err
 	}
 	return value, conn.Close()
@@ -5,6 +5,7 @@ type Mock struct {
 	GetStringFn func(string) (string, error)
 	GetInt64Fn  func(string) (int64, error)
 	SetFn       func(string, interface{}, int) error
+	SetEXFn     func(string, interface{}, int) error
 }
 
 // GetString ...
@@ -30,3 +31,11 @@ func (m *Mock) Set(key string, value interface{}, ttl int) error {
 	}
 	return m.SetFn(key, value, ttl)
 }
+
+// SetEX ...
+func (m *Mock) SetEX(key string, value interface{}, ttl int) error {
+	if m.SetEXFn == nil {
+		panic("You have to override SetEX function in tests")
+	}
+	return m.SetEXFn(key, value, ttl)
+}
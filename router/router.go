@@ -33,6 +33,10 @@ func New(appEnv *env.AppEnv) *mux.Router {
 			path: "/provision/{app-slug}", middleware: services.AuthenticateForDeprovisioning(appEnv),
 			handler: services.DeprovisionHandler, allowedMethods: []string{"DELETE", "OPTIONS"},
 		},
+		{
+			path: "/admin/apps/{app-slug}/builds/{build-slug}/replay", middleware: services.AuthenticateForAdminAppAccess(appEnv),
+			handler: services.BuildReplayPostHandler, allowedMethods: []string{"POST", "OPTIONS"},
+		},
 		{
 			path: "/apps/{app-slug}", middleware: services.AuthorizedAppMiddleware(appEnv),
 			handler: services.AppGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
@@ -65,25 +69,73 @@ func New(appEnv *env.AppEnv) *mux.Router {
 			path: "/apps/{app-slug}/versions/{version-id}/screenshots/uploaded", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
 			handler: services.ScreenshotsUploadedPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
 		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/screenshots/order", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
+			handler: services.ScreenshotsOrderPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/copy-from/{source-version-id}", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
+			handler: services.AppVersionCopyFromPostHandler, allowedMethods: []string{"POST", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/screenshot-archives", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
+			handler: services.ScreenshotArchivePostHandler, allowedMethods: []string{"POST", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/screenshot-archives/{job-id}/uploaded", middleware: services.AuthorizedAppVersionJobStatusMiddleware(appEnv),
+			handler: services.ScreenshotArchiveUploadedPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
+		},
 		{
 			path: "/apps/{app-slug}/versions/{version-id}/screenshots/{screenshot-id}", middleware: services.AuthorizedAppVersionScreenshotMiddleware(appEnv),
 			handler: services.ScreenshotDeleteHandler, allowedMethods: []string{"DELETE", "OPTIONS"},
 		},
 		{
-			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
-			handler: services.FeatureGraphicGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
+			path: "/apps/{app-slug}/versions/{version-id}/app-previews", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
+			handler: services.AppPreviewsGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/app-previews", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
+			handler: services.AppPreviewsPostHandler, allowedMethods: []string{"POST", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/app-previews/{app-preview-id}/uploaded", middleware: services.AuthorizedAppVersionAppPreviewMiddleware(appEnv),
+			handler: services.AppPreviewUploadedPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/app-previews/{app-preview-id}", middleware: services.AuthorizedAppVersionAppPreviewMiddleware(appEnv),
+			handler: services.AppPreviewDeleteHandler, allowedMethods: []string{"DELETE", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic", middleware: services.AuthorizedAppVersionFeatureGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic", middleware: services.AuthorizedAppVersionFeatureGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicPostHandler, allowedMethods: []string{"POST", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic/uploaded", middleware: services.AuthorizedAppVersionFeatureGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicUploadedPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic", middleware: services.AuthorizedAppVersionFeatureGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicDeleteHandler, allowedMethods: []string{"DELETE", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}", middleware: services.AuthorizedAppVersionStoreGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
 		},
 		{
-			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
-			handler: services.FeatureGraphicPostHandler, allowedMethods: []string{"POST", "OPTIONS"},
+			path: "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}", middleware: services.AuthorizedAppVersionStoreGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicPostHandler, allowedMethods: []string{"POST", "OPTIONS"},
 		},
 		{
-			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic/uploaded", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
-			handler: services.FeatureGraphicUploadedPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
+			path: "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}/uploaded", middleware: services.AuthorizedAppVersionStoreGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicUploadedPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
 		},
 		{
-			path: "/apps/{app-slug}/versions/{version-id}/feature-graphic", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
-			handler: services.FeatureGraphicDeleteHandler, allowedMethods: []string{"DELETE", "OPTIONS"},
+			path: "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}", middleware: services.AuthorizedAppVersionStoreGraphicMiddleware(appEnv),
+			handler: services.StoreGraphicDeleteHandler, allowedMethods: []string{"DELETE", "OPTIONS"},
 		},
 		{
 			path: "/apps/{app-slug}/versions/{version-id}/android-config", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
@@ -93,6 +145,10 @@ func New(appEnv *env.AppEnv) *mux.Router {
 			path: "/apps/{app-slug}/versions/{version-id}/ios-config", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
 			handler: services.AppVersionIosConfigGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
 		},
+		{
+			path: "/apps/{app-slug}/builds/{build-slug}/webhook", middleware: services.AuthorizedAppMiddleware(appEnv),
+			handler: services.BuildWebhookGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
+		},
 		{
 			path: "/apps/{app-slug}/settings", middleware: services.AuthorizedAppMiddleware(appEnv),
 			handler: services.AppSettingsGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
@@ -105,10 +161,22 @@ func New(appEnv *env.AppEnv) *mux.Router {
 			path: "/apps/{app-slug}/versions/{version-id}/events", middleware: services.AuthorizedAppVersionMiddleware(appEnv),
 			handler: services.AppVersionEventsGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
 		},
+		{
+			path: "/apps/{app-slug}/events", middleware: services.AuthorizedAppMiddleware(appEnv),
+			handler: services.AppEventsGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
+		},
+		{
+			path: "/apps/{app-slug}/webhook-deliveries", middleware: services.AuthorizedAppMiddleware(appEnv),
+			handler: services.WebhookDeliveriesGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
+		},
 		{
 			path: "/confirm_email", middleware: services.AuthorizeForAppContactEmailConfirmationHandling(appEnv),
 			handler: services.AppContactConfirmPatchHandler, allowedMethods: []string{"PATCH", "OPTIONS"},
 		},
+		{
+			path: "/apps/{app-slug}/jobs/{job-id}", middleware: services.AuthorizedAppJobStatusMiddleware(appEnv),
+			handler: services.JobStatusGetHandler, allowedMethods: []string{"GET", "OPTIONS"},
+		},
 		{
 			path: "/apps/{app-slug}/contacts", middleware: services.AuthorizedAppMiddleware(appEnv),
 			handler: services.AppContactPostHandler, allowedMethods: []string{"POST", "OPTIONS"},
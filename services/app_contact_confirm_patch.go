@@ -42,13 +42,14 @@ func AppContactConfirmPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *ht
 	if env.BitriseAPI == nil {
 		return errors.New("No Bitrise API Service defined for handler")
 	}
+	bitriseAPI := env.BitriseAPI.WithContext(r.Context())
 
 	appContact, err := env.AppContactService.Find(&models.AppContact{Record: models.Record{ID: authorizedAppContactID}})
 	if err != nil {
 		return errors.Wrap(err, "SQL Error")
 	}
 
-	appDetails, err := env.BitriseAPI.GetAppDetails(appContact.App.BitriseAPIToken, appContact.App.AppSlug)
+	appDetails, err := bitriseAPI.GetAppDetails(appContact.App.BitriseAPIToken, appContact.App.AppSlug)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -35,6 +35,7 @@ func AppContactPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reque
 	if env.BitriseAPI == nil {
 		return errors.New("No Bitrise API Service defined for handler")
 	}
+	bitriseAPI := env.BitriseAPI.WithContext(r.Context())
 	if env.Mailer == nil {
 		return errors.New("No Mailer defined for handler")
 	}
@@ -68,7 +69,7 @@ func AppContactPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reque
 	if err != nil {
 		return errors.Wrap(err, "SQL Error")
 	}
-	appDetails, err := env.BitriseAPI.GetAppDetails(appContact.App.BitriseAPIToken, appContact.App.AppSlug)
+	appDetails, err := bitriseAPI.GetAppDetails(appContact.App.BitriseAPIToken, appContact.App.AppSlug)
 	if err != nil {
 		return errors.WithStack(err)
 	}
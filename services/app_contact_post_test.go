@@ -97,7 +97,7 @@ func Test_AppContactPostHandler(t *testing.T) {
 						contact.ConfirmationToken = nil
 						require.Equal(t, &models.AppContact{
 							Email: "someones@email.addr",
-							NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":false,"failed_publish":false}`),
+							NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":false,"failed_publish":false,"build_failed":false,"code_signing_expiry":false}`),
 							AppID: uuid.FromStringOrNil("548bde58-2707-4c28-9474-4f35ba0176cb"),
 							App: &models.App{
 								BitriseAPIToken: "test-api-token",
@@ -113,7 +113,7 @@ func Test_AppContactPostHandler(t *testing.T) {
 			expectedResponse: services.AppContactPostResponse{
 				Data: &models.AppContact{
 					Email: "someones@email.addr",
-					NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":false,"failed_publish":false}`),
+					NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":false,"failed_publish":false,"build_failed":false,"code_signing_expiry":false}`),
 					App: &models.App{
 						Record:          models.Record{ID: uuid.FromStringOrNil("548bde58-2707-4c28-9474-4f35ba0176cb")},
 						BitriseAPIToken: "test-api-token",
@@ -59,7 +59,7 @@ func Test_AppContactPutHandler(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			expectedResponse: services.AppContactPutResponse{
 				Data: &models.AppContact{
-					NotificationPreferencesData: json.RawMessage(`{"new_version":false,"successful_publish":false,"failed_publish":false}`),
+					NotificationPreferencesData: json.RawMessage(`{"new_version":false,"successful_publish":false,"failed_publish":false,"build_failed":false,"code_signing_expiry":false}`),
 				},
 			},
 		})
@@ -78,16 +78,16 @@ func Test_AppContactPutHandler(t *testing.T) {
 					updateFn: func(appContact *models.AppContact, whitelist []string) error {
 						notificationPreferences, err := appContact.NotificationPreferences()
 						require.NoError(t, err)
-						require.Equal(t, models.NotificationPreferences{NewVersion: true}, notificationPreferences)
+						require.Equal(t, models.NotificationPreferences{NewVersion: true, BuildFailed: true}, notificationPreferences)
 						return nil
 					},
 				},
 			},
-			requestBody:        `{"new_version":true}`,
+			requestBody:        `{"new_version":true,"build_failed":true}`,
 			expectedStatusCode: http.StatusOK,
 			expectedResponse: services.AppContactPutResponse{
 				Data: &models.AppContact{
-					NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":false,"failed_publish":false}`),
+					NotificationPreferencesData: json.RawMessage(`{"new_version":true,"successful_publish":false,"failed_publish":false,"build_failed":true,"code_signing_expiry":false}`),
 				},
 			},
 		})
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+// AppEventData ...
+type AppEventData struct {
+	models.AppEvent
+	BuildURL string `json:"build_url"`
+}
+
+// AppEventsGetResponse ...
+type AppEventsGetResponse struct {
+	Data []AppEventData `json:"data"`
+}
+
+// AppEventsGetHandler returns the activity feed of the app, the latest event first
+func AppEventsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if env.AppEventService == nil {
+		return errors.New("No App Event Service defined for handler")
+	}
+
+	appEvents, err := env.AppEventService.FindAll(&models.App{Record: models.Record{ID: authorizedAppID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	data := []AppEventData{}
+	for _, appEvent := range appEvents {
+		data = append(data, AppEventData{AppEvent: appEvent, BuildURL: appEvent.BuildURL()})
+	}
+
+	return httpresponse.RespondWithSuccess(w, AppEventsGetResponse{Data: data})
+}
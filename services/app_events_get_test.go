@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppEventsGetHandler(t *testing.T) {
+	httpMethod := "GET"
+	url := "/apps/{app-slug}/events"
+	handler := services.AppEventsGetHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppEventService"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppEventService: &testAppEventService{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppEventService: &testAppEventService{},
+		},
+	})
+
+	t.Run("ok - minimal", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppEventService: &testAppEventService{
+					findAllFn: func(*models.App) ([]models.AppEvent, error) {
+						return []models.AppEvent{}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   services.AppEventsGetResponse{Data: []services.AppEventData{}},
+		})
+	})
+
+	t.Run("ok - more complex", func(t *testing.T) {
+		testAppID := uuid.NewV4()
+		testAppEvent := models.AppEvent{
+			Status:      models.AppEventStatusBuildFailed,
+			Text:        "Build failed",
+			Workflow:    "deploy",
+			BuildSlug:   "test-build-slug",
+			BuildNumber: 28,
+		}
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env: &env.AppEnv{
+				AppEventService: &testAppEventService{
+					findAllFn: func(app *models.App) ([]models.AppEvent, error) {
+						require.Equal(t, testAppID, app.ID)
+						return []models.AppEvent{testAppEvent}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppEventsGetResponse{
+				Data: []services.AppEventData{
+					{AppEvent: testAppEvent, BuildURL: "https://app.bitrise.io/build/test-build-slug"},
+				},
+			},
+		})
+	})
+
+	t.Run("when error happens at getting app events", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppEventService: &testAppEventService{
+					findAllFn: func(*models.App) ([]models.AppEvent, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+}
@@ -10,9 +10,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// AppGetResponseData ...
+type AppGetResponseData struct {
+	*models.App
+	HeaderPalette models.HeaderPalette `json:"header_palette"`
+}
+
 // AppGetResponse ...
 type AppGetResponse struct {
-	Data *models.App `json:"data"`
+	Data AppGetResponseData `json:"data"`
 }
 
 // AppGetHandler ...
@@ -32,7 +38,11 @@ func AppGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) erro
 	case err != nil:
 		return errors.Wrap(err, "SQL Error")
 	}
+	headerPalette, err := app.HeaderPalette()
+	if err != nil {
+		return errors.WithStack(err)
+	}
 	return httpresponse.RespondWithSuccess(w, AppGetResponse{
-		Data: app,
+		Data: AppGetResponseData{App: app, HeaderPalette: headerPalette},
 	})
 }
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -47,12 +48,12 @@ func Test_AppGetHandler(t *testing.T) {
 				AppService: &testAppService{
 					findFn: func(app *models.App) (*models.App, error) {
 						require.Equal(t, app.ID.String(), "211afc15-127a-40f9-8cbe-1dadc1f86cdf")
-						return nil, nil
+						return &models.App{}, nil
 					},
 				},
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse:   services.AppGetResponse{},
+			expectedResponse:   services.AppGetResponse{Data: services.AppGetResponseData{App: &models.App{}}},
 		})
 	})
 
@@ -65,17 +66,41 @@ func Test_AppGetHandler(t *testing.T) {
 				AppService: &testAppService{
 					findFn: func(app *models.App) (*models.App, error) {
 						return &models.App{
-							AppSlug: "test_app_slug",
+							AppSlug:           "test_app_slug",
+							HeaderColor1:      "#4c2d8c",
+							HeaderColor2:      "#5a3a99",
+							HeaderPaletteData: json.RawMessage(`{"primary":"#4c2d8c","secondary":"#5a3a99","text":"#ffffff"}`),
 						}, nil
 					},
 				},
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponse: services.AppGetResponse{
-				Data: &models.App{
-					AppSlug: "test_app_slug",
+				Data: services.AppGetResponseData{
+					App: &models.App{
+						AppSlug:      "test_app_slug",
+						HeaderColor1: "#4c2d8c",
+						HeaderColor2: "#5a3a99",
+					},
+					HeaderPalette: models.HeaderPalette{Primary: "#4c2d8c", Secondary: "#5a3a99", Text: "#ffffff"},
+				},
+			},
+		})
+	})
+
+	t.Run("when header palette is invalid", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return &models.App{HeaderPaletteData: json.RawMessage(`invalid json`)}, nil
+					},
 				},
 			},
+			expectedInternalErr: "invalid character 'i' looking for beginning of value",
 		})
 	})
 
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+// AppPreviewDeleteResponse ...
+type AppPreviewDeleteResponse struct {
+	Data *models.AppPreview `json:"data"`
+}
+
+// AppPreviewDeleteHandler ...
+func AppPreviewDeleteHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppPreviewID, err := GetAuthorizedAppPreviewIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if env.AppPreviewService == nil {
+		return errors.New("No App Preview Service defined for handler")
+	}
+	if env.Storage == nil {
+		return errors.New("No Storage Provider defined for handler")
+	}
+	if env.AWS == nil {
+		return errors.New("No AWS Provider defined for handler")
+	}
+	if env.AssetService == nil {
+		return errors.New("No Asset Service defined for handler")
+	}
+
+	appPreview, err := env.AppPreviewService.Find(&models.AppPreview{Record: models.Record{ID: authorizedAppPreviewID}})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !appPreview.Uploaded && appPreview.UploadID != "" {
+		err = env.Storage.AbortMultipartUpload(appPreview.UploadAWSPath(), appPreview.UploadID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	// the object is removed only after the record doesn't reference it anymore
+	err = env.AppPreviewService.Delete(appPreview)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	if appPreview.Uploaded {
+		err = removeUploadableObject(env, appPreview.AWSPath(), appPreview.AssetID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return httpresponse.RespondWithSuccess(w, AppPreviewDeleteResponse{
+		Data: appPreview,
+	})
+}
@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppPreviewDeleteHandler(t *testing.T) {
+	httpMethod := "DELETE"
+	url := "/apps/{app-slug}/versions/{version-id}/app-previews/{app-preview-id}"
+	handler := services.AppPreviewDeleteHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppPreviewService", "Storage", "AWS", "AssetService"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppPreviewID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{},
+			Storage:           &storage.Mock{},
+			AWS:               &providers.AWSMock{},
+			AssetService:      &testAssetService{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppPreviewID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppPreviewID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{},
+			Storage:           &storage.Mock{},
+			AWS:               &providers.AWSMock{},
+			AssetService:      &testAssetService{},
+		},
+	})
+
+	testAppPreviewID := uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")
+
+	t.Run("ok - uploaded app preview gets deleted from storage", func(t *testing.T) {
+		deleteObjectCalled := false
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						require.Equal(t, testAppPreviewID, appPreview.ID)
+						appPreview.Uploaded = true
+						return appPreview, nil
+					},
+					deleteFn: func(appPreview *models.AppPreview) error {
+						require.Equal(t, testAppPreviewID, appPreview.ID)
+						return nil
+					},
+				},
+				Storage: &storage.Mock{},
+				AWS: &providers.AWSMock{
+					DeleteObjectFn: func(path string) error {
+						deleteObjectCalled = true
+						return nil
+					},
+				},
+				AssetService: &testAssetService{},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppPreviewDeleteResponse{
+				Data: &models.AppPreview{
+					Record:           models.Record{ID: testAppPreviewID},
+					UploadableObject: models.UploadableObject{Uploaded: true},
+				},
+			},
+		})
+		require.True(t, deleteObjectCalled)
+	})
+
+	t.Run("ok - stored app preview releases its asset", func(t *testing.T) {
+		testAssetID := uuid.NewV4()
+		deletedRecord := false
+		releasedAsset := false
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						appPreview.Uploaded = true
+						appPreview.AssetID = &testAssetID
+						return appPreview, nil
+					},
+					deleteFn: func(appPreview *models.AppPreview) error {
+						deletedRecord = true
+						return nil
+					},
+				},
+				Storage: &storage.Mock{},
+				AWS:     &providers.AWSMock{},
+				AssetService: &testAssetService{
+					releaseFn: func(assetID uuid.UUID, deleteObject func(*models.Asset) error) error {
+						require.True(t, deletedRecord)
+						require.Equal(t, testAssetID, assetID)
+						releasedAsset = true
+						return nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		})
+		require.True(t, releasedAsset)
+	})
+
+	t.Run("ok - unfinished multipart upload gets aborted", func(t *testing.T) {
+		abortCalled := false
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						appPreview.UploadID = "test-upload-id"
+						return appPreview, nil
+					},
+					deleteFn: func(appPreview *models.AppPreview) error {
+						return nil
+					},
+				},
+				Storage: &storage.Mock{
+					AbortMultipartUploadFn: func(key, uploadID string) error {
+						require.Equal(t, "test-upload-id", uploadID)
+						abortCalled = true
+						return nil
+					},
+				},
+				AWS:          &providers.AWSMock{},
+				AssetService: &testAssetService{},
+			},
+			expectedStatusCode: http.StatusOK,
+		})
+		require.True(t, abortCalled)
+	})
+
+	t.Run("when storage error happens", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						appPreview.Uploaded = true
+						return appPreview, nil
+					},
+					deleteFn: func(appPreview *models.AppPreview) error {
+						return nil
+					},
+				},
+				Storage: &storage.Mock{},
+				AWS: &providers.AWSMock{
+					DeleteObjectFn: func(path string) error {
+						return errors.New("SOME-AWS-ERROR")
+					},
+				},
+				AssetService: &testAssetService{},
+			},
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+
+	t.Run("when db error happens at delete", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						return appPreview, nil
+					},
+					deleteFn: func(appPreview *models.AppPreview) error {
+						return errors.New("SOME-SQL-ERROR")
+					},
+				},
+				Storage:      &storage.Mock{},
+				AWS:          &providers.AWSMock{},
+				AssetService: &testAssetService{},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+}
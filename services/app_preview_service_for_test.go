@@ -0,0 +1,46 @@
+package services_test
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+type testAppPreviewService struct {
+	batchCreateFn func([]*models.AppPreview) ([]*models.AppPreview, []error, error)
+	findFn        func(appPreview *models.AppPreview) (*models.AppPreview, error)
+	findAllFn     func(*models.AppVersion) ([]models.AppPreview, error)
+	updateFn      func(models.AppPreview, []string) ([]error, error)
+	deleteFn      func(appPreview *models.AppPreview) error
+}
+
+func (s *testAppPreviewService) BatchCreate(appPreviews []*models.AppPreview) ([]*models.AppPreview, []error, error) {
+	if s.batchCreateFn != nil {
+		return s.batchCreateFn(appPreviews)
+	}
+	panic("You have to override BatchCreate function in tests")
+}
+
+func (s *testAppPreviewService) Find(appPreview *models.AppPreview) (*models.AppPreview, error) {
+	if s.findFn != nil {
+		return s.findFn(appPreview)
+	}
+	panic("You have to override Find function in tests")
+}
+
+func (s *testAppPreviewService) FindAll(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+	if s.findAllFn != nil {
+		return s.findAllFn(appVersion)
+	}
+	panic("You have to override FindAll function in tests")
+}
+
+func (s *testAppPreviewService) Update(appPreview models.AppPreview, whitelist []string) ([]error, error) {
+	if s.updateFn != nil {
+		return s.updateFn(appPreview, whitelist)
+	}
+	panic("You have to override Update function in tests")
+}
+
+func (s *testAppPreviewService) Delete(appPreview *models.AppPreview) error {
+	if s.deleteFn != nil {
+		return s.deleteFn(appPreview)
+	}
+	panic("You have to override the Delete function in tests")
+}
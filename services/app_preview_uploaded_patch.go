@@ -0,0 +1,88 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	"github.com/bitrise-io/api-utils/httprequest"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+type appPreviewUploadedPatchParams struct {
+	Parts []storage.CompletedPart `json:"parts"`
+}
+
+// AppPreviewUploadedPatchResponse ...
+type AppPreviewUploadedPatchResponse struct {
+	Data AppPreviewData `json:"data"`
+}
+
+// AppPreviewUploadedPatchHandler ...
+func AppPreviewUploadedPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppPreviewID, err := GetAuthorizedAppPreviewIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var params appPreviewUploadedPatchParams
+	defer httprequest.BodyCloseWithErrorLog(r)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
+	}
+	if len(params.Parts) == 0 {
+		return httpresponse.RespondWithBadRequestError(w, "No uploaded parts provided")
+	}
+
+	if env.AppPreviewService == nil {
+		return errors.New("No App Preview Service defined for handler")
+	}
+	if env.Storage == nil {
+		return errors.New("No Storage Provider defined for handler")
+	}
+	if env.AWS == nil {
+		return errors.New("No AWS Provider defined for handler")
+	}
+	if env.WorkerService == nil {
+		return errors.New("No Worker Service defined for handler")
+	}
+
+	appPreview, err := env.AppPreviewService.Find(&models.AppPreview{Record: models.Record{ID: authorizedAppPreviewID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	if !appPreview.Uploaded {
+		err = env.Storage.CompleteMultipartUpload(appPreview.UploadAWSPath(), appPreview.UploadID, params.Parts)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		appPreview.Uploaded = true
+		verrs, err := env.AppPreviewService.Update(*appPreview, []string{"Uploaded"})
+		if len(verrs) > 0 {
+			return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+
+		if err := env.WorkerService.EnqueueStoreUploadedAppPreview(appPreview.ID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	presignedURL, err := env.AWS.GeneratePresignedGETURL(appPreview.AWSPath(), presignedURLExpirationInterval)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return httpresponse.RespondWithSuccess(w, AppPreviewUploadedPatchResponse{
+		Data: AppPreviewData{
+			AppPreview:  *appPreview,
+			DownloadURL: presignedURL,
+		},
+	})
+}
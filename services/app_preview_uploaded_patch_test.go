@@ -0,0 +1,248 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppPreviewUploadedPatchHandler(t *testing.T) {
+	httpMethod := "PATCH"
+	url := "/apps/{app-slug}/versions/{version-id}/app-previews/{app-preview-id}/uploaded"
+	handler := services.AppPreviewUploadedPatchHandler
+
+	validRequestBody := `{"parts":[{"part_number":1,"etag":"etag-1"},{"part_number":2,"etag":"etag-2"}]}`
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppPreviewService", "Storage", "AWS", "WorkerService"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppPreviewID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{},
+			Storage:           &storage.Mock{},
+			AWS:               &providers.AWSMock{},
+			WorkerService:     &testWorkerService{},
+		},
+		requestBody: validRequestBody,
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppPreviewID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppPreviewID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{},
+			Storage:           &storage.Mock{},
+			AWS:               &providers.AWSMock{},
+			WorkerService:     &testWorkerService{},
+		},
+		requestBody: validRequestBody,
+	})
+
+	testAppPreviewID := uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")
+	testAppVersion := models.AppVersion{
+		Record: models.Record{ID: uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")},
+		App:    models.App{AppSlug: "test-app-slug"},
+	}
+	expectedPath := "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/app_previews/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.mp4"
+
+	t.Run("ok", func(t *testing.T) {
+		enqueued := false
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						require.Equal(t, testAppPreviewID, appPreview.ID)
+						appPreview.Filename = "preview.mp4"
+						appPreview.DeviceType = "iPhone XS Max"
+						appPreview.ScreenSize = "6.5 inch"
+						appPreview.UploadID = "test-upload-id"
+						appPreview.AppVersion = testAppVersion
+						return appPreview, nil
+					},
+					updateFn: func(appPreview models.AppPreview, whitelist []string) ([]error, error) {
+						require.Equal(t, []string{"Uploaded"}, whitelist)
+						require.True(t, appPreview.Uploaded)
+						return nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CompleteMultipartUploadFn: func(key, uploadID string, parts []storage.CompletedPart) error {
+						require.Equal(t, expectedPath, key)
+						require.Equal(t, "test-upload-id", uploadID)
+						require.Equal(t, []storage.CompletedPart{
+							storage.CompletedPart{PartNumber: 1, ETag: "etag-1"},
+							storage.CompletedPart{PartNumber: 2, ETag: "etag-2"},
+						}, parts)
+						return nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueStoreUploadedAppPreviewFn: func(appPreviewID uuid.UUID) error {
+						require.Equal(t, testAppPreviewID, appPreviewID)
+						enqueued = true
+						return nil
+					},
+				},
+			},
+			requestBody:        validRequestBody,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppPreviewUploadedPatchResponse{
+				Data: services.AppPreviewData{
+					AppPreview: models.AppPreview{
+						Record:           models.Record{ID: testAppPreviewID},
+						UploadableObject: models.UploadableObject{Filename: "preview.mp4", Uploaded: true},
+						DeviceType:       "iPhone XS Max",
+						ScreenSize:       "6.5 inch",
+					},
+					DownloadURL: "http://presigned.aws.url/" + expectedPath,
+				},
+			},
+		})
+		require.True(t, enqueued)
+	})
+
+	t.Run("when app preview is already uploaded", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						appPreview.Uploaded = true
+						return appPreview, nil
+					},
+				},
+				Storage: &storage.Mock{},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "http://presigned.aws.url", nil
+					},
+				},
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:        validRequestBody,
+			expectedStatusCode: http.StatusOK,
+		})
+	})
+
+	t.Run("when no parts are provided", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{},
+				Storage:           &storage.Mock{},
+				AWS:               &providers.AWSMock{},
+				WorkerService:     &testWorkerService{},
+			},
+			requestBody:        `{"parts":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "No uploaded parts provided"},
+		})
+	})
+
+	t.Run("when completing the multipart upload fails", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						return appPreview, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CompleteMultipartUploadFn: func(key, uploadID string, parts []storage.CompletedPart) error {
+						return errors.New("SOME-AWS-ERROR")
+					},
+				},
+				AWS:           &providers.AWSMock{},
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:         validRequestBody,
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+
+	t.Run("when db error happens at update", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						return appPreview, nil
+					},
+					updateFn: func(appPreview models.AppPreview, whitelist []string) ([]error, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				Storage: &storage.Mock{
+					CompleteMultipartUploadFn: func(key, uploadID string, parts []storage.CompletedPart) error {
+						return nil
+					},
+				},
+				AWS:           &providers.AWSMock{},
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:         validRequestBody,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when enqueueing the storing of the upload fails", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppPreviewID: testAppPreviewID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+						return appPreview, nil
+					},
+					updateFn: func(appPreview models.AppPreview, whitelist []string) ([]error, error) {
+						return nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CompleteMultipartUploadFn: func(key, uploadID string, parts []storage.CompletedPart) error {
+						return nil
+					},
+				},
+				AWS: &providers.AWSMock{},
+				WorkerService: &testWorkerService{
+					enqueueStoreUploadedAppPreviewFn: func(appPreviewID uuid.UUID) error {
+						return errors.New("SOME-WORKER-ERROR")
+					},
+				},
+			},
+			requestBody:         validRequestBody,
+			expectedInternalErr: "SOME-WORKER-ERROR",
+		})
+	})
+}
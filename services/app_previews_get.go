@@ -0,0 +1,65 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/pkg/errors"
+)
+
+// AppPreviewData ...
+type AppPreviewData struct {
+	models.AppPreview
+	DownloadURL string       `json:"download_url,omitempty"`
+	UploadParts []UploadPart `json:"upload_parts,omitempty"`
+}
+
+// AppPreviewsGetResponse ...
+type AppPreviewsGetResponse struct {
+	Data []AppPreviewData `json:"data"`
+}
+
+// AppPreviewsGetHandler ...
+func AppPreviewsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if env.AppPreviewService == nil {
+		return errors.New("No App Preview Service defined for handler")
+	}
+
+	appPreviews, err := env.AppPreviewService.FindAll(
+		&models.AppVersion{Record: models.Record{ID: authorizedAppVersionID}},
+	)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	if env.AWS == nil {
+		return errors.New("No AWS Provider defined for handler")
+	}
+	responseData, err := newAppPreviewGetResponseData(appPreviews, env.AWS)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return httpresponse.RespondWithSuccess(w, AppPreviewsGetResponse{
+		Data: responseData,
+	})
+}
+
+func newAppPreviewGetResponseData(appPreviews []models.AppPreview, awsProvider providers.AWSInterface) ([]AppPreviewData, error) {
+	data := []AppPreviewData{}
+	for _, appPreview := range appPreviews {
+		presignedURL, err := awsProvider.GeneratePresignedGETURL(appPreview.AWSPath(), presignedURLExpirationInterval)
+		if err != nil {
+			return []AppPreviewData{}, errors.WithStack(err)
+		}
+		data = append(data, AppPreviewData{AppPreview: appPreview, DownloadURL: presignedURL})
+	}
+	return data, nil
+}
@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppPreviewsGetHandler(t *testing.T) {
+	httpMethod := "GET"
+	url := "/apps/{app-slug}/versions/{version-id}/app-previews"
+	handler := services.AppPreviewsGetHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppPreviewService", "AWS"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{
+				findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+					return []models.AppPreview{}, nil
+				},
+			},
+			AWS: &providers.AWSMock{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{},
+			AWS:               &providers.AWSMock{},
+		},
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+		testAppPreview := models.AppPreview{
+			Record:           models.Record{ID: uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")},
+			UploadableObject: models.UploadableObject{Filename: "preview.mov", Uploaded: true},
+			DeviceType:       "iPad Pro",
+			ScreenSize:       "12.9 inch",
+			AppVersion: models.AppVersion{
+				Record: models.Record{ID: testAppVersionID},
+				App:    models.App{AppSlug: "test-app-slug"},
+			},
+		}
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						require.Equal(t, testAppVersionID, appVersion.ID)
+						return []models.AppPreview{testAppPreview}, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppPreviewsGetResponse{
+				Data: []services.AppPreviewData{
+					services.AppPreviewData{
+						AppPreview:  testAppPreview,
+						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/app_previews/iPad Pro (12.9 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.mov",
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("when db error happens", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when presigned URL generation fails", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{models.AppPreview{}}, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", errors.New("SOME-AWS-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
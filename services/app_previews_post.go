@@ -0,0 +1,112 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/dataservices"
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	"github.com/bitrise-io/api-utils/httprequest"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+type appPreviewsPostParamsElement struct {
+	Filename   string `json:"filename"`
+	Filesize   int64  `json:"filesize"`
+	DeviceType string `json:"device_type"`
+	ScreenSize string `json:"screen_size"`
+	Locale     string `json:"locale"`
+}
+
+type appPreviewsPostParams struct {
+	AppPreviews []appPreviewsPostParamsElement `json:"app_previews"`
+}
+
+// AppPreviewsPostResponse ...
+type AppPreviewsPostResponse struct {
+	Data []AppPreviewData `json:"data"`
+}
+
+// AppPreviewsPostHandler ...
+func AppPreviewsPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var params appPreviewsPostParams
+	defer httprequest.BodyCloseWithErrorLog(r)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
+	}
+
+	if env.AppPreviewService == nil {
+		return errors.New("No App Preview Service defined for handler")
+	}
+	if env.Storage == nil {
+		return errors.New("No Storage Provider defined for handler")
+	}
+
+	createdAppPreviews, verrs, err := env.AppPreviewService.BatchCreate(appPreviewCreateParamsFromRequestParams(params.AppPreviews, authorizedAppVersionID))
+	if len(verrs) > 0 {
+		return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+	}
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	responseData, err := newAppPreviewPostResponseData(createdAppPreviews, env.AppPreviewService, env.Storage)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return httpresponse.RespondWithSuccess(w, AppPreviewsPostResponse{
+		Data: responseData,
+	})
+}
+
+func appPreviewCreateParamsFromRequestParams(params []appPreviewsPostParamsElement, appVersionID uuid.UUID) []*models.AppPreview {
+	var createParams []*models.AppPreview
+	for _, param := range params {
+		createParams = append(createParams, &models.AppPreview{
+			AppVersionID: appVersionID,
+			UploadableObject: models.UploadableObject{
+				Filename: param.Filename,
+				Filesize: param.Filesize,
+			},
+			DeviceType: param.DeviceType,
+			ScreenSize: param.ScreenSize,
+			Locale:     param.Locale,
+		})
+	}
+	return createParams
+}
+
+func newAppPreviewPostResponseData(appPreviews []*models.AppPreview, appPreviewService dataservices.AppPreviewService, storageProvider storage.Interface) ([]AppPreviewData, error) {
+	data := []AppPreviewData{}
+	for _, appPreview := range appPreviews {
+		uploadID, err := storageProvider.CreateMultipartUpload(appPreview.UploadAWSPath())
+		if err != nil {
+			return []AppPreviewData{}, errors.WithStack(err)
+		}
+		appPreview.UploadID = uploadID
+		verrs, err := appPreviewService.Update(*appPreview, []string{"UploadID"})
+		if len(verrs) > 0 {
+			return []AppPreviewData{}, errors.Errorf("Validation error: %#v", verrs)
+		}
+		if err != nil {
+			return []AppPreviewData{}, errors.Wrap(err, "SQL Error")
+		}
+
+		uploadParts, err := presignUploadParts(storageProvider, appPreview.UploadAWSPath(), uploadID, appPreview.Filesize)
+		if err != nil {
+			return []AppPreviewData{}, errors.WithStack(err)
+		}
+		data = append(data, AppPreviewData{AppPreview: *appPreview, UploadParts: uploadParts})
+	}
+	return data, nil
+}
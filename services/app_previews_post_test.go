@@ -0,0 +1,223 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppPreviewsPostHandler(t *testing.T) {
+	httpMethod := "POST"
+	url := "/apps/{app-slug}/versions/{version-id}/app-previews"
+	handler := services.AppPreviewsPostHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppPreviewService", "Storage"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{},
+			Storage:           &storage.Mock{},
+		},
+		requestBody: `{}`,
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppPreviewService: &testAppPreviewService{},
+			Storage:           &storage.Mock{},
+		},
+		requestBody: `{}`,
+	})
+
+	t.Run("ok - minimal", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					batchCreateFn: func(appPreviews []*models.AppPreview) ([]*models.AppPreview, []error, error) {
+						return nil, nil, nil
+					},
+				},
+				Storage: &storage.Mock{},
+			},
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppPreviewsPostResponse{
+				Data: []services.AppPreviewData{},
+			},
+		})
+	})
+
+	t.Run("ok - more complex", func(t *testing.T) {
+		testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+		testAppPreviewID := uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")
+		expectedPath := "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/app_previews/en-US/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.mp4"
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					batchCreateFn: func(appPreviews []*models.AppPreview) ([]*models.AppPreview, []error, error) {
+						require.Equal(t, &models.AppPreview{
+							AppVersionID: testAppVersionID,
+							UploadableObject: models.UploadableObject{
+								Filename: "preview.mp4",
+								Filesize: storage.MultipartPartByteSize + 1,
+							},
+							DeviceType: "iPhone XS Max",
+							ScreenSize: "6.5 inch",
+							Locale:     "en-US",
+						}, appPreviews[0])
+
+						return []*models.AppPreview{
+							&models.AppPreview{
+								Record: models.Record{ID: testAppPreviewID},
+								UploadableObject: models.UploadableObject{
+									Filename: "preview.mp4",
+									Filesize: storage.MultipartPartByteSize + 1,
+								},
+								DeviceType: "iPhone XS Max",
+								ScreenSize: "6.5 inch",
+								Locale:     "en-US",
+								AppVersion: models.AppVersion{
+									Record: models.Record{ID: testAppVersionID},
+									App:    models.App{AppSlug: "test-app-slug"},
+								},
+							},
+						}, nil, nil
+					},
+					updateFn: func(appPreview models.AppPreview, whitelist []string) ([]error, error) {
+						require.Equal(t, []string{"UploadID"}, whitelist)
+						require.Equal(t, "test-upload-id", appPreview.UploadID)
+						return nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CreateMultipartUploadFn: func(key string) (string, error) {
+						require.Equal(t, expectedPath, key)
+						return "test-upload-id", nil
+					},
+					GeneratePresignedUploadPartURLFn: func(key, uploadID string, partNumber int64, expiresIn time.Duration) (string, error) {
+						require.Equal(t, "test-upload-id", uploadID)
+						return fmt.Sprintf("http://presigned.aws.url/%s?partNumber=%d", key, partNumber), nil
+					},
+				},
+			},
+			requestBody:        fmt.Sprintf(`{"app_previews":[{"filename":"preview.mp4","filesize":%d,"device_type":"iPhone XS Max","screen_size":"6.5 inch","locale":"en-US"}]}`, storage.MultipartPartByteSize+1),
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppPreviewsPostResponse{
+				Data: []services.AppPreviewData{
+					services.AppPreviewData{
+						AppPreview: models.AppPreview{
+							Record: models.Record{ID: testAppPreviewID},
+							UploadableObject: models.UploadableObject{
+								Filename: "preview.mp4",
+								Filesize: storage.MultipartPartByteSize + 1,
+							},
+							DeviceType: "iPhone XS Max",
+							ScreenSize: "6.5 inch",
+							Locale:     "en-US",
+						},
+						UploadParts: []services.UploadPart{
+							services.UploadPart{PartNumber: 1, UploadURL: "http://presigned.aws.url/" + expectedPath + "?partNumber=1"},
+							services.UploadPart{PartNumber: 2, UploadURL: "http://presigned.aws.url/" + expectedPath + "?partNumber=2"},
+						},
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("when request body is not a valid JSON", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{},
+				Storage:           &storage.Mock{},
+			},
+			requestBody:        `invalid JSON`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid request body, JSON decode failed"},
+		})
+	})
+
+	t.Run("when validation error happens", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					batchCreateFn: func(appPreviews []*models.AppPreview) ([]*models.AppPreview, []error, error) {
+						return nil, []error{errors.New("SOME-VALIDATION-ERROR")}, nil
+					},
+				},
+				Storage: &storage.Mock{},
+			},
+			requestBody:        `{"app_previews":[{"filename":"preview.avi"}]}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   httpresponse.ValidationErrorRespModel{Message: "Unprocessable Entity", Errors: []string{"SOME-VALIDATION-ERROR"}},
+		})
+	})
+
+	t.Run("when db error happens", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					batchCreateFn: func(appPreviews []*models.AppPreview) ([]*models.AppPreview, []error, error) {
+						return nil, nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				Storage: &storage.Mock{},
+			},
+			requestBody:         `{}`,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when creating the multipart upload fails", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppPreviewService: &testAppPreviewService{
+					batchCreateFn: func(appPreviews []*models.AppPreview) ([]*models.AppPreview, []error, error) {
+						return []*models.AppPreview{&models.AppPreview{}}, nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CreateMultipartUploadFn: func(key string) (string, error) {
+						return "", errors.New("SOME-AWS-ERROR")
+					},
+				},
+			},
+			requestBody:         `{}`,
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
@@ -16,6 +16,7 @@ type IosSettingsData struct {
 	models.IosSettings
 	AvailableProvisioningProfiles  []bitrise.ProvisioningProfile `json:"available_provisioning_profiles"`
 	AvailableCodeSigningIdentities []bitrise.CodeSigningIdentity `json:"available_code_signing_identities"`
+	CodeSigningExpiry              models.CodeSigningExpiry      `json:"code_signing_expiry"`
 }
 
 // AndroidSettingsData ...
@@ -28,9 +29,11 @@ type AndroidSettingsData struct {
 // AppSettingsGetResponseData ...
 type AppSettingsGetResponseData struct {
 	*models.AppSettings
-	ProjectType     string               `json:"project_type"`
-	IosSettings     *IosSettingsData     `json:"ios_settings,omitempty"`
-	AndroidSettings *AndroidSettingsData `json:"android_settings,omitempty"`
+	ProjectType       string                   `json:"project_type"`
+	IosSettings       *IosSettingsData         `json:"ios_settings,omitempty"`
+	AndroidSettings   *AndroidSettingsData     `json:"android_settings,omitempty"`
+	RetentionSettings models.RetentionSettings `json:"retention_settings"`
+	WorkflowRules     models.WorkflowRules     `json:"workflow_rules"`
 }
 
 // AppSettingsGetResponse ...
@@ -59,8 +62,15 @@ func AppSettingsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reque
 	if env.BitriseAPI == nil {
 		return errors.New("No Bitrise API Service defined for handler")
 	}
+	// a no-cache request refetches the code signing files, e.g. right after uploading one on Bitrise
+	if cache, ok := env.BitriseAPI.(bitrise.CacheInvalidator); ok && r.Header.Get("Cache-Control") == "no-cache" {
+		if err := cache.InvalidateApp(appSettings.App.AppSlug); err != nil {
+			return errors.Wrap(err, "Failed to invalidate Bitrise API cache")
+		}
+	}
+	bitriseAPI := env.BitriseAPI.WithContext(r.Context())
 
-	appDetails, err := env.BitriseAPI.GetAppDetails(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
+	appDetails, err := bitriseAPI.GetAppDetails(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
 	if err != nil {
 		return errors.Wrap(err, "Failed to fetch app details")
 	}
@@ -68,35 +78,46 @@ func AppSettingsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reque
 	var androidSettingsData *AndroidSettingsData
 
 	if appDetails.ProjectType != "android" {
-		iosSettingsData, err = makeIosSettingsData(env, appSettings)
+		iosSettingsData, err = makeIosSettingsData(bitriseAPI, appSettings)
 		if err != nil {
 			return errors.WithStack(err)
 		}
 	}
 
 	if appDetails.ProjectType != "ios" {
-		androidSettingsData, err = makeAndroidSettingsData(env, appSettings)
+		androidSettingsData, err = makeAndroidSettingsData(bitriseAPI, appSettings)
 		if err != nil {
 			return errors.WithStack(err)
 		}
 	}
 
+	retentionSettings, err := appSettings.RetentionSettings()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	workflowRules, err := appSettings.WorkflowRules()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	return httpresponse.RespondWithSuccess(w, AppSettingsGetResponse{
 		Data: AppSettingsGetResponseData{
-			AppSettings:     appSettings,
-			ProjectType:     appDetails.ProjectType,
-			IosSettings:     iosSettingsData,
-			AndroidSettings: androidSettingsData,
+			AppSettings:       appSettings,
+			ProjectType:       appDetails.ProjectType,
+			IosSettings:       iosSettingsData,
+			AndroidSettings:   androidSettingsData,
+			RetentionSettings: retentionSettings,
+			WorkflowRules:     workflowRules,
 		},
 	})
 }
 
-func makeIosSettingsData(env *env.AppEnv, appSettings *models.AppSettings) (*IosSettingsData, error) {
-	provisioningProfiles, err := env.BitriseAPI.GetProvisioningProfiles(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
+func makeIosSettingsData(bitriseAPI bitrise.APIInterface, appSettings *models.AppSettings) (*IosSettingsData, error) {
+	provisioningProfiles, err := bitriseAPI.GetProvisioningProfiles(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to fetch provisioning profiles")
 	}
-	codeSigningIdentities, err := env.BitriseAPI.GetCodeSigningIdentities(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
+	codeSigningIdentities, err := bitriseAPI.GetCodeSigningIdentities(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to fetch code signing identities")
 	}
@@ -104,19 +125,24 @@ func makeIosSettingsData(env *env.AppEnv, appSettings *models.AppSettings) (*Ios
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	codeSigningExpiry, err := appSettings.CodeSigningExpiry()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	return &IosSettingsData{
 		IosSettings:                    iosSettings,
 		AvailableProvisioningProfiles:  provisioningProfiles,
 		AvailableCodeSigningIdentities: codeSigningIdentities,
+		CodeSigningExpiry:              codeSigningExpiry,
 	}, nil
 }
 
-func makeAndroidSettingsData(env *env.AppEnv, appSettings *models.AppSettings) (*AndroidSettingsData, error) {
-	androidKeyStoreFiles, err := env.BitriseAPI.GetAndroidKeystoreFiles(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
+func makeAndroidSettingsData(bitriseAPI bitrise.APIInterface, appSettings *models.AppSettings) (*AndroidSettingsData, error) {
+	androidKeyStoreFiles, err := bitriseAPI.GetAndroidKeystoreFiles(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to fetch android keystore files")
 	}
-	serviceAccountfiles, err := env.BitriseAPI.GetServiceAccountFiles(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
+	serviceAccountfiles, err := bitriseAPI.GetServiceAccountFiles(appSettings.App.BitriseAPIToken, appSettings.App.AppSlug)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to fetch service account files")
 	}
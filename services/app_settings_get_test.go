@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/bitrise-io/addons-ship-backend/bitrise"
 	"github.com/bitrise-io/addons-ship-backend/env"
@@ -114,6 +115,7 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 					IosSettings:     &services.IosSettingsData{},
 					AndroidSettings: &services.AndroidSettingsData{},
 					ProjectType:     "other",
+					WorkflowRules:   models.WorkflowRules{},
 				},
 			},
 		})
@@ -187,7 +189,8 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 					AppSettings: &models.AppSettings{
 						AppID: testAppID,
 					},
-					ProjectType: "other",
+					ProjectType:   "other",
+					WorkflowRules: models.WorkflowRules{},
 					IosSettings: &services.IosSettingsData{
 						IosSettings: expectedIosSettingsModel,
 						AvailableProvisioningProfiles: []bitrise.ProvisioningProfile{
@@ -274,8 +277,9 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 					AppSettings: &models.AppSettings{
 						AppID: testAppID,
 					},
-					ProjectType: "android",
-					IosSettings: nil,
+					ProjectType:   "android",
+					WorkflowRules: models.WorkflowRules{},
+					IosSettings:   nil,
 					AndroidSettings: &services.AndroidSettingsData{
 						AndroidSettings: expectedAndroidSettingsModel,
 						AvailableKeystoreFiles: []bitrise.AndroidKeystoreFile{
@@ -294,6 +298,18 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 		expectedIosSettingsModel := models.IosSettings{AppSKU: "2019061"}
 		expectedIosSettings, err := json.Marshal(expectedIosSettingsModel)
 		require.NoError(t, err)
+		expectedCodeSigningExpiryModel := models.CodeSigningExpiry{
+			ProvisioningProfiles: []models.CodeSigningFileExpiry{
+				models.CodeSigningFileExpiry{
+					Slug:       "prov-profile-slug",
+					Filename:   "provision-profile.provisionprofile",
+					ExpireDate: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			CheckedAt: time.Date(2019, time.November, 14, 4, 0, 0, 0, time.UTC),
+		}
+		expectedCodeSigningExpiry, err := json.Marshal(expectedCodeSigningExpiryModel)
+		require.NoError(t, err)
 		expectedAndroidSettingsModel := models.AndroidSettings{Track: "2019062"}
 		expectedAndroidSettings, err := json.Marshal(expectedAndroidSettingsModel)
 		require.NoError(t, err)
@@ -307,9 +323,10 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
 						require.Equal(t, appSettings.AppID, testAppID)
 						return &models.AppSettings{
-							App:                 &models.App{AppSlug: testAppSlug, BitriseAPIToken: testAppApiToken},
-							IosSettingsData:     expectedIosSettings,
-							AndroidSettingsData: expectedAndroidSettings,
+							App:                   &models.App{AppSlug: testAppSlug, BitriseAPIToken: testAppApiToken},
+							IosSettingsData:       expectedIosSettings,
+							AndroidSettingsData:   expectedAndroidSettings,
+							CodeSigningExpiryData: expectedCodeSigningExpiry,
 						}, nil
 					},
 				},
@@ -353,7 +370,8 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 					AppSettings: &models.AppSettings{
 						AppID: testAppID,
 					},
-					ProjectType: "ios",
+					ProjectType:   "ios",
+					WorkflowRules: models.WorkflowRules{},
 					IosSettings: &services.IosSettingsData{
 						IosSettings: expectedIosSettingsModel,
 						AvailableProvisioningProfiles: []bitrise.ProvisioningProfile{
@@ -362,6 +380,7 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 						AvailableCodeSigningIdentities: []bitrise.CodeSigningIdentity{
 							bitrise.CodeSigningIdentity{Filename: "code-signing-id.cert", Slug: "code-signing-slug"},
 						},
+						CodeSigningExpiry: expectedCodeSigningExpiryModel,
 					},
 					AndroidSettings: nil,
 				},
@@ -369,6 +388,68 @@ func Test_AppSettingsGetHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("ok - when cache is bypassed", func(t *testing.T) {
+		invalidated := false
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			requestHeaders: map[string]string{"Cache-Control": "no-cache"},
+			env: &env.AppEnv{
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						return &models.AppSettings{
+							App:                 &models.App{AppSlug: testAppSlug, BitriseAPIToken: testAppApiToken},
+							IosSettingsData:     json.RawMessage(`{}`),
+							AndroidSettingsData: json.RawMessage(`{}`),
+						}, nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					invalidateAppFn: func(appSlug string) error {
+						require.Equal(t, testAppSlug, appSlug)
+						invalidated = true
+						return nil
+					},
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						require.True(t, invalidated)
+						return &bitrise.AppDetails{Title: "Two Brothers", ProjectType: "android"}, nil
+					},
+					getAndroidKeystoreFilesFn: func(apiToken, appSlug string) ([]bitrise.AndroidKeystoreFile, error) {
+						return nil, nil
+					},
+					getServiceAccountFilesFn: func(apiToken, appSlug string) ([]bitrise.GenericProjectFile, error) {
+						return nil, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		})
+		require.True(t, invalidated)
+	})
+
+	t.Run("when failed to invalidate the cache", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			requestHeaders: map[string]string{"Cache-Control": "no-cache"},
+			env: &env.AppEnv{
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						return &models.AppSettings{App: &models.App{AppSlug: testAppSlug}}, nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					invalidateAppFn: func(appSlug string) error {
+						return errors.New("SOME-REDIS-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "Failed to invalidate Bitrise API cache: SOME-REDIS-ERROR",
+		})
+	})
+
 	t.Run("when app settings not found", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
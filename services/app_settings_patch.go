@@ -14,17 +14,19 @@ import (
 
 // AppSettingsPatchParams ...
 type AppSettingsPatchParams struct {
-	IosSettings     models.IosSettings     `json:"ios_settings"`
-	AndroidSettings models.AndroidSettings `json:"android_settings"`
-	IosWorkflow     string                 `json:"ios_workflow"`
-	AndroidWorkflow string                 `json:"android_workflow"`
+	IosSettings       models.IosSettings        `json:"ios_settings"`
+	AndroidSettings   models.AndroidSettings    `json:"android_settings"`
+	RetentionSettings *models.RetentionSettings `json:"retention_settings"`
+	WorkflowRules     *models.WorkflowRules     `json:"workflow_rules"`
 }
 
 // AppSettingsPatchResponseData ...
 type AppSettingsPatchResponseData struct {
 	*models.AppSettings
-	IosSettings     models.IosSettings     `json:"ios_settings"`
-	AndroidSettings models.AndroidSettings `json:"android_settings"`
+	IosSettings       models.IosSettings       `json:"ios_settings"`
+	AndroidSettings   models.AndroidSettings   `json:"android_settings"`
+	RetentionSettings models.RetentionSettings `json:"retention_settings"`
+	WorkflowRules     models.WorkflowRules     `json:"workflow_rules"`
 }
 
 // AppSettingsPatchResponse ...
@@ -97,10 +99,22 @@ func prepareAppSettingsToUpdate(appSettingsToUpdate *models.AppSettings, params
 		appSettingsToUpdate.AndroidSettingsData = androidSettings
 		updateWhiteList = append(updateWhiteList, "AndroidSettingsData")
 	}
-
-	appSettingsToUpdate.IosWorkflow = params.IosWorkflow
-	appSettingsToUpdate.AndroidWorkflow = params.AndroidWorkflow
-	updateWhiteList = append(updateWhiteList, "IosWorkflow", "AndroidWorkflow")
+	if params.RetentionSettings != nil {
+		retentionSettings, err := json.Marshal(params.RetentionSettings)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		appSettingsToUpdate.RetentionSettingsData = retentionSettings
+		updateWhiteList = append(updateWhiteList, "RetentionSettingsData")
+	}
+	if params.WorkflowRules != nil {
+		workflowRules, err := json.Marshal(params.WorkflowRules)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		appSettingsToUpdate.WorkflowRulesData = workflowRules
+		updateWhiteList = append(updateWhiteList, "WorkflowRulesData")
+	}
 
 	return appSettingsToUpdate, updateWhiteList, nil
 }
@@ -114,9 +128,19 @@ func newAppSettingsPatchResponse(appSettings *models.AppSettings) (AppSettingsPa
 	if err != nil {
 		return AppSettingsPatchResponseData{}, err
 	}
+	retentionSettings, err := appSettings.RetentionSettings()
+	if err != nil {
+		return AppSettingsPatchResponseData{}, err
+	}
+	workflowRules, err := appSettings.WorkflowRules()
+	if err != nil {
+		return AppSettingsPatchResponseData{}, err
+	}
 	return AppSettingsPatchResponseData{
-		AppSettings:     appSettings,
-		IosSettings:     iosSettings,
-		AndroidSettings: androidSettings,
+		AppSettings:       appSettings,
+		IosSettings:       iosSettings,
+		AndroidSettings:   androidSettings,
+		RetentionSettings: retentionSettings,
+		WorkflowRules:     workflowRules,
 	}, nil
 }
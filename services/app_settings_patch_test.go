@@ -67,18 +67,24 @@ func Test_AppSettingsPatchHandler(t *testing.T) {
 			requestBody:        `{}`,
 			expectedStatusCode: http.StatusOK,
 			expectedResponse: services.AppSettingsPatchResponse{
-				Data: services.AppSettingsPatchResponseData{AppSettings: &models.AppSettings{}},
+				Data: services.AppSettingsPatchResponseData{AppSettings: &models.AppSettings{}, WorkflowRules: models.WorkflowRules{}},
 			},
 		})
 	})
 
 	t.Run("ok - more complex", func(t *testing.T) {
-		expectedIosSettingsModel := models.IosSettings{AppSKU: "2019061"}
+		expectedIosSettingsModel := models.IosSettings{AppSKU: "2019061", TrackedSchemes: []string{"App"}}
 		expectedIosSettings, err := json.Marshal(expectedIosSettingsModel)
 		require.NoError(t, err)
 		expectedAndroidSettingsModel := models.AndroidSettings{Track: "2019062"}
 		expectedAndroidSettings, err := json.Marshal(expectedAndroidSettingsModel)
 		require.NoError(t, err)
+		expectedWorkflowRulesModel := models.WorkflowRules{
+			{Platform: "ios", Workflows: []string{"ios-deploy"}},
+			{Platform: "android", Exclude: true, Branches: []string{"feature/*"}},
+		}
+		expectedWorkflowRules, err := json.Marshal(expectedWorkflowRulesModel)
+		require.NoError(t, err)
 
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
@@ -96,23 +102,56 @@ func Test_AppSettingsPatchHandler(t *testing.T) {
 						require.Equal(t, testAppID, appSettings.AppID)
 						require.Equal(t, expectedIosSettings, appSettings.IosSettingsData)
 						require.Equal(t, expectedAndroidSettings, appSettings.AndroidSettingsData)
-						require.Equal(t, "ios-deploy", appSettings.IosWorkflow)
-						require.Equal(t, "android-deploy", appSettings.AndroidWorkflow)
+						require.Equal(t, json.RawMessage(expectedWorkflowRules), appSettings.WorkflowRulesData)
+						require.Equal(t, []string{"IosSettingsData", "AndroidSettingsData", "WorkflowRulesData"}, whitelist)
 						return nil, nil
 					},
 				},
 			},
-			requestBody:        `{"ios_settings":{"app_sku":"2019061"},"android_settings":{"track":"2019062"},"ios_workflow":"ios-deploy","android_workflow":"android-deploy"}`,
+			requestBody:        `{"ios_settings":{"app_sku":"2019061","tracked_schemes":["App"]},"android_settings":{"track":"2019062"},"workflow_rules":[{"platform":"ios","workflows":["ios-deploy"]},{"platform":"android","exclude":true,"branches":["feature/*"]}]}`,
 			expectedStatusCode: http.StatusOK,
 			expectedResponse: services.AppSettingsPatchResponse{
 				Data: services.AppSettingsPatchResponseData{
-					AppSettings: &models.AppSettings{
-						AppID:           testAppID,
-						IosWorkflow:     "ios-deploy",
-						AndroidWorkflow: "android-deploy",
-					},
+					AppSettings:     &models.AppSettings{AppID: testAppID},
 					IosSettings:     expectedIosSettingsModel,
 					AndroidSettings: expectedAndroidSettingsModel,
+					WorkflowRules:   expectedWorkflowRulesModel,
+				},
+			},
+		})
+	})
+
+	t.Run("ok - retention settings", func(t *testing.T) {
+		expectedRetentionSettingsModel := models.RetentionSettings{KeepLastVersions: 10, KeepPublished: true}
+		expectedRetentionSettings, err := json.Marshal(expectedRetentionSettingsModel)
+		require.NoError(t, err)
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env: &env.AppEnv{
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						appSettings.IosSettingsData = json.RawMessage(`{}`)
+						appSettings.AndroidSettingsData = json.RawMessage(`{}`)
+						appSettings.RetentionSettingsData = json.RawMessage(`{"delete_unpublished_after_days":30}`)
+						return appSettings, nil
+					},
+					updateFn: func(appSettings *models.AppSettings, whitelist []string) ([]error, error) {
+						require.Equal(t, []string{"RetentionSettingsData"}, whitelist)
+						require.Equal(t, json.RawMessage(expectedRetentionSettings), appSettings.RetentionSettingsData)
+						return nil, nil
+					},
+				},
+			},
+			requestBody:        `{"retention_settings":{"keep_last_versions":10,"keep_published":true}}`,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppSettingsPatchResponse{
+				Data: services.AppSettingsPatchResponseData{
+					AppSettings:       &models.AppSettings{AppID: testAppID},
+					RetentionSettings: expectedRetentionSettingsModel,
+					WorkflowRules:     models.WorkflowRules{},
 				},
 			},
 		})
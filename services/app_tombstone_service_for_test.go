@@ -0,0 +1,30 @@
+package services_test
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+type testAppTombstoneService struct {
+	buryFn   func(app *models.App) (*models.AppTombstone, error)
+	findFn   func(tombstone *models.AppTombstone) (*models.AppTombstone, error)
+	updateFn func(tombstone *models.AppTombstone, whitelist []string) error
+}
+
+func (s *testAppTombstoneService) Bury(app *models.App) (*models.AppTombstone, error) {
+	if s.buryFn == nil {
+		panic("You have to override AppTombstoneService.Bury function in tests")
+	}
+	return s.buryFn(app)
+}
+
+func (s *testAppTombstoneService) Find(tombstone *models.AppTombstone) (*models.AppTombstone, error) {
+	if s.findFn == nil {
+		panic("You have to override AppTombstoneService.Find function in tests")
+	}
+	return s.findFn(tombstone)
+}
+
+func (s *testAppTombstoneService) Update(tombstone *models.AppTombstone, whitelist []string) error {
+	if s.updateFn == nil {
+		panic("You have to override AppTombstoneService.Update function in tests")
+	}
+	return s.updateFn(tombstone, whitelist)
+}
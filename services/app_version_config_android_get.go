@@ -8,7 +8,6 @@ import (
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/api-utils/httpresponse"
 	"github.com/pkg/errors"
-	"go.uber.org/zap"
 )
 
 // AppVersionAndroidConfigGetResponse ...
@@ -30,9 +29,6 @@ func AppVersionAndroidConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r
 	if env.AppSettingsService == nil {
 		return errors.New("No App Settings Service defined for handler")
 	}
-	if env.FeatureGraphicService == nil {
-		return errors.New("No Feature Graphic Service defined for handler")
-	}
 	if env.AWS == nil {
 		return errors.New("No AWS Provider defined for handler")
 	}
@@ -42,6 +38,12 @@ func AppVersionAndroidConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r
 	if env.ScreenshotService == nil {
 		return errors.New("No Screenshot Service defined for handler")
 	}
+	if env.AppPreviewService == nil {
+		return errors.New("No App Preview Service defined for handler")
+	}
+	if env.StoreGraphicService == nil {
+		return errors.New("No Store Graphic Service defined for handler")
+	}
 
 	config := AppVersionAndroidConfigGetResponse{MetaData: MetaData{}}
 
@@ -56,17 +58,6 @@ func AppVersionAndroidConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r
 	}
 	config.MetaData.PackageName = artifactInfo.PackageName
 
-	var featureGraphicPresignedURL string
-	featureGraphic, err := env.FeatureGraphicService.Find(&models.FeatureGraphic{AppVersionID: authorizedAppVersionID})
-	if err == nil {
-		featureGraphicPresignedURL, err = env.AWS.GeneratePresignedGETURL(featureGraphic.AWSPath(), presignedURLExpirationInterval)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-	} else {
-		env.Logger.Error("Failed to get feature graphic", zap.Error(err))
-	}
-
 	storeInfo, err := appVersion.AppStoreInfo()
 	if err != nil {
 		return errors.WithStack(err)
@@ -81,20 +72,36 @@ func AppVersionAndroidConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r
 		return errors.Wrap(err, "SQL Error")
 	}
 
-	scs, err := newScreenshotsResponse(screenshots, env)
-	if err != nil {
-		return errors.WithStack(err)
-	}
 	config.MetaData.ListingInfo = ListingInfos{
 		"en-GB": ListingInfo{
 			ShortDescription: storeInfo.ShortDescription,
 			FullDescription:  storeInfo.FullDescription,
 			WhatsNew:         storeInfo.WhatsNew,
-			FeatureGraphic:   featureGraphicPresignedURL,
 			Title:            appData.Title,
-			Screenshots:      scs,
 		},
 	}
+	err = addAndroidScreenshotsToListingInfos(config.MetaData.ListingInfo, "en-GB", screenshots, env)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	appPreviews, err := env.AppPreviewService.FindAll(appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	err = addAndroidAppPreviewsToListingInfos(config.MetaData.ListingInfo, "en-GB", appPreviews, env)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	storeGraphics, err := env.StoreGraphicService.FindAll(appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	err = addStoreGraphicsToListingInfos(config.MetaData.ListingInfo, "en-GB", storeGraphics, env)
+	if err != nil {
+		return errors.WithStack(err)
+	}
 
 	appSettings, err := env.AppSettingsService.Find(&models.AppSettings{AppID: appVersion.AppID})
 	if err != nil {
@@ -139,13 +146,21 @@ func AppVersionAndroidConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r
 	return httpresponse.RespondWithSuccess(w, config)
 }
 
-func newScreenshotsResponse(screenshotData []models.Screenshot, env *env.AppEnv) (Screenshots, error) {
-	scs := Screenshots{}
-	for _, sc := range screenshotData {
+func addAndroidScreenshotsToListingInfos(listingInfos ListingInfos, defaultLocale string, screenshots []models.Screenshot, env *env.AppEnv) error {
+	for _, sc := range screenshots {
+		if !sc.Uploaded {
+			continue
+		}
 		url, err := env.AWS.GeneratePresignedGETURL(sc.AWSPath(), presignedURLExpirationInterval)
 		if err != nil {
-			return Screenshots{}, errors.WithStack(err)
+			return errors.WithStack(err)
 		}
+		locale := sc.Locale
+		if locale == "" {
+			locale = defaultLocale
+		}
+		listingInfo := androidListingInfoOfLocale(listingInfos, locale, defaultLocale)
+		scs := &listingInfo.Screenshots
 		switch sc.ScreenSize {
 		case "tv":
 			scs.Tv = append(scs.Tv, url)
@@ -158,8 +173,72 @@ func newScreenshotsResponse(screenshotData []models.Screenshot, env *env.AppEnv)
 		case "seven_inch":
 			scs.SevenInch = append(scs.SevenInch, url)
 		}
+		listingInfos[locale] = listingInfo
+	}
+	return nil
+}
+
+func addAndroidAppPreviewsToListingInfos(listingInfos ListingInfos, defaultLocale string, appPreviews []models.AppPreview, env *env.AppEnv) error {
+	for _, appPreview := range appPreviews {
+		if !appPreview.Uploaded {
+			continue
+		}
+		locale := appPreview.Locale
+		if locale == "" {
+			locale = defaultLocale
+		}
+		listingInfo := androidListingInfoOfLocale(listingInfos, locale, defaultLocale)
+		if listingInfo.Video != "" {
+			continue
+		}
+		url, err := env.AWS.GeneratePresignedGETURL(appPreview.AWSPath(), presignedURLExpirationInterval)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		listingInfo.Video = url
+		listingInfos[locale] = listingInfo
+	}
+	return nil
+}
+
+// androidListingInfoOfLocale returns the listing info of the locale. The store info of the app version
+// is not localized, so a locale gets the texts of the default locale when it's first listed.
+func androidListingInfoOfLocale(listingInfos ListingInfos, locale, defaultLocale string) ListingInfo {
+	if listingInfo, ok := listingInfos[locale]; ok {
+		return listingInfo
+	}
+	defaultListingInfo := listingInfos[defaultLocale]
+	return ListingInfo{
+		Title:            defaultListingInfo.Title,
+		WhatsNew:         defaultListingInfo.WhatsNew,
+		FullDescription:  defaultListingInfo.FullDescription,
+		ShortDescription: defaultListingInfo.ShortDescription,
+	}
+}
+
+func addStoreGraphicsToListingInfos(listingInfos ListingInfos, locale string, storeGraphics []models.StoreGraphic, env *env.AppEnv) error {
+	listingInfo := listingInfos[locale]
+	for _, storeGraphic := range storeGraphics {
+		if !storeGraphic.Uploaded {
+			continue
+		}
+		url, err := env.AWS.GeneratePresignedGETURL(storeGraphic.AWSPath(), presignedURLExpirationInterval)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		switch storeGraphic.Type {
+		case models.StoreGraphicTypeFeatureGraphic:
+			listingInfo.FeatureGraphic = url
+		case models.StoreGraphicTypeIcon:
+			listingInfo.Icon = url
+		case models.StoreGraphicTypePromoGraphic:
+			listingInfo.PromoGraphic = url
+		case models.StoreGraphicTypeTvBanner:
+			listingInfo.TvBanner = url
+		}
 	}
-	return scs, nil
+	listingInfos[locale] = listingInfo
+	return nil
 }
 
 func newArtifactResponse(env *env.AppEnv, apiToken, appSlug, buildSlug string, artifacts []bitrise.ArtifactListElementResponseModel, module, flavor string) ([]string, error) {
@@ -19,7 +19,6 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 	"github.com/satori/go.uuid"
-	"go.uber.org/zap"
 )
 
 func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
@@ -28,18 +27,27 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 	handler := services.AppVersionAndroidConfigGetHandler
 
 	behavesAsServiceCravingHandler(t, httpMethod, url, handler,
-		[]string{"AppVersionService", "AppSettingsService", "FeatureGraphicService", "AWS", "BitriseAPI", "ScreenshotService"},
+		[]string{"AppVersionService", "AppSettingsService", "AWS", "BitriseAPI", "ScreenshotService", "AppPreviewService", "StoreGraphicService"},
 		ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
 				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
 			},
 			env: &env.AppEnv{
-				AppVersionService:     &testAppVersionService{},
-				FeatureGraphicService: &testFeatureGraphicService{},
+				AppVersionService:  &testAppVersionService{},
 				AWS:                &providers.AWSMock{},
 				BitriseAPI:         &testBitriseAPI{},
 				AppSettingsService: &testAppSettingsService{},
-				ScreenshotService:  &testScreenshotService{},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
+				ScreenshotService: &testScreenshotService{},
 			},
 		},
 	)
@@ -69,14 +77,80 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{}, nil
+					},
+					getServiceAccountFileFn: func(apiToken, appSlug, serviceJSONSlug string) (*bitrise.GenericProjectFile, error) {
+						return &bitrise.GenericProjectFile{}, nil
+					},
+					getAndroidKeystoreFileFn: func(apiToken, appSlug, keystoreSlug string) (*bitrise.AndroidKeystoreFile, error) {
+						return &bitrise.AndroidKeystoreFile{}, nil
+					},
+					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{}, nil
+					},
+					getArtifactFn: func(apiToken, appSlug, buildSlug, artifactSlug string) (*bitrise.ArtifactShowResponseItemModel, error) {
+						return nil, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						appSettings.AndroidSettingsData = json.RawMessage(`{"selected_service_account":"service-account-slug","selected_keystore_file":"android-keystore-slug"}`)
+						return appSettings, nil
+					},
+				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppVersionAndroidConfigGetResponse{
+				MetaData: services.MetaData{
+					ListingInfo: map[string]services.ListingInfo{
+						"en-GB": services.ListingInfo{},
+					},
+				},
+				Artifacts: []string{},
+			},
+		})
+	})
+
+	t.Run("ok - with app previews", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: &testAppVersionService{
+					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+						appVersion.ArtifactInfoData = json.RawMessage(`{}`)
+						appVersion.AppStoreInfoData = json.RawMessage(`{"short_description":"Short description","full_description":"Full description","whats_new":"Some news"}`)
+						return appVersion, nil
 					},
 				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						if strings.Contains(path, "app_previews") {
+							return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+						}
 						return "", nil
 					},
 				},
@@ -103,6 +177,40 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						testAppVersion := models.AppVersion{Record: models.Record{ID: testAppVersionID}, App: models.App{AppSlug: "test-app-slug"}}
+						return []models.AppPreview{
+							models.AppPreview{
+								Record:           models.Record{ID: uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")},
+								UploadableObject: models.UploadableObject{Filename: "preview.mp4", Uploaded: true},
+								DeviceType:       "Pixel 3",
+								ScreenSize:       "phone",
+								AppVersion:       testAppVersion,
+							},
+							models.AppPreview{
+								Record:           models.Record{ID: uuid.FromStringOrNil("9f235109-34fb-476d-a081-c28047d1d025")},
+								UploadableObject: models.UploadableObject{Filename: "preview.mp4", Uploaded: true},
+								DeviceType:       "Pixel 3",
+								ScreenSize:       "phone",
+								Locale:           "de-DE",
+								AppVersion:       testAppVersion,
+							},
+							models.AppPreview{
+								Record:           models.Record{ID: uuid.FromStringOrNil("123afc15-127a-40f9-8cbe-1dadc1f86cdf")},
+								UploadableObject: models.UploadableObject{Filename: "not-yet-uploaded.mp4"},
+								DeviceType:       "Pixel 3",
+								ScreenSize:       "phone",
+								AppVersion:       testAppVersion,
+							},
+						}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -113,7 +221,113 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 			expectedResponse: services.AppVersionAndroidConfigGetResponse{
 				MetaData: services.MetaData{
 					ListingInfo: map[string]services.ListingInfo{
-						"en-GB": services.ListingInfo{},
+						"en-GB": services.ListingInfo{
+							Video:            "http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/app_previews/Pixel 3 (phone)/42156ba6-3473-493f-ba08-6d74d26c320e.mp4",
+							WhatsNew:         "Some news",
+							FullDescription:  "Full description",
+							ShortDescription: "Short description",
+						},
+						"de-DE": services.ListingInfo{
+							Video:            "http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/app_previews/de-DE/Pixel 3 (phone)/9f235109-34fb-476d-a081-c28047d1d025.mp4",
+							WhatsNew:         "Some news",
+							FullDescription:  "Full description",
+							ShortDescription: "Short description",
+						},
+					},
+				},
+				Artifacts: []string{},
+			},
+		})
+	})
+
+	t.Run("ok - with store graphics", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: &testAppVersionService{
+					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+						appVersion.ArtifactInfoData = json.RawMessage(`{}`)
+						appVersion.AppStoreInfoData = json.RawMessage(`{}`)
+						return appVersion, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						if strings.Contains(path, "/icon/") || strings.Contains(path, "/tv_banner/") {
+							return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+						}
+						return "", nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{}, nil
+					},
+					getServiceAccountFileFn: func(apiToken, appSlug, serviceJSONSlug string) (*bitrise.GenericProjectFile, error) {
+						return &bitrise.GenericProjectFile{}, nil
+					},
+					getAndroidKeystoreFileFn: func(apiToken, appSlug, keystoreSlug string) (*bitrise.AndroidKeystoreFile, error) {
+						return &bitrise.AndroidKeystoreFile{}, nil
+					},
+					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{}, nil
+					},
+					getArtifactFn: func(apiToken, appSlug, buildSlug, artifactSlug string) (*bitrise.ArtifactShowResponseItemModel, error) {
+						return nil, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						appSettings.AndroidSettingsData = json.RawMessage(`{"selected_service_account":"service-account-slug","selected_keystore_file":"android-keystore-slug"}`)
+						return appSettings, nil
+					},
+				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						testAppVersion := models.AppVersion{Record: models.Record{ID: testAppVersionID}, App: models.App{AppSlug: "test-app-slug"}}
+						return []models.StoreGraphic{
+							models.StoreGraphic{
+								Record:           models.Record{ID: uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")},
+								UploadableObject: models.UploadableObject{Filename: "icon.png", Uploaded: true},
+								Type:             models.StoreGraphicTypeIcon,
+								AppVersion:       testAppVersion,
+							},
+							models.StoreGraphic{
+								Record:           models.Record{ID: uuid.FromStringOrNil("9f235109-34fb-476d-a081-c28047d1d025")},
+								UploadableObject: models.UploadableObject{Filename: "banner.jpg", Uploaded: true},
+								Type:             models.StoreGraphicTypeTvBanner,
+								AppVersion:       testAppVersion,
+							},
+							models.StoreGraphic{
+								Record:           models.Record{ID: uuid.FromStringOrNil("123afc15-127a-40f9-8cbe-1dadc1f86cdf")},
+								UploadableObject: models.UploadableObject{Filename: "not-yet-uploaded.png"},
+								Type:             models.StoreGraphicTypePromoGraphic,
+								AppVersion:       testAppVersion,
+							},
+						}, nil
+					},
+				},
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppVersionAndroidConfigGetResponse{
+				MetaData: services.MetaData{
+					ListingInfo: map[string]services.ListingInfo{
+						"en-GB": services.ListingInfo{
+							Icon:     "http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/icon/42156ba6-3473-493f-ba08-6d74d26c320e.png",
+							TvBanner: "http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/tv_banner/9f235109-34fb-476d-a081-c28047d1d025.jpg",
+						},
 					},
 				},
 				Artifacts: []string{},
@@ -135,17 +349,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.ID = testFeatureGraphicID
-						featureGraphic.Filename = "feature_graphic.png"
-						featureGraphic.AppVersion = models.AppVersion{
-							Record: models.Record{ID: testAppVersionID},
-							App:    models.App{AppSlug: "test-app-slug"},
-						}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return fmt.Sprintf("http://presigned.url/%s", path), nil
@@ -206,6 +409,26 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{
+							models.StoreGraphic{
+								Record:           models.Record{ID: testFeatureGraphicID},
+								UploadableObject: models.UploadableObject{Filename: "feature_graphic.png", Uploaded: true},
+								Type:             models.StoreGraphicTypeFeatureGraphic,
+								AppVersion: models.AppVersion{
+									Record: models.Record{ID: testAppVersionID},
+									App:    models.App{AppSlug: "test-app-slug"},
+								},
+							},
+						}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						testAppVersion := models.AppVersion{
@@ -213,11 +436,12 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 							App:    models.App{AppSlug: "test-app-slug"},
 						}
 						return []models.Screenshot{
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png"}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png"}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png"}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png"}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png"}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png", Uploaded: true}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png", Uploaded: true}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png", Uploaded: true}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png", Uploaded: true}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png", Uploaded: true}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("0f5a7e4c-5b55-4d6a-9a41-7c0d3a1b9e21")}, UploadableObject: models.UploadableObject{Filename: "pending.png"}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
 						}, nil
 					},
 				},
@@ -269,17 +493,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.ID = testFeatureGraphicID
-						featureGraphic.Filename = "feature_graphic.png"
-						featureGraphic.AppVersion = models.AppVersion{
-							Record: models.Record{ID: testAppVersionID},
-							App:    models.App{AppSlug: "test-app-slug"},
-						}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return fmt.Sprintf("http://presigned.url/%s", path), nil
@@ -352,6 +565,26 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{
+							models.StoreGraphic{
+								Record:           models.Record{ID: testFeatureGraphicID},
+								UploadableObject: models.UploadableObject{Filename: "feature_graphic.png", Uploaded: true},
+								Type:             models.StoreGraphicTypeFeatureGraphic,
+								AppVersion: models.AppVersion{
+									Record: models.Record{ID: testAppVersionID},
+									App:    models.App{AppSlug: "test-app-slug"},
+								},
+							},
+						}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						testAppVersion := models.AppVersion{
@@ -359,11 +592,11 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 							App:    models.App{AppSlug: "test-app-slug"},
 						}
 						return []models.Screenshot{
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png"}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png"}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png"}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png"}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png"}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png", Uploaded: true}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png", Uploaded: true}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png", Uploaded: true}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png", Uploaded: true}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png", Uploaded: true}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
 						}, nil
 					},
 				},
@@ -412,12 +645,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, gorm.ErrRecordNotFound
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -440,6 +667,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -463,12 +700,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -491,57 +722,14 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
-				ScreenshotService: &testScreenshotService{
-					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
-						return []models.Screenshot{}, nil
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
 					},
 				},
-			},
-			expectedInternalErr: "invalid character 'i' looking for beginning of value",
-		})
-	})
-
-	t.Run("when no feature graphic found", func(t *testing.T) {
-		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
-			contextElements: map[ctxpkg.RequestContextKey]interface{}{
-				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
-			},
-			env: &env.AppEnv{
-				AppVersionService: &testAppVersionService{
-					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
-						appVersion.ArtifactInfoData = json.RawMessage(`{}`)
-						appVersion.AppStoreInfoData = json.RawMessage(`{}`)
-						return appVersion, nil
-					},
-				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						return featureGraphic, gorm.ErrRecordNotFound
-					},
-				},
-				AWS: &providers.AWSMock{
-					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
-						return "", nil
-					},
-				},
-				BitriseAPI: &testBitriseAPI{
-					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
-						return &bitrise.AppDetails{}, nil
-					},
-					getServiceAccountFileFn: func(apiToken, appSlug, serviceJSONSlug string) (*bitrise.GenericProjectFile, error) {
-						return &bitrise.GenericProjectFile{}, nil
-					},
-					getAndroidKeystoreFileFn: func(apiToken, appSlug, keystoreSlug string) (*bitrise.AndroidKeystoreFile, error) {
-						return &bitrise.AndroidKeystoreFile{}, nil
-					},
-					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
-						return []bitrise.ArtifactListElementResponseModel{}, nil
-					},
-				},
-				AppSettingsService: &testAppSettingsService{
-					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
-						appSettings.AndroidSettingsData = json.RawMessage(`{"selected_service_account":"service-account-slug","selected_keystore_file":"android-keystore-slug"}`)
-						return appSettings, nil
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
 					},
 				},
 				ScreenshotService: &testScreenshotService{
@@ -549,13 +737,12 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return []models.Screenshot{}, nil
 					},
 				},
-				Logger: zap.NewNop(),
 			},
-			expectedStatusCode: http.StatusOK,
+			expectedInternalErr: "invalid character 'i' looking for beginning of value",
 		})
 	})
 
-	t.Run("when error happens at finding feature graphic", func(t *testing.T) {
+	t.Run("when failed to get AWS presigned URL for store graphic", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
 				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
@@ -568,14 +755,9 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						return featureGraphic, errors.New("SOME-SQL-ERROR")
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
-						return "", nil
+						return "", errors.New("SOME-AWS-ERROR")
 					},
 				},
 				BitriseAPI: &testBitriseAPI{
@@ -588,9 +770,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 					getAndroidKeystoreFileFn: func(apiToken, appSlug, keystoreSlug string) (*bitrise.AndroidKeystoreFile, error) {
 						return &bitrise.AndroidKeystoreFile{}, nil
 					},
-					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
-						return []bitrise.ArtifactListElementResponseModel{}, nil
-					},
 				},
 				AppSettingsService: &testAppSettingsService{
 					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
@@ -598,64 +777,24 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
-				ScreenshotService: &testScreenshotService{
-					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
-						return []models.Screenshot{}, nil
-					},
-				},
-				Logger: zap.NewNop(),
-			},
-			expectedStatusCode: http.StatusOK,
-			expectedResponse: services.AppVersionAndroidConfigGetResponse{
-				MetaData: services.MetaData{
-					ListingInfo: map[string]services.ListingInfo{
-						"en-GB": services.ListingInfo{},
-					},
-				},
-				Artifacts: []string{},
-			},
-		})
-	})
-
-	t.Run("when failed to get AWS presigned URL for feature graphic", func(t *testing.T) {
-		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
-			contextElements: map[ctxpkg.RequestContextKey]interface{}{
-				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
-			},
-			env: &env.AppEnv{
-				AppVersionService: &testAppVersionService{
-					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
-						appVersion.ArtifactInfoData = json.RawMessage(`{}`)
-						appVersion.AppStoreInfoData = json.RawMessage(`{}`)
-						return appVersion, nil
-					},
-				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
-				AWS: &providers.AWSMock{
-					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
-						return "", errors.New("SOME-AWS-ERROR")
-					},
-				},
-				BitriseAPI: &testBitriseAPI{
-					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
-						return &bitrise.AppDetails{}, nil
-					},
-					getServiceAccountFileFn: func(apiToken, appSlug, serviceJSONSlug string) (*bitrise.GenericProjectFile, error) {
-						return &bitrise.GenericProjectFile{}, nil
-					},
-					getAndroidKeystoreFileFn: func(apiToken, appSlug, keystoreSlug string) (*bitrise.AndroidKeystoreFile, error) {
-						return &bitrise.AndroidKeystoreFile{}, nil
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
 					},
 				},
-				AppSettingsService: &testAppSettingsService{
-					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
-						appSettings.AndroidSettingsData = json.RawMessage(`{"selected_service_account":"service-account-slug","selected_keystore_file":"android-keystore-slug"}`)
-						return appSettings, nil
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{
+							models.StoreGraphic{
+								Record:           models.Record{ID: uuid.NewV4()},
+								UploadableObject: models.UploadableObject{Filename: "feature_graphic.png", Uploaded: true},
+								Type:             models.StoreGraphicTypeFeatureGraphic,
+								AppVersion: models.AppVersion{
+									Record: models.Record{ID: uuid.NewV4()},
+									App:    models.App{AppSlug: "test-app-slug"},
+								},
+							},
+						}, nil
 					},
 				},
 				ScreenshotService: &testScreenshotService{
@@ -681,12 +820,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -709,6 +842,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -732,12 +875,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -760,6 +897,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -783,12 +930,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -810,6 +951,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, gorm.ErrRecordNotFound
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -833,12 +984,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -861,6 +1006,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -884,12 +1039,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -912,6 +1061,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -935,12 +1094,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -963,6 +1116,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -986,12 +1149,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -1014,6 +1171,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, errors.New("SOME-SQL-ERROR")
@@ -1037,12 +1204,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						if strings.Contains(path, "Apple Watch") {
@@ -1068,9 +1229,19 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
-						return []models.Screenshot{models.Screenshot{DeviceType: "Apple Watch"}}, nil
+						return []models.Screenshot{models.Screenshot{UploadableObject: models.UploadableObject{Uploaded: true}, DeviceType: "Apple Watch"}}, nil
 					},
 				},
 			},
@@ -1091,12 +1262,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -1125,6 +1290,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -1148,12 +1323,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -1191,6 +1360,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -1215,17 +1394,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.ID = testFeatureGraphicID
-						featureGraphic.Filename = "feature_graphic.png"
-						featureGraphic.AppVersion = models.AppVersion{
-							Record: models.Record{ID: testAppVersionID},
-							App:    models.App{AppSlug: "test-app-slug"},
-						}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return fmt.Sprintf("http://presigned.url/%s", path), nil
@@ -1275,6 +1443,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						testAppVersion := models.AppVersion{
@@ -1282,11 +1460,11 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 							App:    models.App{AppSlug: "test-app-slug"},
 						}
 						return []models.Screenshot{
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png"}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png"}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png"}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png"}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png"}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png", Uploaded: true}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png", Uploaded: true}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png", Uploaded: true}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png", Uploaded: true}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png", Uploaded: true}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
 						}, nil
 					},
 				},
@@ -1308,12 +1486,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.AppVersion = models.AppVersion{App: models.App{}}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -1351,6 +1523,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -1375,17 +1557,6 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appVersion, nil
 					},
 				},
-				FeatureGraphicService: &testFeatureGraphicService{
-					findFn: func(featureGraphic *models.FeatureGraphic) (*models.FeatureGraphic, error) {
-						featureGraphic.ID = testFeatureGraphicID
-						featureGraphic.Filename = "feature_graphic.png"
-						featureGraphic.AppVersion = models.AppVersion{
-							Record: models.Record{ID: testAppVersionID},
-							App:    models.App{AppSlug: "test-app-slug"},
-						}
-						return featureGraphic, nil
-					},
-				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return fmt.Sprintf("http://presigned.url/%s", path), nil
@@ -1438,6 +1609,16 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				StoreGraphicService: &testStoreGraphicService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+						return []models.StoreGraphic{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						testAppVersion := models.AppVersion{
@@ -1445,11 +1626,11 @@ func Test_AppVersionAndroidConfigGetHandler(t *testing.T) {
 							App:    models.App{AppSlug: "test-app-slug"},
 						}
 						return []models.Screenshot{
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png"}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png"}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png"}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png"}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png"}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "tv.png", Uploaded: true}, ScreenSize: "tv", DeviceType: "TV", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "wear.png", Uploaded: true}, ScreenSize: "wear", DeviceType: "Watch", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "phone.png", Uploaded: true}, ScreenSize: "phone", DeviceType: "Phone", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "ten_inch.png", Uploaded: true}, ScreenSize: "ten_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "seven_inch.png", Uploaded: true}, ScreenSize: "seven_inch", DeviceType: "Tablet", AppVersion: testAppVersion},
 						}, nil
 					},
 				},
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
 	"github.com/bitrise-io/addons-ship-backend/env"
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/api-utils/httpresponse"
@@ -39,6 +40,9 @@ func AppVersionIosConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r *ht
 	if env.ScreenshotService == nil {
 		return errors.New("No Screenshot Service defined for handler")
 	}
+	if env.AppPreviewService == nil {
+		return errors.New("No App Preview Service defined for handler")
+	}
 
 	config := AppVersionIosConfigGetResponse{MetaData: IosConfigMetaData{}}
 
@@ -55,12 +59,8 @@ func AppVersionIosConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r *ht
 	if err != nil {
 		return errors.Wrap(err, "SQL Error")
 	}
-	scs, err := newIosScreenshotsResponse(screenshots, env)
-	if err != nil {
-		return errors.WithStack(err)
-	}
 	listingInfo := IosListingInfo{
-		Screenshots:     scs,
+		Screenshots:     map[string][]string{},
 		Description:     storeInfo.FullDescription,
 		PromotionalText: storeInfo.PromotionalText,
 		SupportURL:      storeInfo.SupportURL,
@@ -70,6 +70,19 @@ func AppVersionIosConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r *ht
 		listingInfo.Keywords = strings.Split(storeInfo.Keywords, ",")
 	}
 	config.MetaData.ListingInfoMap = map[string]IosListingInfo{"en-US": listingInfo}
+	err = addIosScreenshotsToListingInfos(config.MetaData.ListingInfoMap, "en-US", screenshots, env)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	appPreviews, err := env.AppPreviewService.FindAll(appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	err = addIosAppPreviewsToListingInfos(config.MetaData.ListingInfoMap, "en-US", appPreviews, env)
+	if err != nil {
+		return errors.WithStack(err)
+	}
 
 	appSettings, err := env.AppSettingsService.Find(&models.AppSettings{AppID: appVersion.AppID})
 	if err != nil {
@@ -110,7 +123,12 @@ func AppVersionIosConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r *ht
 		return errors.WithStack(err)
 	}
 
-	for _, artifact := range artifacts {
+	artifactSelector := bitrise.NewArtifactSelector(artifacts)
+	iosArtifacts, err := artifactSelector.IosArtifacts(appVersion)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, artifact := range iosArtifacts {
 		if artifact.IsXCodeArchive() {
 			artifactData, err := env.BitriseAPI.GetArtifact(appVersion.App.BitriseAPIToken, appVersion.App.AppSlug, appVersion.BuildSlug, artifact.Slug)
 			if err != nil {
@@ -126,21 +144,70 @@ func AppVersionIosConfigGetHandler(env *env.AppEnv, w http.ResponseWriter, r *ht
 	return httpresponse.RespondWithSuccess(w, config)
 }
 
-func newIosScreenshotsResponse(screenshotData []models.Screenshot, env *env.AppEnv) (map[string][]string, error) {
-	scs := map[string][]string{}
-	for _, sc := range screenshotData {
+func addIosScreenshotsToListingInfos(listingInfos map[string]IosListingInfo, defaultLocale string, screenshots []models.Screenshot, env *env.AppEnv) error {
+	for _, sc := range screenshots {
+		if !sc.Uploaded {
+			continue
+		}
 		url, err := env.AWS.GeneratePresignedGETURL(sc.AWSPath(), presignedURLExpirationInterval)
 		if err != nil {
-			return nil, errors.WithStack(err)
+			return errors.WithStack(err)
+		}
+		locale := sc.Locale
+		if locale == "" {
+			locale = defaultLocale
+		}
+		listingInfo := iosListingInfoOfLocale(listingInfos, locale, defaultLocale)
+		listingInfo.Screenshots[sc.ScreenSize] = append(listingInfo.Screenshots[sc.ScreenSize], url)
+		listingInfos[locale] = listingInfo
+	}
+	return nil
+}
+
+func addIosAppPreviewsToListingInfos(listingInfos map[string]IosListingInfo, defaultLocale string, appPreviews []models.AppPreview, env *env.AppEnv) error {
+	for _, appPreview := range appPreviews {
+		if !appPreview.Uploaded {
+			continue
+		}
+		url, err := env.AWS.GeneratePresignedGETURL(appPreview.AWSPath(), presignedURLExpirationInterval)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		locale := appPreview.Locale
+		if locale == "" {
+			locale = defaultLocale
 		}
-		scs[sc.ScreenSize] = append(scs[sc.ScreenSize], url)
+		listingInfo := iosListingInfoOfLocale(listingInfos, locale, defaultLocale)
+		if listingInfo.AppPreviews == nil {
+			listingInfo.AppPreviews = map[string][]string{}
+		}
+		listingInfo.AppPreviews[appPreview.ScreenSize] = append(listingInfo.AppPreviews[appPreview.ScreenSize], url)
+		listingInfos[locale] = listingInfo
+	}
+	return nil
+}
+
+// iosListingInfoOfLocale returns the listing info of the locale. The store info of the app version is
+// not localized, so a locale gets the texts of the default locale when it's first listed.
+func iosListingInfoOfLocale(listingInfos map[string]IosListingInfo, locale, defaultLocale string) IosListingInfo {
+	if listingInfo, ok := listingInfos[locale]; ok {
+		return listingInfo
+	}
+	defaultListingInfo := listingInfos[defaultLocale]
+	return IosListingInfo{
+		Screenshots:     map[string][]string{},
+		Description:     defaultListingInfo.Description,
+		PromotionalText: defaultListingInfo.PromotionalText,
+		Keywords:        defaultListingInfo.Keywords,
+		SupportURL:      defaultListingInfo.SupportURL,
+		SoftwareURL:     defaultListingInfo.SoftwareURL,
 	}
-	return scs, nil
 }
 
 // IosListingInfo ...
 type IosListingInfo struct {
 	Screenshots     map[string][]string `json:"screenshots" yaml:"screenshots"`
+	AppPreviews     map[string][]string `json:"app_previews,omitempty" yaml:"app_previews,omitempty"`
 	Description     string              `json:"description" yaml:"description"`
 	PromotionalText string              `json:"promotional_text" yaml:"promotional_text"`
 	Keywords        []string            `json:"keywords" yaml:"keywords"`
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,7 +27,7 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 	handler := services.AppVersionIosConfigGetHandler
 
 	behavesAsServiceCravingHandler(t, httpMethod, url, handler,
-		[]string{"AppVersionService", "AppSettingsService", "AWS", "BitriseAPI", "ScreenshotService"},
+		[]string{"AppVersionService", "AppSettingsService", "AWS", "BitriseAPI", "ScreenshotService", "AppPreviewService"},
 		ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
 				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
@@ -36,7 +37,12 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 				AWS:                &providers.AWSMock{},
 				BitriseAPI:         &testBitriseAPI{},
 				AppSettingsService: &testAppSettingsService{},
-				ScreenshotService:  &testScreenshotService{},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				ScreenshotService: &testScreenshotService{},
 			},
 		},
 	)
@@ -94,6 +100,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -116,6 +127,223 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("ok - with app previews", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: &testAppVersionService{
+					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+						appVersion.ArtifactInfoData = json.RawMessage(`{}`)
+						appVersion.AppStoreInfoData = json.RawMessage(`{}`)
+						return appVersion, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						if strings.Contains(path, "app_previews") {
+							return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+						}
+						return "", nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					getAppDetailsFn: func(apiToken, appSlug string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{}, nil
+					},
+					getProvisioningProfileFn: func(apiToken, appSlug, provProfileSlug string) (*bitrise.ProvisioningProfile, error) {
+						return &bitrise.ProvisioningProfile{Slug: "prov-profile-slug", DownloadURL: "http://here.you.can.find.the.prov.profile"}, nil
+					},
+					getCodeSigningIdentityFn: func(apiToken, appSlug, codeSignIDSlug string) (*bitrise.CodeSigningIdentity, error) {
+						return &bitrise.CodeSigningIdentity{Slug: "code-signing-slug", DownloadURL: "http://here.you.can.find.the.code.signing.id", CertificatePassword: "super-secret"}, nil
+					},
+					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{}, nil
+					},
+					getArtifactFn: func(apiToken, appSlug, buildSlug, artifactSlug string) (*bitrise.ArtifactShowResponseItemModel, error) {
+						return nil, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						appSettings.IosSettingsData = json.RawMessage(`{"selected_app_store_provisioning_profiles":["prov-profile-slug"],"selected_code_signing_identity":"code-signing-slug"}`)
+						return appSettings, nil
+					},
+				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						testAppVersion := models.AppVersion{Record: models.Record{ID: testAppVersionID}, App: models.App{AppSlug: "test-app-slug"}}
+						return []models.AppPreview{
+							models.AppPreview{
+								Record:           models.Record{ID: uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")},
+								UploadableObject: models.UploadableObject{Filename: "preview.mp4", Uploaded: true},
+								DeviceType:       "iPhone XS Max",
+								ScreenSize:       "6.5 inch",
+								AppVersion:       testAppVersion,
+							},
+							models.AppPreview{
+								Record:           models.Record{ID: uuid.FromStringOrNil("9f235109-34fb-476d-a081-c28047d1d025")},
+								UploadableObject: models.UploadableObject{Filename: "preview.mp4", Uploaded: true},
+								DeviceType:       "iPhone XS Max",
+								ScreenSize:       "6.5 inch",
+								Locale:           "de-DE",
+								AppVersion:       testAppVersion,
+							},
+							models.AppPreview{
+								Record:           models.Record{ID: uuid.FromStringOrNil("123afc15-127a-40f9-8cbe-1dadc1f86cdf")},
+								UploadableObject: models.UploadableObject{Filename: "not-yet-uploaded.mp4"},
+								DeviceType:       "iPhone XS Max",
+								ScreenSize:       "6.5 inch",
+								AppVersion:       testAppVersion,
+							},
+						}, nil
+					},
+				},
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppVersionIosConfigGetResponse{
+				MetaData: services.IosConfigMetaData{
+					ListingInfoMap: map[string]services.IosListingInfo{
+						"en-US": services.IosListingInfo{
+							Screenshots: map[string][]string{},
+							AppPreviews: map[string][]string{
+								"6.5 inch": []string{"http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/app_previews/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.mp4"},
+							},
+						},
+						"de-DE": services.IosListingInfo{
+							Screenshots: map[string][]string{},
+							AppPreviews: map[string][]string{
+								"6.5 inch": []string{"http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/app_previews/de-DE/iPhone XS Max (6.5 inch)/9f235109-34fb-476d-a081-c28047d1d025.mp4"},
+							},
+						},
+					},
+					Signing: services.Signing{
+						AppStoreProfileURL:                "http://here.you.can.find.the.prov.profile",
+						DistributionCertificateURL:        "http://here.you.can.find.the.code.signing.id",
+						DistributionCertificatePasshprase: "super-secret",
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("ok - with localized screenshots", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: &testAppVersionService{
+					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+						appVersion.ArtifactInfoData = json.RawMessage(`{}`)
+						appVersion.AppStoreInfoData = json.RawMessage(`{"full_description":"Some description","promotional_text":"Some promotion","keywords":"ship,bitrise","support_url":"https://support.url","marketing_url":"https://marketing.url"}`)
+						return appVersion, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					getProvisioningProfileFn: func(apiToken, appSlug, provProfileSlug string) (*bitrise.ProvisioningProfile, error) {
+						return &bitrise.ProvisioningProfile{Slug: "prov-profile-slug", DownloadURL: "http://here.you.can.find.the.prov.profile"}, nil
+					},
+					getCodeSigningIdentityFn: func(apiToken, appSlug, codeSignIDSlug string) (*bitrise.CodeSigningIdentity, error) {
+						return &bitrise.CodeSigningIdentity{Slug: "code-signing-slug", DownloadURL: "http://here.you.can.find.the.code.signing.id", CertificatePassword: "super-secret"}, nil
+					},
+					getArtifactsFn: func(apiToken, appSlug, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{}, nil
+					},
+				},
+				AppSettingsService: &testAppSettingsService{
+					findFn: func(appSettings *models.AppSettings) (*models.AppSettings, error) {
+						appSettings.IosSettingsData = json.RawMessage(`{"selected_app_store_provisioning_profiles":["prov-profile-slug"],"selected_code_signing_identity":"code-signing-slug"}`)
+						return appSettings, nil
+					},
+				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						testAppVersion := models.AppVersion{Record: models.Record{ID: testAppVersionID}, App: models.App{AppSlug: "test-app-slug"}}
+						return []models.Screenshot{
+							models.Screenshot{
+								Record:           models.Record{ID: uuid.FromStringOrNil("9f235109-34fb-476d-a081-c28047d1d025")},
+								UploadableObject: models.UploadableObject{Filename: "screenshot.png", Uploaded: true},
+								DeviceType:       "iPhone XS Max",
+								ScreenSize:       "6.5 inch",
+								Position:         1,
+								AppVersion:       testAppVersion,
+							},
+							models.Screenshot{
+								Record:           models.Record{ID: uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")},
+								UploadableObject: models.UploadableObject{Filename: "screenshot.png", Uploaded: true},
+								DeviceType:       "iPhone XS Max",
+								ScreenSize:       "6.5 inch",
+								Position:         2,
+								AppVersion:       testAppVersion,
+							},
+							models.Screenshot{
+								Record:           models.Record{ID: uuid.FromStringOrNil("123afc15-127a-40f9-8cbe-1dadc1f86cdf")},
+								UploadableObject: models.UploadableObject{Filename: "screenshot.png", Uploaded: true},
+								DeviceType:       "iPhone XS Max",
+								ScreenSize:       "6.5 inch",
+								Locale:           "de-DE",
+								Position:         1,
+								AppVersion:       testAppVersion,
+							},
+						}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppVersionIosConfigGetResponse{
+				MetaData: services.IosConfigMetaData{
+					ListingInfoMap: map[string]services.IosListingInfo{
+						"en-US": services.IosListingInfo{
+							Description:     "Some description",
+							PromotionalText: "Some promotion",
+							Keywords:        []string{"ship", "bitrise"},
+							SupportURL:      "https://support.url",
+							SoftwareURL:     "https://marketing.url",
+							Screenshots: map[string][]string{
+								"6.5 inch": []string{
+									"http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/iPhone XS Max (6.5 inch)/9f235109-34fb-476d-a081-c28047d1d025.png",
+									"http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.png",
+								},
+							},
+						},
+						"de-DE": services.IosListingInfo{
+							Description:     "Some description",
+							PromotionalText: "Some promotion",
+							Keywords:        []string{"ship", "bitrise"},
+							SupportURL:      "https://support.url",
+							SoftwareURL:     "https://marketing.url",
+							Screenshots: map[string][]string{
+								"6.5 inch": []string{"http://presigned.aws.url/test-app-slug/1ca9503a-6230-4140-9fca-3867b6640ce3/iPhone XS Max (6.5 inch)/123afc15-127a-40f9-8cbe-1dadc1f86cdf.png"},
+							},
+						},
+					},
+					Signing: services.Signing{
+						AppStoreProfileURL:                "http://here.you.can.find.the.prov.profile",
+						DistributionCertificateURL:        "http://here.you.can.find.the.code.signing.id",
+						DistributionCertificatePasshprase: "super-secret",
+					},
+				},
+			},
+		})
+	})
+
 	t.Run("ok - more complex", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
@@ -184,6 +412,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						testAppVersion := models.AppVersion{
@@ -191,11 +424,13 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 							App:    models.App{AppSlug: "test-app-slug"},
 						}
 						return []models.Screenshot{
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "iPhone XS Max.png"}, ScreenSize: "6.5 inch", DeviceType: "iPhone XS Max", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "iPad Pro.png"}, ScreenSize: "12.9 inch", DeviceType: "iPad Pro", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "iPhone XS Max 2.png"}, ScreenSize: "6.5 inch", DeviceType: "iPhone XS Max", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "iPhone XS.png"}, ScreenSize: "5.8 inch", DeviceType: "iPhone XS", AppVersion: testAppVersion},
-							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "iPad Pro 2.png"}, ScreenSize: "12.9 inch", DeviceType: "iPad Pro", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("17ec78c9-e3a8-41ee-b3bd-2df9b4117aa2")}, UploadableObject: models.UploadableObject{Filename: "iPhone XS Max.png", Uploaded: true}, ScreenSize: "6.5 inch", DeviceType: "iPhone XS Max", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("d5c8564f-eef4-490a-a7fd-8d3050893320")}, UploadableObject: models.UploadableObject{Filename: "iPad Pro.png", Uploaded: true}, ScreenSize: "12.9 inch", DeviceType: "iPad Pro", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("e4d64d18-e414-4fa3-8583-f94a06b4f9a9")}, UploadableObject: models.UploadableObject{Filename: "iPhone XS Max 2.png", Uploaded: true}, ScreenSize: "6.5 inch", DeviceType: "iPhone XS Max", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("4faa287f-afee-46aa-bd6b-553ab11a959c")}, UploadableObject: models.UploadableObject{Filename: "iPhone XS.png", Uploaded: true}, ScreenSize: "5.8 inch", DeviceType: "iPhone XS", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")}, UploadableObject: models.UploadableObject{Filename: "iPad Pro 2.png", Uploaded: true}, ScreenSize: "12.9 inch", DeviceType: "iPad Pro", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("5e0b8cd4-62b4-4c52-a1e4-2b0a0c6f1a77")}, UploadableObject: models.UploadableObject{Filename: "invalid.png", InvalidReason: "file: Must be a valid PNG or JPEG image"}, ScreenSize: "12.9 inch", DeviceType: "iPad Pro", AppVersion: testAppVersion},
+							models.Screenshot{Record: models.Record{ID: uuid.FromStringOrNil("0f5a7e4c-5b55-4d6a-9a41-7c0d3a1b9e21")}, UploadableObject: models.UploadableObject{Filename: "pending.png"}, ScreenSize: "12.9 inch", DeviceType: "iPad Pro", AppVersion: testAppVersion},
 						}, nil
 					},
 				},
@@ -271,6 +506,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -316,6 +556,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -361,6 +606,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, errors.New("SOME-SQL-ERROR")
@@ -406,9 +656,14 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
-						return []models.Screenshot{models.Screenshot{DeviceType: "Apple Watch"}}, nil
+						return []models.Screenshot{models.Screenshot{UploadableObject: models.UploadableObject{Uploaded: true}, DeviceType: "Apple Watch"}}, nil
 					},
 				},
 			},
@@ -450,6 +705,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, gorm.ErrRecordNotFound
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -495,6 +755,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -540,6 +805,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -585,6 +855,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -636,6 +911,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -687,6 +967,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
@@ -738,6 +1023,11 @@ func Test_AppVersionIosConfigGetHandler(t *testing.T) {
 						return appSettings, nil
 					},
 				},
+				AppPreviewService: &testAppPreviewService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.AppPreview, error) {
+						return []models.AppPreview{}, nil
+					},
+				},
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
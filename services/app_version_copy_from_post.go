@@ -0,0 +1,108 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httprequest"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AppVersionCopyFromPostResponse ...
+type AppVersionCopyFromPostResponse struct {
+	Data *models.JobStatus `json:"data"`
+}
+
+// AppVersionCopyFromPostHandler enqueues copying the selected parts of the listing of another version
+// of the app to the authorized app version, its progress can be followed on the job status
+func AppVersionCopyFromPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var params models.AppVersionCopyParts
+	defer httprequest.BodyCloseWithErrorLog(r)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
+	}
+
+	if env.AppVersionService == nil {
+		return errors.New("No App Version Service defined for handler")
+	}
+	if env.JobStatusService == nil {
+		return errors.New("No Job Status Service defined for handler")
+	}
+	if env.WorkerService == nil {
+		return errors.New("No Worker Service defined for handler")
+	}
+	if env.RequestParams == nil {
+		return errors.New("No RequestParams defined for handler")
+	}
+
+	sourceAppVersionID, err := getUUIDFromRequest(env, r, "source-version-id")
+	if err != nil {
+		return httpresponse.RespondWithBadRequestError(w, err.Error())
+	}
+	sourceAppVersion, err := env.AppVersionService.Find(&models.AppVersion{Record: models.Record{ID: sourceAppVersionID}})
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return httpresponse.RespondWithNotFoundError(w)
+	case err != nil:
+		return errors.Wrap(err, "SQL Error")
+	case !uuid.Equal(sourceAppVersion.AppID, authorizedAppID):
+		return httpresponse.RespondWithNotFoundError(w)
+	}
+	appVersion, err := env.AppVersionService.Find(&models.AppVersion{Record: models.Record{ID: authorizedAppVersionID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	if verrs := validateAppVersionCopyFromParams(params, sourceAppVersion, appVersion); len(verrs) > 0 {
+		return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+	}
+
+	jobStatus, verrs, err := env.JobStatusService.Create(&models.JobStatus{
+		Type:         models.JobTypeAppVersionCopy,
+		Status:       models.JobStatusPending,
+		AppID:        authorizedAppID,
+		AppVersionID: &authorizedAppVersionID,
+	})
+	if len(verrs) > 0 {
+		return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+	}
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	err = env.WorkerService.EnqueueCopyFromAppVersion(jobStatus.ID, sourceAppVersion.ID, params)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return httpresponse.RespondWithSuccess(w, AppVersionCopyFromPostResponse{
+		Data: jobStatus,
+	})
+}
+
+func validateAppVersionCopyFromParams(params models.AppVersionCopyParts, source, target *models.AppVersion) []error {
+	verrs := []error{}
+	if params.Empty() {
+		verrs = append(verrs, errors.New("parts: At least one of store_info, screenshots and feature_graphic has to be selected"))
+	}
+	if uuid.Equal(source.ID, target.ID) {
+		verrs = append(verrs, errors.New("source_version_id: Must be another version"))
+	} else if source.Platform != target.Platform {
+		verrs = append(verrs, errors.New("source_version_id: Must be a version of the same platform"))
+	}
+	return verrs
+}
@@ -0,0 +1,278 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_AppVersionCopyFromPostHandler(t *testing.T) {
+	httpMethod := "POST"
+	url := "/apps/{app-slug}/versions/{version-id}/copy-from/{source-version-id}"
+	handler := services.AppVersionCopyFromPostHandler
+
+	testAppID := uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+	testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+	testSourceAppVersionID := uuid.FromStringOrNil("5b6d5ae2-28a2-4c29-8e09-0e3ab5dc4b1f")
+	testJobStatusID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+
+	appVersionServiceWithVersions := func(sourceAppID uuid.UUID, sourcePlatform string) *testAppVersionService {
+		return &testAppVersionService{
+			findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+				if uuid.Equal(appVersion.ID, testSourceAppVersionID) {
+					appVersion.AppID = sourceAppID
+					appVersion.Platform = sourcePlatform
+					return appVersion, nil
+				}
+				appVersion.AppID = testAppID
+				appVersion.Platform = "ios"
+				return appVersion, nil
+			},
+		}
+	}
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppVersionService", "JobStatusService", "WorkerService", "RequestParams"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID:        testAppID,
+			services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+		},
+		env: &env.AppEnv{
+			AppVersionService: &testAppVersionService{},
+			JobStatusService:  &testJobStatusService{},
+			WorkerService:     &testWorkerService{},
+			RequestParams:     &providers.RequestParamsMock{},
+		},
+		requestBody: `{}`,
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppID, services.ContextKeyAuthorizedAppVersionID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID:        testAppID,
+			services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+		},
+		env: &env.AppEnv{
+			AppVersionService: &testAppVersionService{},
+			JobStatusService:  &testJobStatusService{},
+			WorkerService:     &testWorkerService{},
+			RequestParams:     &providers.RequestParamsMock{},
+		},
+		requestBody: `{}`,
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		enqueued := false
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: appVersionServiceWithVersions(testAppID, "ios"),
+				JobStatusService: &testJobStatusService{
+					createFn: func(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+						require.Equal(t, models.JobTypeAppVersionCopy, jobStatus.Type)
+						require.Equal(t, models.JobStatusPending, jobStatus.Status)
+						require.Equal(t, testAppID, jobStatus.AppID)
+						require.Equal(t, testAppVersionID, *jobStatus.AppVersionID)
+						jobStatus.ID = testJobStatusID
+						jobStatus.Result = json.RawMessage(`{}`)
+						return jobStatus, nil, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueCopyFromAppVersionFn: func(jobStatusID, sourceAppVersionID uuid.UUID, parts models.AppVersionCopyParts) error {
+						require.Equal(t, testJobStatusID, jobStatusID)
+						require.Equal(t, testSourceAppVersionID, sourceAppVersionID)
+						require.Equal(t, models.AppVersionCopyParts{StoreInfo: true, FeatureGraphic: true}, parts)
+						enqueued = true
+						return nil
+					},
+				},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"source-version-id": testSourceAppVersionID.String()},
+				},
+			},
+			requestBody:        `{"store_info":true,"feature_graphic":true}`,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppVersionCopyFromPostResponse{
+				Data: &models.JobStatus{
+					Record:       models.Record{ID: testJobStatusID},
+					Type:         models.JobTypeAppVersionCopy,
+					Status:       models.JobStatusPending,
+					Result:       json.RawMessage(`{}`),
+					AppVersionID: &testAppVersionID,
+				},
+			},
+		})
+		require.True(t, enqueued)
+	})
+
+	t.Run("when request body is not a valid JSON", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: &testAppVersionService{},
+				JobStatusService:  &testJobStatusService{},
+				WorkerService:     &testWorkerService{},
+				RequestParams:     &providers.RequestParamsMock{},
+			},
+			requestBody:        `invalid JSON`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid request body, JSON decode failed"},
+		})
+	})
+
+	t.Run("when source version ID is invalid", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: &testAppVersionService{},
+				JobStatusService:  &testJobStatusService{},
+				WorkerService:     &testWorkerService{},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"source-version-id": "invalid-uuid"},
+				},
+			},
+			requestBody:        `{"store_info":true}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid UUID format for source-version-id"},
+		})
+	})
+
+	t.Run("when source version is not found", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: &testAppVersionService{
+					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+						return nil, gorm.ErrRecordNotFound
+					},
+				},
+				JobStatusService: &testJobStatusService{},
+				WorkerService:    &testWorkerService{},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"source-version-id": testSourceAppVersionID.String()},
+				},
+			},
+			requestBody:        `{"store_info":true}`,
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Not Found"},
+		})
+	})
+
+	t.Run("when source version belongs to another app", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: appVersionServiceWithVersions(uuid.NewV4(), "ios"),
+				JobStatusService:  &testJobStatusService{},
+				WorkerService:     &testWorkerService{},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"source-version-id": testSourceAppVersionID.String()},
+				},
+			},
+			requestBody:        `{"store_info":true}`,
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Not Found"},
+		})
+	})
+
+	t.Run("when no part is selected and source version is of another platform", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: appVersionServiceWithVersions(testAppID, "android"),
+				JobStatusService:  &testJobStatusService{},
+				WorkerService:     &testWorkerService{},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"source-version-id": testSourceAppVersionID.String()},
+				},
+			},
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: httpresponse.ValidationErrorRespModel{
+				Message: "Unprocessable Entity",
+				Errors: []string{
+					"parts: At least one of store_info, screenshots and feature_graphic has to be selected",
+					"source_version_id: Must be a version of the same platform",
+				},
+			},
+		})
+	})
+
+	t.Run("when copying from the same version", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: appVersionServiceWithVersions(testAppID, "ios"),
+				JobStatusService:  &testJobStatusService{},
+				WorkerService:     &testWorkerService{},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"source-version-id": testAppVersionID.String()},
+				},
+			},
+			requestBody:        `{"screenshots":true}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: httpresponse.ValidationErrorRespModel{
+				Message: "Unprocessable Entity",
+				Errors:  []string{"source_version_id: Must be another version"},
+			},
+		})
+	})
+
+	t.Run("when error happens at enqueueing the job", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AppVersionService: appVersionServiceWithVersions(testAppID, "ios"),
+				JobStatusService: &testJobStatusService{
+					createFn: func(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+						return jobStatus, nil, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueCopyFromAppVersionFn: func(jobStatusID, sourceAppVersionID uuid.UUID, parts models.AppVersionCopyParts) error {
+						return errors.New("SOME-REDIS-ERROR")
+					},
+				},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"source-version-id": testSourceAppVersionID.String()},
+				},
+			},
+			requestBody:         `{"screenshots":true}`,
+			expectedInternalErr: "SOME-REDIS-ERROR",
+		})
+	})
+}
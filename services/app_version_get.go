@@ -69,8 +69,9 @@ func AppVersionGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reques
 	if env.BitriseAPI == nil {
 		return errors.New("No Bitrise API Service defined for handler")
 	}
+	bitriseAPI := env.BitriseAPI.WithContext(r.Context())
 
-	artifacts, err := env.BitriseAPI.GetArtifacts(appVersion.App.BitriseAPIToken, appVersion.App.AppSlug, appVersion.BuildSlug)
+	artifacts, err := bitriseAPI.GetArtifacts(appVersion.App.BitriseAPIToken, appVersion.App.AppSlug, appVersion.BuildSlug)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -90,10 +91,15 @@ func newArtifactVersionGetResponse(appVersion *models.AppVersion, env *env.AppEn
 	var ipaExportMethod string
 	var publicInstallPageArtifactSlug string
 	var publishAndShareInfo bitrise.PublishAndShareInfo
-	switch appVersion.Platform {
-	case "ios":
-		_, publishEnabled, publicInstallPageEnabled, ipaExportMethod, publicInstallPageArtifactSlug = selectIosArtifact(artifacts)
-	case "android":
+	switch {
+	case models.IsApplePlatform(appVersion.Platform):
+		artifactSelector := bitrise.NewArtifactSelector(artifacts)
+		iosArtifacts, err := artifactSelector.IosArtifacts(appVersion)
+		if err != nil {
+			return AppVersionGetResponseData{}, errors.WithStack(err)
+		}
+		_, publishEnabled, publicInstallPageEnabled, ipaExportMethod, publicInstallPageArtifactSlug = selectIosArtifact(iosArtifacts)
+	case appVersion.Platform == "android":
 		var err error
 		artifactSelector := bitrise.NewArtifactSelector(artifacts)
 		publishAndShareInfo, err = artifactSelector.PublishAndShareInfo(appVersion)
@@ -45,13 +45,14 @@ func AppVersionPublishPostHandler(env *env.AppEnv, w http.ResponseWriter, r *htt
 	if env.BitriseAPI == nil {
 		return errors.New("No Bitrise API Service defined for handler")
 	}
+	bitriseAPI := env.BitriseAPI.WithContext(r.Context())
 
 	config, err := getConfigJSON()
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	artifactList, err := env.BitriseAPI.GetArtifacts(
+	artifactList, err := bitriseAPI.GetArtifacts(
 		appVersion.App.BitriseAPIToken,
 		appVersion.App.AppSlug,
 		appVersion.BuildSlug,
@@ -68,15 +69,21 @@ func AppVersionPublishPostHandler(env *env.AppEnv, w http.ResponseWriter, r *htt
 	var workflowToTrigger, stackIDForTrigger string
 	var inlineEnvs map[string]string
 	var secrets map[string]interface{}
-	switch appVersion.Platform {
-	case "ios":
-		artifactData, _, _, _, _ := selectIosArtifact(artifactList)
+	switch {
+	case models.IsApplePlatform(appVersion.Platform):
+		artifactSelector := bitrise.NewArtifactSelector(artifactList)
+		iosArtifacts, err := artifactSelector.IosArtifacts(appVersion)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		artifactData, _, _, _, _ := selectIosArtifact(iosArtifacts)
 		workflowToTrigger = "resign_archive_app_store"
 		stackIDForTrigger = "osx-vs4mac-stable"
 		inlineEnvs = map[string]string{
 			"BITRISE_APP_SLUG":      appVersion.App.AppSlug,
 			"BITRISE_BUILD_SLUG":    appVersion.BuildSlug,
 			"BITRISE_ARTIFACT_SLUG": artifactData.Slug,
+			"APP_STORE_PLATFORM":    appVersion.AppStorePlatform(),
 			"CONFIG_JSON_URL":       fmt.Sprintf("%s/apps/%s/versions/%s/ios-config", env.AddonHostURL, appVersion.App.AppSlug, authorizedAppVersionID),
 		}
 		secrets = map[string]interface{}{"envs": []bitrise.TaskSecret{
@@ -84,7 +91,7 @@ func AppVersionPublishPostHandler(env *env.AppEnv, w http.ResponseWriter, r *htt
 			bitrise.TaskSecret{"ADDON_SHIP_APP_ACCESS_TOKEN": authToken},
 			bitrise.TaskSecret{"SSH_RSA_PRIVATE_KEY": os.Getenv("GITHUB_SSH_KEY")},
 		}}
-	case "android":
+	case appVersion.Platform == "android":
 		workflowToTrigger = "resign_android"
 		stackIDForTrigger = "osx-vs4mac-stable"
 		inlineEnvs = map[string]string{
@@ -102,7 +109,7 @@ func AppVersionPublishPostHandler(env *env.AppEnv, w http.ResponseWriter, r *htt
 	if env.PublishTaskService == nil {
 		return errors.New("No Publish Task Service defined for handler")
 	}
-	response, err := env.BitriseAPI.TriggerDENTask(bitrise.TaskParams{
+	response, err := bitriseAPI.TriggerDENTask(bitrise.TaskParams{
 		StackID:     stackIDForTrigger,
 		Workflow:    workflowToTrigger,
 		BuildConfig: config,
@@ -145,6 +145,79 @@ func Test_AppVersionPublishPostHandler(t *testing.T) {
 					},
 					triggerDENTaskFn: func(params bitrise.TaskParams) (*bitrise.TriggerResponse, error) {
 						require.Equal(t, map[string]string{
+							"APP_STORE_PLATFORM":    "ios",
+							"BITRISE_APP_SLUG":      "test-app-slug",
+							"BITRISE_ARTIFACT_SLUG": "test-artifact-slug",
+							"BITRISE_BUILD_SLUG":    "test-build-slug",
+							"CONFIG_JSON_URL":       "http://ship.addon.url/apps/test-app-slug/versions/de438ddc-98e5-4226-a5f4-fd2d53474879/ios-config",
+						}, params.InlineEnvs)
+						require.Equal(t, map[string]interface{}{"envs": []bitrise.TaskSecret{
+							bitrise.TaskSecret{"BITRISE_ACCESS_TOKEN": "bitrise-api-addon-token"},
+							bitrise.TaskSecret{"ADDON_SHIP_APP_ACCESS_TOKEN": "jwt-token"},
+							bitrise.TaskSecret{"SSH_RSA_PRIVATE_KEY": ""},
+						}}, params.Secrets)
+						require.Equal(t, "http://ship.addon.url/task-webhook", params.WebhookURL)
+						require.Equal(t, "resign_archive_app_store", params.Workflow)
+						return &bitrise.TriggerResponse{TaskIdentifier: testTaskIdentifier}, nil
+					},
+				},
+				PublishTaskService: &testPublishTaskService{
+					createFn: func(publishTask *models.PublishTask) (*models.PublishTask, error) {
+						require.Equal(t, testTaskIdentifier, publishTask.TaskID)
+						return publishTask, nil
+					},
+				},
+				JWTService: &security.JWTMock{
+					SignFn: func(token string) (string, error) {
+						require.Equal(t, "addon-access-token", token)
+						return "jwt-token", nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppVersionPublishResponse{
+				Data: &bitrise.TriggerResponse{TaskIdentifier: testTaskIdentifier},
+			},
+		})
+	})
+
+	t.Run("ok - more complex - tvos", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				AddonHostURL: "http://ship.addon.url",
+				AppVersionService: &testAppVersionService{
+					findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+						require.Equal(t, appVersion.ID, testAppVersionID)
+						return &models.AppVersion{
+							App: models.App{
+								AppSlug:         "test-app-slug",
+								BitriseAPIToken: "bitrise-api-addon-token",
+								APIToken:        "addon-access-token",
+							},
+							Platform:         "tvos",
+							AppStoreInfoData: json.RawMessage(`{}`),
+							BuildSlug:        "test-build-slug",
+						}, nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					getArtifactsFn: func(apiToken string, appSlug string, buildSlug string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						require.Equal(t, "bitrise-api-addon-token", apiToken)
+						require.Equal(t, "test-app-slug", appSlug)
+						require.Equal(t, "test-build-slug", buildSlug)
+						return []bitrise.ArtifactListElementResponseModel{
+							bitrise.ArtifactListElementResponseModel{
+								Slug:  "test-artifact-slug",
+								Title: "my-awesome-app.xcarchive.zip",
+							},
+						}, nil
+					},
+					triggerDENTaskFn: func(params bitrise.TaskParams) (*bitrise.TriggerResponse, error) {
+						require.Equal(t, map[string]string{
+							"APP_STORE_PLATFORM":    "appletvos",
 							"BITRISE_APP_SLUG":      "test-app-slug",
 							"BITRISE_ARTIFACT_SLUG": "test-artifact-slug",
 							"BITRISE_BUILD_SLUG":    "test-build-slug",
@@ -2,6 +2,7 @@ package services
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/bitrise-io/addons-ship-backend/env"
 	"github.com/bitrise-io/addons-ship-backend/models"
@@ -31,6 +32,9 @@ type AppVersionsGetResponse struct {
 	Data []AppVersionsGetResponseElement `json:"data"`
 }
 
+// appVersionsFilters are the query parameters the versions can be filtered by, besides the pull request ID
+var appVersionsFilters = []string{"platform", "branch", "tag", "commit_hash", "triggered_workflow", "triggered_by"}
+
 // AppVersionsGetHandler ...
 func AppVersionsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
 	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
@@ -41,9 +45,22 @@ func AppVersionsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reque
 		return errors.New("No App Service defined for handler")
 	}
 
+	if env.AppVersionService == nil {
+		return errors.New("No App Version Service defined for handler")
+	}
+
 	filterParams := map[string]interface{}{}
-	if platformFilter := r.URL.Query().Get("platform"); platformFilter != "" {
-		filterParams["platform"] = platformFilter
+	for _, filter := range appVersionsFilters {
+		if value := r.URL.Query().Get(filter); value != "" {
+			filterParams[filter] = value
+		}
+	}
+	if pullRequestIDFilter := r.URL.Query().Get("pull_request_id"); pullRequestIDFilter != "" {
+		pullRequestID, err := strconv.Atoi(pullRequestIDFilter)
+		if err != nil {
+			return httpresponse.RespondWithBadRequestError(w, "Invalid pull_request_id filter")
+		}
+		filterParams["pull_request_id"] = pullRequestID
 	}
 
 	app, err := env.AppService.Find(&models.App{Record: models.Record{ID: authorizedAppID}})
@@ -51,11 +68,16 @@ func AppVersionsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reque
 		return errors.Wrap(err, "SQL Error")
 	}
 
+	appVersions, err := env.AppVersionService.FindAll(app, filterParams)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
 	if env.BitriseAPI == nil {
 		return errors.New("No Bitrise API Service defined for handler")
 	}
 
-	response, err := newAppVersionsGetResponse(app, env)
+	response, err := newAppVersionsGetResponse(app, appVersions, env)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -65,7 +87,7 @@ func AppVersionsGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Reque
 	})
 }
 
-func newAppVersionsGetResponse(app *models.App, env *env.AppEnv) ([]AppVersionsGetResponseElement, error) {
+func newAppVersionsGetResponse(app *models.App, appVersions []models.AppVersion, env *env.AppEnv) ([]AppVersionsGetResponseElement, error) {
 	elements := []AppVersionsGetResponseElement{}
 
 	appDetails, err := env.BitriseAPI.GetAppDetails(app.BitriseAPIToken, app.AppSlug)
@@ -78,7 +100,7 @@ func newAppVersionsGetResponse(app *models.App, env *env.AppEnv) ([]AppVersionsG
 		ProjectType: appDetails.ProjectType,
 	}
 
-	for _, appVersion := range app.AppVersions {
+	for _, appVersion := range appVersions {
 		artifactInfo, err := appVersion.ArtifactInfo()
 		if err != nil {
 			return nil, err
@@ -10,6 +10,7 @@ import (
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/addons-ship-backend/services"
 	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
 	"github.com/bitrise-io/go-utils/pointers"
 	"github.com/c2fo/testify/require"
 	"github.com/pkg/errors"
@@ -21,7 +22,7 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 	url := "/apps/{app-slug}/app-versions"
 	handler := services.AppVersionsGetHandler
 
-	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppService", "BitriseAPI"}, ControllerTestCase{
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppService", "AppVersionService", "BitriseAPI"}, ControllerTestCase{
 		contextElements: map[ctxpkg.RequestContextKey]interface{}{
 			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
 		},
@@ -31,6 +32,11 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 					return &models.App{}, nil
 				},
 			},
+			AppVersionService: &testAppVersionService{
+				findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+					return []models.AppVersion{}, nil
+				},
+			},
 			BitriseAPI: &testBitriseAPI{},
 		},
 	})
@@ -40,8 +46,9 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
 		},
 		env: &env.AppEnv{
-			AppService: &testAppService{},
-			BitriseAPI: &testBitriseAPI{},
+			AppService:        &testAppService{},
+			AppVersionService: &testAppVersionService{},
+			BitriseAPI:        &testBitriseAPI{},
 		},
 	})
 
@@ -57,6 +64,12 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 						return &models.App{}, nil
 					},
 				},
+				AppVersionService: &testAppVersionService{
+					findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+						require.Equal(t, map[string]interface{}{}, filterParams)
+						return []models.AppVersion{}, nil
+					},
+				},
 				BitriseAPI: &testBitriseAPI{
 					getArtifactsFn: func(string, string, string) ([]bitrise.ArtifactListElementResponseModel, error) {
 						return []bitrise.ArtifactListElementResponseModel{}, nil
@@ -84,16 +97,19 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 			env: &env.AppEnv{
 				AppService: &testAppService{
 					findFn: func(app *models.App) (*models.App, error) {
-						return &models.App{
-							AppVersions: []models.AppVersion{
-								models.AppVersion{
-									Platform:         "ios",
-									ArtifactInfoData: json.RawMessage(`{"version":"v1.0"}`),
-								},
-								models.AppVersion{
-									Platform:         "android",
-									ArtifactInfoData: json.RawMessage(`{"version":"v1.12"}`),
-								},
+						return &models.App{}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+						return []models.AppVersion{
+							models.AppVersion{
+								Platform:         "ios",
+								ArtifactInfoData: json.RawMessage(`{"version":"v1.0"}`),
+							},
+							models.AppVersion{
+								Platform:         "android",
+								ArtifactInfoData: json.RawMessage(`{"version":"v1.12"}`),
 							},
 						}, nil
 					},
@@ -154,12 +170,16 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 				AppService: &testAppService{
 					findFn: func(app *models.App) (*models.App, error) {
 						require.Equal(t, app.ID.String(), "211afc15-127a-40f9-8cbe-1dadc1f86cdf")
-						return &models.App{
-							AppVersions: []models.AppVersion{
-								models.AppVersion{
-									Platform:         "ios",
-									ArtifactInfoData: json.RawMessage(`{"version":"v1.0"}`),
-								},
+						return &models.App{}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+						require.Equal(t, map[string]interface{}{"platform": "ios"}, filterParams)
+						return []models.AppVersion{
+							models.AppVersion{
+								Platform:         "ios",
+								ArtifactInfoData: json.RawMessage(`{"version":"v1.0"}`),
 							},
 						}, nil
 					},
@@ -190,6 +210,75 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("ok - with build metadata filters", func(t *testing.T) {
+		urlWithFilter := url + "?branch=master&tag=1.0.0&commit_hash=a1b2c3&pull_request_id=12&triggered_workflow=deploy&triggered_by=webhook"
+		performControllerTest(t, httpMethod, urlWithFilter, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf"),
+			},
+			env: &env.AppEnv{
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						require.Equal(t, app.ID.String(), "211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+						return &models.App{}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+						require.Equal(t, map[string]interface{}{
+							"branch":             "master",
+							"tag":                "1.0.0",
+							"commit_hash":        "a1b2c3",
+							"pull_request_id":    12,
+							"triggered_workflow": "deploy",
+							"triggered_by":       "webhook",
+						}, filterParams)
+						return []models.AppVersion{
+							models.AppVersion{
+								Platform:         "ios",
+								Branch:           "master",
+								Tag:              "1.0.0",
+								CommitHash:       "a1b2c3",
+								PullRequestID:    12,
+								TriggeredBy:      "webhook",
+								BuildURL:         "https://app.bitrise.io/build/test-build-slug",
+								ArtifactInfoData: json.RawMessage(`{"version":"v1.0"}`),
+							},
+						}, nil
+					},
+				},
+				BitriseAPI: &testBitriseAPI{
+					getArtifactsFn: func(string, string, string) ([]bitrise.ArtifactListElementResponseModel, error) {
+						return []bitrise.ArtifactListElementResponseModel{}, nil
+					},
+					getArtifactPublicPageURLFn: func(string, string, string, string) (string, error) {
+						return "", nil
+					},
+					getAppDetailsFn: func(string, string) (*bitrise.AppDetails, error) {
+						return &bitrise.AppDetails{}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.AppVersionsGetResponse{
+				Data: []services.AppVersionsGetResponseElement{
+					services.AppVersionsGetResponseElement{
+						AppVersion: models.AppVersion{
+							Platform:      "ios",
+							Branch:        "master",
+							Tag:           "1.0.0",
+							CommitHash:    "a1b2c3",
+							PullRequestID: 12,
+							TriggeredBy:   "webhook",
+							BuildURL:      "https://app.bitrise.io/build/test-build-slug",
+						},
+						Version: "v1.0",
+					},
+				},
+			},
+		})
+	})
+
 	t.Run("error - unexpected error in database", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
@@ -201,11 +290,47 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 						return &models.App{}, errors.New("SOME-SQL-ERROR")
 					},
 				},
+				AppVersionService: &testAppVersionService{},
 			},
 			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
 		})
 	})
 
+	t.Run("error - unexpected error at finding versions in database", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return &models.App{}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when pull request ID filter is not a number", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url+"?pull_request_id=feature", handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				AppService:        &testAppService{},
+				AppVersionService: &testAppVersionService{},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid pull_request_id filter"},
+		})
+	})
+
 	t.Run("when invalid JSON is stored in database for artifact info", func(t *testing.T) {
 		urlWithFilter := url + "?platform=ios"
 		performControllerTest(t, httpMethod, urlWithFilter, handler, ControllerTestCase{
@@ -216,12 +341,15 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 				AppService: &testAppService{
 					findFn: func(app *models.App) (*models.App, error) {
 						require.Equal(t, app.ID.String(), "211afc15-127a-40f9-8cbe-1dadc1f86cdf")
-						return &models.App{
-							AppVersions: []models.AppVersion{
-								models.AppVersion{
-									ArtifactInfoData: json.RawMessage(`invalid JSON`),
-									Platform:         "ios",
-								},
+						return &models.App{}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+						return []models.AppVersion{
+							models.AppVersion{
+								ArtifactInfoData: json.RawMessage(`invalid JSON`),
+								Platform:         "ios",
 							},
 						}, nil
 					},
@@ -252,12 +380,15 @@ func Test_AppVersionsGetHandler(t *testing.T) {
 				AppService: &testAppService{
 					findFn: func(app *models.App) (*models.App, error) {
 						require.Equal(t, app.ID.String(), "211afc15-127a-40f9-8cbe-1dadc1f86cdf")
-						return &models.App{
-							AppVersions: []models.AppVersion{
-								models.AppVersion{
-									ArtifactInfoData: json.RawMessage(`{"version":"v1.0"}`),
-									Platform:         "ios",
-								},
+						return &models.App{}, nil
+					},
+				},
+				AppVersionService: &testAppVersionService{
+					findAllFn: func(app *models.App, filterParams map[string]interface{}) ([]models.AppVersion, error) {
+						return []models.AppVersion{
+							models.AppVersion{
+								ArtifactInfoData: json.RawMessage(`{"version":"v1.0"}`),
+								Platform:         "ios",
 							},
 						}, nil
 					},
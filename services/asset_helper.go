@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// removeUploadableObject drops the reference of the deleted uploadable on its asset, or deletes its
+// uploaded file if it's not stored as an asset
+func removeUploadableObject(env *env.AppEnv, awsPath string, assetID *uuid.UUID) error {
+	if assetID == nil {
+		return errors.WithStack(env.AWS.DeleteObject(awsPath))
+	}
+	err := env.AssetService.Release(*assetID, func(asset *models.Asset) error {
+		return env.AWS.DeleteObject(asset.AWSPath())
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to release asset")
+	}
+	return nil
+}
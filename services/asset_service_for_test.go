@@ -0,0 +1,42 @@
+package services_test
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	uuid "github.com/satori/go.uuid"
+)
+
+type testAssetService struct {
+	acquireFn func(*models.Asset) (*models.Asset, error)
+	retainFn  func([]uuid.UUID) error
+	releaseFn func(uuid.UUID, func(*models.Asset) error) error
+
+	findAllReferencedFn func() ([]models.Asset, error)
+}
+
+func (s *testAssetService) Acquire(asset *models.Asset) (*models.Asset, error) {
+	if s.acquireFn != nil {
+		return s.acquireFn(asset)
+	}
+	panic("You have to override Acquire function in tests")
+}
+
+func (s *testAssetService) Retain(assetIDs []uuid.UUID) error {
+	if s.retainFn != nil {
+		return s.retainFn(assetIDs)
+	}
+	panic("You have to override Retain function in tests")
+}
+
+func (s *testAssetService) Release(assetID uuid.UUID, deleteObject func(*models.Asset) error) error {
+	if s.releaseFn != nil {
+		return s.releaseFn(assetID, deleteObject)
+	}
+	panic("You have to override Release function in tests")
+}
+
+func (s *testAssetService) FindAllReferenced() ([]models.Asset, error) {
+	if s.findAllReferencedFn != nil {
+		return s.findAllReferencedFn()
+	}
+	panic("You have to override FindAllReferenced function in tests")
+}
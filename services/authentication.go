@@ -49,7 +49,11 @@ func AuthenticateWithDENSecretHandlerFunc(env *env.AppEnv, h http.Handler) http.
 			return
 		}
 
-		if authToken != denAdminSecret {
+		signatureValid := authToken == denAdminSecret
+		if webhookDelivery := GetWebhookDeliveryFromContext(r.Context()); webhookDelivery != nil {
+			webhookDelivery.SignatureValid = &signatureValid
+		}
+		if !signatureValid {
 			httpresponse.RespondWithUnauthorizedNoErr(w)
 			return
 		}
@@ -226,6 +226,93 @@ func AuthorizeForAppVersionScreenshotAccessHandlerFunc(env *env.AppEnv, h http.H
 	})
 }
 
+// AuthorizeForAppVersionAppPreviewAccessHandlerFunc ...
+func AuthorizeForAppVersionAppPreviewAccessHandlerFunc(env *env.AppEnv, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if env.RequestParams == nil {
+			httpresponse.RespondWithInternalServerError(w, errors.New("No Request Params provided"))
+			return
+		}
+
+		appVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+		if err != nil {
+			httpresponse.RespondWithInternalServerError(w, err)
+			return
+		}
+
+		appPreviewID, err := getUUIDFromRequest(env, r, "app-preview-id")
+		if err != nil {
+			httpresponse.RespondWithBadRequestErrorNoErr(w, err.Error())
+			return
+		}
+
+		if env.AppPreviewService == nil {
+			httpresponse.RespondWithInternalServerError(w, errors.New("No App Preview Service provided"))
+			return
+		}
+
+		appPreview, err := env.AppPreviewService.Find(&models.AppPreview{Record: models.Record{ID: appPreviewID}, AppVersionID: appVersionID})
+
+		switch {
+		case errors.Cause(err) == gorm.ErrRecordNotFound:
+			httpresponse.RespondWithNotFoundErrorNoErr(w)
+			return
+		case err != nil:
+			httpresponse.RespondWithInternalServerError(w, errors.WithStack(err))
+			return
+		}
+
+		// Access granted
+		ctx := ContextWithAuthorizedAppPreviewID(r.Context(), appPreview.ID)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// AuthorizeForAppVersionStoreGraphicAccessHandlerFunc ...
+func AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(env *env.AppEnv, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if env.RequestParams == nil {
+			httpresponse.RespondWithInternalServerError(w, errors.New("No Request Params provided"))
+			return
+		}
+
+		if _, err := GetAuthorizedAppVersionIDFromContext(r.Context()); err != nil {
+			httpresponse.RespondWithInternalServerError(w, err)
+			return
+		}
+
+		urlVars := env.RequestParams.Get(r)
+		graphicType := urlVars["graphic-type"]
+		if graphicType == "" {
+			httpresponse.RespondWithBadRequestErrorNoErr(w, "Failed to fetch URL param graphic-type")
+			return
+		}
+		if !models.IsValidStoreGraphicType(graphicType) {
+			httpresponse.RespondWithNotFoundErrorNoErr(w)
+			return
+		}
+
+		// Access granted
+		ctx := ContextWithAuthorizedStoreGraphicType(r.Context(), graphicType)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// AuthorizeForAppVersionFeatureGraphicAccessHandlerFunc serves the feature graphic endpoints, which
+// predate the other store graphic types, with the store graphic handlers
+func AuthorizeForAppVersionFeatureGraphicAccessHandlerFunc(env *env.AppEnv, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := GetAuthorizedAppVersionIDFromContext(r.Context()); err != nil {
+			httpresponse.RespondWithInternalServerError(w, err)
+			return
+		}
+
+		// Access granted
+		ctx := ContextWithAuthorizedStoreGraphicType(r.Context(), models.StoreGraphicTypeFeatureGraphic)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // AuthorizeForWebhookHandlerFunc ...
 func AuthorizeForWebhookHandlerFunc(env *env.AppEnv, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -258,6 +345,10 @@ func AuthorizeForWebhookHandlerFunc(env *env.AppEnv, h http.Handler) http.Handle
 			return
 		}
 
+		if webhookDelivery := GetWebhookDeliveryFromContext(r.Context()); webhookDelivery != nil && !uuid.Equal(publishTask.AppVersion.AppID, uuid.UUID{}) {
+			webhookDelivery.AppID = &publishTask.AppVersion.AppID
+		}
+
 		// Access granted
 		ctx := ContextWithAuthorizedAppVersionID(r.Context(), publishTask.AppVersionID)
 		h.ServeHTTP(w, r.WithContext(ctx))
@@ -338,6 +429,47 @@ func AuthorizeForAppContactAccessHandlerFunc(env *env.AppEnv, h http.Handler) ht
 	})
 }
 
+// AuthorizeForAppJobStatusAccessHandlerFunc ...
+func AuthorizeForAppJobStatusAccessHandlerFunc(env *env.AppEnv, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if env.RequestParams == nil {
+			httpresponse.RespondWithInternalServerError(w, errors.New("No Request Params provided"))
+			return
+		}
+
+		appID, err := GetAuthorizedAppIDFromContext(r.Context())
+		if err != nil {
+			httpresponse.RespondWithInternalServerError(w, err)
+			return
+		}
+
+		jobStatusID, err := getUUIDFromRequest(env, r, "job-id")
+		if err != nil {
+			httpresponse.RespondWithBadRequestErrorNoErr(w, err.Error())
+			return
+		}
+
+		if env.JobStatusService == nil {
+			httpresponse.RespondWithInternalServerError(w, errors.New("No Job Status Service provided"))
+			return
+		}
+
+		jobStatus, err := env.JobStatusService.Find(&models.JobStatus{Record: models.Record{ID: jobStatusID}, AppID: appID})
+		switch {
+		case errors.Cause(err) == gorm.ErrRecordNotFound:
+			httpresponse.RespondWithNotFoundErrorNoErr(w)
+			return
+		case err != nil:
+			httpresponse.RespondWithInternalServerError(w, errors.WithStack(err))
+			return
+		}
+
+		// Access granted
+		ctx := ContextWithAuthorizedJobStatusID(r.Context(), jobStatus.ID)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // AuthorizeBuildWebhookForAppAccessFunc ...
 func AuthorizeBuildWebhookForAppAccessFunc(env *env.AppEnv, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -355,12 +487,16 @@ func AuthorizeBuildWebhookForAppAccessFunc(env *env.AppEnv, h http.Handler) http
 		app, err := env.AppService.Find(&models.App{AppSlug: appSlug})
 		switch {
 		case errors.Cause(err) == gorm.ErrRecordNotFound:
-			httpresponse.RespondWithNotFoundErrorNoErr(w)
+			respondToBuildWebhookOfMissingApp(env, w, appSlug)
 			return
 		case err != nil:
 			httpresponse.RespondWithInternalServerError(w, err)
 			return
 		}
+		webhookDelivery := GetWebhookDeliveryFromContext(r.Context())
+		if webhookDelivery != nil {
+			webhookDelivery.AppID = &app.ID
+		}
 
 		if len(app.EncryptedSecretIV) == 0 {
 			ctx := ContextWithAuthorizedAppID(r.Context(), app.ID)
@@ -389,7 +525,11 @@ func AuthorizeBuildWebhookForAppAccessFunc(env *env.AppEnv, h http.Handler) http
 		r.Body = ioutil.NopCloser(bytes.NewReader(payloadBytes))
 
 		signatureVerifier := security.NewSignatureVerifier(appSecret, string(payloadBytes), requestPayloadSignature)
-		if !signatureVerifier.Verify() {
+		signatureValid := signatureVerifier.Verify()
+		if webhookDelivery != nil {
+			webhookDelivery.SignatureValid = &signatureValid
+		}
+		if !signatureValid {
 			httpresponse.RespondWithNotFoundErrorNoErr(w)
 			return
 		}
@@ -414,3 +554,22 @@ func getUUIDFromRequest(env *env.AppEnv, r *http.Request, paramName string) (uui
 
 	return paramUUID, nil
 }
+
+// respondToBuildWebhookOfMissingApp drops the late webhooks of deprovisioned apps with success, so they
+// are not retried, while the webhooks of unknown apps are still rejected
+func respondToBuildWebhookOfMissingApp(env *env.AppEnv, w http.ResponseWriter, appSlug string) {
+	if env.AppTombstoneService == nil {
+		httpresponse.RespondWithInternalServerError(w, errors.New("No App Tombstone Service provided"))
+		return
+	}
+
+	_, err := env.AppTombstoneService.Find(&models.AppTombstone{AppSlug: appSlug})
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		httpresponse.RespondWithNotFoundErrorNoErr(w)
+	case err != nil:
+		httpresponse.RespondWithInternalServerError(w, err)
+	default:
+		httpresponse.RespondWithSuccessNoErr(w, nil)
+	}
+}
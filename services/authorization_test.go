@@ -1006,6 +1006,337 @@ func Test_AuthorizeForAppVersionScreenshotAccessHandlerFunc(t *testing.T) {
 	})
 }
 
+func Test_AuthorizeForAppVersionAppPreviewAccessHandlerFunc(t *testing.T) {
+	authHandler := &handlers.TestAuthHandler{
+		ContextElementList: map[string]ctxpkg.RequestContextKey{
+			"authorizedAppID":        services.ContextKeyAuthorizedAppID,
+			"authorizedAppVersionID": services.ContextKeyAuthorizedAppVersionID,
+			"authorizedAppPreviewID": services.ContextKeyAuthorizedAppPreviewID,
+		},
+	}
+	httpMethod := "GET"
+	url := "/apps/test_app_slug/versions/version_uuid/app-previews/app_preview_uuid"
+
+	testAppID := "211afc15-127a-40f9-8cbe-1dadc1f86cdf"
+	testAppVersionID := "de438ddc-98e5-4226-a5f4-fd2d53474879"
+	testAppPreviewID := "123afc15-127a-40f9-8cbe-1dadc1f86cdf"
+	validRequestParams := &providers.RequestParamsMock{
+		Params: map[string]string{
+			"version-id":     testAppVersionID,
+			"app-preview-id": testAppPreviewID,
+		},
+	}
+
+	successfulTestAppPreviewService := &testAppPreviewService{
+		findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+			require.Equal(t, appPreview.AppVersionID.String(), testAppVersionID)
+			require.Equal(t, appPreview.ID.String(), testAppPreviewID)
+
+			return &models.AppPreview{
+				Record: models.Record{ID: uuid.FromStringOrNil(testAppPreviewID)},
+			}, nil
+		},
+	}
+
+	testRequestHeaders := map[string]string{
+		"Authorization": "token test-auth-token",
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			RequestParams:     validRequestParams,
+			AppPreviewService: successfulTestAppPreviewService,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        uuid.FromStringOrNil(testAppID),
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"authorizedAppID":        testAppID,
+				"authorizedAppVersionID": testAppVersionID,
+				"authorizedAppPreviewID": testAppPreviewID,
+			},
+		})
+	})
+
+	t.Run("when no Request Params object is provided", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			AppPreviewService: successfulTestAppPreviewService,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+
+	t.Run("when no authorized app version ID found in context", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			RequestParams:     validRequestParams,
+			AppPreviewService: successfulTestAppPreviewService,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: nil,
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   map[string]interface{}{"message": "Internal Server Error"},
+		})
+	})
+
+	t.Run("when no app preview id found in url params", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{},
+			},
+			AppPreviewService: successfulTestAppPreviewService,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"message": "Failed to fetch URL param app-preview-id",
+			},
+		})
+	})
+
+	t.Run("when no valid app version id found in url params", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"app-preview-id": "invalid-uuid",
+				},
+			},
+			AppPreviewService: successfulTestAppPreviewService,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"message": "Invalid UUID format for app-preview-id",
+			},
+		})
+	})
+
+	t.Run("when no app preview service is provided in app env", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+
+	t.Run("when app not found in database", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+			AppPreviewService: &testAppPreviewService{
+				findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+					require.Equal(t, appPreview.ID.String(), testAppPreviewID)
+					return &models.AppPreview{}, gorm.ErrRecordNotFound
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: map[string]interface{}{
+				"message": "Not Found",
+			},
+		})
+	})
+
+	t.Run("when unexpected error happens at database query", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionAppPreviewAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+			AppPreviewService: &testAppPreviewService{
+				findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+					require.Equal(t, appPreview.ID.String(), testAppPreviewID)
+					return &models.AppPreview{}, errors.New("SOME-SQL-ERROR")
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+}
+
+func Test_AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(t *testing.T) {
+	authHandler := &handlers.TestAuthHandler{
+		ContextElementList: map[string]ctxpkg.RequestContextKey{
+			"authorizedAppVersionID":     services.ContextKeyAuthorizedAppVersionID,
+			"authorizedStoreGraphicType": services.ContextKeyAuthorizedStoreGraphicType,
+		},
+	}
+	httpMethod := "GET"
+	url := "/apps/test_app_slug/versions/version_uuid/store-graphics/icon"
+
+	testAppVersionID := "de438ddc-98e5-4226-a5f4-fd2d53474879"
+	validRequestParams := &providers.RequestParamsMock{
+		Params: map[string]string{
+			"version-id":   testAppVersionID,
+			"graphic-type": "icon",
+		},
+	}
+
+	testRequestHeaders := map[string]string{
+		"Authorization": "token test-auth-token",
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"authorizedAppVersionID":     testAppVersionID,
+				"authorizedStoreGraphicType": "icon",
+			},
+		})
+	})
+
+	t.Run("when no Request Params object is provided", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(&env.AppEnv{}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+
+	t.Run("when no authorized app version ID found in context", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: nil,
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   map[string]interface{}{"message": "Internal Server Error"},
+		})
+	})
+
+	t.Run("when no graphic type found in url params", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"message": "Failed to fetch URL param graphic-type",
+			},
+		})
+	})
+
+	t.Run("when graphic type is not a supported store graphic type", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"graphic-type": "screenshot",
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: map[string]interface{}{
+				"message": "Not Found",
+			},
+		})
+	})
+}
+
+func Test_AuthorizeForAppVersionFeatureGraphicAccessHandlerFunc(t *testing.T) {
+	authHandler := &handlers.TestAuthHandler{
+		ContextElementList: map[string]ctxpkg.RequestContextKey{
+			"authorizedAppVersionID":     services.ContextKeyAuthorizedAppVersionID,
+			"authorizedStoreGraphicType": services.ContextKeyAuthorizedStoreGraphicType,
+		},
+	}
+	httpMethod := "GET"
+	url := "/apps/test_app_slug/versions/version_uuid/feature-graphic"
+
+	testAppVersionID := "de438ddc-98e5-4226-a5f4-fd2d53474879"
+
+	t.Run("ok", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionFeatureGraphicAccessHandlerFunc(&env.AppEnv{}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil(testAppVersionID),
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"authorizedAppVersionID":     testAppVersionID,
+				"authorizedStoreGraphicType": "feature_graphic",
+			},
+		})
+	})
+
+	t.Run("when app version is not authorized", func(t *testing.T) {
+		handler := services.AuthorizeForAppVersionFeatureGraphicAccessHandlerFunc(&env.AppEnv{}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+}
+
 func Test_AuthorizeForWebhookHandlerFunc(t *testing.T) {
 	authHandler := &handlers.TestAuthHandler{
 		ContextElementList: map[string]ctxpkg.RequestContextKey{
@@ -1383,6 +1714,189 @@ func Test_AuthorizeForAppContactAccessHandlerFunc(t *testing.T) {
 	})
 }
 
+func Test_AuthorizeForAppJobStatusAccessHandlerFunc(t *testing.T) {
+	authHandler := &handlers.TestAuthHandler{
+		ContextElementList: map[string]ctxpkg.RequestContextKey{
+			"authorizedAppID":       services.ContextKeyAuthorizedAppID,
+			"authorizedJobStatusID": services.ContextKeyAuthorizedJobStatusID,
+		},
+	}
+	httpMethod := "GET"
+	url := "/apps/test_app_slug/jobs/job_uuid"
+
+	testAppID := "211afc15-127a-40f9-8cbe-1dadc1f86cdf"
+	testJobStatusID := "123afc15-127a-40f9-8cbe-1dadc1f86cdf"
+	validRequestParams := &providers.RequestParamsMock{
+		Params: map[string]string{
+			"job-id": testJobStatusID,
+		},
+	}
+
+	successfulTestJobStatus := &testJobStatusService{
+		findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+			require.Equal(t, testAppID, jobStatus.AppID.String())
+			require.Equal(t, testJobStatusID, jobStatus.ID.String())
+
+			return &models.JobStatus{
+				Record: models.Record{ID: uuid.FromStringOrNil(testJobStatusID)},
+			}, nil
+		},
+	}
+
+	testRequestHeaders := map[string]string{
+		"Authorization": "token test-auth-token",
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			RequestParams:    validRequestParams,
+			JobStatusService: successfulTestJobStatus,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil(testAppID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"authorizedAppID":       testAppID,
+				"authorizedJobStatusID": testJobStatusID,
+			},
+		})
+	})
+
+	t.Run("when no App ID found in context", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{},
+			},
+			JobStatusService: successfulTestJobStatus,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements:    map[ctxpkg.RequestContextKey]interface{}{},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+
+	t.Run("when no Request Params object is provided", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			JobStatusService: successfulTestJobStatus,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil(testAppID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+
+	t.Run("when no job id found in url params", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{},
+			},
+			JobStatusService: successfulTestJobStatus,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil(testAppID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"message": "Failed to fetch URL param job-id",
+			},
+		})
+	})
+
+	t.Run("when no valid job id found in url params", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"job-id": "invalid-uuid",
+				},
+			},
+			JobStatusService: successfulTestJobStatus,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil(testAppID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"message": "Invalid UUID format for job-id",
+			},
+		})
+	})
+
+	t.Run("when no job status service is provided in app env", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil(testAppID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+
+	t.Run("when job status not found in database", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+			JobStatusService: &testJobStatusService{
+				findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+					return nil, gorm.ErrRecordNotFound
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil(testAppID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: map[string]interface{}{
+				"message": "Not Found",
+			},
+		})
+	})
+
+	t.Run("when unexpected error happens at database query", func(t *testing.T) {
+		handler := services.AuthorizeForAppJobStatusAccessHandlerFunc(&env.AppEnv{
+			RequestParams: validRequestParams,
+			JobStatusService: &testJobStatusService{
+				findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+					return nil, errors.New("SOME-SQL-ERROR")
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.FromStringOrNil(testAppID),
+			},
+			requestHeaders:     testRequestHeaders,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"message": "Internal Server Error",
+			},
+		})
+	})
+}
+
 func Test_AuthorizeBuildWebhookForAppAccessFunc(t *testing.T) {
 	authHandler := &handlers.TestAuthHandler{
 		ContextElementList: map[string]ctxpkg.RequestContextKey{
@@ -1475,6 +1989,12 @@ func Test_AuthorizeBuildWebhookForAppAccessFunc(t *testing.T) {
 					return nil, gorm.ErrRecordNotFound
 				},
 			},
+			AppTombstoneService: &testAppTombstoneService{
+				findFn: func(tombstone *models.AppTombstone) (*models.AppTombstone, error) {
+					require.Equal(t, "test-app-slug", tombstone.AppSlug)
+					return nil, gorm.ErrRecordNotFound
+				},
+			},
 		}, authHandler)
 		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
 			requestHeaders:     testRequestHeaders,
@@ -1484,6 +2004,66 @@ func Test_AuthorizeBuildWebhookForAppAccessFunc(t *testing.T) {
 		})
 	})
 
+	t.Run("when app is deprovisioned, it drops the webhook", func(t *testing.T) {
+		handler := services.AuthorizeBuildWebhookForAppAccessFunc(&env.AppEnv{
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return nil, gorm.ErrRecordNotFound
+				},
+			},
+			AppTombstoneService: &testAppTombstoneService{
+				findFn: func(tombstone *models.AppTombstone) (*models.AppTombstone, error) {
+					require.Equal(t, "test-app-slug", tombstone.AppSlug)
+					tombstone.AppID = testAppID
+					return tombstone, nil
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			requestHeaders:     testRequestHeaders,
+			requestPayload:     map[string]string{"app_slug": "test-app-slug"},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   nil,
+		})
+	})
+
+	t.Run("when no app found and there is no app tombstone service defined", func(t *testing.T) {
+		handler := services.AuthorizeBuildWebhookForAppAccessFunc(&env.AppEnv{
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return nil, gorm.ErrRecordNotFound
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			requestHeaders:     testRequestHeaders,
+			requestPayload:     map[string]string{"app_slug": "test-app-slug"},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Internal Server Error"},
+		})
+	})
+
+	t.Run("when error happens at app tombstone finding", func(t *testing.T) {
+		handler := services.AuthorizeBuildWebhookForAppAccessFunc(&env.AppEnv{
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return nil, gorm.ErrRecordNotFound
+				},
+			},
+			AppTombstoneService: &testAppTombstoneService{
+				findFn: func(tombstone *models.AppTombstone) (*models.AppTombstone, error) {
+					return nil, errors.New("SOME-SQL-ERROR")
+				},
+			},
+		}, authHandler)
+		performAuthorizationTest(t, httpMethod, url, handler, AuthorizationTestCase{
+			requestHeaders:     testRequestHeaders,
+			requestPayload:     map[string]string{"app_slug": "test-app-slug"},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Internal Server Error"},
+		})
+	})
+
 	t.Run("when error happens at app finding", func(t *testing.T) {
 		handler := services.AuthorizeBuildWebhookForAppAccessFunc(&env.AppEnv{
 			AppService: &testAppService{
@@ -1,6 +1,10 @@
 package services_test
 
-import "github.com/bitrise-io/addons-ship-backend/bitrise"
+import (
+	"context"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+)
 
 type testBitriseAPI struct {
 	getArtifactDataFn          func(string, string, string) (*bitrise.ArtifactData, error)
@@ -19,6 +23,8 @@ type testBitriseAPI struct {
 	getServiceAccountFileFn    func(string, string, string) (*bitrise.GenericProjectFile, error)
 	triggerDENTaskFn           func(params bitrise.TaskParams) (*bitrise.TriggerResponse, error)
 	registerWebhookFn          func(string, string, string, string) error
+	unregisterWebhookFn        func(string, string, string) error
+	invalidateAppFn            func(string) error
 }
 
 func (a *testBitriseAPI) GetArtifactData(authToken, appSlug, buildSlug string) (*bitrise.ArtifactData, error) {
@@ -132,3 +138,21 @@ func (a *testBitriseAPI) RegisterWebhook(authToken, appSlug, secret, callbackURL
 	}
 	return a.registerWebhookFn(authToken, appSlug, secret, callbackURL)
 }
+
+func (a *testBitriseAPI) UnregisterWebhook(authToken, appSlug, callbackURL string) error {
+	if a.unregisterWebhookFn == nil {
+		panic("You have to override UnregisterWebhook function in tests")
+	}
+	return a.unregisterWebhookFn(authToken, appSlug, callbackURL)
+}
+
+func (a *testBitriseAPI) WithContext(ctx context.Context) bitrise.APIInterface {
+	return a
+}
+
+func (a *testBitriseAPI) InvalidateApp(appSlug string) error {
+	if a.invalidateAppFn == nil {
+		panic("You have to override InvalidateApp function in tests")
+	}
+	return a.invalidateAppFn(appSlug)
+}
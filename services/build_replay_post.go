@@ -0,0 +1,96 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/ingestion"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httprequest"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+// BuildReplayPostParams ...
+type BuildReplayPostParams struct {
+	Force bool `json:"force"`
+}
+
+// BuildReplayPostHandler stores a build webhook of the build as the one Bitrise would send, and enqueues
+// its processing the same way as for the received ones. Versions already created from the build are
+// kept, unless the replay is forced, when they are replaced. Builds still running can't be replayed.
+func BuildReplayPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if env.AppService == nil {
+		return errors.New("No App Service defined for handler")
+	}
+	if env.BitriseAPI == nil {
+		return errors.New("No Bitrise API Service defined for handler")
+	}
+	bitriseAPI := env.BitriseAPI.WithContext(r.Context())
+	if env.BuildWebhookService == nil {
+		return errors.New("No Build Webhook Service defined for handler")
+	}
+	if env.WorkerService == nil {
+		return errors.New("No Worker Service defined for handler")
+	}
+	if env.RequestParams == nil {
+		return errors.New("No RequestParams defined for handler")
+	}
+
+	buildSlug := env.RequestParams.Get(r)["build-slug"]
+	if buildSlug == "" {
+		return httpresponse.RespondWithBadRequestError(w, "Failed to fetch URL param build-slug")
+	}
+
+	var params BuildReplayPostParams
+	defer httprequest.BodyCloseWithErrorLog(r)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && err != io.EOF {
+		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
+	}
+
+	app, err := env.AppService.Find(&models.App{Record: models.Record{ID: authorizedAppID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	buildDetails, err := bitriseAPI.GetBuildDetails(app.BitriseAPIToken, app.AppSlug, buildSlug)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if buildDetails.Status == bitrise.BuildStatusNotFinished {
+		return httpresponse.RespondWithBadRequestError(w, "Build is not finished yet")
+	}
+	payload, err := json.Marshal(ingestion.BuildWebhookPayload{
+		AppSlug:                app.AppSlug,
+		BuildSlug:              buildSlug,
+		BuildNumber:            buildDetails.BuildNumber,
+		BuildStatus:            buildDetails.Status,
+		BuildTriggeredWorkflow: buildDetails.TriggeredWorkflow,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	buildWebhook, err := env.BuildWebhookService.Create(&models.BuildWebhook{
+		AppID:     authorizedAppID,
+		BuildSlug: buildSlug,
+		Payload:   payload,
+		Status:    models.JobStatusPending,
+	})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	err = env.WorkerService.EnqueueReplayBuildWebhook(buildWebhook.ID, params.Force)
+	if err != nil {
+		return errors.Wrap(err, "Worker Error")
+	}
+
+	return httpresponse.RespondWithJSON(w, http.StatusAccepted, BuildWebhookResponse{Data: buildWebhook})
+}
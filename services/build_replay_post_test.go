@@ -0,0 +1,190 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_BuildReplayPostHandler(t *testing.T) {
+	httpMethod := "POST"
+	url := "/admin/apps/{app-slug}/builds/{build-slug}/replay"
+	handler := services.BuildReplayPostHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppService", "BitriseAPI", "BuildWebhookService", "WorkerService", "RequestParams"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppService:          &testAppService{},
+			BitriseAPI:          &testBitriseAPI{},
+			BuildWebhookService: &testBuildWebhookService{},
+			WorkerService:       &testWorkerService{},
+			RequestParams:       &providers.RequestParamsMock{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			AppService:          &testAppService{},
+			BitriseAPI:          &testBitriseAPI{},
+			BuildWebhookService: &testBuildWebhookService{},
+			WorkerService:       &testWorkerService{},
+			RequestParams:       &providers.RequestParamsMock{},
+		},
+	})
+
+	testAppID := uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+	testBuildWebhookID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+	expectedPayload := `{"app_slug":"test-app-slug","build_slug":"test-build-slug","build_number":12,"build_status":1,"build_triggered_workflow":"deploy"}`
+	replayEnv := func(t *testing.T, expectedForce bool) *env.AppEnv {
+		return &env.AppEnv{
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					require.Equal(t, testAppID, app.ID)
+					app.AppSlug = "test-app-slug"
+					app.BitriseAPIToken = "test-api-token"
+					return app, nil
+				},
+			},
+			BitriseAPI: &testBitriseAPI{
+				getBuildDetailsFn: func(apiToken, appSlug, buildSlug string) (*bitrise.BuildDetails, error) {
+					require.Equal(t, "test-api-token", apiToken)
+					require.Equal(t, "test-app-slug", appSlug)
+					require.Equal(t, "test-build-slug", buildSlug)
+					return &bitrise.BuildDetails{BuildNumber: 12, Status: bitrise.BuildStatusSuccessful, TriggeredWorkflow: "deploy"}, nil
+				},
+			},
+			BuildWebhookService: &testBuildWebhookService{
+				createFn: func(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error) {
+					require.Equal(t, testAppID, buildWebhook.AppID)
+					require.Equal(t, "test-build-slug", buildWebhook.BuildSlug)
+					require.Equal(t, models.JobStatusPending, buildWebhook.Status)
+					require.Equal(t, expectedPayload, string(buildWebhook.Payload))
+					buildWebhook.ID = testBuildWebhookID
+					buildWebhook.Result = json.RawMessage(`{}`)
+					return buildWebhook, nil
+				},
+			},
+			WorkerService: &testWorkerService{
+				enqueueReplayBuildWebhookFn: func(buildWebhookID uuid.UUID, replaceVersions bool) error {
+					require.Equal(t, testBuildWebhookID, buildWebhookID)
+					require.Equal(t, expectedForce, replaceVersions)
+					return nil
+				},
+			},
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{"build-slug": "test-build-slug"},
+			},
+		}
+	}
+	expectedResponse := services.BuildWebhookResponse{
+		Data: &models.BuildWebhook{
+			Record:    models.Record{ID: testBuildWebhookID},
+			BuildSlug: "test-build-slug",
+			Payload:   json.RawMessage(expectedPayload),
+			Status:    models.JobStatusPending,
+			Result:    json.RawMessage(`{}`),
+		},
+	}
+
+	t.Run("ok - without request body", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env:                replayEnv(t, false),
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse:   expectedResponse,
+		})
+	})
+
+	t.Run("ok - forced", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env:                replayEnv(t, true),
+			requestBody:        `{"force":true}`,
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse:   expectedResponse,
+		})
+	})
+
+	t.Run("when request body contains invalid JSON", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env:                replayEnv(t, false),
+			requestBody:        `invalid JSON`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid request body, JSON decode failed"},
+		})
+	})
+
+	t.Run("when build is not finished yet", func(t *testing.T) {
+		testEnv := replayEnv(t, false)
+		testEnv.BitriseAPI = &testBitriseAPI{
+			getBuildDetailsFn: func(apiToken, appSlug, buildSlug string) (*bitrise.BuildDetails, error) {
+				return &bitrise.BuildDetails{BuildNumber: 12, Status: bitrise.BuildStatusNotFinished}, nil
+			},
+		}
+		testEnv.BuildWebhookService = &testBuildWebhookService{}
+		testEnv.WorkerService = &testWorkerService{}
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env:                testEnv,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Build is not finished yet"},
+		})
+	})
+
+	t.Run("when error happens at fetching build details", func(t *testing.T) {
+		testEnv := replayEnv(t, false)
+		testEnv.BitriseAPI = &testBitriseAPI{
+			getBuildDetailsFn: func(apiToken, appSlug, buildSlug string) (*bitrise.BuildDetails, error) {
+				return nil, errors.New("SOME-BITRISE-API-ERROR")
+			},
+		}
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env:                 testEnv,
+			expectedInternalErr: "SOME-BITRISE-API-ERROR",
+		})
+	})
+
+	t.Run("when error happens at enqueueing the processing", func(t *testing.T) {
+		testEnv := replayEnv(t, false)
+		testEnv.WorkerService = &testWorkerService{
+			enqueueReplayBuildWebhookFn: func(buildWebhookID uuid.UUID, replaceVersions bool) error {
+				return errors.New("SOME-REDIS-ERROR")
+			},
+		}
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env:                 testEnv,
+			expectedInternalErr: "Worker Error: SOME-REDIS-ERROR",
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// BuildWebhookGetResponse ...
+type BuildWebhookGetResponse struct {
+	Data *models.BuildWebhook `json:"data"`
+}
+
+// BuildWebhookGetHandler returns the latest webhook of the build with the outcome of its processing
+func BuildWebhookGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if env.BuildWebhookService == nil {
+		return errors.New("No Build Webhook Service defined for handler")
+	}
+	if env.RequestParams == nil {
+		return errors.New("No RequestParams defined for handler")
+	}
+
+	buildSlug := env.RequestParams.Get(r)["build-slug"]
+	if buildSlug == "" {
+		return httpresponse.RespondWithBadRequestError(w, "Failed to fetch URL param build-slug")
+	}
+
+	buildWebhook, err := env.BuildWebhookService.Find(&models.BuildWebhook{AppID: authorizedAppID, BuildSlug: buildSlug})
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return httpresponse.RespondWithNotFoundError(w)
+	case err != nil:
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	return httpresponse.RespondWithSuccess(w, BuildWebhookGetResponse{
+		Data: buildWebhook,
+	})
+}
@@ -0,0 +1,124 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_BuildWebhookGetHandler(t *testing.T) {
+	httpMethod := "GET"
+	url := "/apps/{app-slug}/builds/{build-slug}/webhook"
+	handler := services.BuildWebhookGetHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"BuildWebhookService", "RequestParams"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			BuildWebhookService: &testBuildWebhookService{},
+			RequestParams:       &providers.RequestParamsMock{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			BuildWebhookService: &testBuildWebhookService{},
+			RequestParams:       &providers.RequestParamsMock{},
+		},
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		testAppID := uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+		testBuildWebhookID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env: &env.AppEnv{
+				BuildWebhookService: &testBuildWebhookService{
+					findFn: func(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error) {
+						require.Equal(t, testAppID, buildWebhook.AppID)
+						require.Equal(t, "test-build-slug", buildWebhook.BuildSlug)
+						buildWebhook.ID = testBuildWebhookID
+						buildWebhook.Payload = json.RawMessage(`{}`)
+						buildWebhook.Status = models.JobStatusFailed
+						buildWebhook.Attempts = 5
+						buildWebhook.Message = "SOME-BITRISE-API-ERROR"
+						buildWebhook.Result = json.RawMessage(`{}`)
+						return buildWebhook, nil
+					},
+				},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"build-slug": "test-build-slug"},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.BuildWebhookGetResponse{
+				Data: &models.BuildWebhook{
+					Record:    models.Record{ID: testBuildWebhookID},
+					BuildSlug: "test-build-slug",
+					Payload:   json.RawMessage(`{}`),
+					Status:    models.JobStatusFailed,
+					Attempts:  5,
+					Message:   "SOME-BITRISE-API-ERROR",
+					Result:    json.RawMessage(`{}`),
+				},
+			},
+		})
+	})
+
+	t.Run("when no webhook was received for the build", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				BuildWebhookService: &testBuildWebhookService{
+					findFn: func(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error) {
+						return nil, gorm.ErrRecordNotFound
+					},
+				},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"build-slug": "test-build-slug"},
+				},
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Not Found"},
+		})
+	})
+
+	t.Run("when db error happens at finding the webhook", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				BuildWebhookService: &testBuildWebhookService{
+					findFn: func(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				RequestParams: &providers.RequestParamsMock{
+					Params: map[string]string{"build-slug": "test-build-slug"},
+				},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+}
@@ -2,39 +2,25 @@ package services
 
 import (
 	"encoding/json"
-	"fmt"
+	"io/ioutil"
 	"net/http"
-	"runtime/debug"
-	"strings"
-	"time"
 
-	"github.com/bitrise-io/addons-ship-backend/bitrise"
 	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/ingestion"
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/api-utils/httprequest"
 	"github.com/bitrise-io/api-utils/httpresponse"
-	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
-	"github.com/simonmarton/common-colors/processimage"
-	"go.uber.org/zap"
 )
 
-// BuildWebhookPayload ...
-type BuildWebhookPayload struct {
-	AppSlug                string `json:"app_slug"`
-	BuildSlug              string `json:"build_slug"`
-	BuildNumber            int    `json:"build_number"`
-	BuildStatus            int    `json:"build_status"`
-	BuildTriggeredWorkflow string `json:"build_triggered_workflow"`
+// BuildWebhookResponse ...
+type BuildWebhookResponse struct {
+	Data *models.BuildWebhook `json:"data"`
 }
 
-// BuildWebhookHandler ...
+// BuildWebhookHandler stores the payload of finished builds and enqueues their processing, the versions
+// are created by the worker
 func BuildWebhookHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
-	defer func() {
-		if r := recover(); r != nil {
-			env.Logger.Error("Panic recover", zap.String("trace", string(debug.Stack())))
-		}
-	}()
 	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
 	if err != nil {
 		return errors.WithStack(err)
@@ -44,209 +30,42 @@ func BuildWebhookHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request
 	case "build/triggered":
 		return httpresponse.RespondWithSuccess(w, nil)
 	case "build/finished":
-		if env.AppService == nil {
-			return errors.New("No App Service defined for handler")
-		}
-		if env.AppSettingsService == nil {
-			return errors.New("No App Settings Service defined for handler")
-		}
-		if env.AppVersionService == nil {
-			return errors.New("No App Version Service defined for handler")
-		}
-		if env.AppVersionEventService == nil {
-			return errors.New("No App Version Event Service defined for handler")
-		}
-		if env.BitriseAPI == nil {
-			return errors.New("No Bitrise API Service defined for handler")
-		}
-		if env.AppContactService == nil {
-			return errors.New("No App Contact Service defined for handler")
+		if env.BuildWebhookService == nil {
+			return errors.New("No Build Webhook Service defined for handler")
 		}
 		if env.WorkerService == nil {
 			return errors.New("No Worker Service defined for handler")
 		}
-		var params BuildWebhookPayload
 		defer httprequest.BodyCloseWithErrorLog(r)
-		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-			return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
-		}
-
-		_, err := env.AppService.Find(&models.App{Record: models.Record{ID: authorizedAppID}})
+		payload, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			return errors.Wrap(err, "SQL Error")
+			return errors.Wrap(err, "Failed to read request body")
 		}
-
-		appSettings, err := env.AppSettingsService.Find(&models.AppSettings{AppID: authorizedAppID})
-		switch {
-		case errors.Cause(err) == gorm.ErrRecordNotFound:
-			return httpresponse.RespondWithNotFoundError(w)
-		case err != nil:
-			return errors.Wrap(err, "SQL Error")
+		var params ingestion.BuildWebhookPayload
+		if err := json.Unmarshal(payload, &params); err != nil {
+			return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
 		}
 
-		app := appSettings.App
-
-		artifacts, err := env.BitriseAPI.GetArtifacts(app.BitriseAPIToken, app.AppSlug, params.BuildSlug)
+		buildWebhook, err := env.BuildWebhookService.Create(&models.BuildWebhook{
+			AppID:     authorizedAppID,
+			BuildSlug: params.BuildSlug,
+			Payload:   payload,
+			Status:    models.JobStatusPending,
+		})
 		if err != nil {
-			return errors.WithStack(err)
+			return errors.Wrap(err, "SQL Error")
 		}
-
-		appDetails, err := env.BitriseAPI.GetAppDetails(app.BitriseAPIToken, app.AppSlug)
-		if err != nil {
-			return errors.WithStack(err)
+		if webhookDelivery := GetWebhookDeliveryFromContext(r.Context()); webhookDelivery != nil {
+			webhookDelivery.BuildWebhookID = &buildWebhook.ID
 		}
 
-		buildDetails, err := env.BitriseAPI.GetBuildDetails(app.BitriseAPIToken, app.AppSlug, params.BuildSlug)
+		err = env.WorkerService.EnqueueProcessBuildWebhook(buildWebhook.ID)
 		if err != nil {
-			return errors.WithStack(err)
-		}
-
-		if appDetails.AvatarURL != nil {
-			colors, err := processimage.FromURL(*appDetails.AvatarURL)
-			if err != nil {
-				env.Logger.Warn("Failed to generate header colors", zap.Any("app_details", appDetails), zap.Error(err))
-			} else {
-				app.HeaderColor1 = colors[0]
-				app.HeaderColor2 = colors[1]
-				verrs, err := env.AppService.Update(app, []string{"HeaderColor1", "HeaderColor2"})
-				if len(verrs) > 0 {
-					return httpresponse.RespondWithUnprocessableEntity(w, verrs)
-				}
-				if err != nil {
-					return errors.Wrap(err, "SQL Error")
-				}
-			}
+			return errors.Wrap(err, "Worker Error")
 		}
 
-		iosVersionCreated := false
-
-		workflowInWhitelist := params.BuildTriggeredWorkflow != "" && strings.Contains(appSettings.IosWorkflow, params.BuildTriggeredWorkflow)
-		if (appSettings.IosWorkflow == "" || workflowInWhitelist) && hasIosArtifact(artifacts) {
-			latestAppVersion, err := env.AppVersionService.Latest(&models.AppVersion{AppID: app.ID, Platform: "ios"})
-			if err != nil && errors.Cause(err) != gorm.ErrRecordNotFound {
-				return errors.Wrap(err, "SQL Error")
-			}
-			appVersion, err := prepareAppVersionForIosPlatform(w, r, artifacts, params.BuildSlug)
-			if err != nil {
-				return err
-			}
-			appVersion.LastUpdate = time.Now()
-			appVersion.AppID = authorizedAppID
-			appVersion.CommitMessage = buildDetails.CommitMessage
-			if latestAppVersion != nil {
-				appVersion.AppStoreInfoData = latestAppVersion.AppStoreInfoData
-			}
-			appVersion, verrs, err := env.AppVersionService.Create(appVersion)
-			if len(verrs) > 0 {
-				return httpresponse.RespondWithUnprocessableEntity(w, verrs)
-			}
-			if err != nil {
-				return errors.Wrap(err, "SQL Error")
-			}
-			iosVersionCreated = true
-			if latestAppVersion != nil {
-				err := env.WorkerService.EnqueueCopyUploadablesToNewAppVersion(latestAppVersion.ID.String(), appVersion.ID.String())
-				if err != nil {
-					return errors.Wrap(err, "Worker Error")
-				}
-			} else {
-				env.AnalyticsClient.FirstVersionCreated(app.AppSlug, params.BuildSlug, "ios")
-			}
-
-			_, err = env.AppVersionEventService.Create(&models.AppVersionEvent{AppVersionID: appVersion.ID, Text: "New version was created"})
-			if err != nil {
-				return errors.Wrap(err, "SQL Error")
-			}
-
-			if err := sendNotification(env, appVersion, app, appDetails); err != nil {
-				return errors.WithStack(err)
-			}
-		}
-
-		artifactSelector := bitrise.NewArtifactSelector(artifacts)
-		workflowInWhitelist = params.BuildTriggeredWorkflow != "" && strings.Contains(appSettings.AndroidWorkflow, params.BuildTriggeredWorkflow)
-		if (appSettings.AndroidWorkflow == "" || workflowInWhitelist) && artifactSelector.HasAndroidArtifact() {
-			androidSettings, err := appSettings.AndroidSettings()
-			if err != nil {
-				return errors.WithStack(err)
-			}
-
-			appVersions, settingsErr, err := artifactSelector.PrepareAndroidAppVersions(params.BuildSlug, fmt.Sprintf("%d", params.BuildNumber), buildDetails.CommitMessage, androidSettings.Module)
-			if settingsErr != nil {
-				app.AndroidErrors = []string{settingsErr.Error()}
-				verrs, err := env.AppService.Update(app, []string{"AndroidErrors"})
-				if len(verrs) > 0 {
-					return httpresponse.RespondWithUnprocessableEntity(w, verrs)
-				}
-				if err != nil {
-					return errors.Wrap(err, "SQL Error")
-				}
-
-				return httpresponse.RespondWithUnprocessableEntity(w, []error{settingsErr})
-			}
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			for _, version := range appVersions {
-				latestAppVersion, err := env.AppVersionService.Latest(&models.AppVersion{
-					AppID:         app.ID,
-					Platform:      "android",
-					ProductFlavor: version.ProductFlavor,
-				})
-				if err != nil && errors.Cause(err) != gorm.ErrRecordNotFound {
-					return errors.Wrap(err, "SQL Error")
-				}
-				version.AppID = authorizedAppID
-				appVersion, verrs, err := env.AppVersionService.Create(&version)
-				if len(verrs) > 0 {
-					return httpresponse.RespondWithUnprocessableEntity(w, verrs)
-				}
-				if err != nil {
-					return errors.Wrap(err, "SQL Error")
-				}
-
-				if latestAppVersion != nil {
-					err := env.WorkerService.EnqueueCopyUploadablesToNewAppVersion(latestAppVersion.ID.String(), appVersion.ID.String())
-					if err != nil {
-						return errors.Wrap(err, "Worker Error")
-					}
-				} else if !iosVersionCreated {
-					env.AnalyticsClient.FirstVersionCreated(app.AppSlug, params.BuildSlug, "android")
-				}
-
-				_, err = env.AppVersionEventService.Create(&models.AppVersionEvent{AppVersionID: appVersion.ID, Text: "New version was created"})
-				if err != nil {
-					return errors.Wrap(err, "SQL Error")
-				}
-
-				if err := sendNotification(env, appVersion, app, appDetails); err != nil {
-					return errors.WithStack(err)
-				}
-
-				if len(app.AndroidErrors) > 0 {
-					app.AndroidErrors = []string{}
-					verrs, err = env.AppService.Update(app, []string{"AndroidErrors"})
-					if len(verrs) > 0 {
-						return httpresponse.RespondWithUnprocessableEntity(w, verrs)
-					}
-					if err != nil {
-						return errors.Wrap(err, "SQL Error")
-					}
-				}
-			}
-		}
-
-		return httpresponse.RespondWithSuccess(w, nil)
+		return httpresponse.RespondWithJSON(w, http.StatusAccepted, BuildWebhookResponse{Data: buildWebhook})
 	default:
 		return errors.New("Invalid build event")
 	}
 }
-
-func sendNotification(env *env.AppEnv, appVersion *models.AppVersion, app *models.App, appDetails *bitrise.AppDetails) error {
-	appContacts, err := env.AppContactService.FindAll(app)
-	appVersion.App = *app
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	return env.Mailer.SendEmailNewVersion(appVersion, appContacts, env.AddonFrontendHostURL, appDetails)
-}
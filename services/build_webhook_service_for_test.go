@@ -0,0 +1,30 @@
+package services_test
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+type testBuildWebhookService struct {
+	createFn func(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error)
+	findFn   func(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error)
+	updateFn func(buildWebhook *models.BuildWebhook, whitelist []string) error
+}
+
+func (s *testBuildWebhookService) Create(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error) {
+	if s.createFn == nil {
+		panic("You have to override BuildWebhookService.Create function in tests")
+	}
+	return s.createFn(buildWebhook)
+}
+
+func (s *testBuildWebhookService) Find(buildWebhook *models.BuildWebhook) (*models.BuildWebhook, error) {
+	if s.findFn == nil {
+		panic("You have to override BuildWebhookService.Find function in tests")
+	}
+	return s.findFn(buildWebhook)
+}
+
+func (s *testBuildWebhookService) Update(buildWebhook *models.BuildWebhook, whitelist []string) error {
+	if s.updateFn == nil {
+		panic("You have to override BuildWebhookService.Update function in tests")
+	}
+	return s.updateFn(buildWebhook, whitelist)
+}
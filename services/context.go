@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/bitrise-io/addons-ship-backend/models"
 	ctxpkg "github.com/bitrise-io/api-utils/context"
 	uuid "github.com/satori/go.uuid"
 )
@@ -15,8 +16,16 @@ const (
 	ContextKeyAuthorizedAppVersionID ctxpkg.RequestContextKey = "ctx-authorized-app-version-id"
 	// ContextKeyAuthorizedScreenshotID ...
 	ContextKeyAuthorizedScreenshotID ctxpkg.RequestContextKey = "ctx-authorized-screenshot-id"
+	// ContextKeyAuthorizedAppPreviewID ...
+	ContextKeyAuthorizedAppPreviewID ctxpkg.RequestContextKey = "ctx-authorized-app-preview-id"
+	// ContextKeyAuthorizedStoreGraphicType ...
+	ContextKeyAuthorizedStoreGraphicType ctxpkg.RequestContextKey = "ctx-authorized-store-graphic-type"
 	// ContextKeyAuthorizedAppContactID ...
 	ContextKeyAuthorizedAppContactID ctxpkg.RequestContextKey = "ctx-authorized-app-contact-id"
+	// ContextKeyAuthorizedJobStatusID ...
+	ContextKeyAuthorizedJobStatusID ctxpkg.RequestContextKey = "ctx-authorized-job-status-id"
+	// ContextKeyWebhookDelivery ...
+	ContextKeyWebhookDelivery ctxpkg.RequestContextKey = "ctx-webhook-delivery"
 )
 
 // GetAuthorizedAppIDFromContext ...
@@ -61,6 +70,34 @@ func ContextWithAuthorizedScreenshotID(ctx context.Context, screenshotID uuid.UU
 	return context.WithValue(ctx, ContextKeyAuthorizedScreenshotID, screenshotID)
 }
 
+// GetAuthorizedAppPreviewIDFromContext ...
+func GetAuthorizedAppPreviewIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	id, ok := ctx.Value(ContextKeyAuthorizedAppPreviewID).(uuid.UUID)
+	if !ok {
+		return uuid.UUID{}, errors.New("Authorized App Version App Preview ID not found in Context")
+	}
+	return id, nil
+}
+
+// ContextWithAuthorizedAppPreviewID ...
+func ContextWithAuthorizedAppPreviewID(ctx context.Context, appPreviewID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ContextKeyAuthorizedAppPreviewID, appPreviewID)
+}
+
+// GetAuthorizedStoreGraphicTypeFromContext ...
+func GetAuthorizedStoreGraphicTypeFromContext(ctx context.Context) (string, error) {
+	graphicType, ok := ctx.Value(ContextKeyAuthorizedStoreGraphicType).(string)
+	if !ok {
+		return "", errors.New("Authorized Store Graphic Type not found in Context")
+	}
+	return graphicType, nil
+}
+
+// ContextWithAuthorizedStoreGraphicType ...
+func ContextWithAuthorizedStoreGraphicType(ctx context.Context, graphicType string) context.Context {
+	return context.WithValue(ctx, ContextKeyAuthorizedStoreGraphicType, graphicType)
+}
+
 // GetAuthorizedAppContactIDFromContext ...
 func GetAuthorizedAppContactIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	id, ok := ctx.Value(ContextKeyAuthorizedAppContactID).(uuid.UUID)
@@ -74,3 +111,28 @@ func GetAuthorizedAppContactIDFromContext(ctx context.Context) (uuid.UUID, error
 func ContextWithAuthorizedAppContactID(ctx context.Context, appContactID uuid.UUID) context.Context {
 	return context.WithValue(ctx, ContextKeyAuthorizedAppContactID, appContactID)
 }
+
+// GetAuthorizedJobStatusIDFromContext ...
+func GetAuthorizedJobStatusIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	id, ok := ctx.Value(ContextKeyAuthorizedJobStatusID).(uuid.UUID)
+	if !ok {
+		return uuid.UUID{}, errors.New("Authorized Job Status ID not found in Context")
+	}
+	return id, nil
+}
+
+// ContextWithAuthorizedJobStatusID ...
+func ContextWithAuthorizedJobStatusID(ctx context.Context, jobStatusID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ContextKeyAuthorizedJobStatusID, jobStatusID)
+}
+
+// GetWebhookDeliveryFromContext returns the delivery recorded for the webhook request, or nil
+func GetWebhookDeliveryFromContext(ctx context.Context) *models.WebhookDelivery {
+	webhookDelivery, _ := ctx.Value(ContextKeyWebhookDelivery).(*models.WebhookDelivery)
+	return webhookDelivery
+}
+
+// ContextWithWebhookDelivery ...
+func ContextWithWebhookDelivery(ctx context.Context, webhookDelivery *models.WebhookDelivery) context.Context {
+	return context.WithValue(ctx, ContextKeyWebhookDelivery, webhookDelivery)
+}
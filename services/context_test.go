@@ -124,6 +124,72 @@ func Test_ContextWithAuthorizedScreenshotID(t *testing.T) {
 	})
 }
 
+func Test_GetAuthorizedAppPreviewIDFromContext(t *testing.T) {
+	testUUID := uuid.NewV4()
+
+	t.Run("ok", func(t *testing.T) {
+		appPreviewID, err := services.GetAuthorizedAppPreviewIDFromContext(context.WithValue(context.Background(), services.ContextKeyAuthorizedAppPreviewID, testUUID))
+		require.NoError(t, err)
+		require.Equal(t, testUUID, appPreviewID)
+	})
+
+	t.Run("error - value is not an UUID", func(t *testing.T) {
+		appPreviewID, err := services.GetAuthorizedAppPreviewIDFromContext(context.WithValue(context.Background(), services.ContextKeyAuthorizedAppPreviewID, "17"))
+		require.Equal(t, "Authorized App Version App Preview ID not found in Context", err.Error())
+		require.Equal(t, uuid.UUID{}, appPreviewID)
+	})
+
+	t.Run("error - wrong key", func(t *testing.T) {
+		appPreviewID, err := services.GetAuthorizedAppPreviewIDFromContext(context.WithValue(context.Background(), ctxpkg.RequestContextKey("WrongKey"), testUUID))
+		require.Equal(t, "Authorized App Version App Preview ID not found in Context", err.Error())
+		require.Equal(t, uuid.UUID{}, appPreviewID)
+	})
+}
+
+func Test_ContextWithAuthorizedAppPreviewID(t *testing.T) {
+	testUUID := uuid.NewV4()
+	t.Run("ok", func(t *testing.T) {
+		contextWithValue := services.ContextWithAuthorizedAppPreviewID(context.Background(), testUUID)
+		expectedContext := context.WithValue(context.Background(), services.ContextKeyAuthorizedAppPreviewID, testUUID)
+		require.Equal(t, expectedContext, contextWithValue)
+	})
+
+	t.Run("ok - the last set value is the valid", func(t *testing.T) {
+		anotherTestUUID := uuid.NewV4()
+		previousContext := context.WithValue(context.Background(), services.ContextKeyAuthorizedAppPreviewID, testUUID)
+		contextWithValue := services.ContextWithAuthorizedAppPreviewID(previousContext, anotherTestUUID)
+		require.Equal(t, anotherTestUUID, contextWithValue.Value(services.ContextKeyAuthorizedAppPreviewID))
+	})
+}
+
+func Test_GetAuthorizedStoreGraphicTypeFromContext(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		graphicType, err := services.GetAuthorizedStoreGraphicTypeFromContext(context.WithValue(context.Background(), services.ContextKeyAuthorizedStoreGraphicType, "icon"))
+		require.NoError(t, err)
+		require.Equal(t, "icon", graphicType)
+	})
+
+	t.Run("error - value is not a string", func(t *testing.T) {
+		graphicType, err := services.GetAuthorizedStoreGraphicTypeFromContext(context.WithValue(context.Background(), services.ContextKeyAuthorizedStoreGraphicType, 17))
+		require.Equal(t, "Authorized Store Graphic Type not found in Context", err.Error())
+		require.Equal(t, "", graphicType)
+	})
+
+	t.Run("error - wrong key", func(t *testing.T) {
+		graphicType, err := services.GetAuthorizedStoreGraphicTypeFromContext(context.WithValue(context.Background(), ctxpkg.RequestContextKey("WrongKey"), "icon"))
+		require.Equal(t, "Authorized Store Graphic Type not found in Context", err.Error())
+		require.Equal(t, "", graphicType)
+	})
+}
+
+func Test_ContextWithAuthorizedStoreGraphicType(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		contextWithValue := services.ContextWithAuthorizedStoreGraphicType(context.Background(), "icon")
+		expectedContext := context.WithValue(context.Background(), services.ContextKeyAuthorizedStoreGraphicType, "icon")
+		require.Equal(t, expectedContext, contextWithValue)
+	})
+}
+
 func Test_GetAuthorizedAppContactIDFromContext(t *testing.T) {
 	testUUID := uuid.NewV4()
 
@@ -161,3 +227,41 @@ func Test_ContextWithAuthorizedAppContactID(t *testing.T) {
 		require.Equal(t, anotherTestUUID, contextWithValue.Value(services.ContextKeyAuthorizedAppContactID))
 	})
 }
+
+func Test_GetAuthorizedJobStatusIDFromContext(t *testing.T) {
+	testUUID := uuid.NewV4()
+
+	t.Run("ok", func(t *testing.T) {
+		jobStatusID, err := services.GetAuthorizedJobStatusIDFromContext(context.WithValue(context.Background(), services.ContextKeyAuthorizedJobStatusID, testUUID))
+		require.NoError(t, err)
+		require.Equal(t, testUUID, jobStatusID)
+	})
+
+	t.Run("error - value is not an UUID", func(t *testing.T) {
+		jobStatusID, err := services.GetAuthorizedJobStatusIDFromContext(context.WithValue(context.Background(), services.ContextKeyAuthorizedJobStatusID, "17"))
+		require.Equal(t, "Authorized Job Status ID not found in Context", err.Error())
+		require.Equal(t, uuid.UUID{}, jobStatusID)
+	})
+
+	t.Run("error - wrong key", func(t *testing.T) {
+		jobStatusID, err := services.GetAuthorizedJobStatusIDFromContext(context.WithValue(context.Background(), ctxpkg.RequestContextKey("WrongKey"), testUUID))
+		require.Equal(t, "Authorized Job Status ID not found in Context", err.Error())
+		require.Equal(t, uuid.UUID{}, jobStatusID)
+	})
+}
+
+func Test_ContextWithAuthorizedJobStatusID(t *testing.T) {
+	testUUID := uuid.NewV4()
+	t.Run("ok", func(t *testing.T) {
+		contextWithValue := services.ContextWithAuthorizedJobStatusID(context.Background(), testUUID)
+		expectedContext := context.WithValue(context.Background(), services.ContextKeyAuthorizedJobStatusID, testUUID)
+		require.Equal(t, expectedContext, contextWithValue)
+	})
+
+	t.Run("ok - the last set value is the valid", func(t *testing.T) {
+		anotherTestUUID := uuid.NewV4()
+		previousContext := context.WithValue(context.Background(), services.ContextKeyAuthorizedJobStatusID, testUUID)
+		contextWithValue := services.ContextWithAuthorizedJobStatusID(previousContext, anotherTestUUID)
+		require.Equal(t, anotherTestUUID, contextWithValue.Value(services.ContextKeyAuthorizedJobStatusID))
+	})
+}
@@ -10,7 +10,8 @@ import (
 	"github.com/pkg/errors"
 )
 
-// DeprovisionHandler ...
+// DeprovisionHandler deletes the app and records its tombstone, the stored files of the app and its
+// webhook on Bitrise are cleaned up by a worker job
 func DeprovisionHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
 	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
 	if err != nil {
@@ -19,6 +20,12 @@ func DeprovisionHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request)
 	if env.AppService == nil {
 		return errors.New("No App Service defined for handler")
 	}
+	if env.AppTombstoneService == nil {
+		return errors.New("No App Tombstone Service defined for handler")
+	}
+	if env.WorkerService == nil {
+		return errors.New("No Worker Service defined for handler")
+	}
 
 	app, err := env.AppService.Find(&models.App{Record: models.Record{ID: authorizedAppID}})
 	switch {
@@ -28,10 +35,17 @@ func DeprovisionHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request)
 		return errors.Wrap(err, "SQL Error")
 	}
 
-	err = env.AppService.Delete(&models.App{Record: models.Record{ID: authorizedAppID}})
-	if err != nil {
+	tombstone, err := env.AppTombstoneService.Bury(app)
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return httpresponse.RespondWithNotFoundError(w)
+	case err != nil:
 		return errors.Wrap(err, "SQL Error")
 	}
 
+	if err := env.WorkerService.EnqueueDeprovisionApp(tombstone.ID); err != nil {
+		return errors.WithStack(err)
+	}
+
 	return httpresponse.RespondWithSuccess(w, app)
 }
@@ -21,13 +21,16 @@ func Test_DeprovisionHandler(t *testing.T) {
 	handler := services.DeprovisionHandler
 
 	testAppID := uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+	testTombstoneID := uuid.FromStringOrNil("7a8c6a3e-93a4-4bd7-9a43-0c1a5bd1e2f1")
 
-	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppService"}, ControllerTestCase{
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"AppService", "AppTombstoneService", "WorkerService"}, ControllerTestCase{
 		contextElements: map[ctxpkg.RequestContextKey]interface{}{
 			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
 		},
 		env: &env.AppEnv{
-			AppService: &testAppService{},
+			AppService:          &testAppService{},
+			AppTombstoneService: &testAppTombstoneService{},
+			WorkerService:       &testWorkerService{},
 		},
 	})
 
@@ -40,7 +43,8 @@ func Test_DeprovisionHandler(t *testing.T) {
 		},
 	})
 
-	t.Run("ok", func(t *testing.T) {
+	t.Run("ok - buries the app and enqueues the cleanup", func(t *testing.T) {
+		enqueued := false
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
 				services.ContextKeyAuthorizedAppID: testAppID,
@@ -51,8 +55,17 @@ func Test_DeprovisionHandler(t *testing.T) {
 						require.Equal(t, testAppID, app.ID)
 						return app, nil
 					},
-					deleteFn: func(app *models.App) error {
+				},
+				AppTombstoneService: &testAppTombstoneService{
+					buryFn: func(app *models.App) (*models.AppTombstone, error) {
 						require.Equal(t, testAppID, app.ID)
+						return &models.AppTombstone{Record: models.Record{ID: testTombstoneID}, AppID: app.ID}, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueDeprovisionAppFn: func(appTombstoneID uuid.UUID) error {
+						require.Equal(t, testTombstoneID, appTombstoneID)
+						enqueued = true
 						return nil
 					},
 				},
@@ -60,6 +73,7 @@ func Test_DeprovisionHandler(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			expectedResponse:   models.App{Record: models.Record{ID: testAppID}},
 		})
+		require.True(t, enqueued)
 	})
 
 	t.Run("when app not found in database", func(t *testing.T) {
@@ -72,11 +86,9 @@ func Test_DeprovisionHandler(t *testing.T) {
 					findFn: func(app *models.App) (*models.App, error) {
 						return nil, gorm.ErrRecordNotFound
 					},
-					deleteFn: func(app *models.App) error {
-						require.Equal(t, testAppID, app.ID)
-						return nil
-					},
 				},
+				AppTombstoneService: &testAppTombstoneService{},
+				WorkerService:       &testWorkerService{},
 			},
 			expectedStatusCode: http.StatusNotFound,
 			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Not Found"},
@@ -93,17 +105,38 @@ func Test_DeprovisionHandler(t *testing.T) {
 					findFn: func(app *models.App) (*models.App, error) {
 						return nil, errors.New("SOME-SQL-ERROR")
 					},
-					deleteFn: func(app *models.App) error {
-						require.Equal(t, testAppID, app.ID)
-						return nil
-					},
 				},
+				AppTombstoneService: &testAppTombstoneService{},
+				WorkerService:       &testWorkerService{},
 			},
 			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
 		})
 	})
 
-	t.Run("when database error happens at find", func(t *testing.T) {
+	t.Run("when app is deleted in the meantime", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env: &env.AppEnv{
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return app, nil
+					},
+				},
+				AppTombstoneService: &testAppTombstoneService{
+					buryFn: func(app *models.App) (*models.AppTombstone, error) {
+						return nil, gorm.ErrRecordNotFound
+					},
+				},
+				WorkerService: &testWorkerService{},
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Not Found"},
+		})
+	})
+
+	t.Run("when database error happens at bury", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
 				services.ContextKeyAuthorizedAppID: testAppID,
@@ -111,15 +144,43 @@ func Test_DeprovisionHandler(t *testing.T) {
 			env: &env.AppEnv{
 				AppService: &testAppService{
 					findFn: func(app *models.App) (*models.App, error) {
-						require.Equal(t, testAppID, app.ID)
 						return app, nil
 					},
-					deleteFn: func(app *models.App) error {
-						return errors.New("SOME-SQL-ERROR")
+				},
+				AppTombstoneService: &testAppTombstoneService{
+					buryFn: func(app *models.App) (*models.AppTombstone, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
 					},
 				},
+				WorkerService: &testWorkerService{},
 			},
 			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
 		})
 	})
+
+	t.Run("when enqueueing the cleanup fails", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env: &env.AppEnv{
+				AppService: &testAppService{
+					findFn: func(app *models.App) (*models.App, error) {
+						return app, nil
+					},
+				},
+				AppTombstoneService: &testAppTombstoneService{
+					buryFn: func(app *models.App) (*models.AppTombstone, error) {
+						return &models.AppTombstone{Record: models.Record{ID: testTombstoneID}}, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueDeprovisionAppFn: func(appTombstoneID uuid.UUID) error {
+						return errors.New("SOME-REDIS-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SOME-REDIS-ERROR",
+		})
+	})
 }
@@ -25,6 +25,9 @@ type Handler struct {
 func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	err := h.H(h.Env, w, r)
 	if err != nil {
+		if webhookDelivery := GetWebhookDeliveryFromContext(r.Context()); webhookDelivery != nil {
+			webhookDelivery.Error = "Internal Server Error"
+		}
 		if h.Env.Logger != nil {
 			h.Env.Logger.Error(" [!] Exception: Internal Server Error", zap.Error(err))
 			defer func() {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/addons-ship-backend/services"
 	"github.com/bitrise-io/api-utils/httpresponse"
 	"github.com/c2fo/testify/require"
@@ -42,4 +43,20 @@ func Test_Handler_ServeHTTP(t *testing.T) {
 		handler.ServeHTTP(rr, r)
 		require.Equal(t, `{"message":"Internal Server Error"}`+"\n", rr.Body.String())
 	})
+
+	t.Run("when handler of a webhook delivery responds with 5xx error", func(t *testing.T) {
+		handler := services.Handler{
+			Env: &env.AppEnv{},
+			H: func(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+				return errors.New("SQL Error: some internal error")
+			},
+		}
+		r, err := http.NewRequest("POST", "...", nil)
+		require.NoError(t, err)
+		webhookDelivery := &models.WebhookDelivery{}
+		r = r.WithContext(services.ContextWithWebhookDelivery(r.Context(), webhookDelivery))
+
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		require.Equal(t, "Internal Server Error", webhookDelivery.Error)
+	})
 }
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+// JobStatusGetResponse ...
+type JobStatusGetResponse struct {
+	Data *models.JobStatus `json:"data"`
+}
+
+// JobStatusGetHandler ...
+func JobStatusGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedJobStatusID, err := GetAuthorizedJobStatusIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if env.JobStatusService == nil {
+		return errors.New("No Job Status Service defined for handler")
+	}
+
+	jobStatus, err := env.JobStatusService.Find(&models.JobStatus{Record: models.Record{ID: authorizedJobStatusID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	return httpresponse.RespondWithSuccess(w, JobStatusGetResponse{
+		Data: jobStatus,
+	})
+}
@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_JobStatusGetHandler(t *testing.T) {
+	httpMethod := "GET"
+	url := "/apps/{app-slug}/jobs/{job-id}"
+	handler := services.JobStatusGetHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"JobStatusService"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedJobStatusID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			JobStatusService: &testJobStatusService{
+				findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+					return jobStatus, nil
+				},
+			},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedJobStatusID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedJobStatusID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			JobStatusService: &testJobStatusService{},
+		},
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		testJobStatusID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+		testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedJobStatusID: testJobStatusID,
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+						require.Equal(t, testJobStatusID, jobStatus.ID)
+						return &models.JobStatus{
+							Record:       models.Record{ID: testJobStatusID},
+							Type:         models.JobTypeScreenshotArchiveImport,
+							Status:       models.JobStatusFinished,
+							Message:      "1 of 1 files imported",
+							Result:       json.RawMessage(`{"files":[{"file":"iPhone XS Max (6.5 inch)/1.png"}]}`),
+							AppVersionID: &testAppVersionID,
+						}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.JobStatusGetResponse{
+				Data: &models.JobStatus{
+					Record:       models.Record{ID: testJobStatusID},
+					Type:         models.JobTypeScreenshotArchiveImport,
+					Status:       models.JobStatusFinished,
+					Message:      "1 of 1 files imported",
+					Result:       json.RawMessage(`{"files":[{"file":"iPhone XS Max (6.5 inch)/1.png"}]}`),
+					AppVersionID: &testAppVersionID,
+				},
+			},
+		})
+	})
+
+	t.Run("when error happens at finding job status", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedJobStatusID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+}
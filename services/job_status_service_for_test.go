@@ -0,0 +1,30 @@
+package services_test
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+type testJobStatusService struct {
+	createFn func(*models.JobStatus) (*models.JobStatus, []error, error)
+	findFn   func(*models.JobStatus) (*models.JobStatus, error)
+	updateFn func(*models.JobStatus, []string) ([]error, error)
+}
+
+func (s *testJobStatusService) Create(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+	if s.createFn != nil {
+		return s.createFn(jobStatus)
+	}
+	panic("You have to override Create function in tests")
+}
+
+func (s *testJobStatusService) Find(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+	if s.findFn != nil {
+		return s.findFn(jobStatus)
+	}
+	panic("You have to override Find function in tests")
+}
+
+func (s *testJobStatusService) Update(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+	if s.updateFn != nil {
+		return s.updateFn(jobStatus, whitelist)
+	}
+	panic("You have to override Update function in tests")
+}
@@ -6,9 +6,11 @@ import (
 )
 
 type testMailer struct {
-	sendEmailConfirmationFn func(confirmURL string, contact *models.AppContact, appDetails *bitrise.AppDetails) error
-	sendEmailNewVersionFn   func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error
-	sendEmailPublishFn      func(appVersion *models.AppVersion, contacts []models.AppContact, appDetails *bitrise.AppDetails, frontendBaseURL string, publishSucceeded bool) error
+	sendEmailConfirmationFn      func(confirmURL string, contact *models.AppContact, appDetails *bitrise.AppDetails) error
+	sendEmailNewVersionFn        func(appVersion *models.AppVersion, contacts []models.AppContact, frontendBaseURL string, appDetails *bitrise.AppDetails) error
+	sendEmailPublishFn           func(appVersion *models.AppVersion, contacts []models.AppContact, appDetails *bitrise.AppDetails, frontendBaseURL string, publishSucceeded bool) error
+	sendEmailBuildFailedFn       func(appEvent *models.AppEvent, contacts []models.AppContact, appDetails *bitrise.AppDetails) error
+	sendEmailCodeSigningExpiryFn func(app *models.App, expiringFiles []models.CodeSigningFileExpiry, contacts []models.AppContact, appDetails *bitrise.AppDetails, frontendBaseURL string) error
 }
 
 func (m *testMailer) SendEmailConfirmation(confirmURL string, contact *models.AppContact, appDetails *bitrise.AppDetails) error {
@@ -31,3 +33,17 @@ func (m *testMailer) SendEmailPublish(appVersion *models.AppVersion, contacts []
 	}
 	return m.sendEmailPublishFn(appVersion, contacts, appDetails, frontendBaseURL, publishSucceeded)
 }
+
+func (m *testMailer) SendEmailBuildFailed(appEvent *models.AppEvent, contacts []models.AppContact, appDetails *bitrise.AppDetails) error {
+	if m.sendEmailBuildFailedFn == nil {
+		panic("You have to override Mailer.SendEmailBuildFailed function in tests")
+	}
+	return m.sendEmailBuildFailedFn(appEvent, contacts, appDetails)
+}
+
+func (m *testMailer) SendEmailCodeSigningExpiry(app *models.App, expiringFiles []models.CodeSigningFileExpiry, contacts []models.AppContact, appDetails *bitrise.AppDetails, frontendBaseURL string) error {
+	if m.sendEmailCodeSigningExpiryFn == nil {
+		panic("You have to override Mailer.SendEmailCodeSigningExpiry function in tests")
+	}
+	return m.sendEmailCodeSigningExpiryFn(app, expiringFiles, contacts, appDetails, frontendBaseURL)
+}
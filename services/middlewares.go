@@ -32,6 +32,24 @@ func createAuthorizeForAppVersionScreenshotAccessMiddleware(env *env.AppEnv) fun
 	}
 }
 
+func createAuthorizeForAppVersionAppPreviewAccessMiddleware(env *env.AppEnv) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return AuthorizeForAppVersionAppPreviewAccessHandlerFunc(env, h)
+	}
+}
+
+func createAuthorizeForAppVersionStoreGraphicAccessMiddleware(env *env.AppEnv) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return AuthorizeForAppVersionStoreGraphicAccessHandlerFunc(env, h)
+	}
+}
+
+func createAuthorizeForAppVersionFeatureGraphicAccessMiddleware(env *env.AppEnv) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return AuthorizeForAppVersionFeatureGraphicAccessHandlerFunc(env, h)
+	}
+}
+
 func createAuthenticateWithAddonAccessTokenMiddleware(env *env.AppEnv) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return AuthenticateWithAddonAccessTokenHandlerFunc(env, h)
@@ -68,6 +86,12 @@ func createAuthorizeForAppContactAccessMiddleware(env *env.AppEnv) func(http.Han
 	}
 }
 
+func createAuthorizeForAppJobStatusAccessMiddleware(env *env.AppEnv) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return AuthorizeForAppJobStatusAccessHandlerFunc(env, h)
+	}
+}
+
 func createAuthorizeForBuildWebhookMiddleware(env *env.AppEnv) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return AuthorizeBuildWebhookForAppAccessFunc(env, h)
@@ -80,6 +104,12 @@ func createAuthenticatedForLoginMiddleware(env *env.AppEnv) func(http.Handler) h
 	}
 }
 
+func createRecordWebhookDeliveryMiddleware(env *env.AppEnv, endpoint string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return RecordWebhookDeliveryHandlerFunc(env, endpoint, h)
+	}
+}
+
 // CommonMiddleware ...
 func CommonMiddleware(appEnv *env.AppEnv) alice.Chain {
 	baseMiddleware := middleware.CommonMiddleware()
@@ -104,6 +134,14 @@ func AuthenticateForDeprovisioning(appEnv *env.AppEnv) alice.Chain {
 	)
 }
 
+// AuthenticateForAdminAppAccess lets the holder of the addon access token manage the app of the slug
+// in the URL
+func AuthenticateForAdminAppAccess(appEnv *env.AppEnv) alice.Chain {
+	return AuthenticateForProvisioning(appEnv).Append(
+		createAuthorizeForAppDeprovisioningMiddleware(appEnv),
+	)
+}
+
 // AuthorizedAppMiddleware ...
 func AuthorizedAppMiddleware(appEnv *env.AppEnv) alice.Chain {
 	return CommonMiddleware(appEnv).Append(
@@ -132,9 +170,31 @@ func AuthorizedAppVersionScreenshotMiddleware(appEnv *env.AppEnv) alice.Chain {
 	)
 }
 
+// AuthorizedAppVersionAppPreviewMiddleware ...
+func AuthorizedAppVersionAppPreviewMiddleware(appEnv *env.AppEnv) alice.Chain {
+	return AuthorizedAppVersionMiddleware(appEnv).Append(
+		createAuthorizeForAppVersionAppPreviewAccessMiddleware(appEnv),
+	)
+}
+
+// AuthorizedAppVersionStoreGraphicMiddleware ...
+func AuthorizedAppVersionStoreGraphicMiddleware(appEnv *env.AppEnv) alice.Chain {
+	return AuthorizedAppVersionMiddleware(appEnv).Append(
+		createAuthorizeForAppVersionStoreGraphicAccessMiddleware(appEnv),
+	)
+}
+
+// AuthorizedAppVersionFeatureGraphicMiddleware ...
+func AuthorizedAppVersionFeatureGraphicMiddleware(appEnv *env.AppEnv) alice.Chain {
+	return AuthorizedAppVersionMiddleware(appEnv).Append(
+		createAuthorizeForAppVersionFeatureGraphicAccessMiddleware(appEnv),
+	)
+}
+
 // AuthorizeForWebhookHandling ...
 func AuthorizeForWebhookHandling(appEnv *env.AppEnv) alice.Chain {
 	return CommonMiddleware(appEnv).Append(
+		createRecordWebhookDeliveryMiddleware(appEnv, "/task-webhook"),
 		createAuthenticateForWebhookHandlingMiddleware(appEnv),
 		createAuthorizeForWebhookHandlingMiddleware(appEnv),
 	)
@@ -154,9 +214,24 @@ func AuthorizedAppContactMiddleware(appEnv *env.AppEnv) alice.Chain {
 	)
 }
 
+// AuthorizedAppJobStatusMiddleware ...
+func AuthorizedAppJobStatusMiddleware(appEnv *env.AppEnv) alice.Chain {
+	return AuthorizedAppMiddleware(appEnv).Append(
+		createAuthorizeForAppJobStatusAccessMiddleware(appEnv),
+	)
+}
+
+// AuthorizedAppVersionJobStatusMiddleware ...
+func AuthorizedAppVersionJobStatusMiddleware(appEnv *env.AppEnv) alice.Chain {
+	return AuthorizedAppVersionMiddleware(appEnv).Append(
+		createAuthorizeForAppJobStatusAccessMiddleware(appEnv),
+	)
+}
+
 // AuthorizedBuildWebhookMiddleware ...
 func AuthorizedBuildWebhookMiddleware(appEnv *env.AppEnv) alice.Chain {
 	return CommonMiddleware(appEnv).Append(
+		createRecordWebhookDeliveryMiddleware(appEnv, "/webhook"),
 		createAuthorizeForBuildWebhookMiddleware(appEnv),
 	)
 }
@@ -2,11 +2,13 @@ package services_test
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/bitrise-io/addons-ship-backend/env"
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/addons-ship-backend/services"
+	"github.com/bitrise-io/api-utils/httpresponse"
 	"github.com/bitrise-io/api-utils/middleware"
 	"github.com/bitrise-io/api-utils/providers"
 	"github.com/bitrise-io/api-utils/security"
@@ -57,6 +59,31 @@ func Test_AuthenticateForDeprovisioning(t *testing.T) {
 	})
 }
 
+func Test_AuthenticateForAdminAppAccess(t *testing.T) {
+	middleware.PerformTest(t, "POST", "/...", middleware.TestCase{
+		RequestHeaders: map[string]string{
+			"Authentication": "ADDON_AUTH_TOKEN",
+		},
+		ExpectedStatus: http.StatusOK,
+		ExpectedResponse: map[string]interface{}{
+			"message": "Success",
+		},
+		Middleware: services.AuthenticateForAdminAppAccess(&env.AppEnv{
+			AddonAccessToken: "ADDON_AUTH_TOKEN",
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"app-slug": "test_app_slug",
+				},
+			},
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return app, nil
+				},
+			},
+		}),
+	})
+}
+
 func Test_AuthorizedAppMiddleware(t *testing.T) {
 	middleware.PerformTest(t, "GET", "/...", middleware.TestCase{
 		RequestHeaders: map[string]string{
@@ -201,6 +228,127 @@ func Test_AuthorizedAppVersionScreenshotMiddleware(t *testing.T) {
 	})
 }
 
+func Test_AuthorizedAppVersionAppPreviewMiddleware(t *testing.T) {
+	middleware.PerformTest(t, "GET", "/...", middleware.TestCase{
+		RequestHeaders: map[string]string{
+			"Authorization": "token ADDON_AUTH_TOKEN",
+		},
+		ExpectedStatus: http.StatusOK,
+		ExpectedResponse: map[string]interface{}{
+			"message": "Success",
+		},
+		Middleware: services.AuthorizedAppVersionAppPreviewMiddleware(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"app-slug":       "test_app_slug",
+					"version-id":     "de438ddc-98e5-4226-a5f4-fd2d53474879",
+					"app-preview-id": "abcd1234-5678-ef12-9012-fd2d53474123",
+				},
+			},
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return app, nil
+				},
+			},
+			AppVersionService: &testAppVersionService{
+				findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+					return appVersion, nil
+				},
+			},
+			AppPreviewService: &testAppPreviewService{
+				findFn: func(appPreview *models.AppPreview) (*models.AppPreview, error) {
+					return appPreview, nil
+				},
+			},
+			JWTService: &security.JWTMock{
+				VerifyFn: func(token string) (bool, error) {
+					return true, nil
+				},
+				GetTokenFn: func(token string) (interface{}, error) {
+					return "auth-token-from-jwt", nil
+				},
+			},
+		}),
+	})
+}
+
+func Test_AuthorizedAppVersionStoreGraphicMiddleware(t *testing.T) {
+	middleware.PerformTest(t, "GET", "/...", middleware.TestCase{
+		RequestHeaders: map[string]string{
+			"Authorization": "token ADDON_AUTH_TOKEN",
+		},
+		ExpectedStatus: http.StatusOK,
+		ExpectedResponse: map[string]interface{}{
+			"message": "Success",
+		},
+		Middleware: services.AuthorizedAppVersionStoreGraphicMiddleware(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"app-slug":     "test_app_slug",
+					"version-id":   "de438ddc-98e5-4226-a5f4-fd2d53474879",
+					"graphic-type": "tv_banner",
+				},
+			},
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return app, nil
+				},
+			},
+			AppVersionService: &testAppVersionService{
+				findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+					return appVersion, nil
+				},
+			},
+			JWTService: &security.JWTMock{
+				VerifyFn: func(token string) (bool, error) {
+					return true, nil
+				},
+				GetTokenFn: func(token string) (interface{}, error) {
+					return "auth-token-from-jwt", nil
+				},
+			},
+		}),
+	})
+}
+
+func Test_AuthorizedAppVersionFeatureGraphicMiddleware(t *testing.T) {
+	middleware.PerformTest(t, "GET", "/...", middleware.TestCase{
+		RequestHeaders: map[string]string{
+			"Authorization": "token ADDON_AUTH_TOKEN",
+		},
+		ExpectedStatus: http.StatusOK,
+		ExpectedResponse: map[string]interface{}{
+			"message": "Success",
+		},
+		Middleware: services.AuthorizedAppVersionFeatureGraphicMiddleware(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"app-slug":   "test_app_slug",
+					"version-id": "de438ddc-98e5-4226-a5f4-fd2d53474879",
+				},
+			},
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return app, nil
+				},
+			},
+			AppVersionService: &testAppVersionService{
+				findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+					return appVersion, nil
+				},
+			},
+			JWTService: &security.JWTMock{
+				VerifyFn: func(token string) (bool, error) {
+					return true, nil
+				},
+				GetTokenFn: func(token string) (interface{}, error) {
+					return "auth-token-from-jwt", nil
+				},
+			},
+		}),
+	})
+}
+
 func Test_AuthorizeForWebhookHandling(t *testing.T) {
 	revokeFn, err := envutil.RevokableSetenv("BITRISE_DEN_WEBHOOK_SECRET", "secret-token")
 	require.NoError(t, err)
@@ -286,6 +434,88 @@ func Test_AuthorizedAppContactMiddleware(t *testing.T) {
 	})
 }
 
+func Test_AuthorizedAppJobStatusMiddleware(t *testing.T) {
+	middleware.PerformTest(t, "GET", "/...", middleware.TestCase{
+		RequestHeaders: map[string]string{
+			"Authorization": "token ADDON_AUTH_TOKEN",
+		},
+		ExpectedStatus: http.StatusOK,
+		ExpectedResponse: map[string]interface{}{
+			"message": "Success",
+		},
+		Middleware: services.AuthorizedAppJobStatusMiddleware(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"app-slug": "test_app_slug",
+					"job-id":   "de438ddc-98e5-4226-a5f4-fd2d53474879",
+				},
+			},
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return app, nil
+				},
+			},
+			JobStatusService: &testJobStatusService{
+				findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+					return jobStatus, nil
+				},
+			},
+			JWTService: &security.JWTMock{
+				VerifyFn: func(token string) (bool, error) {
+					return true, nil
+				},
+				GetTokenFn: func(token string) (interface{}, error) {
+					return "auth-token-from-jwt", nil
+				},
+			},
+		}),
+	})
+}
+
+func Test_AuthorizedAppVersionJobStatusMiddleware(t *testing.T) {
+	middleware.PerformTest(t, "GET", "/...", middleware.TestCase{
+		RequestHeaders: map[string]string{
+			"Authorization": "token ADDON_AUTH_TOKEN",
+		},
+		ExpectedStatus: http.StatusOK,
+		ExpectedResponse: map[string]interface{}{
+			"message": "Success",
+		},
+		Middleware: services.AuthorizedAppVersionJobStatusMiddleware(&env.AppEnv{
+			RequestParams: &providers.RequestParamsMock{
+				Params: map[string]string{
+					"app-slug":   "test_app_slug",
+					"version-id": "de438ddc-98e5-4226-a5f4-fd2d53474879",
+					"job-id":     "8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90",
+				},
+			},
+			AppService: &testAppService{
+				findFn: func(app *models.App) (*models.App, error) {
+					return app, nil
+				},
+			},
+			AppVersionService: &testAppVersionService{
+				findFn: func(appVersion *models.AppVersion) (*models.AppVersion, error) {
+					return appVersion, nil
+				},
+			},
+			JobStatusService: &testJobStatusService{
+				findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+					return jobStatus, nil
+				},
+			},
+			JWTService: &security.JWTMock{
+				VerifyFn: func(token string) (bool, error) {
+					return true, nil
+				},
+				GetTokenFn: func(token string) (interface{}, error) {
+					return "auth-token-from-jwt", nil
+				},
+			},
+		}),
+	})
+}
+
 func Test_AuthorizedBuildWebhookMiddleware(t *testing.T) {
 	revokeFn, err := envutil.RevokableSetenv("APP_WEBHOOK_SECRET_ENCRYPT_KEY", "06042e86a7bd421c642c8c3e4ab13840")
 	require.NoError(t, err)
@@ -318,6 +548,136 @@ func Test_AuthorizedBuildWebhookMiddleware(t *testing.T) {
 	require.NoError(t, revokeFn())
 }
 
+func Test_AuthorizedBuildWebhookMiddleware_RecordsWebhookDelivery(t *testing.T) {
+	revokeFn, err := envutil.RevokableSetenv("APP_WEBHOOK_SECRET_ENCRYPT_KEY", "06042e86a7bd421c642c8c3e4ab13840")
+	require.NoError(t, err)
+	testAppID := uuid.NewV4()
+	testAppService := &testAppService{
+		findFn: func(app *models.App) (*models.App, error) {
+			app.ID = testAppID
+			iv, err := crypto.GenerateIV()
+			require.NoError(t, err)
+			encryptedSecret, err := crypto.AES256GCMCipher("my-super-secret", iv, "06042e86a7bd421c642c8c3e4ab13840")
+			require.NoError(t, err)
+
+			app.EncryptedSecret = encryptedSecret
+			app.EncryptedSecretIV = iv
+			return app, nil
+		},
+	}
+
+	t.Run("when signature is valid", func(t *testing.T) {
+		var recordedDelivery *models.WebhookDelivery
+		middleware.PerformTest(t, "POST", "/...", middleware.TestCase{
+			RequestHeaders: map[string]string{
+				"Bitrise-App-Id":         "test_app_slug",
+				"Bitrise-Event-Type":     "build/finished",
+				"Bitrise-Hook-Signature": "sha256=0d86929661b1c7b216ca6a7ef4abe740ee6dc07d4afc2f21d78c888235d88713",
+			},
+			ExpectedStatus: http.StatusOK,
+			Middleware: services.AuthorizedBuildWebhookMiddleware(&env.AppEnv{
+				AppService: testAppService,
+				WebhookDeliveryService: &testWebhookDeliveryService{
+					createFn: func(webhookDelivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+						recordedDelivery = webhookDelivery
+						return webhookDelivery, nil
+					},
+				},
+			}),
+			RequestBody: map[string]string{"app_slug": "test-app-slug"},
+		})
+		require.NotNil(t, recordedDelivery)
+		require.Equal(t, "/webhook", recordedDelivery.Endpoint)
+		require.Equal(t, "build/finished", recordedDelivery.EventType)
+		require.Equal(t, `{"app_slug":"test-app-slug"}`, recordedDelivery.Body)
+		require.Equal(t, &testAppID, recordedDelivery.AppID)
+		require.True(t, *recordedDelivery.SignatureValid)
+		require.Equal(t, http.StatusOK, recordedDelivery.StatusCode)
+		require.Equal(t, "", recordedDelivery.Error)
+	})
+
+	t.Run("when signature is invalid", func(t *testing.T) {
+		var recordedDelivery *models.WebhookDelivery
+		middleware.PerformTest(t, "POST", "/...", middleware.TestCase{
+			RequestHeaders: map[string]string{
+				"Bitrise-App-Id":         "test_app_slug",
+				"Bitrise-Hook-Signature": "sha256=invalid-signature",
+			},
+			ExpectedStatus: http.StatusNotFound,
+			Middleware: services.AuthorizedBuildWebhookMiddleware(&env.AppEnv{
+				AppService: testAppService,
+				WebhookDeliveryService: &testWebhookDeliveryService{
+					createFn: func(webhookDelivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+						recordedDelivery = webhookDelivery
+						return webhookDelivery, nil
+					},
+				},
+			}),
+			RequestBody: map[string]string{"app_slug": "test-app-slug"},
+		})
+		require.NotNil(t, recordedDelivery)
+		require.Equal(t, &testAppID, recordedDelivery.AppID)
+		require.False(t, *recordedDelivery.SignatureValid)
+		require.Equal(t, http.StatusNotFound, recordedDelivery.StatusCode)
+		require.Equal(t, "Not Found", recordedDelivery.Error)
+	})
+
+	t.Run("when request body is too large", func(t *testing.T) {
+		middleware.PerformTest(t, "POST", "/...", middleware.TestCase{
+			RequestHeaders: map[string]string{
+				"Bitrise-App-Id":     "test_app_slug",
+				"Bitrise-Event-Type": "build/finished",
+			},
+			ExpectedStatus:   http.StatusBadRequest,
+			ExpectedResponse: httpresponse.StandardErrorRespModel{Message: "Failed to read request body"},
+			Middleware: services.AuthorizedBuildWebhookMiddleware(&env.AppEnv{
+				AppService:             testAppService,
+				WebhookDeliveryService: &testWebhookDeliveryService{},
+			}),
+			RequestBody: map[string]string{"app_slug": strings.Repeat("a", 2*1024*1024)},
+		})
+	})
+	require.NoError(t, revokeFn())
+}
+
+func Test_AuthorizeForWebhookHandling_RecordsWebhookDelivery(t *testing.T) {
+	revokeFn, err := envutil.RevokableSetenv("BITRISE_DEN_WEBHOOK_SECRET", "secret-token")
+	require.NoError(t, err)
+	testAppID := uuid.NewV4()
+
+	var recordedDelivery *models.WebhookDelivery
+	middleware.PerformTest(t, "POST", "/...", middleware.TestCase{
+		RequestHeaders: map[string]string{
+			"Bitrise-Den-Webhook-Secret": "secret-token",
+		},
+		RequestBody: services.WebhookPayload{
+			TaskID: uuid.FromStringOrNil("cb8ddaf5-e6f9-470f-b84e-8bc9a0cbf78a"),
+		},
+		ExpectedStatus: http.StatusOK,
+		Middleware: services.AuthorizeForWebhookHandling(&env.AppEnv{
+			PublishTaskService: &testPublishTaskService{
+				findFn: func(publishTask *models.PublishTask) (*models.PublishTask, error) {
+					publishTask.AppVersion = models.AppVersion{AppID: testAppID}
+					return publishTask, nil
+				},
+			},
+			WebhookDeliveryService: &testWebhookDeliveryService{
+				createFn: func(webhookDelivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+					recordedDelivery = webhookDelivery
+					return webhookDelivery, nil
+				},
+			},
+		}),
+	})
+	require.NotNil(t, recordedDelivery)
+	require.Equal(t, "/task-webhook", recordedDelivery.Endpoint)
+	require.Equal(t, &testAppID, recordedDelivery.AppID)
+	require.True(t, *recordedDelivery.SignatureValid)
+	require.Equal(t, http.StatusOK, recordedDelivery.StatusCode)
+	require.NotContains(t, string(recordedDelivery.Headers), "secret-token")
+	require.NoError(t, revokeFn())
+}
+
 func Test_AuthenticatedForLoginMiddleware(t *testing.T) {
 	testLogger, err := zap.NewDevelopment()
 	require.NoError(t, err)
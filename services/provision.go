@@ -46,6 +46,7 @@ func ProvisionHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) e
 	if env.BitriseAPI == nil {
 		return errors.New("No Bitrise API Service defined for handler")
 	}
+	bitriseAPI := env.BitriseAPI.WithContext(r.Context())
 
 	app, err := env.AppService.Find(&models.App{AppSlug: params.AppSlug})
 	switch {
@@ -63,7 +64,7 @@ func ProvisionHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) e
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		err = env.BitriseAPI.RegisterWebhook(params.BitriseAPIToken, params.AppSlug, secret, fmt.Sprintf("%s/webhook", env.AddonHostURL))
+		err = bitriseAPI.RegisterWebhook(params.BitriseAPIToken, params.AppSlug, secret, fmt.Sprintf("%s/webhook", env.AddonHostURL))
 		if err != nil {
 			return errors.WithStack(err)
 		}
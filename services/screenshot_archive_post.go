@@ -0,0 +1,109 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httprequest"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+type screenshotArchivePostParams struct {
+	Filename string `json:"filename"`
+	Filesize int64  `json:"filesize"`
+}
+
+// ScreenshotArchiveData ...
+type ScreenshotArchiveData struct {
+	models.JobStatus
+	UploadParts []UploadPart `json:"upload_parts"`
+}
+
+// ScreenshotArchivePostResponse ...
+type ScreenshotArchivePostResponse struct {
+	Data ScreenshotArchiveData `json:"data"`
+}
+
+// ScreenshotArchivePostHandler creates the job status of the screenshot archive import and starts the
+// multipart upload of the zip archive, the URLs of its parts are returned
+func ScreenshotArchivePostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var params screenshotArchivePostParams
+	defer httprequest.BodyCloseWithErrorLog(r)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
+	}
+
+	if env.JobStatusService == nil {
+		return errors.New("No Job Status Service defined for handler")
+	}
+	if env.Storage == nil {
+		return errors.New("No Storage Provider defined for handler")
+	}
+
+	if verrs := validateScreenshotArchiveParams(params); len(verrs) > 0 {
+		return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+	}
+
+	jobStatus, verrs, err := env.JobStatusService.Create(&models.JobStatus{
+		Type:         models.JobTypeScreenshotArchiveImport,
+		Status:       models.JobStatusAwaitingUpload,
+		AppID:        authorizedAppID,
+		AppVersionID: &authorizedAppVersionID,
+	})
+	if len(verrs) > 0 {
+		return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+	}
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	uploadID, err := env.Storage.CreateMultipartUpload(jobStatus.ScreenshotArchiveAWSPath())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	jobStatus.UploadID = uploadID
+	verrs, err = env.JobStatusService.Update(jobStatus, []string{"UploadID"})
+	if len(verrs) > 0 {
+		return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+	}
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	uploadParts, err := presignUploadParts(env.Storage, jobStatus.ScreenshotArchiveAWSPath(), uploadID, params.Filesize)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return httpresponse.RespondWithSuccess(w, ScreenshotArchivePostResponse{
+		Data: ScreenshotArchiveData{
+			JobStatus:   *jobStatus,
+			UploadParts: uploadParts,
+		},
+	})
+}
+
+func validateScreenshotArchiveParams(params screenshotArchivePostParams) []error {
+	verrs := []error{}
+	if strings.ToLower(filepath.Ext(params.Filename)) != ".zip" {
+		verrs = append(verrs, errors.New("filename: Must be a .zip file"))
+	}
+	if params.Filesize > models.MaxScreenshotArchiveFileByteSize {
+		verrs = append(verrs, errors.New("filesize: Must be smaller than 500 megabytes"))
+	}
+	return verrs
+}
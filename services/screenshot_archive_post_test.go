@@ -0,0 +1,241 @@
+package services_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_ScreenshotArchivePostHandler(t *testing.T) {
+	httpMethod := "POST"
+	url := "/apps/{app-slug}/versions/{version-id}/screenshot-archives"
+	handler := services.ScreenshotArchivePostHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"JobStatusService", "Storage"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			JobStatusService: &testJobStatusService{},
+			Storage:          &storage.Mock{},
+		},
+		requestBody: `{}`,
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppID, services.ContextKeyAuthorizedAppVersionID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			JobStatusService: &testJobStatusService{},
+			Storage:          &storage.Mock{},
+		},
+		requestBody: `{}`,
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		testAppID := uuid.FromStringOrNil("211afc15-127a-40f9-8cbe-1dadc1f86cdf")
+		testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+		testJobStatusID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+		expectedPath := "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/screenshot_archives/8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90.zip"
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        testAppID,
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					createFn: func(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+						require.Equal(t, models.JobTypeScreenshotArchiveImport, jobStatus.Type)
+						require.Equal(t, models.JobStatusAwaitingUpload, jobStatus.Status)
+						require.Equal(t, testAppID, jobStatus.AppID)
+						require.Equal(t, testAppVersionID, *jobStatus.AppVersionID)
+						jobStatus.ID = testJobStatusID
+						jobStatus.Result = json.RawMessage(`{}`)
+						jobStatus.App = models.App{AppSlug: "test-app-slug"}
+						return jobStatus, nil, nil
+					},
+					updateFn: func(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+						require.Equal(t, []string{"UploadID"}, whitelist)
+						require.Equal(t, "test-upload-id", jobStatus.UploadID)
+						return nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CreateMultipartUploadFn: func(key string) (string, error) {
+						require.Equal(t, expectedPath, key)
+						return "test-upload-id", nil
+					},
+					GeneratePresignedUploadPartURLFn: func(key, uploadID string, partNumber int64, expiresIn time.Duration) (string, error) {
+						require.Equal(t, "test-upload-id", uploadID)
+						return fmt.Sprintf("http://presigned.aws.url/%s?partNumber=%d", key, partNumber), nil
+					},
+				},
+			},
+			requestBody:        fmt.Sprintf(`{"filename":"screenshots.zip","filesize":%d}`, storage.MultipartPartByteSize+1),
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.ScreenshotArchivePostResponse{
+				Data: services.ScreenshotArchiveData{
+					JobStatus: models.JobStatus{
+						Record:       models.Record{ID: testJobStatusID},
+						Type:         models.JobTypeScreenshotArchiveImport,
+						Status:       models.JobStatusAwaitingUpload,
+						Result:       json.RawMessage(`{}`),
+						AppVersionID: &testAppVersionID,
+					},
+					UploadParts: []services.UploadPart{
+						services.UploadPart{PartNumber: 1, UploadURL: "http://presigned.aws.url/" + expectedPath + "?partNumber=1"},
+						services.UploadPart{PartNumber: 2, UploadURL: "http://presigned.aws.url/" + expectedPath + "?partNumber=2"},
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("when request body is not a valid JSON", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{},
+				Storage:          &storage.Mock{},
+			},
+			requestBody:        `invalid JSON`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid request body, JSON decode failed"},
+		})
+	})
+
+	t.Run("when the file is not a zip archive or too large", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{},
+				Storage:          &storage.Mock{},
+			},
+			requestBody:        fmt.Sprintf(`{"filename":"screenshots.tar","filesize":%d}`, models.MaxScreenshotArchiveFileByteSize+1),
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: httpresponse.ValidationErrorRespModel{
+				Message: "Unprocessable Entity",
+				Errors:  []string{"filename: Must be a .zip file", "filesize: Must be smaller than 500 megabytes"},
+			},
+		})
+	})
+
+	t.Run("when error happens at creating the job status", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					createFn: func(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+						return nil, nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				Storage: &storage.Mock{},
+			},
+			requestBody:         `{"filename":"screenshots.zip","filesize":1234}`,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when error happens at starting the multipart upload", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					createFn: func(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+						return jobStatus, nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CreateMultipartUploadFn: func(key string) (string, error) {
+						return "", errors.New("SOME-AWS-ERROR")
+					},
+				},
+			},
+			requestBody:         `{"filename":"screenshots.zip","filesize":1234}`,
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+
+	t.Run("when error happens at storing the upload ID", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					createFn: func(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+						return jobStatus, nil, nil
+					},
+					updateFn: func(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				Storage: &storage.Mock{
+					CreateMultipartUploadFn: func(key string) (string, error) {
+						return "test-upload-id", nil
+					},
+				},
+			},
+			requestBody:         `{"filename":"screenshots.zip","filesize":1234}`,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when error happens at generating the upload URLs", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID:        uuid.NewV4(),
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					createFn: func(jobStatus *models.JobStatus) (*models.JobStatus, []error, error) {
+						return jobStatus, nil, nil
+					},
+					updateFn: func(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+						return nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CreateMultipartUploadFn: func(key string) (string, error) {
+						return "test-upload-id", nil
+					},
+					GeneratePresignedUploadPartURLFn: func(key, uploadID string, partNumber int64, expiresIn time.Duration) (string, error) {
+						return "", errors.New("SOME-AWS-ERROR")
+					},
+				},
+			},
+			requestBody:         `{"filename":"screenshots.zip","filesize":1234}`,
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
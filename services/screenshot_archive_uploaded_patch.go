@@ -0,0 +1,88 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	"github.com/bitrise-io/api-utils/httprequest"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+type screenshotArchiveUploadedPatchParams struct {
+	Parts []storage.CompletedPart `json:"parts"`
+}
+
+// ScreenshotArchiveUploadedPatchResponse ...
+type ScreenshotArchiveUploadedPatchResponse struct {
+	Data *models.JobStatus `json:"data"`
+}
+
+// ScreenshotArchiveUploadedPatchHandler completes the multipart upload of the screenshot archive and
+// enqueues its import, the progress can be followed on the job status
+func ScreenshotArchiveUploadedPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	authorizedJobStatusID, err := GetAuthorizedJobStatusIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var params screenshotArchiveUploadedPatchParams
+	defer httprequest.BodyCloseWithErrorLog(r)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
+	}
+	if len(params.Parts) == 0 {
+		return httpresponse.RespondWithBadRequestError(w, "No uploaded parts provided")
+	}
+
+	if env.JobStatusService == nil {
+		return errors.New("No Job Status Service defined for handler")
+	}
+	if env.Storage == nil {
+		return errors.New("No Storage Provider defined for handler")
+	}
+	if env.WorkerService == nil {
+		return errors.New("No Worker Service defined for handler")
+	}
+
+	jobStatus, err := env.JobStatusService.Find(&models.JobStatus{Record: models.Record{ID: authorizedJobStatusID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if jobStatus.Type != models.JobTypeScreenshotArchiveImport ||
+		jobStatus.AppVersionID == nil || !uuid.Equal(*jobStatus.AppVersionID, authorizedAppVersionID) {
+		return httpresponse.RespondWithNotFoundError(w)
+	}
+
+	if jobStatus.Status == models.JobStatusAwaitingUpload {
+		err = env.Storage.CompleteMultipartUpload(jobStatus.ScreenshotArchiveAWSPath(), jobStatus.UploadID, params.Parts)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		jobStatus.Status = models.JobStatusPending
+		verrs, err := env.JobStatusService.Update(jobStatus, []string{"Status"})
+		if len(verrs) > 0 {
+			return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		err = env.WorkerService.EnqueueImportScreenshotArchive(jobStatus.ID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return httpresponse.RespondWithSuccess(w, ScreenshotArchiveUploadedPatchResponse{
+		Data: jobStatus,
+	})
+}
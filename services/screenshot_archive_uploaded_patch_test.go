@@ -0,0 +1,267 @@
+package services_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_ScreenshotArchiveUploadedPatchHandler(t *testing.T) {
+	httpMethod := "PATCH"
+	url := "/apps/{app-slug}/versions/{version-id}/screenshot-archives/{job-id}/uploaded"
+	handler := services.ScreenshotArchiveUploadedPatchHandler
+
+	testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+	testJobStatusID := uuid.FromStringOrNil("8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90")
+	contextElements := map[ctxpkg.RequestContextKey]interface{}{
+		services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+		services.ContextKeyAuthorizedJobStatusID:  testJobStatusID,
+	}
+	awaitingUploadJobStatus := func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+		return &models.JobStatus{
+			Record:       models.Record{ID: testJobStatusID},
+			Type:         models.JobTypeScreenshotArchiveImport,
+			Status:       models.JobStatusAwaitingUpload,
+			UploadID:     "test-upload-id",
+			App:          models.App{AppSlug: "test-app-slug"},
+			AppVersionID: &testAppVersionID,
+		}, nil
+	}
+	completedUpload := &storage.Mock{
+		CompleteMultipartUploadFn: func(key, uploadID string, parts []storage.CompletedPart) error {
+			return nil
+		},
+	}
+	validRequestBody := `{"parts":[{"part_number":1,"etag":"etag-1"}]}`
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"JobStatusService", "Storage", "WorkerService"}, ControllerTestCase{
+		contextElements: contextElements,
+		env: &env.AppEnv{
+			JobStatusService: &testJobStatusService{
+				findFn: awaitingUploadJobStatus,
+				updateFn: func(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+					return nil, nil
+				},
+			},
+			Storage: completedUpload,
+			WorkerService: &testWorkerService{
+				enqueueImportScreenshotArchiveFn: func(jobStatusID uuid.UUID) error {
+					return nil
+				},
+			},
+		},
+		requestBody: validRequestBody,
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID, services.ContextKeyAuthorizedJobStatusID}, ControllerTestCase{
+		contextElements: contextElements,
+		env: &env.AppEnv{
+			JobStatusService: &testJobStatusService{},
+			Storage:          &storage.Mock{},
+			WorkerService:    &testWorkerService{},
+		},
+		requestBody: validRequestBody,
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		uploadCompleted := false
+		importEnqueued := false
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+						require.Equal(t, testJobStatusID, jobStatus.ID)
+						return awaitingUploadJobStatus(jobStatus)
+					},
+					updateFn: func(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+						require.True(t, uploadCompleted)
+						require.Equal(t, models.JobStatusPending, jobStatus.Status)
+						require.Equal(t, []string{"Status"}, whitelist)
+						return nil, nil
+					},
+				},
+				Storage: &storage.Mock{
+					CompleteMultipartUploadFn: func(key, uploadID string, parts []storage.CompletedPart) error {
+						require.Equal(t, "test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/screenshot_archives/8a1c3e0b-3f2c-4b8e-9d4e-0f6f7c2b1a90.zip", key)
+						require.Equal(t, "test-upload-id", uploadID)
+						require.Equal(t, []storage.CompletedPart{storage.CompletedPart{PartNumber: 1, ETag: "etag-1"}}, parts)
+						uploadCompleted = true
+						return nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueImportScreenshotArchiveFn: func(jobStatusID uuid.UUID) error {
+						require.Equal(t, testJobStatusID, jobStatusID)
+						importEnqueued = true
+						return nil
+					},
+				},
+			},
+			requestBody:        validRequestBody,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.ScreenshotArchiveUploadedPatchResponse{
+				Data: &models.JobStatus{
+					Record:       models.Record{ID: testJobStatusID},
+					Type:         models.JobTypeScreenshotArchiveImport,
+					Status:       models.JobStatusPending,
+					AppVersionID: &testAppVersionID,
+				},
+			},
+		})
+		require.True(t, importEnqueued)
+	})
+
+	t.Run("ok - when the import is already enqueued", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+						return &models.JobStatus{
+							Record:       models.Record{ID: testJobStatusID},
+							Type:         models.JobTypeScreenshotArchiveImport,
+							Status:       models.JobStatusRunning,
+							AppVersionID: &testAppVersionID,
+						}, nil
+					},
+				},
+				Storage:       &storage.Mock{},
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:        validRequestBody,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.ScreenshotArchiveUploadedPatchResponse{
+				Data: &models.JobStatus{
+					Record:       models.Record{ID: testJobStatusID},
+					Type:         models.JobTypeScreenshotArchiveImport,
+					Status:       models.JobStatusRunning,
+					AppVersionID: &testAppVersionID,
+				},
+			},
+		})
+	})
+
+	t.Run("when the job belongs to another app version", func(t *testing.T) {
+		otherAppVersionID := uuid.NewV4()
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+						return &models.JobStatus{
+							Record:       models.Record{ID: testJobStatusID},
+							Type:         models.JobTypeScreenshotArchiveImport,
+							Status:       models.JobStatusAwaitingUpload,
+							AppVersionID: &otherAppVersionID,
+						}, nil
+					},
+				},
+				Storage:       &storage.Mock{},
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:        validRequestBody,
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Not Found"},
+		})
+	})
+
+	t.Run("when error happens at finding job status", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: func(jobStatus *models.JobStatus) (*models.JobStatus, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				Storage:       &storage.Mock{},
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:         validRequestBody,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when error happens at updating job status", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: awaitingUploadJobStatus,
+					updateFn: func(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				Storage:       completedUpload,
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:         validRequestBody,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when error happens at enqueueing the import", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: awaitingUploadJobStatus,
+					updateFn: func(jobStatus *models.JobStatus, whitelist []string) ([]error, error) {
+						return nil, nil
+					},
+				},
+				Storage: completedUpload,
+				WorkerService: &testWorkerService{
+					enqueueImportScreenshotArchiveFn: func(jobStatusID uuid.UUID) error {
+						return errors.New("SOME-WORKER-ERROR")
+					},
+				},
+			},
+			requestBody:         validRequestBody,
+			expectedInternalErr: "SOME-WORKER-ERROR",
+		})
+	})
+
+	t.Run("when no parts are provided", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{},
+				Storage:          &storage.Mock{},
+				WorkerService:    &testWorkerService{},
+			},
+			requestBody:        `{"parts":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "No uploaded parts provided"},
+		})
+	})
+
+	t.Run("when completing the multipart upload fails", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: contextElements,
+			env: &env.AppEnv{
+				JobStatusService: &testJobStatusService{
+					findFn: awaitingUploadJobStatus,
+				},
+				Storage: &storage.Mock{
+					CompleteMultipartUploadFn: func(key, uploadID string, parts []storage.CompletedPart) error {
+						return errors.New("SOME-AWS-ERROR")
+					},
+				},
+				WorkerService: &testWorkerService{},
+			},
+			requestBody:         validRequestBody,
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
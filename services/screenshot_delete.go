@@ -7,6 +7,7 @@ import (
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/api-utils/httpresponse"
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 )
 
 // ScreenshotDeleteResponse ...
@@ -29,16 +30,28 @@ func ScreenshotDeleteHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Req
 		return errors.New("No AWS Provider defined for handler")
 	}
 
+	if env.AssetService == nil {
+		return errors.New("No Asset Service defined for handler")
+	}
+
 	screenshot, err := env.ScreenshotService.Find(&models.Screenshot{Record: models.Record{ID: screenshotID}})
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	err = env.AWS.DeleteObject(screenshot.AWSPath())
+	screenshots, err := env.ScreenshotService.FindAll(&screenshot.AppVersion)
 	if err != nil {
-		return errors.WithStack(err)
+		return errors.Wrap(err, "SQL Error")
+	}
+	derivedScreenshots := []models.Screenshot{}
+	for _, derivedScreenshot := range screenshots {
+		if derivedScreenshot.SourceScreenshotID != nil && uuid.Equal(*derivedScreenshot.SourceScreenshotID, screenshot.ID) {
+			derivedScreenshots = append(derivedScreenshots, derivedScreenshot)
+		}
 	}
 
+	// the records of the derived screenshots are removed by the database together with their source,
+	// the objects are removed only after the records don't reference them anymore
 	err = env.ScreenshotService.Delete(
 		screenshot,
 	)
@@ -47,6 +60,18 @@ func ScreenshotDeleteHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Req
 		return errors.Wrap(err, "SQL Error")
 	}
 
+	for _, derivedScreenshot := range derivedScreenshots {
+		err = removeUploadableObject(env, derivedScreenshot.AWSPath(), derivedScreenshot.AssetID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	err = removeUploadableObject(env, screenshot.AWSPath(), screenshot.AssetID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	return httpresponse.RespondWithSuccess(w, ScreenshotDeleteResponse{
 		Data: screenshot,
 	})
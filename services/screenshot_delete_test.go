@@ -22,11 +22,15 @@ func Test_ScreenshotDeleteHandler(t *testing.T) {
 	screenshotID := uuid.NewV4()
 	testScreenshot := &models.Screenshot{Record: models.Record{ID: screenshotID}}
 
-	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"ScreenshotService"}, ControllerTestCase{
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"ScreenshotService", "AssetService"}, ControllerTestCase{
 		contextElements: map[ctxpkg.RequestContextKey]interface{}{
 			services.ContextKeyAuthorizedScreenshotID: screenshotID,
 		},
-		env: &env.AppEnv{},
+		env: &env.AppEnv{
+			ScreenshotService: &testScreenshotService{},
+			AWS:               &providers.AWSMock{},
+			AssetService:      &testAssetService{},
+		},
 	})
 
 	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedScreenshotID}, ControllerTestCase{
@@ -50,12 +54,16 @@ func Test_ScreenshotDeleteHandler(t *testing.T) {
 						require.Equal(t, screenshot.ID.String(), screenshotID.String())
 						return testScreenshot, nil
 					},
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{*testScreenshot}, nil
+					},
 				},
 				AWS: &providers.AWSMock{
 					DeleteObjectFn: func(path string) error {
 						return nil
 					},
 				},
+				AssetService: &testAssetService{},
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponse: services.ScreenshotDeleteResponse{
@@ -64,6 +72,108 @@ func Test_ScreenshotDeleteHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("ok - deletes the files of the screenshots derived from it", func(t *testing.T) {
+		derivedScreenshot := models.Screenshot{
+			Record:             models.Record{ID: uuid.FromStringOrNil("59b2f2e3-aee4-4fc5-9a4e-30ff2f55ad34")},
+			UploadableObject:   models.UploadableObject{Filename: "screenshot.png"},
+			DeviceType:         "iPhone 8 Plus",
+			ScreenSize:         "5.5 inch",
+			SourceScreenshotID: &screenshotID,
+		}
+		otherScreenshot := models.Screenshot{
+			Record:           models.Record{ID: uuid.FromStringOrNil("bd0eb4c4-ba5e-4c68-8ec6-c1ee6ea7a1a5")},
+			UploadableObject: models.UploadableObject{Filename: "other.png"},
+			DeviceType:       "iPhone XS Max",
+			ScreenSize:       "6.5 inch",
+		}
+		deletedPaths := []string{}
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedScreenshotID: screenshotID,
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					deleteFn: func(*models.Screenshot) error {
+						return nil
+					},
+					findFn: func(screenshot *models.Screenshot) (*models.Screenshot, error) {
+						return testScreenshot, nil
+					},
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{*testScreenshot, derivedScreenshot, otherScreenshot}, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					DeleteObjectFn: func(path string) error {
+						deletedPaths = append(deletedPaths, path)
+						return nil
+					},
+				},
+				AssetService: &testAssetService{},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.ScreenshotDeleteResponse{
+				Data: testScreenshot,
+			},
+		})
+		require.Equal(t, []string{derivedScreenshot.AWSPath(), testScreenshot.AWSPath()}, deletedPaths)
+	})
+
+	t.Run("ok - releases the assets of the screenshot and the screenshots derived from it", func(t *testing.T) {
+		assetID := uuid.FromStringOrNil("2bd1f7a4-5a8d-4b8c-8f61-3c1d1c3e4e8a")
+		derivedAssetID := uuid.FromStringOrNil("76b5a3a2-8a9e-4e4c-9d61-0f35b8d8e6d1")
+		assetBackedScreenshot := &models.Screenshot{Record: models.Record{ID: screenshotID}, AssetID: &assetID}
+		derivedScreenshot := models.Screenshot{
+			Record:             models.Record{ID: uuid.FromStringOrNil("59b2f2e3-aee4-4fc5-9a4e-30ff2f55ad34")},
+			SourceScreenshotID: &screenshotID,
+			AssetID:            &derivedAssetID,
+		}
+		screenshotDeleted := false
+		releasedAssetIDs := []uuid.UUID{}
+		deletedPaths := []string{}
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedScreenshotID: screenshotID,
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					deleteFn: func(*models.Screenshot) error {
+						screenshotDeleted = true
+						return nil
+					},
+					findFn: func(screenshot *models.Screenshot) (*models.Screenshot, error) {
+						return assetBackedScreenshot, nil
+					},
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{*assetBackedScreenshot, derivedScreenshot}, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					DeleteObjectFn: func(path string) error {
+						deletedPaths = append(deletedPaths, path)
+						return nil
+					},
+				},
+				AssetService: &testAssetService{
+					releaseFn: func(assetID uuid.UUID, deleteObject func(*models.Asset) error) error {
+						require.True(t, screenshotDeleted)
+						releasedAssetIDs = append(releasedAssetIDs, assetID)
+						if uuid.Equal(assetID, derivedAssetID) {
+							return deleteObject(&models.Asset{Checksum: "abcdef", Extension: ".png"})
+						}
+						return nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.ScreenshotDeleteResponse{
+				Data: assetBackedScreenshot,
+			},
+		})
+		require.Equal(t, []uuid.UUID{derivedAssetID, assetID}, releasedAssetIDs)
+		require.Equal(t, []string{"assets/ab/abcdef.png"}, deletedPaths)
+	})
+
 	t.Run("error - unexpected error in database", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
@@ -75,7 +185,8 @@ func Test_ScreenshotDeleteHandler(t *testing.T) {
 						return nil, errors.New("SOME-SQL-ERROR")
 					},
 				},
-				AWS: &providers.AWSMock{},
+				AWS:          &providers.AWSMock{},
+				AssetService: &testAssetService{},
 			},
 			expectedInternalErr: "SOME-SQL-ERROR",
 		})
@@ -88,15 +199,22 @@ func Test_ScreenshotDeleteHandler(t *testing.T) {
 			},
 			env: &env.AppEnv{
 				ScreenshotService: &testScreenshotService{
+					deleteFn: func(*models.Screenshot) error {
+						return nil
+					},
 					findFn: func(screenshot *models.Screenshot) (*models.Screenshot, error) {
 						return testScreenshot, nil
 					},
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{*testScreenshot}, nil
+					},
 				},
 				AWS: &providers.AWSMock{
 					DeleteObjectFn: func(path string) error {
 						return errors.New("An AWS error")
 					},
 				},
+				AssetService: &testAssetService{},
 			},
 			expectedInternalErr: "An AWS error",
 		})
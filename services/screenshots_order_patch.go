@@ -0,0 +1,148 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httprequest"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+type screenshotsOrderPatchParams struct {
+	ScreenshotIDs []uuid.UUID `json:"screenshot_ids"`
+}
+
+// ScreenshotsOrderPatchResponse ...
+type ScreenshotsOrderPatchResponse struct {
+	Data []ScreenshotData `json:"data"`
+}
+
+// ScreenshotsOrderPatchHandler reorders the screenshots of the app version. The listed screenshots
+// are put in the given order within their device type, screen size and locale, the screenshots which
+// are not listed keep their relative order after them.
+func ScreenshotsOrderPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var params screenshotsOrderPatchParams
+	defer httprequest.BodyCloseWithErrorLog(r)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
+	}
+
+	if env.ScreenshotService == nil {
+		return errors.New("No Screenshot Service defined for handler")
+	}
+
+	screenshots, err := env.ScreenshotService.FindAll(&models.AppVersion{Record: models.Record{ID: authorizedAppVersionID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	screenshotIDs := map[uuid.UUID]bool{}
+	for _, screenshot := range screenshots {
+		screenshotIDs[screenshot.ID] = true
+	}
+	requestedOrder := map[uuid.UUID]int{}
+	for idx, screenshotID := range params.ScreenshotIDs {
+		if !screenshotIDs[screenshotID] {
+			return httpresponse.RespondWithBadRequestError(w, fmt.Sprintf("Screenshot not found: %s", screenshotID))
+		}
+		if _, ok := requestedOrder[screenshotID]; ok {
+			return httpresponse.RespondWithBadRequestError(w, fmt.Sprintf("Duplicated screenshot ID: %s", screenshotID))
+		}
+		requestedOrder[screenshotID] = idx
+	}
+
+	orderedScreenshots, screenshotsToUpdate := reorderScreenshots(screenshots, requestedOrder)
+	if len(screenshotsToUpdate) > 0 {
+		verrs, err := env.ScreenshotService.BatchUpdate(screenshotsToUpdate, []string{"Position"})
+		if len(verrs) > 0 {
+			return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+	}
+
+	if env.AWS == nil {
+		return errors.New("No AWS Provider defined for handler")
+	}
+	responseData, err := newScreenshotGetResponseData(orderedScreenshots, env.AWS)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return httpresponse.RespondWithSuccess(w, ScreenshotsOrderPatchResponse{
+		Data: responseData,
+	})
+}
+
+// reorderScreenshots expects the screenshots in the order returned by FindAll, it returns them in the
+// new order and the screenshots which got a new position. Derived screenshots take the position of
+// their source, so the resized screenshots keep the order of the uploaded ones.
+func reorderScreenshots(screenshots []models.Screenshot, requestedOrder map[uuid.UUID]int) ([]models.Screenshot, []models.Screenshot) {
+	groupKeys := []string{}
+	groups := map[string][]models.Screenshot{}
+	for _, screenshot := range screenshots {
+		key := fmt.Sprintf("%s/%s/%s", screenshot.DeviceType, screenshot.ScreenSize, screenshot.Locale)
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], screenshot)
+	}
+
+	positions := map[uuid.UUID]int{}
+	for _, key := range groupKeys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool {
+			iOrder, iRequested := requestedOrder[group[i].ID]
+			jOrder, jRequested := requestedOrder[group[j].ID]
+			if iRequested && jRequested {
+				return iOrder < jOrder
+			}
+			return iRequested && !jRequested
+		})
+		position := 0
+		for _, screenshot := range group {
+			if !screenshot.IsDerived() {
+				position++
+				positions[screenshot.ID] = position
+			}
+		}
+	}
+	for _, screenshot := range screenshots {
+		if !screenshot.IsDerived() {
+			continue
+		}
+		if sourcePosition, ok := positions[*screenshot.SourceScreenshotID]; ok {
+			positions[screenshot.ID] = sourcePosition
+		} else {
+			positions[screenshot.ID] = screenshot.Position
+		}
+	}
+
+	orderedScreenshots := []models.Screenshot{}
+	screenshotsToUpdate := []models.Screenshot{}
+	for _, key := range groupKeys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool {
+			return positions[group[i].ID] < positions[group[j].ID]
+		})
+		for _, screenshot := range group {
+			if screenshot.Position != positions[screenshot.ID] {
+				screenshot.Position = positions[screenshot.ID]
+				screenshotsToUpdate = append(screenshotsToUpdate, screenshot)
+			}
+			orderedScreenshots = append(orderedScreenshots, screenshot)
+		}
+	}
+	return orderedScreenshots, screenshotsToUpdate
+}
@@ -0,0 +1,345 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_ScreenshotsOrderPatchHandler(t *testing.T) {
+	httpMethod := "PATCH"
+	url := "/apps/{app-slug}/versions/{version-id}/screenshots/order"
+	handler := services.ScreenshotsOrderPatchHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"ScreenshotService", "AWS"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			ScreenshotService: &testScreenshotService{
+				findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+					return []models.Screenshot{}, nil
+				},
+			},
+			AWS: &providers.AWSMock{},
+		},
+		requestBody: `{}`,
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			ScreenshotService: &testScreenshotService{},
+			AWS:               &providers.AWSMock{},
+		},
+		requestBody: `{}`,
+	})
+
+	testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+	testAppVersion := models.AppVersion{
+		Record: models.Record{ID: testAppVersionID},
+		App:    models.App{AppSlug: "test-app-slug"},
+	}
+	testScreenshotUUID1 := uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")
+	testScreenshotUUID2 := uuid.FromStringOrNil("9f235109-34fb-476d-a081-c28047d1d025")
+	testScreenshotUUID3 := uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")
+	testScreenshotUUID4 := uuid.FromStringOrNil("b4c1a7a0-6a3c-4a4f-8f5e-0b8c1d6a2e11")
+	testScreenshots := func() []models.Screenshot {
+		return []models.Screenshot{
+			models.Screenshot{
+				Record:           models.Record{ID: testScreenshotUUID1},
+				UploadableObject: models.UploadableObject{Filename: "screenshot1.png"},
+				DeviceType:       "iPhone XS Max",
+				ScreenSize:       "6.5 inch",
+				Position:         1,
+				AppVersion:       testAppVersion,
+			},
+			models.Screenshot{
+				Record:           models.Record{ID: testScreenshotUUID2},
+				UploadableObject: models.UploadableObject{Filename: "screenshot2.png"},
+				DeviceType:       "iPhone XS Max",
+				ScreenSize:       "6.5 inch",
+				Position:         2,
+				AppVersion:       testAppVersion,
+			},
+			models.Screenshot{
+				Record:           models.Record{ID: testScreenshotUUID3},
+				UploadableObject: models.UploadableObject{Filename: "screenshot3.png"},
+				DeviceType:       "iPhone XS Max",
+				ScreenSize:       "6.5 inch",
+				Position:         3,
+				AppVersion:       testAppVersion,
+			},
+			models.Screenshot{
+				Record:           models.Record{ID: testScreenshotUUID4},
+				UploadableObject: models.UploadableObject{Filename: "screenshot4.png"},
+				DeviceType:       "iPhone XS Max",
+				ScreenSize:       "6.5 inch",
+				Locale:           "de-DE",
+				Position:         1,
+				AppVersion:       testAppVersion,
+			},
+		}
+	}
+
+	t.Run("ok - minimal", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return []models.Screenshot{}, nil
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			requestBody:        `{"screenshot_ids":[]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.ScreenshotsOrderPatchResponse{
+				Data: []services.ScreenshotData{},
+			},
+		})
+	})
+
+	t.Run("ok - listed screenshots go first in the given order", func(t *testing.T) {
+		var updatedScreenshots []models.Screenshot
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						require.Equal(t, testAppVersionID, appVersion.ID)
+						return testScreenshots(), nil
+					},
+					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
+						require.Equal(t, []string{"Position"}, whitelist)
+						updatedScreenshots = screenshots
+						return nil, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+					},
+				},
+			},
+			requestBody:        fmt.Sprintf(`{"screenshot_ids":["%s","%s"]}`, testScreenshotUUID3, testScreenshotUUID1),
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.ScreenshotsOrderPatchResponse{
+				Data: []services.ScreenshotData{
+					services.ScreenshotData{
+						Screenshot: models.Screenshot{
+							Record:           models.Record{ID: testScreenshotUUID3},
+							UploadableObject: models.UploadableObject{Filename: "screenshot3.png"},
+							DeviceType:       "iPhone XS Max",
+							ScreenSize:       "6.5 inch",
+							Position:         1,
+						},
+						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS Max (6.5 inch)/27cee0a1-1afd-4280-8d9f-f22526dc3d16.png",
+					},
+					services.ScreenshotData{
+						Screenshot: models.Screenshot{
+							Record:           models.Record{ID: testScreenshotUUID1},
+							UploadableObject: models.UploadableObject{Filename: "screenshot1.png"},
+							DeviceType:       "iPhone XS Max",
+							ScreenSize:       "6.5 inch",
+							Position:         2,
+						},
+						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.png",
+					},
+					services.ScreenshotData{
+						Screenshot: models.Screenshot{
+							Record:           models.Record{ID: testScreenshotUUID2},
+							UploadableObject: models.UploadableObject{Filename: "screenshot2.png"},
+							DeviceType:       "iPhone XS Max",
+							ScreenSize:       "6.5 inch",
+							Position:         3,
+						},
+						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS Max (6.5 inch)/9f235109-34fb-476d-a081-c28047d1d025.png",
+					},
+					services.ScreenshotData{
+						Screenshot: models.Screenshot{
+							Record:           models.Record{ID: testScreenshotUUID4},
+							UploadableObject: models.UploadableObject{Filename: "screenshot4.png"},
+							DeviceType:       "iPhone XS Max",
+							ScreenSize:       "6.5 inch",
+							Locale:           "de-DE",
+							Position:         1,
+						},
+						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS Max (6.5 inch)/b4c1a7a0-6a3c-4a4f-8f5e-0b8c1d6a2e11.png",
+					},
+				},
+			},
+		})
+		require.Len(t, updatedScreenshots, 3)
+		require.Equal(t, testScreenshotUUID3, updatedScreenshots[0].ID)
+		require.Equal(t, 1, updatedScreenshots[0].Position)
+		require.Equal(t, testScreenshotUUID1, updatedScreenshots[1].ID)
+		require.Equal(t, 2, updatedScreenshots[1].Position)
+		require.Equal(t, testScreenshotUUID2, updatedScreenshots[2].ID)
+		require.Equal(t, 3, updatedScreenshots[2].Position)
+	})
+
+	t.Run("ok - derived screenshots follow the position of their source", func(t *testing.T) {
+		testDerivedScreenshotUUID1 := uuid.FromStringOrNil("5d1e7c2a-0b5e-4c0e-9a43-2f8c7b1d9e01")
+		testDerivedScreenshotUUID3 := uuid.FromStringOrNil("5d1e7c2a-0b5e-4c0e-9a43-2f8c7b1d9e03")
+		var updatedScreenshots []models.Screenshot
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return append(testScreenshots()[:3],
+							models.Screenshot{
+								Record:             models.Record{ID: testDerivedScreenshotUUID1},
+								UploadableObject:   models.UploadableObject{Filename: "screenshot1.png"},
+								DeviceType:         "iPhone 8 Plus",
+								ScreenSize:         "5.5 inch",
+								Position:           1,
+								SourceScreenshotID: &testScreenshotUUID1,
+								AppVersion:         testAppVersion,
+							},
+							models.Screenshot{
+								Record:             models.Record{ID: testDerivedScreenshotUUID3},
+								UploadableObject:   models.UploadableObject{Filename: "screenshot3.png"},
+								DeviceType:         "iPhone 8 Plus",
+								ScreenSize:         "5.5 inch",
+								Position:           3,
+								SourceScreenshotID: &testScreenshotUUID3,
+								AppVersion:         testAppVersion,
+							},
+						), nil
+					},
+					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
+						updatedScreenshots = screenshots
+						return nil, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", nil
+					},
+				},
+			},
+			requestBody:        fmt.Sprintf(`{"screenshot_ids":["%s","%s"]}`, testScreenshotUUID3, testScreenshotUUID1),
+			expectedStatusCode: http.StatusOK,
+		})
+		require.Len(t, updatedScreenshots, 5)
+		require.Equal(t, testDerivedScreenshotUUID3, updatedScreenshots[3].ID)
+		require.Equal(t, 1, updatedScreenshots[3].Position)
+		require.Equal(t, testDerivedScreenshotUUID1, updatedScreenshots[4].ID)
+		require.Equal(t, 2, updatedScreenshots[4].Position)
+	})
+
+	t.Run("when request body is invalid", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{},
+				AWS:               &providers.AWSMock{},
+			},
+			requestBody:        `invalid JSON`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid request body, JSON decode failed"},
+		})
+	})
+
+	t.Run("when a listed screenshot doesn't belong to the app version", func(t *testing.T) {
+		otherScreenshotID := uuid.FromStringOrNil("c0f9b0c2-4f1d-4e0a-9f35-8f3a4f1d8e2b")
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return testScreenshots(), nil
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			requestBody:        fmt.Sprintf(`{"screenshot_ids":["%s"]}`, otherScreenshotID),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Screenshot not found: c0f9b0c2-4f1d-4e0a-9f35-8f3a4f1d8e2b"},
+		})
+	})
+
+	t.Run("when a screenshot is listed twice", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return testScreenshots(), nil
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			requestBody:        fmt.Sprintf(`{"screenshot_ids":["%s","%s"]}`, testScreenshotUUID1, testScreenshotUUID1),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Duplicated screenshot ID: 42156ba6-3473-493f-ba08-6d74d26c320e"},
+		})
+	})
+
+	t.Run("when unexpected error happens at find", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			requestBody:         `{"screenshot_ids":[]}`,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("when unexpected error happens at update", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
+			},
+			env: &env.AppEnv{
+				ScreenshotService: &testScreenshotService{
+					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
+						return testScreenshots(), nil
+					},
+					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			requestBody:         fmt.Sprintf(`{"screenshot_ids":["%s"]}`, testScreenshotUUID2),
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+}
@@ -18,6 +18,7 @@ type screenshotsPostParamsElement struct {
 	Filesize   int64  `json:"filesize"`
 	DeviceType string `json:"device_type"`
 	ScreenSize string `json:"screen_size"`
+	Locale     string `json:"locale"`
 }
 
 type screenshotsPostParams struct {
@@ -78,6 +79,7 @@ func screenshotCreateParamsFromRequestParams(params []screenshotsPostParamsEleme
 			},
 			DeviceType: param.DeviceType,
 			ScreenSize: param.ScreenSize,
+			Locale:     param.Locale,
 		})
 	}
 	return createParams
@@ -86,7 +88,7 @@ func screenshotCreateParamsFromRequestParams(params []screenshotsPostParamsEleme
 func newScreenshotPostResponseData(screenshots []*models.Screenshot, awsProvider providers.AWSInterface) ([]ScreenshotData, error) {
 	data := []ScreenshotData{}
 	for _, screenshot := range screenshots {
-		presignedURL, err := awsProvider.GeneratePresignedPUTURL(screenshot.AWSPath(), presignedURLExpirationInterval, screenshot.Filesize)
+		presignedURL, err := awsProvider.GeneratePresignedPUTURL(screenshot.UploadAWSPath(), presignedURLExpirationInterval, screenshot.Filesize)
 		if err != nil {
 			return []ScreenshotData{}, errors.WithStack(err)
 		}
@@ -3,12 +3,10 @@ package services
 import (
 	"net/http"
 
-	"github.com/bitrise-io/addons-ship-backend/dataservices"
 	"github.com/bitrise-io/addons-ship-backend/env"
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/api-utils/httpresponse"
 	"github.com/pkg/errors"
-	uuid "github.com/satori/go.uuid"
 )
 
 // ScreenshotsUploadedPatchResponse ...
@@ -16,7 +14,9 @@ type ScreenshotsUploadedPatchResponse struct {
 	Data []ScreenshotData `json:"data"`
 }
 
-// ScreenshotsUploadedPatchHandler ...
+// ScreenshotsUploadedPatchHandler enqueues the verification of the screenshots of the app version,
+// screenshots become uploaded only after the uploaded image is verified. Invalid screenshots are
+// verified again, as their file might have been uploaded again.
 func ScreenshotsUploadedPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
 	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
 	if err != nil {
@@ -26,24 +26,46 @@ func ScreenshotsUploadedPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *
 	if env.ScreenshotService == nil {
 		return errors.New("No Screenshot Service defined for handler")
 	}
+	if env.WorkerService == nil {
+		return errors.New("No Worker Service defined for handler")
+	}
 
-	screenshotsToUpdate, err := prepareScreenshotsToUpdate(env.ScreenshotService, authorizedAppVersionID)
+	screenshots, err := env.ScreenshotService.FindAll(&models.AppVersion{Record: models.Record{ID: authorizedAppVersionID}})
 	if err != nil {
 		return errors.Wrap(err, "SQL Error")
 	}
 
-	verrs, err := env.ScreenshotService.BatchUpdate(screenshotsToUpdate, []string{"Uploaded"})
-	if len(verrs) > 0 {
-		return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+	invalidScreenshots := []models.Screenshot{}
+	for i := range screenshots {
+		if screenshots[i].IsInvalid() {
+			screenshots[i].InvalidReason = ""
+			invalidScreenshots = append(invalidScreenshots, screenshots[i])
+		}
 	}
-	if err != nil {
-		return errors.Wrap(err, "SQL Error")
+	if len(invalidScreenshots) > 0 {
+		verrs, err := env.ScreenshotService.BatchUpdate(invalidScreenshots, []string{"InvalidReason"})
+		if len(verrs) > 0 {
+			return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+	}
+
+	for _, screenshot := range screenshots {
+		if !screenshot.IsPendingVerification() {
+			continue
+		}
+		err := env.WorkerService.EnqueueVerifyUploadedImage(models.UploadableTypeScreenshot, screenshot.ID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
 	}
 
 	if env.AWS == nil {
 		return errors.New("No AWS Provider defined for handler")
 	}
-	responseData, err := newScreenshotGetResponseData(screenshotsToUpdate, env.AWS)
+	responseData, err := newScreenshotGetResponseData(screenshots, env.AWS)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -51,17 +73,3 @@ func ScreenshotsUploadedPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *
 		Data: responseData,
 	})
 }
-
-func prepareScreenshotsToUpdate(screenshotService dataservices.ScreenshotService, appVersionID uuid.UUID) ([]models.Screenshot, error) {
-	var screenshotsToUpdate []models.Screenshot
-
-	screenshots, err := screenshotService.FindAll(&models.AppVersion{Record: models.Record{ID: appVersionID}})
-	if err != nil {
-		return []models.Screenshot{}, err
-	}
-	for _, screenshot := range screenshots {
-		screenshot.Uploaded = true
-		screenshotsToUpdate = append(screenshotsToUpdate, screenshot)
-	}
-	return screenshotsToUpdate, nil
-}
@@ -10,7 +10,6 @@ import (
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/bitrise-io/addons-ship-backend/services"
 	ctxpkg "github.com/bitrise-io/api-utils/context"
-	"github.com/bitrise-io/api-utils/httpresponse"
 	"github.com/bitrise-io/api-utils/providers"
 	"github.com/c2fo/testify/require"
 	"github.com/pkg/errors"
@@ -22,7 +21,7 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 	url := "/apps/{app-slug}/versions/{version-id}/screenshots/uploaded"
 	handler := services.ScreenshotsUploadedPatchHandler
 
-	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"ScreenshotService", "AWS"}, ControllerTestCase{
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"ScreenshotService", "WorkerService", "AWS"}, ControllerTestCase{
 		contextElements: map[ctxpkg.RequestContextKey]interface{}{
 			services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
 		},
@@ -31,11 +30,9 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 				findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 					return []models.Screenshot{}, nil
 				},
-				batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
-					return nil, nil
-				},
 			},
-			AWS: &providers.AWSMock{},
+			WorkerService: &testWorkerService{},
+			AWS:           &providers.AWSMock{},
 		},
 	})
 
@@ -45,6 +42,7 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 		},
 		env: &env.AppEnv{
 			ScreenshotService: &testScreenshotService{},
+			WorkerService:     &testWorkerService{},
 			AWS:               &providers.AWSMock{},
 		},
 	})
@@ -59,10 +57,8 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, nil
 					},
-					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
-						return nil, nil
-					},
 				},
+				WorkerService: &testWorkerService{},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", nil
@@ -77,74 +73,65 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 	})
 
 	t.Run("ok - more complex", func(t *testing.T) {
+		testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
 		testScreenshotUUID1 := uuid.FromStringOrNil("42156ba6-3473-493f-ba08-6d74d26c320e")
 		testScreenshotUUID2 := uuid.FromStringOrNil("9f235109-34fb-476d-a081-c28047d1d025")
+		testScreenshotUUID3 := uuid.FromStringOrNil("27cee0a1-1afd-4280-8d9f-f22526dc3d16")
+		testAppVersion := models.AppVersion{
+			Record: models.Record{ID: testAppVersionID},
+			App:    models.App{AppSlug: "test-app-slug"},
+		}
+		enqueuedScreenshotIDs := []uuid.UUID{}
+		resetScreenshotIDs := []uuid.UUID{}
 
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
-				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879"),
+				services.ContextKeyAuthorizedAppVersionID: testAppVersionID,
 			},
 			env: &env.AppEnv{
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
-						require.Equal(t, appVersion.ID.String(), "de438ddc-98e5-4226-a5f4-fd2d53474879")
+						require.Equal(t, testAppVersionID, appVersion.ID)
 						return []models.Screenshot{
 							models.Screenshot{
-								Record:     models.Record{ID: testScreenshotUUID1},
+								Record:           models.Record{ID: testScreenshotUUID1},
 								UploadableObject: models.UploadableObject{Filename: "screenshot.png"},
-								DeviceType: "iPhone XS Max",
-								ScreenSize: "6.5 inch",
-								AppVersion: models.AppVersion{
-									Record: models.Record{ID: appVersion.ID},
-									App:    models.App{AppSlug: "test-app-slug"},
-								},
+								DeviceType:       "iPhone XS Max",
+								ScreenSize:       "6.5 inch",
+								AppVersion:       testAppVersion,
 							},
 							models.Screenshot{
-								Record:     models.Record{ID: testScreenshotUUID2},
-								UploadableObject: models.UploadableObject{Filename: "screenshot2.png"},
-								DeviceType: "iPhone XS",
-								ScreenSize: "5.5 inch",
-								AppVersion: models.AppVersion{
-									Record: models.Record{ID: appVersion.ID},
-									App:    models.App{AppSlug: "test-app-slug"},
-								},
+								Record:           models.Record{ID: testScreenshotUUID2},
+								UploadableObject: models.UploadableObject{Filename: "screenshot2.png", Uploaded: true},
+								DeviceType:       "iPhone XS",
+								ScreenSize:       "5.5 inch",
+								AppVersion:       testAppVersion,
+							},
+							models.Screenshot{
+								Record:           models.Record{ID: testScreenshotUUID3},
+								UploadableObject: models.UploadableObject{Filename: "screenshot3.png", InvalidReason: "file: Not found in storage"},
+								DeviceType:       "iPhone XS",
+								ScreenSize:       "5.5 inch",
+								AppVersion:       testAppVersion,
 							},
 						}, nil
 					},
 					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
-						appVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
-
-						require.Equal(t, []string{"Uploaded"}, whitelist)
-						require.Equal(t, models.Screenshot{
-							Record: models.Record{ID: testScreenshotUUID1},
-							UploadableObject: models.UploadableObject{
-								Filename: "screenshot.png",
-								Uploaded: true,
-							},
-							DeviceType: "iPhone XS Max",
-							ScreenSize: "6.5 inch",
-							AppVersion: models.AppVersion{
-								Record: models.Record{ID: appVersionID},
-								App:    models.App{AppSlug: "test-app-slug"},
-							},
-						}, screenshots[0])
-						require.Equal(t, models.Screenshot{
-							Record: models.Record{ID: testScreenshotUUID2},
-							UploadableObject: models.UploadableObject{
-								Filename: "screenshot2.png",
-								Uploaded: true,
-							},
-							DeviceType: "iPhone XS",
-							ScreenSize: "5.5 inch",
-							AppVersion: models.AppVersion{
-								Record: models.Record{ID: appVersionID},
-								App:    models.App{AppSlug: "test-app-slug"},
-							},
-						}, screenshots[1])
-
+						require.Equal(t, []string{"InvalidReason"}, whitelist)
+						for _, screenshot := range screenshots {
+							require.Empty(t, screenshot.InvalidReason)
+							resetScreenshotIDs = append(resetScreenshotIDs, screenshot.ID)
+						}
 						return nil, nil
 					},
 				},
+				WorkerService: &testWorkerService{
+					enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+						require.Equal(t, models.UploadableTypeScreenshot, uploadableType)
+						enqueuedScreenshotIDs = append(enqueuedScreenshotIDs, uploadableID)
+						return nil
+					},
+				},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
@@ -152,35 +139,40 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 				},
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse: services.ScreenshotsGetResponse{
+			expectedResponse: services.ScreenshotsUploadedPatchResponse{
 				Data: []services.ScreenshotData{
 					services.ScreenshotData{
 						Screenshot: models.Screenshot{
-							Record: models.Record{ID: testScreenshotUUID1},
-							UploadableObject: models.UploadableObject{
-								Filename: "screenshot.png",
-								Uploaded: true,
-							},
-							DeviceType: "iPhone XS Max",
-							ScreenSize: "6.5 inch",
+							Record:           models.Record{ID: testScreenshotUUID1},
+							UploadableObject: models.UploadableObject{Filename: "screenshot.png"},
+							DeviceType:       "iPhone XS Max",
+							ScreenSize:       "6.5 inch",
 						},
 						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS Max (6.5 inch)/42156ba6-3473-493f-ba08-6d74d26c320e.png",
 					},
 					services.ScreenshotData{
 						Screenshot: models.Screenshot{
-							Record: models.Record{ID: testScreenshotUUID2},
-							UploadableObject: models.UploadableObject{
-								Filename: "screenshot2.png",
-								Uploaded: true,
-							},
-							DeviceType: "iPhone XS",
-							ScreenSize: "5.5 inch",
+							Record:           models.Record{ID: testScreenshotUUID2},
+							UploadableObject: models.UploadableObject{Filename: "screenshot2.png", Uploaded: true},
+							DeviceType:       "iPhone XS",
+							ScreenSize:       "5.5 inch",
 						},
 						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS (5.5 inch)/9f235109-34fb-476d-a081-c28047d1d025.png",
 					},
+					services.ScreenshotData{
+						Screenshot: models.Screenshot{
+							Record:           models.Record{ID: testScreenshotUUID3},
+							UploadableObject: models.UploadableObject{Filename: "screenshot3.png"},
+							DeviceType:       "iPhone XS",
+							ScreenSize:       "5.5 inch",
+						},
+						DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/iPhone XS (5.5 inch)/27cee0a1-1afd-4280-8d9f-f22526dc3d16.png",
+					},
 				},
 			},
 		})
+		require.Equal(t, []uuid.UUID{testScreenshotUUID3}, resetScreenshotIDs)
+		require.Equal(t, []uuid.UUID{testScreenshotUUID1, testScreenshotUUID3}, enqueuedScreenshotIDs)
 	})
 
 	t.Run("when unexpected error happpens at find", func(t *testing.T) {
@@ -193,21 +185,15 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{}, errors.New("SOME-SQL-ERROR-AT-FIND")
 					},
-					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
-						return nil, nil
-					},
-				},
-				AWS: &providers.AWSMock{
-					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
-						return "", nil
-					},
 				},
+				WorkerService: &testWorkerService{},
+				AWS:           &providers.AWSMock{},
 			},
 			expectedInternalErr: "SQL Error: SOME-SQL-ERROR-AT-FIND",
 		})
 	})
 
-	t.Run("when validation error at screenshot update", func(t *testing.T) {
+	t.Run("when error happens at enqueueing verification", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
 				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
@@ -215,66 +201,34 @@ func Test_ScreenshotsUploadedPatchHandler(t *testing.T) {
 			env: &env.AppEnv{
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
-						return []models.Screenshot{}, nil
-					},
-					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
-						return []error{errors.New("SOME-VALIDATION-ERROR")}, nil
-					},
-				},
-				AWS: &providers.AWSMock{
-					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
-						return "", nil
-					},
-				},
-			},
-			expectedStatusCode: http.StatusUnprocessableEntity,
-			expectedResponse: httpresponse.ValidationErrorRespModel{
-				Message: "Unprocessable Entity",
-				Errors:  []string{"SOME-VALIDATION-ERROR"},
-			},
-		})
-	})
-
-	t.Run("when unexpected error at update", func(t *testing.T) {
-		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
-			contextElements: map[ctxpkg.RequestContextKey]interface{}{
-				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
-			},
-			env: &env.AppEnv{
-				ScreenshotService: &testScreenshotService{
-					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
-						return []models.Screenshot{}, nil
-					},
-					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
-						return nil, errors.New("SOME-SQL-ERROR")
+						return []models.Screenshot{models.Screenshot{}}, nil
 					},
 				},
-				AWS: &providers.AWSMock{
-					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
-						return "", nil
+				WorkerService: &testWorkerService{
+					enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+						return errors.New("SOME-REDIS-ERROR")
 					},
 				},
+				AWS: &providers.AWSMock{},
 			},
-			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+			expectedInternalErr: "SOME-REDIS-ERROR",
 		})
 	})
 
 	t.Run("when error at generating AWS presigned URL", func(t *testing.T) {
 		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
 			contextElements: map[ctxpkg.RequestContextKey]interface{}{
-				services.ContextKeyAuthorizedAppVersionID: uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879"),
+				services.ContextKeyAuthorizedAppVersionID: uuid.NewV4(),
 			},
 			env: &env.AppEnv{
 				ScreenshotService: &testScreenshotService{
 					findAllFn: func(appVersion *models.AppVersion) ([]models.Screenshot, error) {
 						return []models.Screenshot{
-							models.Screenshot{},
+							models.Screenshot{UploadableObject: models.UploadableObject{Uploaded: true}},
 						}, nil
 					},
-					batchUpdateFn: func(screenshots []models.Screenshot, whitelist []string) ([]error, error) {
-						return nil, nil
-					},
 				},
+				WorkerService: &testWorkerService{},
 				AWS: &providers.AWSMock{
 					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
 						return "", errors.New("SOME-AWS-ERROR")
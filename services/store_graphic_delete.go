@@ -0,0 +1,59 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+// StoreGraphicDeleteResponse ...
+type StoreGraphicDeleteResponse struct {
+	Data *models.StoreGraphic `json:"data"`
+}
+
+// StoreGraphicDeleteHandler ...
+func StoreGraphicDeleteHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	graphicType, err := GetAuthorizedStoreGraphicTypeFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if env.StoreGraphicService == nil {
+		return errors.New("No Store Graphic Service defined for handler")
+	}
+
+	if env.AWS == nil {
+		return errors.New("No AWS Provider defined for handler")
+	}
+
+	if env.AssetService == nil {
+		return errors.New("No Asset Service defined for handler")
+	}
+
+	storeGraphic, err := env.StoreGraphicService.Find(
+		&models.StoreGraphic{AppVersionID: authorizedAppVersionID, Type: graphicType})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	err = env.StoreGraphicService.Delete(storeGraphic)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	err = removeUploadableObject(env, storeGraphic.AWSPath(), storeGraphic.AssetID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return httpresponse.RespondWithSuccess(w, StoreGraphicDeleteResponse{
+		Data: storeGraphic,
+	})
+}
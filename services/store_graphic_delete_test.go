@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_StoreGraphicDeleteHandler(t *testing.T) {
+	httpMethod := "DELETE"
+	url := "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}"
+	handler := services.StoreGraphicDeleteHandler
+
+	testAppVersionID := uuid.NewV4()
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"StoreGraphicService", "AWS", "AssetService"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{
+			StoreGraphicService: &testStoreGraphicService{
+				deleteFn: func(storeGraphic *models.StoreGraphic) error {
+					return nil
+				},
+				findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+					return &models.StoreGraphic{}, nil
+				},
+			},
+			AWS:          &providers.AWSMock{},
+			AssetService: &testAssetService{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID, services.ContextKeyAuthorizedStoreGraphicType}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{},
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeTvBanner,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					deleteFn: func(storeGraphic *models.StoreGraphic) error {
+						return nil
+					},
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						require.Equal(t, testAppVersionID, storeGraphic.AppVersionID)
+						require.Equal(t, models.StoreGraphicTypeTvBanner, storeGraphic.Type)
+						return storeGraphic, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					DeleteObjectFn: func(path string) error {
+						return nil
+					},
+				},
+				AssetService: &testAssetService{},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicDeleteResponse{
+				Data: &models.StoreGraphic{AppVersionID: testAppVersionID, Type: models.StoreGraphicTypeTvBanner},
+			},
+		})
+	})
+
+	t.Run("ok - releases the asset of a verified store graphic", func(t *testing.T) {
+		assetID := uuid.NewV4()
+		storeGraphicDeleted := false
+		releasedAssetIDs := []uuid.UUID{}
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeFeatureGraphic,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					deleteFn: func(storeGraphic *models.StoreGraphic) error {
+						storeGraphicDeleted = true
+						return nil
+					},
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						storeGraphic.AssetID = &assetID
+						return storeGraphic, nil
+					},
+				},
+				AWS: &providers.AWSMock{},
+				AssetService: &testAssetService{
+					releaseFn: func(id uuid.UUID, deleteObject func(*models.Asset) error) error {
+						require.True(t, storeGraphicDeleted)
+						releasedAssetIDs = append(releasedAssetIDs, id)
+						return nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicDeleteResponse{
+				Data: &models.StoreGraphic{AppVersionID: testAppVersionID, Type: models.StoreGraphicTypeFeatureGraphic},
+			},
+		})
+		require.Equal(t, []uuid.UUID{assetID}, releasedAssetIDs)
+	})
+
+	t.Run("error - unexpected error in database at find", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				AWS:          &providers.AWSMock{},
+				AssetService: &testAssetService{},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("error - unexpected error in database at delete", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{}, nil
+					},
+					deleteFn: func(storeGraphic *models.StoreGraphic) error {
+						return errors.New("SOME-SQL-ERROR")
+					},
+				},
+				AWS: &providers.AWSMock{
+					DeleteObjectFn: func(path string) error {
+						return nil
+					},
+				},
+				AssetService: &testAssetService{},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("error - aws error", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{}, nil
+					},
+					deleteFn: func(storeGraphic *models.StoreGraphic) error {
+						return nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					DeleteObjectFn: func(path string) error {
+						return errors.New("SOME-AWS-ERROR")
+					},
+				},
+				AssetService: &testAssetService{},
+			},
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
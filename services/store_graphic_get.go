@@ -0,0 +1,63 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// StoreGraphicData ...
+type StoreGraphicData struct {
+	models.StoreGraphic
+	DownloadURL string `json:"download_url,omitempty"`
+	UploadURL   string `json:"upload_url,omitempty"`
+}
+
+// StoreGraphicGetResponse ...
+type StoreGraphicGetResponse struct {
+	Data StoreGraphicData `json:"data"`
+}
+
+// StoreGraphicGetHandler ...
+func StoreGraphicGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	graphicType, err := GetAuthorizedStoreGraphicTypeFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if env.StoreGraphicService == nil {
+		return errors.New("No Store Graphic Service defined for handler")
+	}
+
+	storeGraphic, err := env.StoreGraphicService.Find(
+		&models.StoreGraphic{AppVersionID: authorizedAppVersionID, Type: graphicType},
+	)
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return httpresponse.RespondWithNotFoundError(w)
+	case err != nil:
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	if env.AWS == nil {
+		return errors.New("No AWS Provider defined for handler")
+	}
+	presignedURL, err := env.AWS.GeneratePresignedGETURL(storeGraphic.AWSPath(), presignedURLExpirationInterval)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return httpresponse.RespondWithSuccess(w, StoreGraphicGetResponse{
+		Data: StoreGraphicData{
+			StoreGraphic: *storeGraphic,
+			DownloadURL:  presignedURL,
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_StoreGraphicGetHandler(t *testing.T) {
+	httpMethod := "GET"
+	url := "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}"
+	handler := services.StoreGraphicGetHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"StoreGraphicService", "AWS"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{
+			StoreGraphicService: &testStoreGraphicService{
+				findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+					return &models.StoreGraphic{}, nil
+				},
+			},
+			AWS: &providers.AWSMock{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID, services.ContextKeyAuthorizedStoreGraphicType}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{
+			StoreGraphicService: &testStoreGraphicService{},
+			AWS:                 &providers.AWSMock{},
+		},
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		testStoreGraphicUUID := uuid.FromStringOrNil("33c7223f-2203-4109-b439-6026e7a374c9")
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879"),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypePromoGraphic,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						require.Equal(t, "de438ddc-98e5-4226-a5f4-fd2d53474879", storeGraphic.AppVersionID.String())
+						require.Equal(t, models.StoreGraphicTypePromoGraphic, storeGraphic.Type)
+						return &models.StoreGraphic{
+							Record:           models.Record{ID: testStoreGraphicUUID},
+							UploadableObject: models.UploadableObject{Filename: "promo.png", Uploaded: true},
+							Type:             models.StoreGraphicTypePromoGraphic,
+							AppVersion: models.AppVersion{
+								Record: models.Record{ID: storeGraphic.AppVersionID},
+								App:    models.App{AppSlug: "test-app-slug"},
+							},
+						}, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicGetResponse{
+				Data: services.StoreGraphicData{
+					StoreGraphic: models.StoreGraphic{
+						Record:           models.Record{ID: testStoreGraphicUUID},
+						UploadableObject: models.UploadableObject{Filename: "promo.png", Uploaded: true},
+						Type:             models.StoreGraphicTypePromoGraphic,
+					},
+					DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/promo_graphic/33c7223f-2203-4109-b439-6026e7a374c9.png",
+				},
+			},
+		})
+	})
+
+	t.Run("error - not found in database", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return nil, gorm.ErrRecordNotFound
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Not Found"},
+		})
+	})
+
+	t.Run("error - unexpected error in database", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("error - when generating AWS presigned URL", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{}, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", errors.New("SOME-AWS-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
@@ -11,38 +11,43 @@ import (
 	"github.com/pkg/errors"
 )
 
-type featureGraphicPostParamsElement struct {
+type storeGraphicPostParams struct {
 	Filename string `json:"filename"`
 	Filesize int64  `json:"filesize"`
 }
 
-// FeatureGraphicPostResponse ...
-type FeatureGraphicPostResponse struct {
-	Data FeatureGraphicData `json:"data"`
+// StoreGraphicPostResponse ...
+type StoreGraphicPostResponse struct {
+	Data StoreGraphicData `json:"data"`
 }
 
-// FeatureGraphicPostHandler ...
-func FeatureGraphicPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+// StoreGraphicPostHandler ...
+func StoreGraphicPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
 	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	graphicType, err := GetAuthorizedStoreGraphicTypeFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
 
-	var params featureGraphicPostParamsElement
+	var params storeGraphicPostParams
 	defer httprequest.BodyCloseWithErrorLog(r)
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		return httpresponse.RespondWithBadRequestError(w, "Invalid request body, JSON decode failed")
 	}
 
-	if env.FeatureGraphicService == nil {
-		return errors.New("No Feature Graphic Service defined for handler")
+	if env.StoreGraphicService == nil {
+		return errors.New("No Store Graphic Service defined for handler")
 	}
 
-	createdFeatureGraphic, verrs, err := env.FeatureGraphicService.Create(&models.FeatureGraphic{
+	createdStoreGraphic, verrs, err := env.StoreGraphicService.Create(&models.StoreGraphic{
 		UploadableObject: models.UploadableObject{
 			Filename: params.Filename,
 			Filesize: params.Filesize,
 		},
+		Type:         graphicType,
 		AppVersionID: authorizedAppVersionID,
 	})
 	if len(verrs) > 0 {
@@ -55,15 +60,15 @@ func FeatureGraphicPostHandler(env *env.AppEnv, w http.ResponseWriter, r *http.R
 	if env.AWS == nil {
 		return errors.New("No AWS Provider defined for handler")
 	}
-	presignedURL, err := env.AWS.GeneratePresignedPUTURL(createdFeatureGraphic.AWSPath(), presignedURLExpirationInterval, createdFeatureGraphic.Filesize)
+	presignedURL, err := env.AWS.GeneratePresignedPUTURL(createdStoreGraphic.UploadAWSPath(), presignedURLExpirationInterval, createdStoreGraphic.Filesize)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	return httpresponse.RespondWithSuccess(w, FeatureGraphicPostResponse{
-		Data: FeatureGraphicData{
-			FeatureGraphic: *createdFeatureGraphic,
-			UploadURL:      presignedURL,
+	return httpresponse.RespondWithSuccess(w, StoreGraphicPostResponse{
+		Data: StoreGraphicData{
+			StoreGraphic: *createdStoreGraphic,
+			UploadURL:    presignedURL,
 		},
 	})
 }
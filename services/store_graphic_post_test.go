@@ -0,0 +1,188 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_StoreGraphicPostHandler(t *testing.T) {
+	httpMethod := "POST"
+	url := "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}"
+	handler := services.StoreGraphicPostHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"StoreGraphicService", "AWS"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{
+			StoreGraphicService: &testStoreGraphicService{
+				createFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, []error, error) {
+					return &models.StoreGraphic{}, nil, nil
+				},
+			},
+			AWS: &providers.AWSMock{},
+		},
+		requestBody: `{}`,
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID, services.ContextKeyAuthorizedStoreGraphicType}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{
+			StoreGraphicService: &testStoreGraphicService{},
+			AWS:                 &providers.AWSMock{},
+		},
+		requestBody: `{}`,
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		testStoreGraphicUUID := uuid.FromStringOrNil("33c7223f-2203-4109-b439-6026e7a374c9")
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879"),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeTvBanner,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					createFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, []error, error) {
+						appVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+
+						require.Equal(t, &models.StoreGraphic{
+							AppVersionID: appVersionID,
+							Type:         models.StoreGraphicTypeTvBanner,
+							UploadableObject: models.UploadableObject{
+								Filename: "banner.jpg",
+								Filesize: 1234,
+							},
+						}, storeGraphic)
+
+						return &models.StoreGraphic{
+							Record:           models.Record{ID: testStoreGraphicUUID},
+							UploadableObject: models.UploadableObject{Filename: "banner.jpg", Filesize: 1234},
+							Type:             models.StoreGraphicTypeTvBanner,
+							AppVersion: models.AppVersion{
+								Record: models.Record{ID: appVersionID},
+								App:    models.App{AppSlug: "test-app-slug"},
+							},
+						}, nil, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedPUTURLFn: func(path string, expiration time.Duration, size int64) (string, error) {
+						require.Equal(t, int64(1234), size)
+						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+					},
+				},
+			},
+			requestBody:        `{"filename":"banner.jpg","filesize":1234}`,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicPostResponse{
+				Data: services.StoreGraphicData{
+					StoreGraphic: models.StoreGraphic{
+						Record:           models.Record{ID: testStoreGraphicUUID},
+						UploadableObject: models.UploadableObject{Filename: "banner.jpg", Filesize: 1234},
+						Type:             models.StoreGraphicTypeTvBanner,
+					},
+					UploadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/tv_banner/33c7223f-2203-4109-b439-6026e7a374c9.jpg",
+				},
+			},
+		})
+	})
+
+	t.Run("error - invalid request body format", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{},
+				AWS:                 &providers.AWSMock{},
+			},
+			requestBody:        `invalid JSON`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   httpresponse.StandardErrorRespModel{Message: "Invalid request body, JSON decode failed"},
+		})
+	})
+
+	t.Run("error - validation error", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					createFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, []error, error) {
+						return nil, []error{errors.New("store_graphics: Maximum count of icon store graphics is 1")}, nil
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			requestBody:        `{"filename":"icon.png","filesize":1234}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: httpresponse.ValidationErrorRespModel{
+				Message: "Unprocessable Entity",
+				Errors:  []string{"store_graphics: Maximum count of icon store graphics is 1"},
+			},
+		})
+	})
+
+	t.Run("error - unexpected error in database", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					createFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, []error, error) {
+						return nil, nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			requestBody:         `{"filename":"icon.png","filesize":1234}`,
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+
+	t.Run("error - when generating AWS presigned URL", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					createFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, []error, error) {
+						return &models.StoreGraphic{}, nil, nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedPUTURLFn: func(path string, expiration time.Duration, size int64) (string, error) {
+						return "", errors.New("SOME-AWS-ERROR")
+					},
+				},
+			},
+			requestBody:         `{"filename":"icon.png","filesize":1234}`,
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
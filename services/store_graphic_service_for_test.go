@@ -0,0 +1,46 @@
+package services_test
+
+import "github.com/bitrise-io/addons-ship-backend/models"
+
+type testStoreGraphicService struct {
+	createFn  func(*models.StoreGraphic) (*models.StoreGraphic, []error, error)
+	findFn    func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error)
+	findAllFn func(*models.AppVersion) ([]models.StoreGraphic, error)
+	updateFn  func(models.StoreGraphic, []string) ([]error, error)
+	deleteFn  func(storeGraphic *models.StoreGraphic) error
+}
+
+func (s *testStoreGraphicService) Create(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, []error, error) {
+	if s.createFn != nil {
+		return s.createFn(storeGraphic)
+	}
+	panic("You have to override Create function in tests")
+}
+
+func (s *testStoreGraphicService) Find(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+	if s.findFn != nil {
+		return s.findFn(storeGraphic)
+	}
+	panic("You have to override Find function in tests")
+}
+
+func (s *testStoreGraphicService) FindAll(appVersion *models.AppVersion) ([]models.StoreGraphic, error) {
+	if s.findAllFn != nil {
+		return s.findAllFn(appVersion)
+	}
+	panic("You have to override FindAll function in tests")
+}
+
+func (s *testStoreGraphicService) Update(storeGraphic models.StoreGraphic, whitelist []string) ([]error, error) {
+	if s.updateFn != nil {
+		return s.updateFn(storeGraphic, whitelist)
+	}
+	panic("You have to override Update function in tests")
+}
+
+func (s *testStoreGraphicService) Delete(storeGraphic *models.StoreGraphic) error {
+	if s.deleteFn != nil {
+		return s.deleteFn(storeGraphic)
+	}
+	panic("You have to override the Delete function in tests")
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+// StoreGraphicUploadedPatchResponse ...
+type StoreGraphicUploadedPatchResponse struct {
+	Data StoreGraphicData `json:"data"`
+}
+
+// StoreGraphicUploadedPatchHandler enqueues the verification of the store graphic. An invalid store
+// graphic is verified again, as its file might have been uploaded again.
+func StoreGraphicUploadedPatchHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppVersionID, err := GetAuthorizedAppVersionIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	graphicType, err := GetAuthorizedStoreGraphicTypeFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if env.StoreGraphicService == nil {
+		return errors.New("No Store Graphic Service defined for handler")
+	}
+	if env.WorkerService == nil {
+		return errors.New("No Worker Service defined for handler")
+	}
+
+	storeGraphic, err := env.StoreGraphicService.Find(
+		&models.StoreGraphic{AppVersionID: authorizedAppVersionID, Type: graphicType},
+	)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	if storeGraphic.IsInvalid() {
+		storeGraphic.InvalidReason = ""
+		verrs, err := env.StoreGraphicService.Update(*storeGraphic, []string{"InvalidReason"})
+		if len(verrs) > 0 {
+			return httpresponse.RespondWithUnprocessableEntity(w, verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+	}
+
+	if storeGraphic.IsPendingVerification() {
+		err = env.WorkerService.EnqueueVerifyUploadedImage(models.UploadableTypeStoreGraphic, storeGraphic.ID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if env.AWS == nil {
+		return errors.New("No AWS Provider defined for handler")
+	}
+	presignedURL, err := env.AWS.GeneratePresignedGETURL(storeGraphic.AWSPath(), presignedURLExpirationInterval)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return httpresponse.RespondWithSuccess(w, StoreGraphicUploadedPatchResponse{
+		Data: StoreGraphicData{
+			StoreGraphic: *storeGraphic,
+			DownloadURL:  presignedURL,
+		},
+	})
+}
@@ -0,0 +1,286 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_StoreGraphicUploadedPatchHandler(t *testing.T) {
+	httpMethod := "PATCH"
+	url := "/apps/{app-slug}/versions/{version-id}/store-graphics/{graphic-type}/uploaded"
+	handler := services.StoreGraphicUploadedPatchHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"StoreGraphicService", "WorkerService", "AWS"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{
+			StoreGraphicService: &testStoreGraphicService{
+				findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+					return &models.StoreGraphic{}, nil
+				},
+			},
+			WorkerService: &testWorkerService{
+				enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+					return nil
+				},
+			},
+			AWS: &providers.AWSMock{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppVersionID, services.ContextKeyAuthorizedStoreGraphicType}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+			services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+		},
+		env: &env.AppEnv{
+			StoreGraphicService: &testStoreGraphicService{},
+			WorkerService:       &testWorkerService{},
+			AWS:                 &providers.AWSMock{},
+		},
+	})
+
+	t.Run("ok - minimal", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{}, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+						return nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicUploadedPatchResponse{
+				Data: services.StoreGraphicData{},
+			},
+		})
+	})
+
+	t.Run("ok - more complex", func(t *testing.T) {
+		testAppVersionID := uuid.FromStringOrNil("de438ddc-98e5-4226-a5f4-fd2d53474879")
+		testStoreGraphicUUID := uuid.FromStringOrNil("33c7223f-2203-4109-b439-6026e7a374c9")
+		verificationEnqueued := false
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     testAppVersionID,
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						require.Equal(t, storeGraphic.AppVersionID, testAppVersionID)
+						require.Equal(t, models.StoreGraphicTypeIcon, storeGraphic.Type)
+						return &models.StoreGraphic{
+							Record:           models.Record{ID: testStoreGraphicUUID},
+							UploadableObject: models.UploadableObject{Filename: "icon.png"},
+							Type:             models.StoreGraphicTypeIcon,
+							AppVersion: models.AppVersion{
+								Record: models.Record{ID: storeGraphic.AppVersionID},
+								App:    models.App{AppSlug: "test-app-slug"},
+							},
+						}, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+						require.Equal(t, models.UploadableTypeStoreGraphic, uploadableType)
+						require.Equal(t, testStoreGraphicUUID, uploadableID)
+						verificationEnqueued = true
+						return nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return fmt.Sprintf("http://presigned.aws.url/%s", path), nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicUploadedPatchResponse{
+				Data: services.StoreGraphicData{
+					StoreGraphic: models.StoreGraphic{
+						Record:           models.Record{ID: testStoreGraphicUUID},
+						UploadableObject: models.UploadableObject{Filename: "icon.png"},
+						Type:             models.StoreGraphicTypeIcon,
+					},
+					DownloadURL: "http://presigned.aws.url/test-app-slug/de438ddc-98e5-4226-a5f4-fd2d53474879/icon/33c7223f-2203-4109-b439-6026e7a374c9.png",
+				},
+			},
+		})
+		require.True(t, verificationEnqueued)
+	})
+
+	t.Run("ok - when store graphic is already verified", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{UploadableObject: models.UploadableObject{Uploaded: true}}, nil
+					},
+				},
+				WorkerService: &testWorkerService{},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicUploadedPatchResponse{
+				Data: services.StoreGraphicData{
+					StoreGraphic: models.StoreGraphic{UploadableObject: models.UploadableObject{Uploaded: true}},
+				},
+			},
+		})
+	})
+
+	t.Run("ok - when store graphic is invalid", func(t *testing.T) {
+		testStoreGraphicUUID := uuid.NewV4()
+		invalidReasonReset := false
+		verificationEnqueued := false
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{
+							Record:           models.Record{ID: testStoreGraphicUUID},
+							UploadableObject: models.UploadableObject{InvalidReason: "file: Not found in storage"},
+						}, nil
+					},
+					updateFn: func(storeGraphic models.StoreGraphic, whitelist []string) ([]error, error) {
+						require.Equal(t, []string{"InvalidReason"}, whitelist)
+						require.Empty(t, storeGraphic.InvalidReason)
+						invalidReasonReset = true
+						return nil, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+						require.True(t, invalidReasonReset)
+						require.Equal(t, testStoreGraphicUUID, uploadableID)
+						verificationEnqueued = true
+						return nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: services.StoreGraphicUploadedPatchResponse{
+				Data: services.StoreGraphicData{
+					StoreGraphic: models.StoreGraphic{Record: models.Record{ID: testStoreGraphicUUID}},
+				},
+			},
+		})
+		require.True(t, verificationEnqueued)
+	})
+
+	t.Run("when unexpected error happpens at find", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return nil, errors.New("SOME-SQL-ERROR-AT-FIND")
+					},
+				},
+				WorkerService: &testWorkerService{},
+				AWS:           &providers.AWSMock{},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR-AT-FIND",
+		})
+	})
+
+	t.Run("when error happens at enqueueing verification", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{}, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+						return errors.New("SOME-REDIS-ERROR")
+					},
+				},
+				AWS: &providers.AWSMock{},
+			},
+			expectedInternalErr: "SOME-REDIS-ERROR",
+		})
+	})
+
+	t.Run("when error at generating AWS presigned URL", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppVersionID:     uuid.NewV4(),
+				services.ContextKeyAuthorizedStoreGraphicType: models.StoreGraphicTypeIcon,
+			},
+			env: &env.AppEnv{
+				StoreGraphicService: &testStoreGraphicService{
+					findFn: func(storeGraphic *models.StoreGraphic) (*models.StoreGraphic, error) {
+						return &models.StoreGraphic{}, nil
+					},
+				},
+				WorkerService: &testWorkerService{
+					enqueueVerifyUploadedImageFn: func(uploadableType string, uploadableID uuid.UUID) error {
+						return nil
+					},
+				},
+				AWS: &providers.AWSMock{
+					GeneratePresignedGETURLFn: func(path string, expiration time.Duration) (string, error) {
+						return "", errors.New("SOME-AWS-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SOME-AWS-ERROR",
+		})
+	})
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	"github.com/pkg/errors"
+)
+
+// UploadPart ...
+type UploadPart struct {
+	PartNumber int64  `json:"part_number"`
+	UploadURL  string `json:"upload_url"`
+}
+
+// presignUploadParts returns the URLs each part of a file of the given size can be uploaded to, as part
+// of the multipart upload
+func presignUploadParts(storageProvider storage.Interface, key, uploadID string, fileSize int64) ([]UploadPart, error) {
+	uploadParts := []UploadPart{}
+	for partNumber := int64(1); partNumber <= storage.PartCount(fileSize); partNumber++ {
+		presignedURL, err := storageProvider.GeneratePresignedUploadPartURL(key, uploadID, partNumber, presignedURLExpirationInterval)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		uploadParts = append(uploadParts, UploadPart{PartNumber: partNumber, UploadURL: presignedURL})
+	}
+	return uploadParts, nil
+}
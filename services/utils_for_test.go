@@ -98,27 +98,54 @@ func behavesAsServiceCravingHandler(t *testing.T, method, url string, handler fu
 			} else if sn == "ScreenshotService" {
 				controllerTestCase.env.ScreenshotService = nil
 				controllerTestCase.expectedInternalErr = "No Screenshot Service defined for handler"
-			} else if sn == "FeatureGraphicService" {
-				controllerTestCase.env.FeatureGraphicService = nil
-				controllerTestCase.expectedInternalErr = "No Feature Graphic Service defined for handler"
+			} else if sn == "AppPreviewService" {
+				controllerTestCase.env.AppPreviewService = nil
+				controllerTestCase.expectedInternalErr = "No App Preview Service defined for handler"
+			} else if sn == "StoreGraphicService" {
+				controllerTestCase.env.StoreGraphicService = nil
+				controllerTestCase.expectedInternalErr = "No Store Graphic Service defined for handler"
 			} else if sn == "AppSettingsService" {
 				controllerTestCase.env.AppSettingsService = nil
 				controllerTestCase.expectedInternalErr = "No App Settings Service defined for handler"
 			} else if sn == "AppVersionEventService" {
 				controllerTestCase.env.AppVersionEventService = nil
 				controllerTestCase.expectedInternalErr = "No App Version Event Service defined for handler"
+			} else if sn == "AppEventService" {
+				controllerTestCase.env.AppEventService = nil
+				controllerTestCase.expectedInternalErr = "No App Event Service defined for handler"
 			} else if sn == "PublishTaskService" {
 				controllerTestCase.env.PublishTaskService = nil
 				controllerTestCase.expectedInternalErr = "No Publish Task Service defined for handler"
 			} else if sn == "AppContactService" {
 				controllerTestCase.env.AppContactService = nil
 				controllerTestCase.expectedInternalErr = "No App Contact Service defined for handler"
+			} else if sn == "JobStatusService" {
+				controllerTestCase.env.JobStatusService = nil
+				controllerTestCase.expectedInternalErr = "No Job Status Service defined for handler"
+			} else if sn == "AssetService" {
+				controllerTestCase.env.AssetService = nil
+				controllerTestCase.expectedInternalErr = "No Asset Service defined for handler"
+			} else if sn == "AppTombstoneService" {
+				controllerTestCase.env.AppTombstoneService = nil
+				controllerTestCase.expectedInternalErr = "No App Tombstone Service defined for handler"
+			} else if sn == "ProcessedBuildService" {
+				controllerTestCase.env.ProcessedBuildService = nil
+				controllerTestCase.expectedInternalErr = "No Processed Build Service defined for handler"
+			} else if sn == "BuildWebhookService" {
+				controllerTestCase.env.BuildWebhookService = nil
+				controllerTestCase.expectedInternalErr = "No Build Webhook Service defined for handler"
+			} else if sn == "WebhookDeliveryService" {
+				controllerTestCase.env.WebhookDeliveryService = nil
+				controllerTestCase.expectedInternalErr = "No Webhook Delivery Service defined for handler"
 			} else if sn == "RequestParams" {
 				controllerTestCase.env.RequestParams = nil
 				controllerTestCase.expectedInternalErr = "No RequestParams defined for handler"
 			} else if sn == "AWS" {
 				controllerTestCase.env.AWS = nil
 				controllerTestCase.expectedInternalErr = "No AWS Provider defined for handler"
+			} else if sn == "Storage" {
+				controllerTestCase.env.Storage = nil
+				controllerTestCase.expectedInternalErr = "No Storage Provider defined for handler"
 			} else if sn == "BitriseAPI" {
 				controllerTestCase.env.BitriseAPI = nil
 				controllerTestCase.expectedInternalErr = "No Bitrise API Service defined for handler"
@@ -140,6 +167,10 @@ func behavesAsContextCravingHandler(t *testing.T, method, url string, handler fu
 	t.Run("behaves as context craving handler", func(t *testing.T) {
 		for _, ck := range contextKeys {
 			controllerTestCase := baseCT
+			controllerTestCase.contextElements = map[ctxpkg.RequestContextKey]interface{}{}
+			for k, v := range baseCT.contextElements {
+				controllerTestCase.contextElements[k] = v
+			}
 			if ck == services.ContextKeyAuthorizedAppID {
 				controllerTestCase.contextElements[ck] = nil
 				controllerTestCase.expectedInternalErr = "Authorized App ID not found in Context"
@@ -152,6 +183,15 @@ func behavesAsContextCravingHandler(t *testing.T, method, url string, handler fu
 			} else if ck == services.ContextKeyAuthorizedScreenshotID {
 				controllerTestCase.contextElements[ck] = nil
 				controllerTestCase.expectedInternalErr = "Authorized App Version Screenshot ID not found in Context"
+			} else if ck == services.ContextKeyAuthorizedAppPreviewID {
+				controllerTestCase.contextElements[ck] = nil
+				controllerTestCase.expectedInternalErr = "Authorized App Version App Preview ID not found in Context"
+			} else if ck == services.ContextKeyAuthorizedStoreGraphicType {
+				controllerTestCase.contextElements[ck] = nil
+				controllerTestCase.expectedInternalErr = "Authorized Store Graphic Type not found in Context"
+			} else if ck == services.ContextKeyAuthorizedJobStatusID {
+				controllerTestCase.contextElements[ck] = nil
+				controllerTestCase.expectedInternalErr = "Authorized Job Status ID not found in Context"
 			} else {
 
 				t.Fatalf("Invalid context element name defined: %s", ck)
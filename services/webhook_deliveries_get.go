@@ -0,0 +1,34 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"github.com/pkg/errors"
+)
+
+// WebhookDeliveriesGetResponse ...
+type WebhookDeliveriesGetResponse struct {
+	Data []models.WebhookDelivery `json:"data"`
+}
+
+// WebhookDeliveriesGetHandler returns the recorded webhook deliveries of the app, the latest first
+func WebhookDeliveriesGetHandler(env *env.AppEnv, w http.ResponseWriter, r *http.Request) error {
+	authorizedAppID, err := GetAuthorizedAppIDFromContext(r.Context())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if env.WebhookDeliveryService == nil {
+		return errors.New("No Webhook Delivery Service defined for handler")
+	}
+
+	webhookDeliveries, err := env.WebhookDeliveryService.FindAll(&models.App{Record: models.Record{ID: authorizedAppID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	return httpresponse.RespondWithSuccess(w, WebhookDeliveriesGetResponse{Data: webhookDeliveries})
+}
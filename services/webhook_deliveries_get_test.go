@@ -0,0 +1,84 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/services"
+	ctxpkg "github.com/bitrise-io/api-utils/context"
+	"github.com/c2fo/testify/require"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+func Test_WebhookDeliveriesGetHandler(t *testing.T) {
+	httpMethod := "GET"
+	url := "/apps/{app-slug}/webhook-deliveries"
+	handler := services.WebhookDeliveriesGetHandler
+
+	behavesAsServiceCravingHandler(t, httpMethod, url, handler, []string{"WebhookDeliveryService"}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			WebhookDeliveryService: &testWebhookDeliveryService{},
+		},
+	})
+
+	behavesAsContextCravingHandler(t, httpMethod, url, handler, []ctxpkg.RequestContextKey{services.ContextKeyAuthorizedAppID}, ControllerTestCase{
+		contextElements: map[ctxpkg.RequestContextKey]interface{}{
+			services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+		},
+		env: &env.AppEnv{
+			WebhookDeliveryService: &testWebhookDeliveryService{},
+		},
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		testAppID := uuid.NewV4()
+		signatureValid := true
+		testWebhookDelivery := models.WebhookDelivery{
+			Endpoint:       "/webhook",
+			EventType:      "build/finished",
+			Headers:        json.RawMessage(`{"Bitrise-Event-Type":"build/finished"}`),
+			Body:           `{"build_slug":"test-build-slug"}`,
+			SignatureValid: &signatureValid,
+			StatusCode:     http.StatusAccepted,
+		}
+
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: testAppID,
+			},
+			env: &env.AppEnv{
+				WebhookDeliveryService: &testWebhookDeliveryService{
+					findAllFn: func(app *models.App) ([]models.WebhookDelivery, error) {
+						require.Equal(t, testAppID, app.ID)
+						return []models.WebhookDelivery{testWebhookDelivery}, nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   services.WebhookDeliveriesGetResponse{Data: []models.WebhookDelivery{testWebhookDelivery}},
+		})
+	})
+
+	t.Run("when error happens at getting webhook deliveries", func(t *testing.T) {
+		performControllerTest(t, httpMethod, url, handler, ControllerTestCase{
+			contextElements: map[ctxpkg.RequestContextKey]interface{}{
+				services.ContextKeyAuthorizedAppID: uuid.NewV4(),
+			},
+			env: &env.AppEnv{
+				WebhookDeliveryService: &testWebhookDeliveryService{
+					findAllFn: func(*models.App) ([]models.WebhookDelivery, error) {
+						return nil, errors.New("SOME-SQL-ERROR")
+					},
+				},
+			},
+			expectedInternalErr: "SQL Error: SOME-SQL-ERROR",
+		})
+	})
+}
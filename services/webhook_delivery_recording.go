@@ -0,0 +1,80 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/bitrise-io/addons-ship-backend/env"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/httpresponse"
+	"go.uber.org/zap"
+)
+
+// webhookDeliveryMaxRequestBodySize is the size above which the requests on the webhook endpoints are rejected
+const webhookDeliveryMaxRequestBodySize = 1024 * 1024
+
+// webhookDeliveryResponseRecorder keeps the status code and the body of the response, to
+// record the outcome of the delivery
+type webhookDeliveryResponseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *webhookDeliveryResponseRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *webhookDeliveryResponseRecorder) Write(data []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// RecordWebhookDeliveryHandlerFunc records the request with the outcome of its handling. The handlers
+// down the chain fill in the app, the signature validity and the errors of the delivery they get
+// from the context.
+func RecordWebhookDeliveryHandlerFunc(env *env.AppEnv, endpoint string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if env.WebhookDeliveryService == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		payloadBytes, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, webhookDeliveryMaxRequestBodySize))
+		if err != nil {
+			httpresponse.RespondWithBadRequestErrorNoErr(w, "Failed to read request body")
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(payloadBytes))
+
+		webhookDelivery, err := models.NewWebhookDelivery(endpoint, r.Header, payloadBytes)
+		if err != nil {
+			env.Logger.Error("Failed to record webhook delivery", zap.Error(err))
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &webhookDeliveryResponseRecorder{ResponseWriter: w}
+		h.ServeHTTP(recorder, r.WithContext(ContextWithWebhookDelivery(r.Context(), webhookDelivery)))
+
+		webhookDelivery.StatusCode = recorder.statusCode
+		if webhookDelivery.Error == "" && recorder.statusCode >= http.StatusBadRequest {
+			var errorResponse struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(recorder.body.Bytes(), &errorResponse); err == nil {
+				webhookDelivery.Error = errorResponse.Message
+			}
+		}
+
+		if _, err := env.WebhookDeliveryService.Create(webhookDelivery); err != nil {
+			env.Logger.Error("Failed to record webhook delivery", zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package services_test
+
+import (
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+)
+
+type testWebhookDeliveryService struct {
+	createFn          func(*models.WebhookDelivery) (*models.WebhookDelivery, error)
+	findAllFn         func(app *models.App) ([]models.WebhookDelivery, error)
+	deleteOlderThanFn func(before time.Time) (int64, error)
+}
+
+func (s *testWebhookDeliveryService) Create(webhookDelivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	if s.createFn != nil {
+		return s.createFn(webhookDelivery)
+	}
+	panic("You have to override WebhookDeliveryService.Create function in tests")
+}
+
+func (s *testWebhookDeliveryService) FindAll(app *models.App) ([]models.WebhookDelivery, error) {
+	if s.findAllFn != nil {
+		return s.findAllFn(app)
+	}
+	panic("You have to override WebhookDeliveryService.FindAll function in tests")
+}
+
+func (s *testWebhookDeliveryService) DeleteOlderThan(before time.Time) (int64, error) {
+	if s.deleteOlderThanFn != nil {
+		return s.deleteOlderThanFn(before)
+	}
+	panic("You have to override WebhookDeliveryService.DeleteOlderThan function in tests")
+}
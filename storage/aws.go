@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/bitrise-io/api-utils/providers"
+	"github.com/pkg/errors"
+)
+
+// AWS ...
+type AWS struct {
+	Config providers.AWSConfig
+}
+
+func (p *AWS) createS3Client() (*s3.S3, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(
+			p.Config.AccessKeyID,
+			p.Config.SecretAccessKey,
+			""),
+		Region: aws.String(p.Config.Region),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Session creation failed")
+	}
+	return s3.New(sess), nil
+}
+
+// CreateMultipartUpload ...
+func (p *AWS) CreateMultipartUpload(key string) (string, error) {
+	svc, err := p.createS3Client()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	output, err := svc.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(p.Config.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if output.UploadId == nil {
+		return "", errors.New("No upload ID returned")
+	}
+	return *output.UploadId, nil
+}
+
+// GeneratePresignedUploadPartURL ...
+func (p *AWS) GeneratePresignedUploadPartURL(key, uploadID string, partNumber int64, expiresIn time.Duration) (string, error) {
+	svc, err := p.createS3Client()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	req, _ := svc.UploadPartRequest(&s3.UploadPartInput{
+		Bucket:     aws.String(p.Config.Bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int64(partNumber),
+	})
+	presignedURL, err := req.Presign(expiresIn)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return presignedURL, nil
+}
+
+// CompleteMultipartUpload ...
+func (p *AWS) CompleteMultipartUpload(key, uploadID string, parts []CompletedPart) error {
+	svc, err := p.createS3Client()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	completedParts := []*s3.CompletedPart{}
+	for _, part := range parts {
+		completedParts = append(completedParts, &s3.CompletedPart{
+			ETag:       aws.String(part.ETag),
+			PartNumber: aws.Int64(part.PartNumber),
+		})
+	}
+
+	_, err = svc.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(p.Config.Bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completedParts},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload ...
+func (p *AWS) AbortMultipartUpload(key, uploadID string) error {
+	svc, err := p.createS3Client()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = svc.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(p.Config.Bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// GetObject returns the content of the object, ErrObjectNotFound is returned if the key doesn't exist
+func (p *AWS) GetObject(key string) (io.ReadCloser, error) {
+	svc, err := p.createS3Client()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	output, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(p.Config.Bucket),
+		Key:    aws.String(key),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return output.Body, nil
+}
+
+// ListObjects returns every object of the bucket whose key starts with the prefix
+func (p *AWS) ListObjects(prefix string) ([]ObjectInfo, error) {
+	svc, err := p.createS3Client()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	objects := []ObjectInfo{}
+	err = svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(p.Config.Bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          aws.StringValue(object.Key),
+				Size:         aws.Int64Value(object.Size),
+				LastModified: aws.TimeValue(object.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return objects, nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"io"
+	"time"
+
+	"github.com/bitrise-io/api-utils/constants"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MultipartPartByteSize ...
+	MultipartPartByteSize = 10 * constants.MegaByte
+)
+
+// Interface ...
+type Interface interface {
+	CreateMultipartUpload(key string) (string, error)
+	GeneratePresignedUploadPartURL(key, uploadID string, partNumber int64, expiresIn time.Duration) (string, error)
+	CompleteMultipartUpload(key, uploadID string, parts []CompletedPart) error
+	AbortMultipartUpload(key, uploadID string) error
+	GetObject(key string) (io.ReadCloser, error)
+	ListObjects(prefix string) ([]ObjectInfo, error)
+}
+
+// ErrObjectNotFound ...
+var ErrObjectNotFound = errors.New("Object not found in storage")
+
+// ObjectInfo ...
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// CompletedPart ...
+type CompletedPart struct {
+	PartNumber int64  `json:"part_number"`
+	ETag       string `json:"etag"`
+}
+
+// PartCount returns the number of parts a file of the given size has to be split into
+func PartCount(fileSize int64) int64 {
+	if fileSize <= 0 {
+		return 1
+	}
+	return (fileSize + MultipartPartByteSize - 1) / MultipartPartByteSize
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"io"
+	"time"
+)
+
+// Mock ...
+type Mock struct {
+	CreateMultipartUploadFn          func(key string) (string, error)
+	GeneratePresignedUploadPartURLFn func(key, uploadID string, partNumber int64, expiresIn time.Duration) (string, error)
+	CompleteMultipartUploadFn        func(key, uploadID string, parts []CompletedPart) error
+	AbortMultipartUploadFn           func(key, uploadID string) error
+	GetObjectFn                      func(key string) (io.ReadCloser, error)
+	ListObjectsFn                    func(prefix string) ([]ObjectInfo, error)
+}
+
+// CreateMultipartUpload ...
+func (m *Mock) CreateMultipartUpload(key string) (string, error) {
+	if m.CreateMultipartUploadFn == nil {
+		panic("You have to override CreateMultipartUpload function in tests")
+	}
+	return m.CreateMultipartUploadFn(key)
+}
+
+// GeneratePresignedUploadPartURL ...
+func (m *Mock) GeneratePresignedUploadPartURL(key, uploadID string, partNumber int64, expiresIn time.Duration) (string, error) {
+	if m.GeneratePresignedUploadPartURLFn == nil {
+		panic("You have to override GeneratePresignedUploadPartURL function in tests")
+	}
+	return m.GeneratePresignedUploadPartURLFn(key, uploadID, partNumber, expiresIn)
+}
+
+// CompleteMultipartUpload ...
+func (m *Mock) CompleteMultipartUpload(key, uploadID string, parts []CompletedPart) error {
+	if m.CompleteMultipartUploadFn == nil {
+		panic("You have to override CompleteMultipartUpload function in tests")
+	}
+	return m.CompleteMultipartUploadFn(key, uploadID, parts)
+}
+
+// AbortMultipartUpload ...
+func (m *Mock) AbortMultipartUpload(key, uploadID string) error {
+	if m.AbortMultipartUploadFn == nil {
+		panic("You have to override AbortMultipartUpload function in tests")
+	}
+	return m.AbortMultipartUploadFn(key, uploadID)
+}
+
+// GetObject ...
+func (m *Mock) GetObject(key string) (io.ReadCloser, error) {
+	if m.GetObjectFn == nil {
+		panic("You have to override GetObject function in tests")
+	}
+	return m.GetObjectFn(key)
+}
+
+// ListObjects ...
+func (m *Mock) ListObjects(prefix string) ([]ObjectInfo, error) {
+	if m.ListObjectsFn == nil {
+		panic("You have to override ListObjects function in tests")
+	}
+	return m.ListObjectsFn(prefix)
+}
@@ -10,30 +10,42 @@ func init() {
 
 	// define files
 	file3 := &embedded.EmbeddedFile{
+		Filename:    "email/build_failed.html",
+		FileModTime: time.Unix(1792371268, 0),
+
+		Content: string("<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Transitional//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd\">\n<html>\n  <head></head>\n  <body\n    style=\"font-family: -apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,Oxygen-Sans,Ubuntu,Cantarell,'Helvetica Neue',sans-serif; background-color: #0dacc9\"\n  >\n    <table style=\"width: 100%; border-spacing: 0; padding: 40px; padding-bottom: 120px;\">\n      <tr>\n        <td style=\"padding: 0;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"width: 50%; padding: 0;\"></td>\n              <td style=\"padding: 0;\">\n                <table\n                  style=\"width: 500px; border-radius: 8px; border-spacing: 0; padding: 40px 48px; background-color: #fff;\"\n                >\n                  <tr>\n                    <td style=\"padding: 0;\">\n                      <table style=\"border-collapse: collapse;\">\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; text-align: center;\">\n                            <a href=\"https://www.bitrise.io/\" target=\"_blank\"\n                              ><img\n                                alt=\"SHIP\"\n                                height=\"46px\"\n                                src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/ship-email-logo.png\"\n                                width=\"240px\"\n                            /></a>\n                          </td>\n                        </tr>\n                        <tr style=\"height: 31px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr style=\"height: 1px;\">\n                          <td style=\"width: 436px; padding: 0; background-color: #ececec;\"></td>\n                        </tr>\n                        <tr style=\"height: 24px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; line-height: 16px; font-size: 15px; font-weight: 700; color: #683d87\">\n                            Hey {{ Name }},\n                          </td>\n                        </tr>\n                        <tr>\n                          <td\n                            style=\"padding: 0; padding-top: 8px; line-height: 24px; font-size: 19px; font-weight: 500; color: #492f5c;\"\n                          >\n                            A build of your app\n                            <a\n                              href=\"{{ BuildURL }}\"\n                              style=\"\n                                text-decoration: none;\n                                color: #ff2158;\"\n                              >{{ if BuildAborted }}has been aborted{{ else }}has failed{{ end }}</a\n                            >\n                            on Bitrise.\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 24px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td\n                                  style=\"\n                                    border: 1px solid #ff2158;\n                                    border-radius: 8px;\n                                    padding: 10px;\n                                    background-color: #ffe8ee;\"\n                                >\n                                  <table style=\"width: 100%; border-spacing: 0;\">\n                                    <tr>\n                                      <td style=\"border-radius: 4px; padding: 0;\">\n                                        <img\n                                          alt=\"{{ AppTitle }} #{{ BuildNumber }}\"\n                                          height=\"32px\"\n                                          src=\"{{ AppIconURL }}\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"32px\"\n                                        />\n                                      </td>\n                                      <td\n                                        style=\"width: 100%; padding: 0; padding-left: 16px; line-height: 16px; font-size: 16px; font-weight: 700; color: #351d48;\"\n                                      >\n                                        {{ AppTitle }} #{{ BuildNumber }}\n                                      </td>\n                                      <td\n                                        style=\"padding: 0; padding-left: 16px; line-height: 16px; font-size: 13px; font-weight: 500; color: #351d48; text-transform: uppercase;\"\n                                      >\n                                        {{ Workflow }}\n                                      </td>\n                                    </tr>\n                                  </table>\n                                </td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 32px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                                <td style=\"width: 200px; padding: 0;\">\n                                  <a href=\"{{ BuildURL }}\" style=\"text-decoration: none;\"\n                                    ><table style=\"width: 200px; border-spacing: 0;\">\n                                      <tr>\n                                        <td\n                                          style=\"border-radius: 4px; padding: 14px 16px; line-height: 20px; text-align: center; font-size: 16px; font-weight: 700; color: #f8f8f8; background: #760fc3; background-image: linear-gradient(#6c0eb2, #450674 97%);\"\n                                        >\n                                          View on Bitrise\n                                        </td>\n                                      </tr>\n                                    </table></a\n                                  >\n                                </td>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                      </table>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n              <td style=\"width: 50%; padding: 0;\"></td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n      <tr>\n        <td style=\"padding: 0; padding-top: 40px;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"padding: 0; line-height: 16px; text-align: center; font-size: 13px; color: #fff;\">\n                <table style=\"width: 100%; border-spacing: 0;\">\n                  <tr height=\"24px\">\n                    <td>\n                      <img\n                        alt=\"BITRISE\"\n                        height=\"24px\"\n                        src=\"https://s3.amazonaws.com/bitrise-public-content-production/emails/bitrise-icon.png\"\n                        width=\"30px\"\n                      />\n                    </td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>Bitrise Limited</td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>\n                      Need Help? <a href=\"mailto:letsconnect@bitrise.io\" style=\"color: #fff\">letsconnect@bitrise.io</a>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n    </table>\n  </body>\n</html>\n"),
+	}
+	file4 := &embedded.EmbeddedFile{
+		Filename:    "email/code_signing_expiry.html",
+		FileModTime: time.Unix(1792373626, 0),
+
+		Content: string("<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Transitional//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd\">\n<html>\n  <head></head>\n  <body\n    style=\"font-family: -apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,Oxygen-Sans,Ubuntu,Cantarell,'Helvetica Neue',sans-serif; background-color: #0dacc9\"\n  >\n    <table style=\"width: 100%; border-spacing: 0; padding: 40px; padding-bottom: 120px;\">\n      <tr>\n        <td style=\"padding: 0;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"width: 50%; padding: 0;\"></td>\n              <td style=\"padding: 0;\">\n                <table\n                  style=\"width: 500px; border-radius: 8px; border-spacing: 0; padding: 40px 48px; background-color: #fff;\"\n                >\n                  <tr>\n                    <td style=\"padding: 0;\">\n                      <table style=\"border-collapse: collapse;\">\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; text-align: center;\">\n                            <a href=\"https://www.bitrise.io/\" target=\"_blank\"\n                              ><img\n                                alt=\"SHIP\"\n                                height=\"46px\"\n                                src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/ship-email-logo.png\"\n                                width=\"240px\"\n                            /></a>\n                          </td>\n                        </tr>\n                        <tr style=\"height: 31px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr style=\"height: 1px;\">\n                          <td style=\"width: 436px; padding: 0; background-color: #ececec;\"></td>\n                        </tr>\n                        <tr style=\"height: 24px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; line-height: 16px; font-size: 15px; font-weight: 700; color: #683d87\">\n                            Hey {{ Name }},\n                          </td>\n                        </tr>\n                        <tr>\n                          <td\n                            style=\"padding: 0; padding-top: 8px; line-height: 24px; font-size: 19px; font-weight: 500; color: #492f5c;\"\n                          >\n                            Code signing files selected for\n                            <a\n                              href=\"{{ AppURL }}\"\n                              style=\"\n                                text-decoration: none;\n                                color: #760fc3;\"\n                              >{{ AppTitle }}</a\n                            >\n                            {{ if AnyExpired }}expired or{{ end }} are about to expire. Publishing to App Store Connect fails once they expire.\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 24px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td\n                                  style=\"\n                                    border: 1px solid #ffa940;\n                                    border-radius: 8px;\n                                    padding: 10px;\n                                    background-color: #fff5e6;\"\n                                >\n                                  <table style=\"width: 100%; border-spacing: 0;\">\n                                    <tr>\n                                      <td style=\"border-radius: 4px; padding: 0; vertical-align: top;\" rowspan=\"100\">\n                                        <img\n                                          alt=\"{{ AppTitle }}\"\n                                          height=\"32px\"\n                                          src=\"{{ AppIconURL }}\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"32px\"\n                                        />\n                                      </td>\n                                    </tr>\n                                    {{ range ExpiringFiles }}\n                                    <tr>\n                                      <td\n                                        style=\"width: 100%; padding: 4px 0; padding-left: 16px; line-height: 16px; font-size: 16px; font-weight: 700; color: #351d48;\"\n                                      >\n                                        {{ .Filename }}\n                                      </td>\n                                      <td\n                                        style=\"padding: 4px 0; padding-left: 16px; line-height: 16px; font-size: 13px; font-weight: 500; color: #351d48; white-space: nowrap;\"\n                                      >\n                                        {{ if .Expired }}expired on{{ else }}expires on{{ end }} {{ .ExpireDate.Format \"Jan 2, 2006\" }}\n                                      </td>\n                                    </tr>\n                                    {{ end }}\n                                  </table>\n                                </td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 32px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                                <td style=\"width: 200px; padding: 0;\">\n                                  <a href=\"{{ AppURL }}\" style=\"text-decoration: none;\"\n                                    ><table style=\"width: 200px; border-spacing: 0;\">\n                                      <tr>\n                                        <td\n                                          style=\"border-radius: 4px; padding: 14px 16px; line-height: 20px; text-align: center; font-size: 16px; font-weight: 700; color: #f8f8f8; background: #760fc3; background-image: linear-gradient(#6c0eb2, #450674 97%);\"\n                                        >\n                                          Open settings\n                                        </td>\n                                      </tr>\n                                    </table></a\n                                  >\n                                </td>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                      </table>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n              <td style=\"width: 50%; padding: 0;\"></td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n      <tr>\n        <td style=\"padding: 0; padding-top: 40px;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"padding: 0; line-height: 16px; text-align: center; font-size: 13px; color: #fff;\">\n                <table style=\"width: 100%; border-spacing: 0;\">\n                  <tr height=\"24px\">\n                    <td>\n                      <img\n                        alt=\"BITRISE\"\n                        height=\"24px\"\n                        src=\"https://s3.amazonaws.com/bitrise-public-content-production/emails/bitrise-icon.png\"\n                        width=\"30px\"\n                      />\n                    </td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>Bitrise Limited</td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>\n                      Need Help? <a href=\"mailto:letsconnect@bitrise.io\" style=\"color: #fff\">letsconnect@bitrise.io</a>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n    </table>\n  </body>\n</html>\n"),
+	}
+	file5 := &embedded.EmbeddedFile{
 		Filename:    "email/confirmation.html",
 		FileModTime: time.Unix(1571914896, 0),
 
 		Content: string("<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Transitional//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd\">\n<html>\n  <head></head>\n  <body\n    style=\"font-family: -apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,Oxygen-Sans,Ubuntu,Cantarell,'Helvetica Neue',sans-serif; background-color: #0dacc9\"\n  >\n    <table style=\"width: 100%; border-spacing: 0; padding: 40px; padding-bottom: 120px;\">\n      <tr>\n        <td style=\"padding: 0;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"width: 50%; padding: 0;\"></td>\n              <td style=\"padding: 0;\">\n                <table\n                  style=\"width: 500px; border-radius: 8px; border-spacing: 0; padding: 40px 48px; background-color: #fff;\"\n                >\n                  <tr>\n                    <td style=\"padding: 0;\">\n                      <table style=\"border-collapse: collapse;\">\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; text-align: center;\">\n                            <a href=\"https://www.bitrise.io/\" target=\"_blank\"\n                              ><img\n                                alt=\"SHIP\"\n                                height=\"46px\"\n                                src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/ship-email-logo.png\"\n                                width=\"240px\"\n                            /></a>\n                          </td>\n                        </tr>\n                        <tr style=\"height: 31px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr style=\"height: 1px;\">\n                          <td style=\"width: 436px; padding: 0; background-color: #ececec;\"></td>\n                        </tr>\n                        <tr style=\"height: 24px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; line-height: 16px; font-size: 15px; font-weight: 700; color: #683d87\">\n                            Hey {{ Name }},\n                          </td>\n                        </tr>\n                        <tr>\n                          <td\n                            style=\"padding: 0; padding-top: 8px; line-height: 24px; font-size: 19px; font-weight: 500; color: #492f5c;\"\n                          >\n                            Ship wants to send you notifications about the activity of this app:\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 24px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td style=\"border: 1px solid #ececec; border-radius: 8px; padding: 10px;\">\n                                  <table style=\"width: 100%; border-spacing: 0;\">\n                                    <tr>\n                                      <td style=\"border-radius: 4px; padding: 0;\">\n                                        <img\n                                          alt=\"{{ AppTitle }}\"\n                                          height=\"32px\"\n                                          src=\"{{ AppIconURL }}\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"32px\"\n                                        />\n                                      </td>\n                                      <td\n                                        style=\"width: 100%; padding: 0; padding-left: 16px; line-height: 16px; font-size: 16px; font-weight: 700; color: #777;\"\n                                      >\n                                        {{ AppTitle }}\n                                      </td>\n                                    </tr>\n                                  </table>\n                                </td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 16px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr style=\"height: 32px;\">\n                                <td style=\"padding: 0; font-weight: 700; color: #616161;\">You'd get notified about:</td>\n                              </tr>\n                              <tr>\n                                <td style=\"padding: 0;\">\n                                  <table style=\"width: 100%; border-spacing: 0;\">\n                                    <tr style=\"height: 24px;\">\n                                      <td style=\"width: 32px; padding: 0;\">\n                                        <img\n                                          alt=\"enabled\"\n                                          height=\"10px\"\n                                          src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/tick.png\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"14px\"\n                                          margin=\"6px\"\n                                        />\n                                      </td>\n                                      <td style=\"padding: 0; font-weight: 500; color: #616161;\">New app versions</td>\n                                    </tr>\n                                    <tr style=\"height: 24px;\">\n                                      <td style=\"width: 32px; padding: 0;\">\n                                        <img\n                                          alt=\"enabled\"\n                                          height=\"10px\"\n                                          src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/tick.png\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"14px\"\n                                          margin=\"6px\"\n                                        />\n                                      </td>\n                                      <td style=\"padding: 0; font-weight: 500; color: #616161;\">\n                                        Successful publications\n                                      </td>\n                                    </tr>\n                                    <tr style=\"height: 24px;\">\n                                      <td style=\"width: 32px; padding: 0;\">\n                                        <img\n                                          alt=\"enabled\"\n                                          height=\"10px\"\n                                          src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/tick.png\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"14px\"\n                                          margin=\"6px\"\n                                        />\n                                      </td>\n                                      <td style=\"padding: 0; font-weight: 500; color: #616161;\">Failed publications</td>\n                                    </tr>\n                                  </table>\n                                </td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 32px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                                <td style=\"width: 200px; padding: 0;\">\n                                  <a href=\"{{ AppURL }}\" style=\"text-decoration: none;\"\n                                    ><table style=\"width: 200px; border-spacing: 0;\">\n                                      <tr>\n                                        <td\n                                          style=\"border-radius: 4px; padding: 14px 16px; line-height: 20px; text-align: center; font-size: 16px; font-weight: 700; color: #f8f8f8; background: #760fc3; background-image: linear-gradient(#6c0eb2, #450674 97%);\"\n                                        >\n                                          Confirm Notifications\n                                        </td>\n                                      </tr>\n                                    </table></a\n                                  >\n                                </td>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr>\n                          <td\n                            style=\"padding: 0; padding-top: 32px; line-height: 24px; font-size: 16px; font-weight: 400; color: #616161;\"\n                          >\n                            If you don’t want to get notifications from this app, just ignore this email.\n                          </td>\n                        </tr>\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                      </table>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n              <td style=\"width: 50%; padding: 0;\"></td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n      <tr>\n        <td style=\"padding: 0; padding-top: 40px;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"padding: 0; line-height: 16px; text-align: center; font-size: 13px; color: #fff;\">\n                <table style=\"width: 100%; border-spacing: 0;\">\n                  <tr height=\"24px\">\n                    <td>\n                      <img\n                        alt=\"BITRISE\"\n                        height=\"24px\"\n                        src=\"https://s3.amazonaws.com/bitrise-public-content-production/emails/bitrise-icon.png\"\n                        width=\"30px\"\n                      />\n                    </td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>Bitrise Limited</td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>\n                      Need Help? <a href=\"mailto:letsconnect@bitrise.io\" style=\"color: #fff\">letsconnect@bitrise.io</a>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n    </table>\n  </body>\n</html>\n"),
 	}
-	file4 := &embedded.EmbeddedFile{
+	file6 := &embedded.EmbeddedFile{
 		Filename:    "email/new_version.html",
 		FileModTime: time.Unix(1570091869, 0),
 
 		Content: string("<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Transitional//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd\">\n<html>\n  <head></head>\n  <body\n    style=\"font-family: -apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,Oxygen-Sans,Ubuntu,Cantarell,'Helvetica Neue',sans-serif; background-color: #0dacc9\"\n  >\n    <table style=\"width: 100%; border-spacing: 0; padding: 40px; padding-bottom: 120px;\">\n      <tr>\n        <td style=\"padding: 0;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"width: 50%; padding: 0;\"></td>\n              <td style=\"padding: 0;\">\n                <table\n                  style=\"width: 500px; border-radius: 8px; border-spacing: 0; padding: 40px 48px; background-color: #fff;\"\n                >\n                  <tr>\n                    <td style=\"padding: 0;\">\n                      <table style=\"border-collapse: collapse;\">\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; text-align: center;\">\n                            <a href=\"https://www.bitrise.io/\" target=\"_blank\"\n                              ><img\n                                alt=\"SHIP\"\n                                height=\"46px\"\n                                src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/ship-email-logo.png\"\n                                width=\"240px\"\n                            /></a>\n                          </td>\n                        </tr>\n                        <tr style=\"height: 31px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr style=\"height: 1px;\">\n                          <td style=\"width: 436px; padding: 0; background-color: #ececec;\"></td>\n                        </tr>\n                        <tr style=\"height: 24px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; line-height: 16px; font-size: 15px; font-weight: 700; color: #683d87\">\n                            Hey {{ Name }},\n                          </td>\n                        </tr>\n                        <tr>\n                          <td\n                            style=\"padding: 0; padding-top: 8px; line-height: 24px; font-size: 19px; font-weight: 500; color: #492f5c;\"\n                          >\n                            A new App version of this app is available on Ship:\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 24px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td style=\"border: 1px solid #ececec; border-radius: 8px; padding: 10px;\">\n                                  <table style=\"width: 100%; border-spacing: 0;\">\n                                    <tr>\n                                      <td style=\"border-radius: 4px; padding: 0;\">\n                                        <img\n                                          alt=\"{{ AppTitle }} v{{ NewVersion }} ({{ BuildNumber }})\"\n                                          height=\"32px\"\n                                          src=\"{{ AppIconURL }}\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"32px\"\n                                        />\n                                      </td>\n                                      <td\n                                        style=\"width: 100%; padding: 0; padding-left: 16px; line-height: 16px; font-size: 16px; font-weight: 700; color: #777;\"\n                                      >\n                                        {{ AppTitle }} v{{ NewVersion }} ({{ BuildNumber }})\n                                      </td>\n                                      <td\n                                        style=\"padding: 0; padding-left: 16px; line-height: 16px; font-size: 13px; font-weight: 500; color: #616161; text-transform: uppercase;\"\n                                      >\n                                        {{ AppPlatform }}\n                                      </td>\n                                    </tr>\n                                  </table>\n                                </td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 32px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                                <td style=\"width: 200px; padding: 0;\">\n                                  <a href=\"{{ AppURL }}\" style=\"text-decoration: none;\"\n                                    ><table style=\"width: 200px; border-spacing: 0;\">\n                                      <tr>\n                                        <td\n                                          style=\"border-radius: 4px; padding: 14px 16px; line-height: 20px; text-align: center; font-size: 16px; font-weight: 700; color: #f8f8f8; background: #760fc3; background-image: linear-gradient(#6c0eb2, #450674 97%);\"\n                                        >\n                                          View on Ship\n                                        </td>\n                                      </tr>\n                                    </table></a\n                                  >\n                                </td>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                      </table>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n              <td style=\"width: 50%; padding: 0;\"></td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n      <tr>\n        <td style=\"padding: 0; padding-top: 40px;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"padding: 0; line-height: 16px; text-align: center; font-size: 13px; color: #fff;\">\n                <table style=\"width: 100%; border-spacing: 0;\">\n                  <tr height=\"24px\">\n                    <td>\n                      <img\n                        alt=\"BITRISE\"\n                        height=\"24px\"\n                        src=\"https://s3.amazonaws.com/bitrise-public-content-production/emails/bitrise-icon.png\"\n                        width=\"30px\"\n                      />\n                    </td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>Bitrise Limited</td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>\n                      Need Help? <a href=\"mailto:letsconnect@bitrise.io\" style=\"color: #fff\">letsconnect@bitrise.io</a>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n    </table>\n  </body>\n</html>\n"),
 	}
-	file5 := &embedded.EmbeddedFile{
+	file7 := &embedded.EmbeddedFile{
 		Filename:    "email/publish.html",
 		FileModTime: time.Unix(1570091869, 0),
 
 		Content: string("<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Transitional//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd\">\n<html>\n  <head></head>\n  <body\n    style=\"font-family: -apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,Oxygen-Sans,Ubuntu,Cantarell,'Helvetica Neue',sans-serif; background-color: #0dacc9\"\n  >\n    <table style=\"width: 100%; border-spacing: 0; padding: 40px; padding-bottom: 120px;\">\n      <tr>\n        <td style=\"padding: 0;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"width: 50%; padding: 0;\"></td>\n              <td style=\"padding: 0;\">\n                <table\n                  style=\"width: 500px; border-radius: 8px; border-spacing: 0; padding: 40px 48px; background-color: #fff;\"\n                >\n                  <tr>\n                    <td style=\"padding: 0;\">\n                      <table style=\"border-collapse: collapse;\">\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; text-align: center;\">\n                            <a href=\"https://www.bitrise.io/\" target=\"_blank\"\n                              ><img\n                                alt=\"SHIP\"\n                                height=\"46px\"\n                                src=\"https://bitrise-public-content-production.s3.amazonaws.com/addons-ship/ship-email-logo.png\"\n                                width=\"240px\"\n                            /></a>\n                          </td>\n                        </tr>\n                        <tr style=\"height: 31px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr style=\"height: 1px;\">\n                          <td style=\"width: 436px; padding: 0; background-color: #ececec;\"></td>\n                        </tr>\n                        <tr style=\"height: 24px;\">\n                          <td style=\"padding: 0;\"></td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; line-height: 16px; font-size: 15px; font-weight: 700; color: #683d87\">\n                            Hey {{ Name }},\n                          </td>\n                        </tr>\n                        <tr>\n                          <td\n                            style=\"padding: 0; padding-top: 8px; line-height: 24px; font-size: 19px; font-weight: 500; color: #492f5c;\"\n                          >\n                            {{ if PublishSucceeded }}\n                            Your app has been\n                            <a\n                              href=\"{{ PublishURL }}\"\n                              style=\"\n                                text-decoration: none;\n                                color: #35c894;\"\n                              >successfully published</a\n                            >\n                            to {{ PublishTarget }}.\n                            {{ else }}\n                            Your app has\n                            <a\n                              href=\"{{ AppURL }}\"\n                              style=\"\n                                text-decoration: none;\n                                color: #ff2158;\"\n                              >failed to publish</a\n                            >\n                            to {{ PublishTarget }}.\n                            {{ end }}\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 24px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td\n                                  style=\"\n                                    border: 1px solid {{ if PublishSucceeded }}#0fc389{{ else }}#ff2158{{ end }};\n                                    border-radius: 8px;\n                                    padding: 10px;\n                                    background-color: {{ if PublishSucceeded }}#e7f9f3{{ else }}#ffe8ee{{ end }};\"\n                                >\n                                  <table style=\"width: 100%; border-spacing: 0;\">\n                                    <tr>\n                                      <td style=\"border-radius: 4px; padding: 0;\">\n                                        <img\n                                          alt=\"{{ AppTitle }} v{{ Version }} ({{ BuildNumber }})\"\n                                          height=\"32px\"\n                                          src=\"{{ AppIconURL }}\"\n                                          style=\"display: block; border-radius: 3px;\"\n                                          width=\"32px\"\n                                        />\n                                      </td>\n                                      <td\n                                        style=\"width: 100%; padding: 0; padding-left: 16px; line-height: 16px; font-size: 16px; font-weight: 700; color: #351d48;\"\n                                      >\n                                        {{ AppTitle }} v{{ Version }} ({{ BuildNumber }})\n                                      </td>\n                                      <td\n                                        style=\"padding: 0; padding-left: 16px; line-height: 16px; font-size: 13px; font-weight: 500; color: #351d48; text-transform: uppercase;\"\n                                      >\n                                        {{ AppPlatform }}\n                                      </td>\n                                    </tr>\n                                  </table>\n                                </td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr>\n                          <td style=\"padding: 0; padding-top: 32px;\">\n                            <table style=\"width: 100%; border-spacing: 0;\">\n                              <tr>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                                <td style=\"width: 200px; padding: 0;\">\n                                  <a href=\"{{ AppURL }}\" style=\"text-decoration: none;\"\n                                    ><table style=\"width: 200px; border-spacing: 0;\">\n                                      <tr>\n                                        <td\n                                          style=\"border-radius: 4px; padding: 14px 16px; line-height: 20px; text-align: center; font-size: 16px; font-weight: 700; color: #f8f8f8; background: #760fc3; background-image: linear-gradient(#6c0eb2, #450674 97%);\"\n                                        >\n                                          View on Ship\n                                        </td>\n                                      </tr>\n                                    </table></a\n                                  >\n                                </td>\n                                <td style=\"width: 50%; padding: 0;\"></td>\n                              </tr>\n                            </table>\n                          </td>\n                        </tr>\n                        <tr style=\"display: none;\">\n                          <td>{{ CurrentTime }}</td>\n                        </tr>\n                      </table>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n              <td style=\"width: 50%; padding: 0;\"></td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n      <tr>\n        <td style=\"padding: 0; padding-top: 40px;\">\n          <table style=\"width: 100%; border-spacing: 0;\">\n            <tr>\n              <td style=\"padding: 0; line-height: 16px; text-align: center; font-size: 13px; color: #fff;\">\n                <table style=\"width: 100%; border-spacing: 0;\">\n                  <tr height=\"24px\">\n                    <td>\n                      <img\n                        alt=\"BITRISE\"\n                        height=\"24px\"\n                        src=\"https://s3.amazonaws.com/bitrise-public-content-production/emails/bitrise-icon.png\"\n                        width=\"30px\"\n                      />\n                    </td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>Bitrise Limited</td>\n                  </tr>\n                  <tr height=\"12px\">\n                    <td></td>\n                  </tr>\n                  <tr>\n                    <td>\n                      Need Help? <a href=\"mailto:letsconnect@bitrise.io\" style=\"color: #fff\">letsconnect@bitrise.io</a>\n                    </td>\n                  </tr>\n                </table>\n              </td>\n            </tr>\n          </table>\n        </td>\n      </tr>\n    </table>\n  </body>\n</html>\n"),
 	}
-	file6 := &embedded.EmbeddedFile{
+	file8 := &embedded.EmbeddedFile{
 		Filename:    "rice-box.go",
-		FileModTime: time.Unix(1573574063, 0),
+		FileModTime: time.Unix(1792373626, 0),
 
 		Content: string(""),
 	}
-	file7 := &embedded.EmbeddedFile{
+	file9 := &embedded.EmbeddedFile{
 		Filename:    "templates.go",
 		FileModTime: time.Unix(1562156948, 0),
 
@@ -45,18 +57,20 @@ func init() {
 		Filename:   "",
 		DirModTime: time.Unix(1571914896, 0),
 		ChildFiles: []*embedded.EmbeddedFile{
-			file6, // "rice-box.go"
-			file7, // "templates.go"
+			file8, // "rice-box.go"
+			file9, // "templates.go"
 
 		},
 	}
 	dir2 := &embedded.EmbeddedDir{
 		Filename:   "email",
-		DirModTime: time.Unix(1571914896, 0),
+		DirModTime: time.Unix(1792373626, 0),
 		ChildFiles: []*embedded.EmbeddedFile{
-			file3, // "email/confirmation.html"
-			file4, // "email/new_version.html"
-			file5, // "email/publish.html"
+			file3, // "email/build_failed.html"
+			file4, // "email/code_signing_expiry.html"
+			file5, // "email/confirmation.html"
+			file6, // "email/new_version.html"
+			file7, // "email/publish.html"
 
 		},
 	}
@@ -71,17 +85,19 @@ func init() {
 	// register embeddedBox
 	embedded.RegisterEmbeddedBox(``, &embedded.EmbeddedBox{
 		Name: ``,
-		Time: time.Unix(1571914896, 0),
+		Time: time.Unix(1792373626, 0),
 		Dirs: map[string]*embedded.EmbeddedDir{
 			"":      dir1,
 			"email": dir2,
 		},
 		Files: map[string]*embedded.EmbeddedFile{
-			"email/confirmation.html": file3,
-			"email/new_version.html":  file4,
-			"email/publish.html":      file5,
-			"rice-box.go":             file6,
-			"templates.go":            file7,
+			"email/build_failed.html":        file3,
+			"email/code_signing_expiry.html": file4,
+			"email/confirmation.html":        file5,
+			"email/new_version.html":         file6,
+			"email/publish.html":             file7,
+			"rice-box.go":                    file8,
+			"templates.go":                   file9,
 		},
 	})
 }
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"net/url"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+// storeAsAsset copies the verified upload to the asset of its content and takes a reference on it.
+// The object is copied even if the asset exists already, so it never depends on an upload which may
+// still be in progress.
+func (c *Context) storeAsAsset(uploadPath string, uploadable models.UploadableObject) (uuid.UUID, error) {
+	asset, err := c.env.AssetService.Acquire(models.NewAssetFor(uploadable))
+	if err != nil {
+		return uuid.UUID{}, errors.Wrap(err, "SQL Error")
+	}
+	if err := c.env.AWS.CopyObject(url.QueryEscape(uploadPath), asset.AWSPath()); err != nil {
+		c.discardAsset(asset.ID)
+		return uuid.UUID{}, errors.WithStack(err)
+	}
+	return asset.ID, nil
+}
+
+// releaseAsset drops a reference of the asset, its object is deleted with the last reference
+func (c *Context) releaseAsset(assetID uuid.UUID) error {
+	return c.env.AssetService.Release(assetID, func(asset *models.Asset) error {
+		return c.env.AWS.DeleteObject(asset.AWSPath())
+	})
+}
+
+// discardAsset releases the reference taken by a failed attempt, the original error is returned by
+// the caller, so a failure here is only logged
+func (c *Context) discardAsset(assetID uuid.UUID) {
+	if err := c.releaseAsset(assetID); err != nil {
+		c.env.Logger.Error("Failed to release asset", zap.String("asset_id", assetID.String()), zap.Error(err))
+	}
+}
+
+// deleteUpload removes the uploaded file once its content is stored as an asset
+func (c *Context) deleteUpload(uploadPath string) {
+	if err := c.env.AWS.DeleteObject(uploadPath); err != nil {
+		c.env.Logger.Error("Failed to delete uploaded file", zap.String("path", uploadPath), zap.Error(err))
+	}
+}
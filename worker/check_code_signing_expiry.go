@@ -0,0 +1,180 @@
+package worker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/gocraft/work"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var checkCodeSigningExpiry = "check_code_signing_expiry"
+
+const defaultCodeSigningExpirySchedule = "0 0 4 * * *"
+
+var codeSigningFileHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// codeSigningExpirySchedule is the cron spec (with seconds) of the nightly check of code signing files
+func codeSigningExpirySchedule() string {
+	if schedule := os.Getenv("CODE_SIGNING_EXPIRY_SCHEDULE"); schedule != "" {
+		return schedule
+	}
+	return defaultCodeSigningExpirySchedule
+}
+
+// CheckCodeSigningExpiry stores when the provisioning profiles and the code signing identity selected in the
+// iOS settings of the apps expire, and warns the contacts who opted in 30, 7 and 1 days ahead. Apps whose files
+// can't be checked are skipped until the next run.
+func (c *Context) CheckCodeSigningExpiry(job *work.Job) error {
+	c.env.Logger.Info("[i] Job CheckCodeSigningExpiry started")
+	now := c.env.TimeService.Now()
+
+	apps, err := c.env.AppService.FindAll()
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	warnedCount := 0
+	for _, app := range apps {
+		warned, err := c.checkCodeSigningExpiryOfApp(app, now)
+		if err != nil {
+			c.env.Logger.Warn("Failed to check code signing expiry", zap.String("app_slug", app.AppSlug), zap.Error(err))
+			continue
+		}
+		if warned {
+			warnedCount++
+		}
+	}
+
+	c.env.Logger.Info("[i] Job CheckCodeSigningExpiry finished", zap.Int("warned_app_count", warnedCount))
+	return nil
+}
+
+func (c *Context) checkCodeSigningExpiryOfApp(app models.App, now time.Time) (bool, error) {
+	appSettings, err := c.env.AppSettingsService.Find(&models.AppSettings{AppID: app.ID})
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return false, nil
+	case err != nil:
+		return false, errors.Wrap(err, "SQL Error")
+	}
+	iosSettings, err := appSettings.IosSettings()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	storedExpiry, err := appSettings.CodeSigningExpiry()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if len(iosSettings.SelectedAppStoreProvisioningProfiles) == 0 && iosSettings.SelectedCodeSigningIdentity == "" &&
+		reflect.DeepEqual(storedExpiry, models.CodeSigningExpiry{}) {
+		return false, nil
+	}
+
+	expiry := models.CodeSigningExpiry{ProvisioningProfiles: []models.CodeSigningFileExpiry{}, CheckedAt: now}
+	for _, slug := range iosSettings.SelectedAppStoreProvisioningProfiles {
+		profile, err := c.env.BitriseAPI.GetProvisioningProfile(app.BitriseAPIToken, app.AppSlug, slug)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		content, err := c.downloadCodeSigningFile(profile.DownloadURL)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		expireDate, err := models.ProvisioningProfileExpireDate(content)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		expiry.ProvisioningProfiles = append(expiry.ProvisioningProfiles,
+			storedExpiry.CarryOver(models.CodeSigningFileExpiry{Slug: slug, Filename: profile.Filename, ExpireDate: expireDate}))
+	}
+	if iosSettings.SelectedCodeSigningIdentity != "" {
+		identity, err := c.env.BitriseAPI.GetCodeSigningIdentity(app.BitriseAPIToken, app.AppSlug, iosSettings.SelectedCodeSigningIdentity)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		content, err := c.downloadCodeSigningFile(identity.DownloadURL)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		expireDate, err := models.CodeSigningIdentityExpireDate(content, identity.CertificatePassword)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		identityExpiry := storedExpiry.CarryOver(models.CodeSigningFileExpiry{Slug: iosSettings.SelectedCodeSigningIdentity, Filename: identity.Filename, ExpireDate: expireDate})
+		expiry.CodeSigningIdentity = &identityExpiry
+	}
+
+	expiringFiles := []models.CodeSigningFileExpiry{}
+	files := []*models.CodeSigningFileExpiry{}
+	for i := range expiry.ProvisioningProfiles {
+		files = append(files, &expiry.ProvisioningProfiles[i])
+	}
+	if expiry.CodeSigningIdentity != nil {
+		files = append(files, expiry.CodeSigningIdentity)
+	}
+	for _, file := range files {
+		if daysAhead, due := file.DueWarning(now); due {
+			file.NotifiedDaysAhead = daysAhead
+			expiringFiles = append(expiringFiles, *file)
+		}
+	}
+	var appDetails *bitrise.AppDetails
+	var appContacts []models.AppContact
+	if len(expiringFiles) > 0 {
+		appDetails, err = c.env.BitriseAPI.GetAppDetails(app.BitriseAPIToken, app.AppSlug)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		appContacts, err = c.env.AppContactService.FindAll(&app)
+		if err != nil {
+			return false, errors.Wrap(err, "SQL Error")
+		}
+	}
+
+	// the warnings are stored as sent before sending them, so a failed save can't send them again the next run
+	expiryData, err := json.Marshal(expiry)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	appSettings.CodeSigningExpiryData = expiryData
+	verrs, err := c.env.AppSettingsService.Update(appSettings, []string{"CodeSigningExpiryData"})
+	if len(verrs) > 0 {
+		return false, errors.Errorf("Validation errors: %#v", verrs)
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "SQL Error")
+	}
+
+	if len(expiringFiles) > 0 {
+		err = c.env.Mailer.SendEmailCodeSigningExpiry(&app, expiringFiles, appContacts, appDetails, c.env.AddonFrontendHostURL)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+	}
+	return len(expiringFiles) > 0, nil
+}
+
+func (c *Context) downloadCodeSigningFile(downloadURL string) ([]byte, error) {
+	resp, err := codeSigningFileHTTPClient.Get(downloadURL)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.env.Logger.Error("Failed to close code signing file response body", zap.Error(err))
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Failed to download code signing file, status: %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
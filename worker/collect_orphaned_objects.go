@@ -0,0 +1,215 @@
+package worker
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var collectOrphanedObjects = "collect_orphaned_objects"
+
+const defaultOrphanedObjectsGCSchedule = "0 0 4 * * *"
+
+// orphanedObjectsGCSchedule is the cron spec (with seconds) of the periodic garbage collection
+func orphanedObjectsGCSchedule() string {
+	if schedule := os.Getenv("ORPHANED_OBJECTS_GC_SCHEDULE"); schedule != "" {
+		return schedule
+	}
+	return defaultOrphanedObjectsGCSchedule
+}
+
+type orphanedObjectsReport struct {
+	objectCount  int
+	byteSize     int64
+	deletedCount int
+}
+
+// CollectOrphanedObjects deletes the objects under the prefixes of the apps which are not referenced by
+// any screenshot, app preview, store graphic or app version event log, and which are
+// older than the grace period. Objects of app versions removed from the database are orphans as well.
+// Asset objects are orphans when no screenshot or store graphic references their asset.
+// Unless ORPHANED_OBJECTS_GC_DRY_RUN is set to false, the orphans are only reported.
+func (c *Context) CollectOrphanedObjects(job *work.Job) error {
+	c.env.Logger.Info("[i] Job CollectOrphanedObjects started")
+	dryRun := os.Getenv("ORPHANED_OBJECTS_GC_DRY_RUN") != "false"
+	gracePeriod := time.Duration(utils.GetInt64EnvWithDefault("ORPHANED_OBJECTS_GC_GRACE_PERIOD_HOURS", 72)) * time.Hour
+	modifiedBefore := c.env.TimeService.Now().Add(-gracePeriod)
+
+	apps, err := c.env.AppService.FindAll()
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	report := orphanedObjectsReport{}
+	for _, app := range apps {
+		if app.AppSlug == "" {
+			continue
+		}
+		if err := c.collectOrphanedObjectsOfApp(app, modifiedBefore, dryRun, &report); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := c.collectOrphanedAssetObjects(modifiedBefore, dryRun, &report); err != nil {
+		return errors.WithStack(err)
+	}
+
+	c.env.Logger.Info("[i] Job CollectOrphanedObjects finished",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("orphaned_object_count", report.objectCount),
+		zap.Int64("orphaned_byte_size", report.byteSize),
+		zap.Int("deleted_object_count", report.deletedCount))
+	return nil
+}
+
+func (c *Context) collectOrphanedObjectsOfApp(app models.App, modifiedBefore time.Time, dryRun bool, report *orphanedObjectsReport) error {
+	appVersions, err := c.env.AppVersionService.FindAll(&app, map[string]interface{}{})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	appVersionIDs := map[uuid.UUID]bool{}
+	referencedKeys := map[string]bool{}
+	for _, appVersion := range appVersions {
+		appVersion.App = app
+		appVersionIDs[appVersion.ID] = true
+		if err := c.addReferencedObjectKeys(appVersion, referencedKeys); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	for _, prefix := range []string{app.AppSlug + "/", "logs/" + app.AppSlug + "/"} {
+		objects, err := c.env.Storage.ListObjects(prefix)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, object := range objects {
+			if referencedKeys[object.Key] || !object.LastModified.Before(modifiedBefore) {
+				continue
+			}
+			// every object of an app is stored under the prefix of one of its versions
+			appVersionID := uuid.FromStringOrNil(strings.SplitN(strings.TrimPrefix(object.Key, prefix), "/", 2)[0])
+			if uuid.Equal(appVersionID, uuid.UUID{}) {
+				c.env.Logger.Warn("[!] CollectOrphanedObjects: Skipping object outside of app version prefixes", zap.String("key", object.Key))
+				continue
+			}
+			reason := "not referenced"
+			if !appVersionIDs[appVersionID] {
+				reason = "app version deleted"
+			}
+
+			report.objectCount++
+			report.byteSize += object.Size
+			c.env.Logger.Info("[i] CollectOrphanedObjects: Orphaned object",
+				zap.String("key", object.Key),
+				zap.String("reason", reason),
+				zap.Int64("size", object.Size),
+				zap.Time("last_modified", object.LastModified),
+				zap.Bool("dry_run", dryRun))
+			if dryRun {
+				continue
+			}
+			if err := c.env.AWS.DeleteObject(object.Key); err != nil {
+				return errors.WithStack(err)
+			}
+			report.deletedCount++
+		}
+	}
+	return nil
+}
+
+// collectOrphanedAssetObjects deletes the objects of the assets which are removed from the database or
+// which are not referenced by any screenshot or store graphic anymore
+func (c *Context) collectOrphanedAssetObjects(modifiedBefore time.Time, dryRun bool, report *orphanedObjectsReport) error {
+	assets, err := c.env.AssetService.FindAllReferenced()
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	referencedKeys := map[string]bool{}
+	for _, asset := range assets {
+		referencedKeys[asset.AWSPath()] = true
+	}
+
+	objects, err := c.env.Storage.ListObjects("assets/")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, object := range objects {
+		if referencedKeys[object.Key] || !object.LastModified.Before(modifiedBefore) {
+			continue
+		}
+
+		report.objectCount++
+		report.byteSize += object.Size
+		c.env.Logger.Info("[i] CollectOrphanedObjects: Orphaned object",
+			zap.String("key", object.Key),
+			zap.String("reason", "asset not referenced"),
+			zap.Int64("size", object.Size),
+			zap.Time("last_modified", object.LastModified),
+			zap.Bool("dry_run", dryRun))
+		if dryRun {
+			continue
+		}
+		if err := c.env.AWS.DeleteObject(object.Key); err != nil {
+			return errors.WithStack(err)
+		}
+		report.deletedCount++
+	}
+	return nil
+}
+
+// addReferencedObjectKeys collects the keys of the objects the records of the app version point to.
+// Uploads which are not marked as uploaded are protected only by the grace period.
+func (c *Context) addReferencedObjectKeys(appVersion models.AppVersion, keys map[string]bool) error {
+	screenshots, err := c.env.ScreenshotService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, screenshot := range screenshots {
+		screenshot.AppVersion = appVersion
+		if screenshot.Uploaded {
+			keys[screenshot.AWSPath()] = true
+		}
+	}
+
+	appPreviews, err := c.env.AppPreviewService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, appPreview := range appPreviews {
+		appPreview.AppVersion = appVersion
+		if appPreview.Uploaded {
+			keys[appPreview.AWSPath()] = true
+		}
+	}
+
+	storeGraphics, err := c.env.StoreGraphicService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, storeGraphic := range storeGraphics {
+		storeGraphic.AppVersion = appVersion
+		if storeGraphic.Uploaded {
+			keys[storeGraphic.AWSPath()] = true
+		}
+	}
+
+	appVersionEvents, err := c.env.AppVersionEventService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, appVersionEvent := range appVersionEvents {
+		appVersionEvent.AppVersion = appVersion
+		logPath, err := appVersionEvent.LogAWSPath()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		keys[logPath] = true
+	}
+	return nil
+}
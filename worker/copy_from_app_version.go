@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var copyFromAppVersion = "copy_from_app_version"
+
+// copyFromAppVersionMaxFails is the number of attempts after which copying from an app version is
+// given up
+func copyFromAppVersionMaxFails() uint {
+	return uint(utils.GetInt64EnvWithDefault("COPY_FROM_APP_VERSION_MAX_FAILS", 4))
+}
+
+// CopyFromAppVersion replaces the selected parts of the listing of an app version with the ones of
+// another version of the app. The progress is reported on the job status, the copied parts are listed
+// in its result. A failed attempt is retried until the max fails is reached.
+func (c *Context) CopyFromAppVersion(job *work.Job) error {
+	c.env.Logger.Info("[i] Job CopyFromAppVersion started")
+	jobStatusID := uuid.FromStringOrNil(job.ArgString("job_status_id"))
+	if uuid.Equal(jobStatusID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of job status")
+		return errors.New("Failed to get job_status_id")
+	}
+	sourceAppVersionID := uuid.FromStringOrNil(job.ArgString("source_app_version_id"))
+	if uuid.Equal(sourceAppVersionID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of app version to copy from")
+		return errors.New("Failed to get source_app_version_id")
+	}
+	parts := models.AppVersionCopyParts{
+		StoreInfo:      job.ArgBool("store_info"),
+		Screenshots:    job.ArgBool("screenshots"),
+		FeatureGraphic: job.ArgBool("feature_graphic"),
+	}
+	if err := job.ArgError(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	jobStatus, err := c.env.JobStatusService.Find(&models.JobStatus{Record: models.Record{ID: jobStatusID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if jobStatus.IsDone() || jobStatus.AppVersionID == nil {
+		return nil
+	}
+	if err := c.updateJobStatus(jobStatus, models.JobStatusRunning, "", nil); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := c.copyFromAppVersion(sourceAppVersionID, *jobStatus.AppVersionID, parts); err != nil {
+		c.env.Logger.Error("[!] CopyFromAppVersion: Failed to copy", zap.String("job_status_id", jobStatusID.String()), zap.Error(err))
+		// the job status stays pending while the job is retried
+		status := models.JobStatusPending
+		if job.Fails+1 >= int64(copyFromAppVersionMaxFails()) {
+			status = models.JobStatusFailed
+		}
+		if updateErr := c.updateJobStatus(jobStatus, status, "Failed to copy from app version", nil); updateErr != nil {
+			c.env.Logger.Error("Failed to update job status", zap.Error(updateErr))
+		}
+		return errors.WithStack(err)
+	}
+
+	if err := c.updateJobStatus(jobStatus, models.JobStatusFinished, "", parts); err != nil {
+		return errors.WithStack(err)
+	}
+	c.env.Logger.Info("[i] Job CopyFromAppVersion finished")
+	return nil
+}
+
+func (c *Context) copyFromAppVersion(sourceID, targetID uuid.UUID, parts models.AppVersionCopyParts) error {
+	if parts.StoreInfo {
+		source, err := c.env.AppVersionService.Find(&models.AppVersion{Record: models.Record{ID: sourceID}})
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		target, err := c.env.AppVersionService.Find(&models.AppVersion{Record: models.Record{ID: targetID}})
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		target.AppStoreInfoData = source.AppStoreInfoData
+		verrs, err := c.env.AppVersionService.Update(target, []string{"AppStoreInfoData"})
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation errors: %#v", verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "Failed to update store info")
+		}
+	}
+	// the items of the target are deleted only after the copy succeeded, the ones copied by a failed
+	// attempt are deleted together with them by the retry
+	if parts.Screenshots {
+		previousScreenshots, err := c.env.ScreenshotService.FindAll(&models.AppVersion{Record: models.Record{ID: targetID}})
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		if err := c.copyScreenshots(sourceID, targetID); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := c.deleteScreenshots(previousScreenshots); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if parts.FeatureGraphic {
+		previousStoreGraphics, err := c.findStoreGraphics(targetID, models.StoreGraphicTypeFeatureGraphic)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := c.copyStoreGraphics(sourceID, targetID, models.StoreGraphicTypeFeatureGraphic); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := c.deleteStoreGraphics(previousStoreGraphics); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -1,14 +1,10 @@
 package worker
 
 import (
-	"net/url"
-
 	"github.com/bitrise-io/addons-ship-backend/models"
 	"github.com/gocraft/work"
-	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
-	"go.uber.org/zap"
 )
 
 var copyUploadablesToNewAppVersion = "copy_uploadables_to_new_app_version"
@@ -27,66 +23,41 @@ func (c *Context) CopyUploadablesToNewAppVersion(job *work.Job) error {
 		return errors.New("Failed to get to_id")
 	}
 
-	c.env.Logger.Info("[i] CopyUploadablesToNewAppVersion: Copying screenshots...")
-	originalScreenshots, err := c.env.ScreenshotService.FindAll(&models.AppVersion{Record: models.Record{ID: uuid.FromStringOrNil(appVersionFromID)}})
+	fromID := uuid.FromStringOrNil(appVersionFromID)
+	newAppVersionID := uuid.FromStringOrNil(appVersionToID)
+
+	// a retry skips the uploadables copied by a previous attempt, the screenshots and the app previews
+	// are copied all or nothing, the store graphics one by one
+	newAppVersion := &models.AppVersion{Record: models.Record{ID: newAppVersionID}}
+	screenshots, err := c.env.ScreenshotService.FindAll(newAppVersion)
 	if err != nil {
 		return errors.Wrap(err, "SQL Error")
 	}
-	newAppVersionID := uuid.FromStringOrNil(appVersionToID)
-	if len(originalScreenshots) > 0 {
-		screenShotsToCreate := []*models.Screenshot{}
-		for _, sc := range originalScreenshots {
-			screenShotsToCreate = append(screenShotsToCreate, &models.Screenshot{
-				UploadableObject: sc.UploadableObject,
-				DeviceType:       sc.DeviceType,
-				ScreenSize:       sc.ScreenSize,
-				AppVersionID:     newAppVersionID,
-			})
-		}
-		createsScreenshots, verrs, err := c.env.ScreenshotService.BatchCreate(screenShotsToCreate)
-		if err != nil {
-			return errors.Wrap(err, "SQL Error")
-		}
-		if len(verrs) > 0 {
-			return errors.Errorf("Validation errors: %#v", verrs)
-		}
-
-		c.env.Logger.Info("[i] CopyUploadablesToNewAppVersion: Copying S3 files...")
-
-		for idx, sc := range originalScreenshots {
-			from := url.QueryEscape(sc.AWSPath())
-			to := createsScreenshots[idx].AWSPath()
-
-			err = c.env.AWS.CopyObject(from, to)
-			if err != nil {
-				c.env.Logger.Error("[!] CopyUploadablesToNewAppVersion: Failed to copy AWS file", zap.Any("error", err))
-				return errors.WithStack(err)
-			}
+	if len(screenshots) == 0 {
+		c.env.Logger.Info("[i] CopyUploadablesToNewAppVersion: Copying screenshots...")
+		if err := c.copyScreenshots(fromID, newAppVersionID); err != nil {
+			return errors.WithStack(err)
 		}
 	}
 
-	c.env.Logger.Info("[i] CopyUploadablesToNewAppVersion: Copying feature graphic...")
-	originalFeatureGraphic, err := c.env.FeatureGraphicService.Find(&models.FeatureGraphic{AppVersionID: uuid.FromStringOrNil(appVersionFromID)})
-	if err != nil && errors.Cause(err) != gorm.ErrRecordNotFound {
+	appPreviews, err := c.env.AppPreviewService.FindAll(newAppVersion)
+	if err != nil {
 		return errors.Wrap(err, "SQL Error")
 	}
-
-	if originalFeatureGraphic != nil {
-		createsFeatureGraphic, verrs, err := c.env.FeatureGraphicService.Create(&models.FeatureGraphic{
-			UploadableObject: originalFeatureGraphic.UploadableObject,
-			AppVersionID:     newAppVersionID,
-		})
-		if err != nil {
-			return errors.Wrap(err, "SQL Error")
-		}
-		if len(verrs) > 0 {
-			return errors.Errorf("Validation error: %#v", verrs)
+	if len(appPreviews) == 0 {
+		c.env.Logger.Info("[i] CopyUploadablesToNewAppVersion: Copying app previews...")
+		if err := c.copyAppPreviews(fromID, newAppVersionID); err != nil {
+			return errors.WithStack(err)
 		}
+	}
 
-		from := url.QueryEscape(originalFeatureGraphic.AWSPath())
-		to := createsFeatureGraphic.AWSPath()
-		err = c.env.AWS.CopyObject(from, to)
-		if err != nil {
+	graphicTypes, err := c.missingStoreGraphicTypes(fromID, newAppVersionID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(graphicTypes) > 0 {
+		c.env.Logger.Info("[i] CopyUploadablesToNewAppVersion: Copying store graphics...")
+		if err := c.copyStoreGraphics(fromID, newAppVersionID, graphicTypes...); err != nil {
 			return errors.WithStack(err)
 		}
 	}
@@ -94,3 +65,28 @@ func (c *Context) CopyUploadablesToNewAppVersion(job *work.Job) error {
 	c.env.Logger.Info("[i] Job CopyUploadablesToNewAppVersion finished")
 	return nil
 }
+
+// missingStoreGraphicTypes returns the types of the store graphics of an app version which another one
+// doesn't have yet
+func (c *Context) missingStoreGraphicTypes(fromID, toID uuid.UUID) ([]string, error) {
+	storeGraphics, err := c.findStoreGraphics(fromID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	existingStoreGraphics, err := c.findStoreGraphics(toID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	existingTypes := map[string]bool{}
+	for _, storeGraphic := range existingStoreGraphics {
+		existingTypes[storeGraphic.Type] = true
+	}
+	graphicTypes := []string{}
+	for _, storeGraphic := range storeGraphics {
+		if !existingTypes[storeGraphic.Type] {
+			graphicTypes = append(graphicTypes, storeGraphic.Type)
+			existingTypes[storeGraphic.Type] = true
+		}
+	}
+	return graphicTypes, nil
+}
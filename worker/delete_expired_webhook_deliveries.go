@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"os"
+	"time"
+
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var deleteExpiredWebhookDeliveries = "delete_expired_webhook_deliveries"
+
+const (
+	defaultWebhookDeliveryRetentionSchedule = "0 30 3 * * *"
+	defaultWebhookDeliveryRetentionDays     = 30
+)
+
+// webhookDeliveryRetentionSchedule is the cron spec (with seconds) of the nightly cleanup of the webhook delivery log
+func webhookDeliveryRetentionSchedule() string {
+	if schedule := os.Getenv("WEBHOOK_DELIVERY_RETENTION_SCHEDULE"); schedule != "" {
+		return schedule
+	}
+	return defaultWebhookDeliveryRetentionSchedule
+}
+
+// DeleteExpiredWebhookDeliveries deletes the recorded webhook deliveries older than the retention period
+func (c *Context) DeleteExpiredWebhookDeliveries(job *work.Job) error {
+	c.env.Logger.Info("[i] Job DeleteExpiredWebhookDeliveries started")
+	retentionDays := utils.GetInt64EnvWithDefault("WEBHOOK_DELIVERY_RETENTION_DAYS", defaultWebhookDeliveryRetentionDays)
+	expiry := c.env.TimeService.Now().Add(-time.Duration(retentionDays) * 24 * time.Hour)
+
+	deletedCount, err := c.env.WebhookDeliveryService.DeleteOlderThan(expiry)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	c.env.Logger.Info("[i] Job DeleteExpiredWebhookDeliveries finished", zap.Int64("deleted_webhook_delivery_count", deletedCount))
+	return nil
+}
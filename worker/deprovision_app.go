@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-io/addons-ship-backend/bitrise"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/gocraft/work"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var deprovisionApp = "deprovision_app"
+
+// DeprovisionApp cleans up after a deprovisioned app: it unregisters the build webhook of the app on
+// Bitrise unless the app has been provisioned again, deletes the objects stored under the prefixes of
+// the app, releases the assets its screenshots and store graphics referenced and drops its cached
+// Bitrise API responses. The steps are safe to be retried.
+func (c *Context) DeprovisionApp(job *work.Job) error {
+	c.env.Logger.Info("[i] Job DeprovisionApp started")
+	tombstoneID := uuid.FromStringOrNil(job.ArgString("app_tombstone_id"))
+	if uuid.Equal(tombstoneID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of app tombstone")
+		return errors.New("Failed to get app_tombstone_id")
+	}
+
+	tombstone, err := c.env.AppTombstoneService.Find(&models.AppTombstone{Record: models.Record{ID: tombstoneID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if tombstone.CleanedUpAt != nil {
+		return nil
+	}
+
+	// the app might have been provisioned again in the meantime, its webhook and new versions are kept
+	liveApp, err := c.env.AppService.Find(&models.App{AppSlug: tombstone.AppSlug})
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		liveApp = nil
+	case err != nil:
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	// the API token is cleared right after it's used, so it's not kept if a later step keeps failing
+	if tombstone.BitriseAPIToken != "" {
+		if liveApp == nil {
+			// the API token may already be revoked by Bitrise, which is not worth retrying the job for
+			err = c.env.BitriseAPI.UnregisterWebhook(tombstone.BitriseAPIToken, tombstone.AppSlug, fmt.Sprintf("%s/webhook", c.env.AddonHostURL))
+			if err != nil {
+				c.env.Logger.Error("Failed to unregister webhook", zap.String("app_slug", tombstone.AppSlug), zap.Error(err))
+			}
+		}
+		tombstone.BitriseAPIToken = ""
+		if err := c.env.AppTombstoneService.Update(tombstone, []string{"BitriseAPIToken"}); err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+	}
+
+	if err := c.deleteObjectsOfDeprovisionedApp(tombstone, liveApp); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for len(tombstone.AssetIDs) > 0 {
+		assetID := uuid.FromStringOrNil(tombstone.AssetIDs[0])
+		err := c.releaseAsset(assetID)
+		if err != nil && errors.Cause(err) != gorm.ErrRecordNotFound {
+			return errors.WithStack(err)
+		}
+		tombstone.AssetIDs = tombstone.AssetIDs[1:]
+		if err := c.env.AppTombstoneService.Update(tombstone, []string{"AssetIDs"}); err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+	}
+
+	if cache, ok := c.env.BitriseAPI.(bitrise.CacheInvalidator); ok {
+		if err := cache.InvalidateApp(tombstone.AppSlug); err != nil {
+			c.env.Logger.Error("Failed to invalidate Bitrise API cache", zap.String("app_slug", tombstone.AppSlug), zap.Error(err))
+		}
+	}
+
+	cleanedUpAt := c.env.TimeService.Now()
+	tombstone.CleanedUpAt = &cleanedUpAt
+	if err := c.env.AppTombstoneService.Update(tombstone, []string{"CleanedUpAt"}); err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	c.env.Logger.Info("[i] Job DeprovisionApp finished", zap.String("app_slug", tombstone.AppSlug))
+	return nil
+}
+
+// deleteObjectsOfDeprovisionedApp deletes every object stored under the prefixes of the app. If the app
+// has been provisioned again in the meantime, the objects of its new versions are kept.
+func (c *Context) deleteObjectsOfDeprovisionedApp(tombstone *models.AppTombstone, liveApp *models.App) error {
+	keptAppVersionIDs := map[string]bool{}
+	if liveApp != nil {
+		for _, appVersion := range liveApp.AppVersions {
+			keptAppVersionIDs[appVersion.ID.String()] = true
+		}
+	}
+
+	deletedCount := 0
+	for _, prefix := range []string{tombstone.AppSlug + "/", "logs/" + tombstone.AppSlug + "/"} {
+		objects, err := c.env.Storage.ListObjects(prefix)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, object := range objects {
+			appVersionID := strings.SplitN(strings.TrimPrefix(object.Key, prefix), "/", 2)[0]
+			if keptAppVersionIDs[appVersionID] {
+				continue
+			}
+			if err := c.env.AWS.DeleteObject(object.Key); err != nil {
+				return errors.WithStack(err)
+			}
+			deletedCount++
+		}
+	}
+	c.env.Logger.Info("[i] DeprovisionApp: Deleted objects of app",
+		zap.String("app_slug", tombstone.AppSlug),
+		zap.Int("deleted_object_count", deletedCount))
+	return nil
+}
@@ -0,0 +1,188 @@
+package worker
+
+import (
+	"os"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/gocraft/work"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var enforceRetentionPolicies = "enforce_retention_policies"
+
+const defaultRetentionPolicySchedule = "0 0 3 * * *"
+
+// retentionPolicySchedule is the cron spec (with seconds) of the nightly enforcement of retention settings
+func retentionPolicySchedule() string {
+	if schedule := os.Getenv("RETENTION_POLICY_SCHEDULE"); schedule != "" {
+		return schedule
+	}
+	return defaultRetentionPolicySchedule
+}
+
+// EnforceRetentionPolicies deletes the app versions expired by the retention settings of their app,
+// together with their screenshots, graphics, events and logs
+func (c *Context) EnforceRetentionPolicies(job *work.Job) error {
+	c.env.Logger.Info("[i] Job EnforceRetentionPolicies started")
+	now := c.env.TimeService.Now()
+
+	apps, err := c.env.AppService.FindAll()
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	deletedCount := 0
+	for _, app := range apps {
+		count, err := c.enforceRetentionPolicyOfApp(app, now)
+		deletedCount += count
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	c.env.Logger.Info("[i] Job EnforceRetentionPolicies finished", zap.Int("deleted_app_version_count", deletedCount))
+	return nil
+}
+
+func (c *Context) enforceRetentionPolicyOfApp(app models.App, now time.Time) (int, error) {
+	appSettings, err := c.env.AppSettingsService.Find(&models.AppSettings{AppID: app.ID})
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, errors.Wrap(err, "SQL Error")
+	}
+	retentionSettings, err := appSettings.RetentionSettings()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if !retentionSettings.Enabled() {
+		return 0, nil
+	}
+
+	appVersions, err := c.env.AppVersionService.FindAll(&app, map[string]interface{}{})
+	if err != nil {
+		return 0, errors.Wrap(err, "SQL Error")
+	}
+	publishedAppVersions, err := c.env.AppVersionService.FindAllPublished(&app)
+	if err != nil {
+		return 0, errors.Wrap(err, "SQL Error")
+	}
+	publishedIDs := map[uuid.UUID]bool{}
+	for _, appVersion := range publishedAppVersions {
+		publishedIDs[appVersion.ID] = true
+	}
+
+	deletedCount := 0
+	for _, appVersion := range retentionSettings.ExpiredAppVersions(appVersions, publishedIDs, now) {
+		appVersion.App = app
+		if err := c.deleteAppVersion(appVersion); err != nil {
+			return deletedCount, errors.WithStack(err)
+		}
+		c.env.Logger.Info("[i] EnforceRetentionPolicies: Deleted app version",
+			zap.String("app_slug", app.AppSlug),
+			zap.String("app_version_id", appVersion.ID.String()),
+			zap.Time("created_at", appVersion.CreatedAt))
+		deletedCount++
+	}
+	return deletedCount, nil
+}
+
+// deleteAppVersion releases the assets of the screenshots, app previews and store graphics of the app
+// version, then deletes the app version with all of its records and the rest of its stored files. The
+// reference on an asset is cleared before it's released, so a retry never releases it twice. Objects
+// left behind by a failure, under the app version prefixes or of unreferenced assets, are picked up by
+// the orphaned object collection.
+func (c *Context) deleteAppVersion(appVersion models.AppVersion) error {
+	screenshots, err := c.env.ScreenshotService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, screenshot := range screenshots {
+		if screenshot.AssetID == nil {
+			continue
+		}
+		assetID := *screenshot.AssetID
+		screenshot.AssetID = nil
+		verrs, err := c.env.ScreenshotService.BatchUpdate([]models.Screenshot{screenshot}, []string{"AssetID"})
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation errors: %#v", verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "Failed to update screenshot")
+		}
+		if err := c.releaseAsset(assetID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	appPreviews, err := c.env.AppPreviewService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, appPreview := range appPreviews {
+		if appPreview.AssetID == nil {
+			continue
+		}
+		assetID := *appPreview.AssetID
+		appPreview.AssetID = nil
+		verrs, err := c.env.AppPreviewService.Update(appPreview, []string{"AssetID"})
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation errors: %#v", verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "Failed to update app preview")
+		}
+		if err := c.releaseAsset(assetID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	storeGraphics, err := c.env.StoreGraphicService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, storeGraphic := range storeGraphics {
+		if storeGraphic.AssetID == nil {
+			continue
+		}
+		assetID := *storeGraphic.AssetID
+		storeGraphic.AssetID = nil
+		verrs, err := c.env.StoreGraphicService.Update(storeGraphic, []string{"AssetID"})
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation errors: %#v", verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "Failed to update store graphic")
+		}
+		if err := c.releaseAsset(assetID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	err = c.env.AppVersionService.Delete(&appVersion)
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	for _, prefix := range []string{
+		appVersion.App.AppSlug + "/" + appVersion.ID.String() + "/",
+		"logs/" + appVersion.App.AppSlug + "/" + appVersion.ID.String() + "/",
+	} {
+		objects, err := c.env.Storage.ListObjects(prefix)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, object := range objects {
+			if err := c.env.AWS.DeleteObject(object.Key); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package worker
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var importScreenshotArchive = "import_screenshot_archive"
+
+// importScreenshotArchiveMaxFails is the number of attempts after which importing a screenshot archive
+// is given up
+func importScreenshotArchiveMaxFails() uint {
+	return uint(utils.GetInt64EnvWithDefault("IMPORT_SCREENSHOT_ARCHIVE_MAX_FAILS", 4))
+}
+
+const screenshotArchiveTooLargeMessage = "archive: Must be smaller than 1000 megabytes unpacked"
+
+// ImportScreenshotArchive unpacks the uploaded zip archive of screenshots, creates a screenshot for
+// each file and reports the result of every file on the job status. The files are stored first, then
+// the screenshots of the whole archive are created in one transaction. The screenshots get IDs derived
+// from the job and the file, so a retry doesn't create the ones of a previous attempt again.
+func (c *Context) ImportScreenshotArchive(job *work.Job) error {
+	c.env.Logger.Info("[i] Job ImportScreenshotArchive started")
+	jobStatusID := uuid.FromStringOrNil(job.ArgString("job_status_id"))
+	if uuid.Equal(jobStatusID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of job status")
+		return errors.New("Failed to get job_status_id")
+	}
+
+	jobStatus, err := c.env.JobStatusService.Find(&models.JobStatus{Record: models.Record{ID: jobStatusID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if jobStatus.IsDone() || jobStatus.AppVersionID == nil {
+		return nil
+	}
+	if err := c.updateJobStatus(jobStatus, models.JobStatusRunning, "", nil); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := c.importScreenshotArchive(jobStatus); err != nil {
+		c.env.Logger.Error("[!] ImportScreenshotArchive: Failed to import", zap.String("job_status_id", jobStatusID.String()), zap.Error(err))
+		// the job status stays pending while the job is retried
+		status := models.JobStatusPending
+		if job.Fails+1 >= int64(importScreenshotArchiveMaxFails()) {
+			status = models.JobStatusFailed
+		}
+		if updateErr := c.updateJobStatus(jobStatus, status, "Failed to import screenshot archive", nil); updateErr != nil {
+			c.env.Logger.Error("Failed to update job status", zap.Error(updateErr))
+		}
+		return errors.WithStack(err)
+	}
+	c.env.Logger.Info("[i] Job ImportScreenshotArchive finished")
+	return nil
+}
+
+// importScreenshotArchive imports the archive of the job, an invalid archive fails the job status
+// without an error
+func (c *Context) importScreenshotArchive(jobStatus *models.JobStatus) error {
+	archiveFile, err := ioutil.TempFile("", "screenshot-archive-*.zip")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := archiveFile.Close(); err != nil {
+			c.env.Logger.Error("Failed to close screenshot archive file", zap.Error(err))
+		}
+		if err := os.Remove(archiveFile.Name()); err != nil {
+			c.env.Logger.Error("Failed to remove screenshot archive file", zap.Error(err))
+		}
+	}()
+
+	object, err := c.env.Storage.GetObject(jobStatus.ScreenshotArchiveAWSPath())
+	if errors.Cause(err) == storage.ErrObjectNotFound {
+		return c.updateJobStatus(jobStatus, models.JobStatusFailed, "archive: Not found in storage", nil)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	archiveSize, err := io.Copy(archiveFile, io.LimitReader(object, models.MaxScreenshotArchiveFileByteSize+1))
+	if closeErr := object.Close(); closeErr != nil {
+		c.env.Logger.Error("Failed to close storage object", zap.Error(closeErr))
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if archiveSize > models.MaxScreenshotArchiveFileByteSize {
+		return c.updateJobStatus(jobStatus, models.JobStatusFailed, "archive: Must be smaller than 500 megabytes", nil)
+	}
+	archive, err := zip.NewReader(archiveFile, archiveSize)
+	if err != nil {
+		return c.updateJobStatus(jobStatus, models.JobStatusFailed, "archive: Must be a valid zip file", nil)
+	}
+
+	// the sizes declared by the archive are checked first, the actual sizes are checked while reading
+	if len(archive.File) > models.MaxScreenshotArchiveEntryCount {
+		return c.updateJobStatus(jobStatus, models.JobStatusFailed, fmt.Sprintf("archive: Must contain at most %d files", models.MaxScreenshotArchiveEntryCount), nil)
+	}
+	var declaredSize uint64
+	for _, file := range archive.File {
+		declaredSize += file.UncompressedSize64
+	}
+	if declaredSize > uint64(models.MaxScreenshotArchiveUncompressedByteSize) {
+		return c.updateJobStatus(jobStatus, models.JobStatusFailed, screenshotArchiveTooLargeMessage, nil)
+	}
+
+	manifest := map[string]models.ScreenshotArchiveEntry{}
+	files := []*zip.File{}
+	for _, file := range archive.File {
+		if file.Name == models.ScreenshotArchiveManifestFilename {
+			content, err := c.readArchiveFile(file, models.MaxScreenshotFileByteSize)
+			if err != nil {
+				return c.updateJobStatus(jobStatus, models.JobStatusFailed, "manifest: Failed to read", nil)
+			}
+			manifest, err = models.ParseScreenshotArchiveManifest(content)
+			if err != nil {
+				return c.updateJobStatus(jobStatus, models.JobStatusFailed, err.Error(), nil)
+			}
+			continue
+		}
+		if !models.IsIgnoredScreenshotArchiveFile(file.Name) {
+			files = append(files, file)
+		}
+	}
+	// screenshots are appended to their group in the order of their file names
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	appVersion := models.AppVersion{Record: models.Record{ID: *jobStatus.AppVersionID}, App: jobStatus.App}
+	existingScreenshots, err := c.env.ScreenshotService.FindAll(&appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	existingScreenshotIDs := map[uuid.UUID]bool{}
+	for _, screenshot := range existingScreenshots {
+		existingScreenshotIDs[screenshot.ID] = true
+	}
+
+	importResult := models.ScreenshotArchiveImportResult{Files: []models.ScreenshotArchiveFileResult{}}
+	screenshotsToCreate := []*models.Screenshot{}
+	var unpackedSize int64
+	for _, file := range files {
+		result := models.ScreenshotArchiveFileResult{File: file.Name}
+		screenshot, content, err := c.screenshotFromArchive(file, manifest, jobStatus)
+		unpackedSize += int64(len(content))
+		if unpackedSize > models.MaxScreenshotArchiveUncompressedByteSize {
+			return c.updateJobStatus(jobStatus, models.JobStatusFailed, screenshotArchiveTooLargeMessage, nil)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			importResult.Files = append(importResult.Files, result)
+			continue
+		}
+		result.ScreenshotID = &screenshot.ID
+		importResult.Files = append(importResult.Files, result)
+		if existingScreenshotIDs[screenshot.ID] {
+			continue
+		}
+
+		// the object is stored before the screenshot is created, the objects of a failed attempt are
+		// overwritten by the retry
+		uploadedScreenshot := *screenshot
+		uploadedScreenshot.AppVersion = appVersion
+		if err := c.env.AWS.PutObject(uploadedScreenshot.UploadAWSPath(), content); err != nil {
+			return errors.Wrap(err, "Failed to store screenshot")
+		}
+		screenshotsToCreate = append(screenshotsToCreate, screenshot)
+	}
+
+	if len(screenshotsToCreate) > 0 {
+		_, verrs, err := c.env.ScreenshotService.BatchCreate(screenshotsToCreate)
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation errors: %#v", verrs)
+		}
+		if err != nil {
+			return errors.Wrap(err, "Failed to create screenshots")
+		}
+	}
+	for _, result := range importResult.Files {
+		if result.ScreenshotID == nil {
+			continue
+		}
+		if err := c.env.WorkerService.EnqueueVerifyUploadedImage(models.UploadableTypeScreenshot, *result.ScreenshotID); err != nil {
+			c.env.Logger.Error("Failed to enqueue screenshot verification", zap.Error(err))
+		}
+	}
+
+	importedCount := 0
+	for _, result := range importResult.Files {
+		if result.ScreenshotID != nil {
+			importedCount++
+		}
+	}
+	message := fmt.Sprintf("%d of %d files imported", importedCount, len(importResult.Files))
+	if err := c.updateJobStatus(jobStatus, models.JobStatusFinished, message, importResult); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := c.env.AWS.DeleteObject(jobStatus.ScreenshotArchiveAWSPath()); err != nil {
+		c.env.Logger.Error("Failed to delete screenshot archive", zap.String("job_status_id", jobStatus.ID.String()), zap.Error(err))
+	}
+	return nil
+}
+
+// screenshotFromArchive returns the screenshot to create for the file with the content of the file.
+// The returned error is reported as the result of the file, the content read so far is returned with it.
+func (c *Context) screenshotFromArchive(file *zip.File, manifest map[string]models.ScreenshotArchiveEntry, jobStatus *models.JobStatus) (*models.Screenshot, []byte, error) {
+	entry, err := models.ScreenshotArchiveEntryFor(file.Name, manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := c.readArchiveFile(file, models.MaxScreenshotFileByteSize)
+	if err != nil {
+		return nil, content, errors.New("file: Failed to read from the archive")
+	}
+	if int64(len(content)) > models.MaxScreenshotFileByteSize {
+		return nil, content, errors.New("filesize: Must be smaller than 10 megabytes")
+	}
+
+	return &models.Screenshot{
+		Record: models.Record{ID: uuid.NewV5(jobStatus.ID, file.Name)},
+		UploadableObject: models.UploadableObject{
+			Filename: path.Base(file.Name),
+			Filesize: int64(len(content)),
+		},
+		DeviceType:   entry.DeviceType,
+		ScreenSize:   entry.ScreenSize,
+		Locale:       entry.Locale,
+		AppVersionID: *jobStatus.AppVersionID,
+	}, content, nil
+}
+
+func (c *Context) updateJobStatus(jobStatus *models.JobStatus, status, message string, result interface{}) error {
+	jobStatus.Status = status
+	jobStatus.Message = message
+	whitelist := []string{"Status", "Message"}
+	if result != nil {
+		resultData, err := json.Marshal(result)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		jobStatus.Result = resultData
+		whitelist = append(whitelist, "Result")
+	}
+	verrs, err := c.env.JobStatusService.Update(jobStatus, whitelist)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if len(verrs) > 0 {
+		return errors.Errorf("Validation errors: %#v", verrs)
+	}
+	return nil
+}
+
+// readArchiveFile reads at most one byte more than maxSize, so oversized files can be detected
+// without decompressing them fully
+func (c *Context) readArchiveFile(file *zip.File, maxSize int64) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			c.env.Logger.Error("Failed to close archive file", zap.Error(err))
+		}
+	}()
+	return ioutil.ReadAll(io.LimitReader(reader, maxSize+1))
+}
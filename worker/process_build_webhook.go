@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/ingestion"
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var processBuildWebhook = "process_build_webhook"
+
+// processBuildWebhookMaxFails is the number of attempts after which the processing of a build webhook
+// is given up
+func processBuildWebhookMaxFails() uint {
+	return uint(utils.GetInt64EnvWithDefault("PROCESS_BUILD_WEBHOOK_MAX_FAILS", 5))
+}
+
+// ProcessBuildWebhook creates the app versions of a finished build from its stored webhook. A failed
+// attempt is retried until the max fails is reached, the outcome is recorded on the build webhook.
+// Replayed webhooks with replace_versions set delete the versions created from the build before.
+func (c *Context) ProcessBuildWebhook(job *work.Job) error {
+	c.env.Logger.Info("[i] Job ProcessBuildWebhook started")
+	buildWebhookID := uuid.FromStringOrNil(job.ArgString("build_webhook_id"))
+	if uuid.Equal(buildWebhookID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of build webhook")
+		return errors.New("Failed to get build_webhook_id")
+	}
+
+	buildWebhook, err := c.env.BuildWebhookService.Find(&models.BuildWebhook{Record: models.Record{ID: buildWebhookID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if buildWebhook.IsDone() {
+		return nil
+	}
+
+	buildWebhook.Status = models.JobStatusRunning
+	buildWebhook.Attempts++
+	if err := c.env.BuildWebhookService.Update(buildWebhook, []string{"Status", "Attempts"}); err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	replaceVersions, _ := job.Args["replace_versions"].(bool)
+	appVersionIDs, err := c.processBuildWebhook(buildWebhook, replaceVersions)
+	if err != nil {
+		c.env.Logger.Error("[!] ProcessBuildWebhook: Failed to process build webhook",
+			zap.String("build_webhook_id", buildWebhookID.String()),
+			zap.Int("attempts", buildWebhook.Attempts),
+			zap.Error(err))
+		buildWebhook.Status = models.JobStatusPending
+		if job.Fails+1 >= int64(processBuildWebhookMaxFails()) {
+			buildWebhook.Status = models.JobStatusFailed
+		}
+		buildWebhook.Message = err.Error()
+		if updateErr := c.env.BuildWebhookService.Update(buildWebhook, []string{"Status", "Message"}); updateErr != nil {
+			c.env.Logger.Error("Failed to update build webhook", zap.Error(updateErr))
+		}
+		return errors.WithStack(err)
+	}
+
+	result, err := json.Marshal(models.BuildWebhookResult{AppVersionIDs: appVersionIDs})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	processedAt := time.Now()
+	buildWebhook.Status = models.JobStatusFinished
+	buildWebhook.Message = ""
+	buildWebhook.Result = result
+	buildWebhook.ProcessedAt = &processedAt
+	if err := c.env.BuildWebhookService.Update(buildWebhook, []string{"Status", "Message", "Result", "ProcessedAt"}); err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	c.env.Logger.Info("[i] Job ProcessBuildWebhook finished")
+	return nil
+}
+
+func (c *Context) processBuildWebhook(buildWebhook *models.BuildWebhook, replaceVersions bool) ([]uuid.UUID, error) {
+	var params ingestion.BuildWebhookPayload
+	if err := json.Unmarshal(buildWebhook.Payload, &params); err != nil {
+		return nil, errors.Wrap(err, "Invalid build webhook payload")
+	}
+	if replaceVersions {
+		if err := c.deleteAppVersionsOfBuild(buildWebhook.AppID, buildWebhook.BuildSlug); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	appVersions, err := ingestion.ProcessBuildWebhook(c.env, buildWebhook.AppID, params)
+	if err != nil {
+		return nil, err
+	}
+	appVersionIDs := []uuid.UUID{}
+	for _, appVersion := range appVersions {
+		appVersionIDs = append(appVersionIDs, appVersion.ID)
+	}
+	return appVersionIDs, nil
+}
+
+// deleteAppVersionsOfBuild deletes the versions created from the build, and releases the build so its
+// versions are created again
+func (c *Context) deleteAppVersionsOfBuild(appID uuid.UUID, buildSlug string) error {
+	app, err := c.env.AppService.Find(&models.App{Record: models.Record{ID: appID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	appVersions, err := c.env.AppVersionService.FindAll(app, map[string]interface{}{"build_slug": buildSlug})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	for _, appVersion := range appVersions {
+		appVersion.App = *app
+		if err := c.deleteAppVersion(appVersion); err != nil {
+			return errors.WithStack(err)
+		}
+		c.env.Logger.Info("[i] ProcessBuildWebhook: Deleted app version to be replaced",
+			zap.String("app_slug", app.AppSlug),
+			zap.String("app_version_id", appVersion.ID.String()))
+	}
+	if err := c.env.ProcessedBuildService.ReleaseBuild(appID, buildSlug); err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	return nil
+}
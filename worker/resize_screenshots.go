@@ -0,0 +1,225 @@
+package worker
+
+import (
+	"image"
+	"image/color"
+	// register the decoders of the accepted screenshot formats
+	_ "image/jpeg"
+	_ "image/png"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/gocraft/work"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var resizeScreenshots = "resize_screenshots"
+
+// ResizeScreenshots derives the screenshots of the required store sizes which are missing from the
+// app version, from the verified screenshots of the largest uploaded screen size of the same device
+// family. Derived screenshots are regenerated when their source changes and removed when they are not
+// needed anymore.
+func (c *Context) ResizeScreenshots(job *work.Job) error {
+	c.env.Logger.Info("[i] Job ResizeScreenshots started")
+	appVersionID := uuid.FromStringOrNil(job.ArgString("app_version_id"))
+	if uuid.Equal(appVersionID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of app version to resize screenshots of")
+		return errors.New("Failed to get app_version_id")
+	}
+
+	appVersion, err := c.env.AppVersionService.Find(&models.AppVersion{Record: models.Record{ID: appVersionID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if appVersion.Platform != "ios" {
+		return nil
+	}
+
+	appSettings, err := c.env.AppSettingsService.Find(&models.AppSettings{AppID: appVersion.AppID})
+	switch {
+	case errors.Cause(err) == gorm.ErrRecordNotFound:
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "SQL Error")
+	}
+	iosSettings, err := appSettings.IosSettings()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !iosSettings.AutoResizeScreenshots {
+		c.env.Logger.Info("[i] Automatic screenshot resizing is disabled for the app", zap.String("app_id", appVersion.AppID.String()))
+		return nil
+	}
+	background, err := iosSettings.ScreenshotBackground()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	screenshots, err := c.env.ScreenshotService.FindAll(appVersion)
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	// only verified screenshots count, and a single screen size of each device family is resized, so
+	// that the required sizes are derived once
+	uploadedScreenSizes := map[string]bool{}
+	localeScreenSizes := map[string][]string{}
+	for _, screenshot := range screenshots {
+		if screenshot.IsDerived() || !screenshot.Uploaded {
+			continue
+		}
+		key := screenSizeKey(screenshot.Locale, screenshot.ScreenSize)
+		if !uploadedScreenSizes[key] {
+			uploadedScreenSizes[key] = true
+			localeScreenSizes[screenshot.Locale] = append(localeScreenSizes[screenshot.Locale], screenshot.ScreenSize)
+		}
+	}
+	sourceScreenSizes := map[string]bool{}
+	for locale, screenSizes := range localeScreenSizes {
+		for _, screenSize := range models.ResizingSourceScreenSizes(screenSizes) {
+			sourceScreenSizes[screenSizeKey(locale, screenSize)] = true
+		}
+	}
+	sources := map[uuid.UUID]models.Screenshot{}
+	for _, screenshot := range screenshots {
+		if !screenshot.IsDerived() && screenshot.Uploaded && sourceScreenSizes[screenSizeKey(screenshot.Locale, screenshot.ScreenSize)] {
+			sources[screenshot.ID] = screenshot
+		}
+	}
+
+	derivedScreenshots := map[string]models.Screenshot{}
+	for _, screenshot := range screenshots {
+		if !screenshot.IsDerived() {
+			continue
+		}
+		source, ok := sources[*screenshot.SourceScreenshotID]
+		if !ok || uploadedScreenSizes[screenSizeKey(screenshot.Locale, screenshot.ScreenSize)] {
+			if err := c.deleteScreenshot(screenshot); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
+		derivedScreenshots[screenSizeKey(source.ID.String(), screenshot.ScreenSize)] = screenshot
+	}
+
+	for _, source := range screenshots {
+		if _, ok := sources[source.ID]; !ok {
+			continue
+		}
+		sourceID := source.ID
+		for _, required := range source.RequiredScreenshotSizes() {
+			if uploadedScreenSizes[screenSizeKey(source.Locale, required.ScreenSize)] {
+				continue
+			}
+			derived, ok := derivedScreenshots[screenSizeKey(source.ID.String(), required.ScreenSize)]
+			if ok && derived.SourceChecksum == source.Checksum && derived.Uploaded {
+				continue
+			}
+			if !ok {
+				derived = models.Screenshot{
+					UploadableObject: models.UploadableObject{
+						Filename: strings.TrimSuffix(source.Filename, filepath.Ext(source.Filename)) + ".png",
+					},
+					DeviceType:         required.DeviceType,
+					ScreenSize:         required.ScreenSize,
+					Locale:             source.Locale,
+					Position:           source.Position,
+					SourceScreenshotID: &sourceID,
+					AppVersionID:       appVersion.ID,
+				}
+			}
+			if err := c.generateDerivedScreenshot(source, derived, required.ImageDimensions, background); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	c.env.Logger.Info("[i] Job ResizeScreenshots finished")
+	return nil
+}
+
+func screenSizeKey(scope, screenSize string) string {
+	return scope + "/" + screenSize
+}
+
+// generateDerivedScreenshot stores the resized image of the source, the record of the derived
+// screenshot is created first, and marked as uploaded only after the image is stored, so a failed
+// attempt is picked up again on retry
+func (c *Context) generateDerivedScreenshot(source, derived models.Screenshot, dimensions models.ImageDimensions, background color.Color) error {
+	if uuid.Equal(derived.ID, uuid.UUID{}) {
+		createdScreenshots, verrs, err := c.env.ScreenshotService.BatchCreate([]*models.Screenshot{&derived})
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		if len(verrs) > 0 {
+			return errors.Errorf("Validation errors: %#v", verrs)
+		}
+		derived = *createdScreenshots[0]
+	}
+
+	object, err := c.env.Storage.GetObject(source.AWSPath())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := object.Close(); err != nil {
+			c.env.Logger.Error("Failed to close storage object", zap.Error(err))
+		}
+	}()
+	sourceImage, _, err := image.Decode(object)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode source screenshot")
+	}
+
+	imageBytes, checksum, err := models.EncodePNG(models.ResizeImageToFit(sourceImage, dimensions, background))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	previousAssetID := derived.AssetID
+	// derived screenshots generated before assets were introduced are stored at their upload path
+	storedAtUploadPath := derived.AssetID == nil && derived.Uploaded
+	asset, err := c.env.AssetService.Acquire(&models.Asset{
+		Checksum:  checksum,
+		Extension: models.AssetExtension(derived.Filename),
+		Filesize:  int64(len(imageBytes)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if err := c.env.AWS.PutObject(asset.AWSPath(), imageBytes); err != nil {
+		c.discardAsset(asset.ID)
+		return errors.WithStack(err)
+	}
+
+	derived.AssetID = &asset.ID
+	derived.Filesize = int64(len(imageBytes))
+	derived.Width = dimensions.Width
+	derived.Height = dimensions.Height
+	derived.Checksum = checksum
+	derived.InvalidReason = ""
+	derived.Uploaded = true
+	derived.SourceChecksum = source.Checksum
+	verrs, err := c.env.ScreenshotService.BatchUpdate([]models.Screenshot{derived}, append([]string{"AssetID", "Filesize", "SourceChecksum"}, models.UploadableVerificationFields...))
+	if err == nil && len(verrs) > 0 {
+		err = errors.Errorf("Validation errors: %#v", verrs)
+	}
+	if err != nil {
+		c.discardAsset(asset.ID)
+		return errors.Wrap(err, "Failed to update derived screenshot")
+	}
+
+	// the image the derived screenshot was generated from previously
+	if previousAssetID != nil {
+		if err := c.releaseAsset(*previousAssetID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if storedAtUploadPath {
+		c.deleteUpload(derived.UploadAWSPath())
+	}
+	return nil
+}
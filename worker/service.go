@@ -65,3 +65,135 @@ func (*Service) EnqueueCopyUploadablesToNewAppVersion(appVersionFromCopyID, appV
 	}
 	return nil
 }
+
+// EnqueueVerifyUploadedImage ...
+func (*Service) EnqueueVerifyUploadedImage(uploadableType string, uploadableID uuid.UUID) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"uploadable_type": uploadableType,
+		"uploadable_id":   uploadableID.String(),
+	}
+
+	_, err := enqueuer.EnqueueUnique(verifyUploadedImage, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueStoreUploadedAppPreview ...
+func (*Service) EnqueueStoreUploadedAppPreview(appPreviewID uuid.UUID) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"app_preview_id": appPreviewID.String(),
+	}
+
+	_, err := enqueuer.EnqueueUnique(storeUploadedAppPreview, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueResizeScreenshots ...
+func (*Service) EnqueueResizeScreenshots(appVersionID uuid.UUID) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"app_version_id": appVersionID.String(),
+	}
+
+	_, err := enqueuer.EnqueueUnique(resizeScreenshots, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueImportScreenshotArchive ...
+func (*Service) EnqueueImportScreenshotArchive(jobStatusID uuid.UUID) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"job_status_id": jobStatusID.String(),
+	}
+
+	_, err := enqueuer.EnqueueUnique(importScreenshotArchive, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueCopyFromAppVersion ...
+func (*Service) EnqueueCopyFromAppVersion(jobStatusID, sourceAppVersionID uuid.UUID, parts models.AppVersionCopyParts) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"job_status_id":         jobStatusID.String(),
+		"source_app_version_id": sourceAppVersionID.String(),
+		"store_info":            parts.StoreInfo,
+		"screenshots":           parts.Screenshots,
+		"feature_graphic":       parts.FeatureGraphic,
+	}
+
+	_, err := enqueuer.EnqueueUnique(copyFromAppVersion, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueProcessBuildWebhook ...
+func (*Service) EnqueueProcessBuildWebhook(buildWebhookID uuid.UUID) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{"build_webhook_id": buildWebhookID.String()}
+
+	_, err := enqueuer.EnqueueUnique(processBuildWebhook, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueReplayBuildWebhook enqueues the processing of a replayed build webhook, the versions already
+// created from the build are deleted first if replaceVersions is set
+func (*Service) EnqueueReplayBuildWebhook(buildWebhookID uuid.UUID, replaceVersions bool) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"build_webhook_id": buildWebhookID.String(),
+		"replace_versions": replaceVersions,
+	}
+
+	_, err := enqueuer.EnqueueUnique(processBuildWebhook, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueDeprovisionApp ...
+func (*Service) EnqueueDeprovisionApp(appTombstoneID uuid.UUID) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"app_tombstone_id": appTombstoneID.String(),
+	}
+
+	_, err := enqueuer.EnqueueUnique(deprovisionApp, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnqueueUpdateHeaderPalette ...
+func (*Service) EnqueueUpdateHeaderPalette(appID uuid.UUID, avatarURL string) error {
+	enqueuer := work.NewEnqueuer(namespace, redisPool)
+	jobParams := work.Q{
+		"app_id":     appID.String(),
+		"avatar_url": avatarURL,
+	}
+
+	_, err := enqueuer.EnqueueUnique(updateHeaderPalette, jobParams)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var storeUploadedAppPreview = "store_uploaded_app_preview"
+
+// storeUploadedAppPreviewMaxFails is the number of attempts after which storing an app preview is given
+// up, the preview is served from its upload then
+func storeUploadedAppPreviewMaxFails() uint {
+	return uint(utils.GetInt64EnvWithDefault("STORE_UPLOADED_APP_PREVIEW_MAX_FAILS", 4))
+}
+
+// StoreUploadedAppPreview stores the completed upload of an app preview as the asset of its content,
+// so the app versions it's carried forward to share the same object
+func (c *Context) StoreUploadedAppPreview(job *work.Job) error {
+	c.env.Logger.Info("[i] Job StoreUploadedAppPreview started")
+	appPreviewID := uuid.FromStringOrNil(job.ArgString("app_preview_id"))
+	if uuid.Equal(appPreviewID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of app preview to store")
+		return errors.New("Failed to get app_preview_id")
+	}
+
+	appPreview, err := c.env.AppPreviewService.Find(&models.AppPreview{Record: models.Record{ID: appPreviewID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if appPreview.AssetID != nil {
+		c.env.Logger.Info("[i] App preview is already stored", zap.String("app_preview_id", appPreview.ID.String()))
+		return nil
+	}
+	if !appPreview.Uploaded {
+		c.env.Logger.Info("[i] Upload of app preview is not completed", zap.String("app_preview_id", appPreview.ID.String()))
+		return nil
+	}
+
+	checksum, err := c.uploadChecksum(appPreview.UploadAWSPath())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	appPreview.Checksum = checksum
+	assetID, err := c.storeAsAsset(appPreview.UploadAWSPath(), appPreview.UploadableObject)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	appPreview.AssetID = &assetID
+	verrs, err := c.env.AppPreviewService.Update(*appPreview, []string{"AssetID", "Checksum"})
+	if err == nil && len(verrs) > 0 {
+		err = errors.Errorf("Validation errors: %#v", verrs)
+	}
+	if err != nil {
+		c.discardAsset(assetID)
+		return errors.Wrap(err, "Failed to update app preview")
+	}
+	c.deleteUpload(appPreview.UploadAWSPath())
+
+	c.env.Logger.Info("[i] Job StoreUploadedAppPreview finished")
+	return nil
+}
+
+// uploadChecksum calculates the checksum of the uploaded object, which identifies its asset
+func (c *Context) uploadChecksum(uploadPath string) (string, error) {
+	object, err := c.env.Storage.GetObject(uploadPath)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer func() {
+		if err := object.Close(); err != nil {
+			c.env.Logger.Error("Failed to close storage object", zap.Error(err))
+		}
+	}()
+	return models.ContentChecksum(object)
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"image"
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var updateHeaderPalette = "update_header_palette"
+
+var avatarHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// UpdateHeaderPalette calculates the header palette of the app from its avatar. The palette is only
+// calculated again when the URL or the content of the avatar changed since the last calculation.
+func (c *Context) UpdateHeaderPalette(job *work.Job) error {
+	c.env.Logger.Info("[i] Job UpdateHeaderPalette started")
+	appID := uuid.FromStringOrNil(job.ArgString("app_id"))
+	if uuid.Equal(appID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of app")
+		return errors.New("Failed to get app_id")
+	}
+	avatarURL := job.ArgString("avatar_url")
+	if err := job.ArgError(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	app, err := c.env.AppService.Find(&models.App{Record: models.Record{ID: appID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	avatar, err := c.downloadAvatar(avatarURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	checksum := sha256.Sum256(avatar)
+	avatarChecksum := hex.EncodeToString(checksum[:])
+	if app.AvatarURL == avatarURL && app.AvatarChecksum == avatarChecksum {
+		c.env.Logger.Info("[i] Job UpdateHeaderPalette finished, avatar didn't change")
+		return nil
+	}
+
+	palette, err := headerPaletteOfAvatar(avatar)
+	if err != nil {
+		// the avatar is not stored as processed, so the palette is calculated again on the next attempt
+		c.env.Logger.Warn("Failed to calculate header palette", zap.String("avatar_url", avatarURL), zap.Error(err))
+		return nil
+	}
+	paletteData, err := json.Marshal(palette)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	app.AvatarURL = avatarURL
+	app.AvatarChecksum = avatarChecksum
+	app.HeaderPaletteData = paletteData
+	app.HeaderColor1 = palette.Primary
+	app.HeaderColor2 = palette.Secondary
+	whitelist := []string{"AvatarURL", "AvatarChecksum", "HeaderPaletteData", "HeaderColor1", "HeaderColor2"}
+
+	verrs, err := c.env.AppService.Update(app, whitelist)
+	if len(verrs) > 0 {
+		return errors.Errorf("Validation errors: %#v", verrs)
+	}
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+
+	c.env.Logger.Info("[i] Job UpdateHeaderPalette finished")
+	return nil
+}
+
+func (c *Context) downloadAvatar(avatarURL string) ([]byte, error) {
+	resp, err := avatarHTTPClient.Get(avatarURL)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.env.Logger.Error("Failed to close avatar response body", zap.Error(err))
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Failed to download avatar, status: %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func headerPaletteOfAvatar(avatar []byte) (models.HeaderPalette, error) {
+	img, _, err := image.Decode(bytes.NewReader(avatar))
+	if err != nil {
+		return models.HeaderPalette{}, errors.WithStack(err)
+	}
+	return models.NewHeaderPalette(img)
+}
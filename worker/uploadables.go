@@ -0,0 +1,301 @@
+package worker
+
+import (
+	"net/url"
+	"sort"
+
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+// copyScreenshots creates a copy of each screenshot of an app version for another one. Screenshots
+// stored as assets take another reference on them, the files of other screenshots are copied. The
+// copies are deleted if a file can't be copied, so a failed attempt leaves no screenshots behind.
+func (c *Context) copyScreenshots(fromID, toID uuid.UUID) error {
+	originalScreenshots, err := c.env.ScreenshotService.FindAll(&models.AppVersion{Record: models.Record{ID: fromID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if len(originalScreenshots) == 0 {
+		return nil
+	}
+
+	// source screenshots are created first, so derived screenshots can reference their copies
+	sort.SliceStable(originalScreenshots, func(i, j int) bool {
+		return !originalScreenshots[i].IsDerived() && originalScreenshots[j].IsDerived()
+	})
+	copiedScreenshotIDs := map[uuid.UUID]uuid.UUID{}
+	screenShotsToCreate := []*models.Screenshot{}
+	assetIDs := []uuid.UUID{}
+	for _, sc := range originalScreenshots {
+		screenshotToCreate := &models.Screenshot{
+			Record:           models.Record{ID: uuid.NewV4()},
+			UploadableObject: sc.UploadableObject,
+			DeviceType:       sc.DeviceType,
+			ScreenSize:       sc.ScreenSize,
+			Locale:           sc.Locale,
+			Position:         sc.Position,
+			SourceChecksum:   sc.SourceChecksum,
+			AssetID:          sc.AssetID,
+			AppVersionID:     toID,
+		}
+		if sc.IsDerived() {
+			sourceID := copiedScreenshotIDs[*sc.SourceScreenshotID]
+			screenshotToCreate.SourceScreenshotID = &sourceID
+		}
+		if sc.AssetID != nil {
+			assetIDs = append(assetIDs, *sc.AssetID)
+		}
+		copiedScreenshotIDs[sc.ID] = screenshotToCreate.ID
+		screenShotsToCreate = append(screenShotsToCreate, screenshotToCreate)
+	}
+	// the references are taken first, so an asset can't be deleted while it's being carried forward
+	if err := c.env.AssetService.Retain(assetIDs); err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	createsScreenshots, verrs, err := c.env.ScreenshotService.BatchCreate(screenShotsToCreate)
+	if err == nil && len(verrs) > 0 {
+		err = errors.Errorf("Validation errors: %#v", verrs)
+	}
+	if err != nil {
+		for _, assetID := range assetIDs {
+			c.discardAsset(assetID)
+		}
+		return errors.Wrap(err, "Failed to create screenshots")
+	}
+
+	for idx, sc := range originalScreenshots {
+		if sc.AssetID != nil {
+			continue
+		}
+		from := url.QueryEscape(sc.AWSPath())
+		to := createsScreenshots[idx].UploadAWSPath()
+
+		err = c.env.AWS.CopyObject(from, to)
+		if err != nil {
+			c.env.Logger.Error("[!] Failed to copy AWS file of screenshot", zap.Any("error", err))
+			copies := []models.Screenshot{}
+			for _, createdScreenshot := range createsScreenshots {
+				copies = append(copies, *createdScreenshot)
+			}
+			if deleteErr := c.deleteScreenshots(copies); deleteErr != nil {
+				c.env.Logger.Error("Failed to delete copied screenshots", zap.Error(deleteErr))
+			}
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// copyAppPreviews creates a copy of each uploaded app preview of an app version for another one. App
+// previews stored as assets take another reference on them, the files of other app previews are copied.
+// The copies are deleted if a file can't be copied, so a failed attempt leaves no app previews behind.
+func (c *Context) copyAppPreviews(fromID, toID uuid.UUID) error {
+	originalAppPreviews, err := c.env.AppPreviewService.FindAll(&models.AppVersion{Record: models.Record{ID: fromID}})
+	if err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	uploadedAppPreviews := []models.AppPreview{}
+	for _, ap := range originalAppPreviews {
+		if ap.Uploaded {
+			uploadedAppPreviews = append(uploadedAppPreviews, ap)
+		}
+	}
+	if len(uploadedAppPreviews) == 0 {
+		return nil
+	}
+
+	appPreviewsToCreate := []*models.AppPreview{}
+	assetIDs := []uuid.UUID{}
+	for _, ap := range uploadedAppPreviews {
+		appPreviewsToCreate = append(appPreviewsToCreate, &models.AppPreview{
+			UploadableObject: ap.UploadableObject,
+			DeviceType:       ap.DeviceType,
+			ScreenSize:       ap.ScreenSize,
+			Locale:           ap.Locale,
+			AssetID:          ap.AssetID,
+			AppVersionID:     toID,
+		})
+		if ap.AssetID != nil {
+			assetIDs = append(assetIDs, *ap.AssetID)
+		}
+	}
+	// the references are taken first, so an asset can't be deleted while it's being carried forward
+	if err := c.env.AssetService.Retain(assetIDs); err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	createdAppPreviews, verrs, err := c.env.AppPreviewService.BatchCreate(appPreviewsToCreate)
+	if err == nil && len(verrs) > 0 {
+		err = errors.Errorf("Validation errors: %#v", verrs)
+	}
+	if err != nil {
+		for _, assetID := range assetIDs {
+			c.discardAsset(assetID)
+		}
+		return errors.Wrap(err, "Failed to create app previews")
+	}
+
+	for idx, ap := range uploadedAppPreviews {
+		if ap.AssetID != nil {
+			continue
+		}
+		from := url.QueryEscape(ap.AWSPath())
+		to := createdAppPreviews[idx].UploadAWSPath()
+
+		err = c.env.AWS.CopyObject(from, to)
+		if err != nil {
+			c.env.Logger.Error("[!] Failed to copy AWS file of app preview", zap.Any("error", err))
+			copies := []models.AppPreview{}
+			for _, createdAppPreview := range createdAppPreviews {
+				copies = append(copies, *createdAppPreview)
+			}
+			if deleteErr := c.deleteAppPreviews(copies); deleteErr != nil {
+				c.env.Logger.Error("Failed to delete copied app previews", zap.Error(deleteErr))
+			}
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// copyStoreGraphics creates a copy of the uploaded store graphics of the given types of an app version
+// for another one, or of all of them if no type is given
+func (c *Context) copyStoreGraphics(fromID, toID uuid.UUID, graphicTypes ...string) error {
+	originalStoreGraphics, err := c.findStoreGraphics(fromID, graphicTypes...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, originalStoreGraphic := range originalStoreGraphics {
+		if !originalStoreGraphic.Uploaded {
+			continue
+		}
+		assetID := originalStoreGraphic.AssetID
+		if assetID != nil {
+			if err := c.env.AssetService.Retain([]uuid.UUID{*assetID}); err != nil {
+				return errors.Wrap(err, "SQL Error")
+			}
+		}
+		createdStoreGraphic, verrs, err := c.env.StoreGraphicService.Create(&models.StoreGraphic{
+			UploadableObject: originalStoreGraphic.UploadableObject,
+			Type:             originalStoreGraphic.Type,
+			AssetID:          assetID,
+			AppVersionID:     toID,
+		})
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation error: %#v", verrs)
+		}
+		if err != nil {
+			if assetID != nil {
+				c.discardAsset(*assetID)
+			}
+			return errors.Wrap(err, "Failed to create store graphic")
+		}
+
+		if assetID == nil {
+			from := url.QueryEscape(originalStoreGraphic.AWSPath())
+			to := createdStoreGraphic.UploadAWSPath()
+			if err := c.env.AWS.CopyObject(from, to); err != nil {
+				// the copy is deleted, so a failed attempt leaves no store graphic without a file behind
+				if deleteErr := c.deleteStoreGraphics([]models.StoreGraphic{*createdStoreGraphic}); deleteErr != nil {
+					c.env.Logger.Error("Failed to delete copied store graphic", zap.Error(deleteErr))
+				}
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteScreenshots deletes the given screenshots, derived screenshots are deleted before their
+// sources, so their files are not left behind by the cascading delete
+func (c *Context) deleteScreenshots(screenshots []models.Screenshot) error {
+	sort.SliceStable(screenshots, func(i, j int) bool {
+		return screenshots[i].IsDerived() && !screenshots[j].IsDerived()
+	})
+	for _, screenshot := range screenshots {
+		if err := c.deleteScreenshot(screenshot); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// deleteScreenshot deletes the screenshot, then releases its asset or deletes its file
+func (c *Context) deleteScreenshot(screenshot models.Screenshot) error {
+	if err := c.env.ScreenshotService.Delete(&screenshot); err != nil {
+		return errors.Wrap(err, "SQL Error")
+	}
+	if screenshot.AssetID == nil {
+		return errors.WithStack(c.env.AWS.DeleteObject(screenshot.AWSPath()))
+	}
+	if err := c.releaseAsset(*screenshot.AssetID); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// deleteAppPreviews deletes the given app previews, then releases their assets or deletes their files
+func (c *Context) deleteAppPreviews(appPreviews []models.AppPreview) error {
+	for _, appPreview := range appPreviews {
+		if err := c.env.AppPreviewService.Delete(&appPreview); err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		if appPreview.AssetID == nil {
+			if err := c.env.AWS.DeleteObject(appPreview.AWSPath()); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
+		if err := c.releaseAsset(*appPreview.AssetID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// findStoreGraphics returns the store graphics of the given types of the app version, or all of them if
+// no type is given
+func (c *Context) findStoreGraphics(appVersionID uuid.UUID, graphicTypes ...string) ([]models.StoreGraphic, error) {
+	storeGraphics, err := c.env.StoreGraphicService.FindAll(&models.AppVersion{Record: models.Record{ID: appVersionID}})
+	if err != nil {
+		return nil, errors.Wrap(err, "SQL Error")
+	}
+	return filterStoreGraphics(storeGraphics, graphicTypes), nil
+}
+
+// deleteStoreGraphics deletes the given store graphics, then releases their assets or deletes their files
+func (c *Context) deleteStoreGraphics(storeGraphics []models.StoreGraphic) error {
+	for _, storeGraphic := range storeGraphics {
+		if err := c.env.StoreGraphicService.Delete(&storeGraphic); err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		if storeGraphic.AssetID == nil {
+			if err := c.env.AWS.DeleteObject(storeGraphic.AWSPath()); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
+		if err := c.releaseAsset(*storeGraphic.AssetID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func filterStoreGraphics(storeGraphics []models.StoreGraphic, graphicTypes []string) []models.StoreGraphic {
+	if len(graphicTypes) == 0 {
+		return storeGraphics
+	}
+	filtered := []models.StoreGraphic{}
+	for _, storeGraphic := range storeGraphics {
+		for _, graphicType := range graphicTypes {
+			if storeGraphic.Type == graphicType {
+				filtered = append(filtered, storeGraphic)
+				break
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"github.com/bitrise-io/addons-ship-backend/models"
+	"github.com/bitrise-io/addons-ship-backend/storage"
+	"github.com/bitrise-io/api-utils/utils"
+	"github.com/gocraft/work"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+)
+
+var verifyUploadedImage = "verify_uploaded_image"
+
+// verifyUploadedImageMaxFails is the number of attempts after which an uploaded file that can't be
+// found in the storage is considered invalid
+func verifyUploadedImageMaxFails() uint {
+	return uint(utils.GetInt64EnvWithDefault("VERIFY_UPLOADED_IMAGE_MAX_FAILS", 4))
+}
+
+// VerifyUploadedImage ...
+func (c *Context) VerifyUploadedImage(job *work.Job) error {
+	c.env.Logger.Info("[i] Job VerifyUploadedImage started")
+	uploadableType := job.ArgString("uploadable_type")
+	uploadableID := uuid.FromStringOrNil(job.ArgString("uploadable_id"))
+	if uuid.Equal(uploadableID, uuid.UUID{}) {
+		c.env.Logger.Error("Failed to get ID of uploadable to verify")
+		return errors.New("Failed to get uploadable_id")
+	}
+	// the upload might not be visible in the storage yet, it's only reported missing at the last attempt
+	lastAttempt := job.Fails+1 >= int64(verifyUploadedImageMaxFails())
+
+	switch uploadableType {
+	case models.UploadableTypeScreenshot:
+		screenshot, err := c.env.ScreenshotService.Find(&models.Screenshot{Record: models.Record{ID: uploadableID}})
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		if screenshot.AssetID != nil {
+			c.env.Logger.Info("[i] Screenshot is already verified", zap.String("screenshot_id", screenshot.ID.String()))
+			break
+		}
+		err = c.verifyImage(screenshot.UploadAWSPath(), &screenshot.UploadableObject, screenshot.VerifyDimensions, lastAttempt)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		whitelist := models.UploadableVerificationFields
+		if screenshot.Uploaded {
+			assetID, err := c.storeAsAsset(screenshot.UploadAWSPath(), screenshot.UploadableObject)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			screenshot.AssetID = &assetID
+			whitelist = append([]string{"AssetID"}, whitelist...)
+		}
+		verrs, err := c.env.ScreenshotService.BatchUpdate([]models.Screenshot{*screenshot}, whitelist)
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation errors: %#v", verrs)
+		}
+		if err != nil {
+			if screenshot.AssetID != nil {
+				c.discardAsset(*screenshot.AssetID)
+			}
+			return errors.Wrap(err, "Failed to update screenshot")
+		}
+		if screenshot.AssetID != nil {
+			c.deleteUpload(screenshot.UploadAWSPath())
+		}
+		if screenshot.Uploaded && !screenshot.IsDerived() {
+			if err := c.env.WorkerService.EnqueueResizeScreenshots(screenshot.AppVersionID); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	// feature graphics are stored as store graphics, the type is kept for jobs enqueued before the move
+	case models.UploadableTypeFeatureGraphic, models.UploadableTypeStoreGraphic:
+		storeGraphic, err := c.env.StoreGraphicService.Find(&models.StoreGraphic{Record: models.Record{ID: uploadableID}})
+		if err != nil {
+			return errors.Wrap(err, "SQL Error")
+		}
+		if storeGraphic.AssetID != nil {
+			c.env.Logger.Info("[i] Store graphic is already verified", zap.String("store_graphic_id", storeGraphic.ID.String()))
+			break
+		}
+		err = c.verifyImage(storeGraphic.UploadAWSPath(), &storeGraphic.UploadableObject, storeGraphic.VerifyDimensions, lastAttempt)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		whitelist := models.UploadableVerificationFields
+		if storeGraphic.Uploaded {
+			assetID, err := c.storeAsAsset(storeGraphic.UploadAWSPath(), storeGraphic.UploadableObject)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			storeGraphic.AssetID = &assetID
+			whitelist = append([]string{"AssetID"}, whitelist...)
+		}
+		verrs, err := c.env.StoreGraphicService.Update(*storeGraphic, whitelist)
+		if err == nil && len(verrs) > 0 {
+			err = errors.Errorf("Validation errors: %#v", verrs)
+		}
+		if err != nil {
+			if storeGraphic.AssetID != nil {
+				c.discardAsset(*storeGraphic.AssetID)
+			}
+			return errors.Wrap(err, "Failed to update store graphic")
+		}
+		if storeGraphic.AssetID != nil {
+			c.deleteUpload(storeGraphic.UploadAWSPath())
+		}
+	default:
+		c.env.Logger.Error("Invalid uploadable type", zap.String("uploadable_type", uploadableType))
+		return errors.Errorf("Invalid uploadable_type: %s", uploadableType)
+	}
+
+	c.env.Logger.Info("[i] Job VerifyUploadedImage finished")
+	return nil
+}
+
+// verifyImage fetches the object from the storage and records the result of the verification on
+// the uploadable. An error is returned only if the verification couldn't be completed, so that the
+// job gets retried. A missing object is recorded as invalid only at the last attempt.
+func (c *Context) verifyImage(awsPath string, uploadable *models.UploadableObject, verifyDimensions func(models.ImageDimensions) error, lastAttempt bool) error {
+	uploadable.Uploaded = false
+	uploadable.InvalidReason = ""
+
+	object, err := c.env.Storage.GetObject(awsPath)
+	if errors.Cause(err) == storage.ErrObjectNotFound && lastAttempt {
+		uploadable.InvalidReason = "file: Not found in storage"
+		return nil
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := object.Close(); err != nil {
+			c.env.Logger.Error("Failed to close storage object", zap.Error(err))
+		}
+	}()
+
+	info, err := models.InspectImage(object)
+	if err == models.ErrInvalidImage {
+		uploadable.InvalidReason = "file: " + err.Error()
+		return nil
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	uploadable.Width = info.Width
+	uploadable.Height = info.Height
+	uploadable.Checksum = info.Checksum
+
+	if err := verifyDimensions(info.ImageDimensions); err != nil {
+		uploadable.InvalidReason = err.Error()
+		return nil
+	}
+	uploadable.Uploaded = true
+	return nil
+}
@@ -37,6 +37,23 @@ func Start(appEnv *env.AppEnv) error {
 	pool.Job(storeLogToAWS, (&context).StoreLogToAWS)
 	pool.Job(storeLogChunkToRedis, (&context).StoreLogChunkToRedis)
 	pool.Job(copyUploadablesToNewAppVersion, (&context).CopyUploadablesToNewAppVersion)
+	pool.JobWithOptions(verifyUploadedImage, work.JobOptions{MaxFails: verifyUploadedImageMaxFails()}, (&context).VerifyUploadedImage)
+	pool.JobWithOptions(storeUploadedAppPreview, work.JobOptions{MaxFails: storeUploadedAppPreviewMaxFails()}, (&context).StoreUploadedAppPreview)
+	pool.Job(resizeScreenshots, (&context).ResizeScreenshots)
+	pool.JobWithOptions(importScreenshotArchive, work.JobOptions{MaxFails: importScreenshotArchiveMaxFails()}, (&context).ImportScreenshotArchive)
+	pool.JobWithOptions(copyFromAppVersion, work.JobOptions{MaxFails: copyFromAppVersionMaxFails()}, (&context).CopyFromAppVersion)
+	pool.JobWithOptions(processBuildWebhook, work.JobOptions{MaxFails: processBuildWebhookMaxFails()}, (&context).ProcessBuildWebhook)
+	pool.Job(collectOrphanedObjects, (&context).CollectOrphanedObjects)
+	pool.Job(deprovisionApp, (&context).DeprovisionApp)
+	pool.Job(enforceRetentionPolicies, (&context).EnforceRetentionPolicies)
+	pool.Job(updateHeaderPalette, (&context).UpdateHeaderPalette)
+	pool.Job(checkCodeSigningExpiry, (&context).CheckCodeSigningExpiry)
+	pool.Job(deleteExpiredWebhookDeliveries, (&context).DeleteExpiredWebhookDeliveries)
+
+	pool.PeriodicallyEnqueue(orphanedObjectsGCSchedule(), collectOrphanedObjects)
+	pool.PeriodicallyEnqueue(retentionPolicySchedule(), enforceRetentionPolicies)
+	pool.PeriodicallyEnqueue(codeSigningExpirySchedule(), checkCodeSigningExpiry)
+	pool.PeriodicallyEnqueue(webhookDeliveryRetentionSchedule(), deleteExpiredWebhookDeliveries)
 
 	pool.Start()
 	defer pool.Stop()